@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"christjesus/internal/db"
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var bootstrapSuperadminCommand = &cli.Command{
+	Name:  "bootstrap-superadmin",
+	Usage: "Grant the superadmin role to the first admin; later grants are made from the admin roles page",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "user-id",
+			Usage: "Internal user ID to grant superadmin",
+		},
+		&cli.StringFlag{
+			Name:  "email",
+			Usage: "Email of the user to grant superadmin; the user must have signed in at least once",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Grant even when a superadmin already exists",
+		},
+	},
+	Action: bootstrapSuperadmin,
+}
+
+func bootstrapSuperadmin(cCtx *cli.Context) error {
+	userID := strings.TrimSpace(cCtx.String("user-id"))
+	email := strings.TrimSpace(cCtx.String("email"))
+	if (userID == "") == (email == "") {
+		return fmt.Errorf("exactly one of --user-id or --email is required")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	ctx := cCtx.Context
+
+	pool, err := db.Connect(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	userRepo := store.NewUserRepository(pool)
+	adminRoleRepo := store.NewAdminRoleRepository(pool)
+
+	existing, err := adminRoleRepo.ActiveRoleCount(ctx, types.AdminRoleSuperadmin)
+	if err != nil {
+		return fmt.Errorf("failed to count superadmins: %w", err)
+	}
+	if existing > 0 && !cCtx.Bool("force") {
+		return fmt.Errorf("%d superadmin(s) already exist; grant further roles from the admin roles page or pass --force", existing)
+	}
+
+	var user *types.User
+	if userID != "" {
+		user, err = userRepo.User(ctx, userID)
+	} else {
+		user, err = userRepo.UserByEmail(ctx, email)
+	}
+	if err != nil {
+		if errors.Is(err, types.ErrUserNotFound) {
+			return fmt.Errorf("user not found; they must sign in once before being granted a role")
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	grant, err := adminRoleRepo.GrantRole(ctx, user.ID, types.AdminRoleSuperadmin, nil)
+	if err != nil {
+		if errors.Is(err, types.ErrAdminRoleAlreadyHeld) {
+			logger.WithField("user_id", user.ID).Info("user is already a superadmin")
+			return nil
+		}
+		return fmt.Errorf("failed to grant superadmin: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"grant_id": grant.ID,
+		"user_id":  user.ID,
+		"email":    derefString(user.Email),
+	}).Info("superadmin granted")
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"christjesus/internal/db"
+	"christjesus/internal/store"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var completeReallocationsCommand = &cli.Command{
+	Name:  "complete-reallocations",
+	Usage: "Redirect proposed fund reallocations whose donor consent window has closed",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "limit",
+			Value: 100,
+			Usage: "Maximum number of reallocations to complete in one run",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Log due reallocations without moving any funds",
+		},
+	},
+	Action: completeReallocations,
+}
+
+func completeReallocations(cCtx *cli.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	ctx := cCtx.Context
+
+	pool, err := db.Connect(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	fundReallocationRepo := store.NewFundReallocationRepository(pool)
+
+	limit := cCtx.Int("limit")
+	if limit <= 0 {
+		limit = 100
+	}
+
+	dryRun := cCtx.Bool("dry-run")
+	cutoff := time.Now()
+
+	reallocations, err := fundReallocationRepo.DueReallocations(ctx, cutoff, limit)
+	if err != nil {
+		return fmt.Errorf("failed to query due fund reallocations: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"limit":   limit,
+		"matched": len(reallocations),
+		"dry_run": dryRun,
+	}).Info("loaded due fund reallocations")
+
+	var completedCount int
+	var skippedCount int
+	var movedCents int
+
+	for _, reallocation := range reallocations {
+		if dryRun {
+			logger.WithFields(logrus.Fields{
+				"reallocation_id":    reallocation.ID,
+				"need_id":            reallocation.NeedID,
+				"amount_cents":       reallocation.AmountCents,
+				"target_need_id":     derefString(reallocation.TargetNeedID),
+				"target_category_id": derefString(reallocation.TargetCategoryID),
+			}).Info("dry-run reallocation completion")
+			continue
+		}
+
+		moved, err := fundReallocationRepo.CompleteReallocation(ctx, reallocation.ID)
+		if err != nil {
+			logger.WithError(err).WithField("reallocation_id", reallocation.ID).Warn("failed to complete fund reallocation")
+			skippedCount++
+			continue
+		}
+
+		completedCount++
+		movedCents += moved
+	}
+
+	logger.WithFields(logrus.Fields{
+		"processed":   len(reallocations),
+		"completed":   completedCount,
+		"skipped":     skippedCount,
+		"moved_cents": movedCents,
+		"dry_run":     dryRun,
+	}).Info("fund reallocation run complete")
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"christjesus/internal/db"
+	"christjesus/internal/store"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var expireRestrictionsCommand = &cli.Command{
+	Name:  "expire-restrictions",
+	Usage: "Lift suspensions that have run out and show the needs they hid",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "limit",
+			Value: 100,
+			Usage: "Maximum number of suspensions to lift in one run",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Log expired suspensions without lifting them",
+		},
+	},
+	Action: expireRestrictions,
+}
+
+func expireRestrictions(cCtx *cli.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	ctx := cCtx.Context
+
+	pool, err := db.Connect(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	restrictionRepo := store.NewUserRestrictionRepository(pool)
+	needsRepo := store.NewNeedRepository(pool)
+
+	limit := cCtx.Int("limit")
+	if limit <= 0 {
+		limit = 100
+	}
+
+	dryRun := cCtx.Bool("dry-run")
+
+	restrictions, err := restrictionRepo.ExpiredRestrictions(ctx, time.Now(), limit)
+	if err != nil {
+		return fmt.Errorf("failed to query expired restrictions: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"limit":   limit,
+		"matched": len(restrictions),
+		"dry_run": dryRun,
+	}).Info("loaded expired restrictions")
+
+	var liftedCount int
+	var skippedCount int
+	var unhiddenCount int
+
+	for _, restriction := range restrictions {
+		if dryRun {
+			logger.WithFields(logrus.Fields{
+				"restriction_id": restriction.ID,
+				"user_id":        restriction.UserID,
+				"expires_at":     restriction.ExpiresAt,
+			}).Info("dry-run restriction expiry")
+			continue
+		}
+
+		var unhidden int
+		err := store.WithTx(ctx, restrictionRepo, func(tx pgx.Tx) error {
+			// Lifting fails if an admin lifted it since the query ran.
+			if err := restrictionRepo.LiftRestrictionTx(ctx, tx, restriction.ID, nil, "suspension expired"); err != nil {
+				return err
+			}
+
+			var err error
+			unhidden, err = needsRepo.UnhideNeedsByRestrictionTx(ctx, tx, restriction.ID)
+			return err
+		})
+		if err != nil {
+			logger.WithError(err).WithField("restriction_id", restriction.ID).Warn("failed to lift expired restriction")
+			skippedCount++
+			continue
+		}
+
+		liftedCount++
+		unhiddenCount += unhidden
+	}
+
+	logger.WithFields(logrus.Fields{
+		"processed":      len(restrictions),
+		"lifted":         liftedCount,
+		"skipped":        skippedCount,
+		"needs_unhidden": unhiddenCount,
+		"dry_run":        dryRun,
+	}).Info("restriction expiry run complete")
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"christjesus/internal/db"
@@ -17,7 +18,10 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-const censusZCTAURL = "https://www2.census.gov/geo/docs/maps-data/data/gazetteer/2024_Gazetteer/2024_Gaz_zcta_national.zip"
+const (
+	censusZCTAURL       = "https://www2.census.gov/geo/docs/maps-data/data/gazetteer/2024_Gazetteer/2024_Gaz_zcta_national.zip"
+	censusZCTACountyURL = "https://www2.census.gov/geo/docs/maps-data/data/rel2020/zcta520/tab20_zcta520_county20_natl.txt"
+)
 
 var importZipsCommand = &cli.Command{
 	Name:  "import-zips",
@@ -28,6 +32,11 @@ var importZipsCommand = &cli.Command{
 			Value: censusZCTAURL,
 			Usage: "URL of the Census ZCTA gazetteer ZIP archive",
 		},
+		&cli.StringFlag{
+			Name:  "county-url",
+			Value: censusZCTACountyURL,
+			Usage: "URL of the Census ZCTA-to-county relationship file; empty skips county names",
+		},
 	},
 	Action: importZips,
 }
@@ -56,6 +65,21 @@ func importZips(cCtx *cli.Context) error {
 
 	logrus.WithField("rows", len(rows)).Info("parsed ZCTA centroids")
 
+	if countyURL := cCtx.String("county-url"); countyURL != "" {
+		logrus.WithField("url", countyURL).Info("downloading ZCTA county relationship file")
+
+		countyByZip, err := downloadAndParseZCTACounties(ctx, countyURL)
+		if err != nil {
+			return fmt.Errorf("failed to download and parse ZCTA county data: %w", err)
+		}
+
+		for i := range rows {
+			rows[i].CountyName = countyByZip[rows[i].ZipCode]
+		}
+
+		logrus.WithField("zips", len(countyByZip)).Info("parsed ZCTA county names")
+	}
+
 	if err := loadZipCentroids(ctx, pool, rows); err != nil {
 		return fmt.Errorf("failed to load zip centroids: %w", err)
 	}
@@ -65,9 +89,10 @@ func importZips(cCtx *cli.Context) error {
 }
 
 type zipCentroid struct {
-	ZipCode   string
-	Latitude  string
-	Longitude string
+	ZipCode    string
+	Latitude   string
+	Longitude  string
+	CountyName string
 }
 
 func downloadAndParseZCTA(ctx context.Context, url string) ([]zipCentroid, error) {
@@ -170,6 +195,89 @@ func parseZCTAFile(r io.Reader) ([]zipCentroid, error) {
 	return rows, nil
 }
 
+func downloadAndParseZCTACounties(ctx context.Context, url string) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return parseZCTACountyFile(resp.Body)
+}
+
+// parseZCTACountyFile reads the pipe-delimited ZCTA-to-county relationship
+// file. A ZCTA can straddle counties; the one holding most of its land area
+// wins.
+func parseZCTACountyFile(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = '|'
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	colIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		colIndex[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+
+	zipIdx, ok := colIndex["GEOID_ZCTA5_20"]
+	if !ok {
+		return nil, fmt.Errorf("missing GEOID_ZCTA5_20 column")
+	}
+	countyIdx, ok := colIndex["NAMELSAD_COUNTY_20"]
+	if !ok {
+		return nil, fmt.Errorf("missing NAMELSAD_COUNTY_20 column")
+	}
+	areaIdx, ok := colIndex["AREALAND_PART"]
+	if !ok {
+		return nil, fmt.Errorf("missing AREALAND_PART column")
+	}
+
+	countyByZip := make(map[string]string)
+	largestArea := make(map[string]int64)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+		if len(record) <= max(zipIdx, countyIdx, areaIdx) {
+			continue
+		}
+
+		zipCode := strings.TrimSpace(record[zipIdx])
+		countyName := strings.TrimSpace(record[countyIdx])
+		if zipCode == "" || countyName == "" {
+			continue
+		}
+
+		area, _ := strconv.ParseInt(strings.TrimSpace(record[areaIdx]), 10, 64)
+		if current, seen := largestArea[zipCode]; seen && current >= area {
+			continue
+		}
+
+		largestArea[zipCode] = area
+		countyByZip[zipCode] = countyName
+	}
+
+	return countyByZip, nil
+}
+
 func loadZipCentroids(ctx context.Context, pool interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
 }, rows []zipCentroid) error {
@@ -186,9 +294,13 @@ func loadZipCentroids(ctx context.Context, pool interface {
 	copyCount, err := tx.CopyFrom(
 		ctx,
 		pgx.Identifier{"christjesus", "zip_centroids"},
-		[]string{"zip_code", "latitude", "longitude"},
+		[]string{"zip_code", "latitude", "longitude", "county_name"},
 		pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
-			return []any{rows[i].ZipCode, rows[i].Latitude, rows[i].Longitude}, nil
+			var countyName *string
+			if rows[i].CountyName != "" {
+				countyName = &rows[i].CountyName
+			}
+			return []any{rows[i].ZipCode, rows[i].Latitude, rows[i].Longitude, countyName}, nil
 		}),
 	)
 	if err != nil {
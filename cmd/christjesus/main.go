@@ -15,6 +15,11 @@ func main() {
 			serveCommand,
 			seedCommand,
 			reconcileDonationsCommand,
+			completeReallocationsCommand,
+			releaseStaleClaimsCommand,
+			expireRestrictionsCommand,
+			unfeatureNeedsCommand,
+			bootstrapSuperadminCommand,
 			nanoidCommand,
 			importZipsCommand,
 			e2eResetCommand,
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"christjesus/internal/db"
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var releaseStaleClaimsCommand = &cli.Command{
+	Name:  "release-stale-claims",
+	Usage: "Return claimed needs to the review queue when the reviewer has gone idle",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "limit",
+			Value: 100,
+			Usage: "Maximum number of claims to release in one run",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Log stale claims without releasing them",
+		},
+	},
+	Action: releaseStaleClaims,
+}
+
+func releaseStaleClaims(cCtx *cli.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	ctx := cCtx.Context
+
+	pool, err := db.Connect(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	needsRepo := store.NewNeedRepository(pool)
+	progressRepo := store.NewNeedProgressRepository(pool)
+
+	limit := cCtx.Int("limit")
+	if limit <= 0 {
+		limit = 100
+	}
+
+	staleHours := cfg.ReviewClaimStaleHours
+	if staleHours <= 0 {
+		staleHours = 24
+	}
+
+	dryRun := cCtx.Bool("dry-run")
+	cutoff := time.Now().Add(-time.Duration(staleHours) * time.Hour)
+
+	needs, err := needsRepo.StaleReviewerClaims(ctx, cutoff, limit)
+	if err != nil {
+		return fmt.Errorf("failed to query stale reviewer claims: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"limit":       limit,
+		"matched":     len(needs),
+		"stale_hours": staleHours,
+		"dry_run":     dryRun,
+	}).Info("loaded stale reviewer claims")
+
+	var releasedCount int
+	var skippedCount int
+
+	for _, need := range needs {
+		reviewerUserID := derefString(need.ReviewerUserID)
+		if dryRun {
+			logger.WithFields(logrus.Fields{
+				"need_id":          need.ID,
+				"reviewer_user_id": reviewerUserID,
+				"assigned_at":      need.AssignedAt,
+			}).Info("dry-run stale claim release")
+			continue
+		}
+
+		err := store.WithTx(ctx, needsRepo, func(tx pgx.Tx) error {
+			current, err := needsRepo.NeedReviewerForUpdateTx(ctx, tx, need.ID)
+			if err != nil {
+				return err
+			}
+			if current == nil || *current != reviewerUserID {
+				// Claimed, released or reassigned since the query ran.
+				return types.ErrNeedNotClaimed
+			}
+
+			if err := needsRepo.SetNeedReviewerTx(ctx, tx, need.ID, nil); err != nil {
+				return err
+			}
+
+			note := fmt.Sprintf("%s's claim released after %dh without reviewer activity", reviewerUserID, staleHours)
+			_, err = progressRepo.RecordSystemModerationActionEventTx(ctx, tx, need.ID, types.NeedModerationActionTypeReviewClaimExpired, &note)
+			return err
+		})
+		if err != nil {
+			logger.WithError(err).WithField("need_id", need.ID).Warn("failed to release stale reviewer claim")
+			skippedCount++
+			continue
+		}
+
+		releasedCount++
+	}
+
+	logger.WithFields(logrus.Fields{
+		"processed": len(needs),
+		"released":  releasedCount,
+		"skipped":   skippedCount,
+		"dry_run":   dryRun,
+	}).Info("stale reviewer claim run complete")
+
+	return nil
+}
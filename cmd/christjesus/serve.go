@@ -74,12 +74,19 @@ func serve(cCtx *cli.Context) error {
 	defer pool.Close()
 
 	needsRepo := store.NewNeedRepository(pool)
+	needsRepo.SecondApprovalThresholdCents = config.SecondApprovalThresholdCents
 	progressRepo := store.NewNeedProgressRepository(pool)
 	categoryRepo := store.NewCategoryRepository(pool)
 	needCategoryAssignmentsRepo := store.NewAssignmentRepository(pool)
 	storyRepo := store.NewStoryRepository(pool)
 	documentRepo := store.NewDocumentRepository(pool)
 	needReviewMessageRepo := store.NewNeedReviewMessageRepository(pool)
+	needRevisionRepo := store.NewNeedRevisionRepository(pool)
+	needFlagRepo := store.NewNeedFlagRepository(pool)
+	needGoalChangeRepo := store.NewNeedGoalChangeRepository(pool)
+	fundReallocationRepo := store.NewFundReallocationRepository(pool)
+	needLineItemRepo := store.NewNeedLineItemRepository(pool)
+	shareLinkRepo := store.NewShareLinkRepository(pool)
 	userAddressRepo := store.NewUserAddressRepository(pool)
 	userRepo := store.NewUserRepository(pool)
 	donorPreferenceRepo := store.NewDonorPreferenceRepository(pool)
@@ -87,6 +94,12 @@ func serve(cCtx *cli.Context) error {
 	donationIntentRepo := store.NewDonationIntentRepository(pool)
 	savedNeedRepo := store.NewSavedNeedRepository(pool)
 	emailRepo := store.NewEmailRepository(pool)
+	adminRoleRepo := store.NewAdminRoleRepository(pool)
+	reviewResponseRepo := store.NewReviewResponseRepository(pool)
+	adminAuditRepo := store.NewAdminAuditRepository(pool)
+	userRestrictionRepo := store.NewUserRestrictionRepository(pool)
+	needFeatureRepo := store.NewNeedFeatureRepository(pool)
+	adminNoteRepo := store.NewAdminNoteRepository(pool)
 	emailSender, err := email.NewResendSender(config.ResendAPIKey)
 	if err != nil {
 		return fmt.Errorf("failed to initialize email sender: %w", err)
@@ -120,6 +133,12 @@ func serve(cCtx *cli.Context) error {
 		StoryRepo:                   storyRepo,
 		DocumentRepo:                documentRepo,
 		NeedReviewMessageRepo:       needReviewMessageRepo,
+		NeedRevisionRepo:            needRevisionRepo,
+		NeedFlagRepo:                needFlagRepo,
+		NeedGoalChangeRepo:          needGoalChangeRepo,
+		FundReallocationRepo:        fundReallocationRepo,
+		NeedLineItemRepo:            needLineItemRepo,
+		ShareLinkRepo:               shareLinkRepo,
 		UserAddressRepo:             userAddressRepo,
 		UserRepo:                    userRepo,
 		DonorPreferenceRepo:         donorPreferenceRepo,
@@ -127,6 +146,12 @@ func serve(cCtx *cli.Context) error {
 		DonationIntentRepo:          donationIntentRepo,
 		SavedNeedRepo:               savedNeedRepo,
 		EmailRepo:                   emailRepo,
+		AdminRoleRepo:               adminRoleRepo,
+		ReviewResponseRepo:          reviewResponseRepo,
+		AdminAuditRepo:              adminAuditRepo,
+		UserRestrictionRepo:         userRestrictionRepo,
+		NeedFeatureRepo:             needFeatureRepo,
+		AdminNoteRepo:               adminNoteRepo,
 		EmailSender:                 emailSender,
 		JWKCache:                    jwkCache,
 		JWKSURL:                     jwksURL,
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+
+	"christjesus/internal/db"
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var unfeatureNeedsCommand = &cli.Command{
+	Name:  "unfeature-needs",
+	Usage: "End featuring for needs that are funded, closed or deleted",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "limit",
+			Value: 100,
+			Usage: "Maximum number of needs to unfeature in one run",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Log needs that would be unfeatured without changing them",
+		},
+	},
+	Action: unfeatureNeeds,
+}
+
+func unfeatureNeeds(cCtx *cli.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	ctx := cCtx.Context
+
+	pool, err := db.Connect(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	featureRepo := store.NewNeedFeatureRepository(pool)
+	needsRepo := store.NewNeedRepository(pool)
+
+	limit := cCtx.Int("limit")
+	if limit <= 0 {
+		limit = 100
+	}
+
+	dryRun := cCtx.Bool("dry-run")
+
+	needs, err := featureRepo.NeedsToUnfeature(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("failed to query needs to unfeature: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"limit":   limit,
+		"matched": len(needs),
+		"dry_run": dryRun,
+	}).Info("loaded needs to unfeature")
+
+	var unfeaturedCount int
+	var skippedCount int
+	var slotsEndedCount int
+
+	for _, need := range needs {
+		reason := unfeatureReason(need)
+
+		if dryRun {
+			logger.WithFields(logrus.Fields{
+				"need_id": need.ID,
+				"status":  need.Status,
+				"reason":  reason,
+			}).Info("dry-run need unfeature")
+			continue
+		}
+
+		var ended int
+		err := store.WithTx(ctx, featureRepo, func(tx pgx.Tx) error {
+			var err error
+			ended, err = featureRepo.EndSlotsForNeedTx(ctx, tx, need.ID, nil, reason)
+			if err != nil {
+				return err
+			}
+			return needsRepo.UnfeatureNeedTx(ctx, tx, need.ID)
+		})
+		if err != nil {
+			logger.WithError(err).WithField("need_id", need.ID).Warn("failed to unfeature need")
+			skippedCount++
+			continue
+		}
+
+		unfeaturedCount++
+		slotsEndedCount += ended
+	}
+
+	logger.WithFields(logrus.Fields{
+		"processed":   len(needs),
+		"unfeatured":  unfeaturedCount,
+		"skipped":     skippedCount,
+		"slots_ended": slotsEndedCount,
+		"dry_run":     dryRun,
+	}).Info("need unfeature run complete")
+
+	return nil
+}
+
+// unfeatureReason is recorded on each slot the job ends.
+func unfeatureReason(need *types.Need) string {
+	switch {
+	case need.DeletedAt != nil:
+		return "need deleted"
+	case need.Status == types.NeedStatusFunded:
+		return "need funded"
+	default:
+		return "need closed"
+	}
+}
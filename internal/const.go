@@ -7,10 +7,9 @@ const (
 	COOKIE_REGISTER_CONFIRM  = "cja_register_confirm"
 	COOKIE_AUTH_STATE        = "cja_auth_state"
 	COOKIE_AUTH_NONCE        = "cja_auth_nonce"
+	COOKIE_SHARE_REFERRAL    = "cja_share_ref"
 )
 
 const (
-	AuthAdminClaim       string = "https://christjesus.app/claims/roles"
-	AuthAdminValue       string = "admin"
 	AuthDisplayNameClaim string = "https://christjesus.app/claims/display_name"
 )
@@ -0,0 +1,48 @@
+// Package qrcode encodes short payloads such as URLs into QR symbols for the
+// share card and flyer renderers. Encoding is done by boombuler/barcode; this
+// package only exposes the module grid so callers can draw it at any scale.
+package qrcode
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/boombuler/barcode/qr"
+)
+
+// Code is an encoded QR symbol. Modules are addressed by column (x) and
+// row (y), both in the range [0, Size). The quiet zone is not included.
+type Code struct {
+	Size int
+
+	modules [][]bool
+}
+
+// Encode builds the smallest QR symbol at error correction level M that
+// holds data.
+func Encode(data string) (*Code, error) {
+	symbol, err := qr.Encode(data, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("qrcode: %w", err)
+	}
+
+	bounds := symbol.Bounds()
+	code := &Code{Size: bounds.Dx(), modules: make([][]bool, bounds.Dy())}
+	for y := range code.modules {
+		code.modules[y] = make([]bool, code.Size)
+		for x := range code.modules[y] {
+			gray := color.GrayModel.Convert(symbol.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+			code.modules[y][x] = gray.Y < 0x80
+		}
+	}
+
+	return code, nil
+}
+
+// Dark reports whether the module at column x, row y is dark.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
@@ -0,0 +1,74 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncode_PicksSmallestVersion(t *testing.T) {
+	cases := map[int]string{
+		1: "https://x.co",
+		4: "https://bodyofchrist.example/need/abcdefghijklmnop/donate",
+	}
+
+	for version, data := range cases {
+		code, err := Encode(data)
+		if err != nil {
+			t.Fatalf("encode %q: %v", data, err)
+		}
+		if code.Size != version*4+17 {
+			t.Fatalf("encode %q: expected version %d (size %d), got size %d", data, version, version*4+17, code.Size)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 3000)); err == nil {
+		t.Fatalf("expected an error for data that does not fit any version")
+	}
+}
+
+func TestEncode_FinderPatterns(t *testing.T) {
+	code, err := Encode("https://x.co")
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		for dy := 0; dy < 7; dy++ {
+			for dx := 0; dx < 7; dx++ {
+				ring := max(abs(dx-3), abs(dy-3))
+				want := ring != 2
+				if got := code.Dark(corner[0]+dx, corner[1]+dy); got != want {
+					t.Fatalf("finder at %v: module (%d,%d) dark=%v want %v", corner, dx, dy, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestEncode_Orientation(t *testing.T) {
+	code, err := Encode("https://bodyofchrist.example/need/abcdefghijklmnop/donate")
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	// The dark module sits beside the bottom-left finder at column 8; a
+	// transposed grid would move it next to the top-right finder instead.
+	if !code.Dark(8, code.Size-8) {
+		t.Fatalf("expected the dark module at (8,%d)", code.Size-8)
+	}
+
+	// Timing patterns alternate along row 6 and column 6 between the finders.
+	for i := 8; i < code.Size-8; i++ {
+		want := i%2 == 0
+		if code.Dark(i, 6) != want || code.Dark(6, i) != want {
+			t.Fatalf("timing pattern broken at %d", i)
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -18,6 +18,9 @@ import (
 // To add a category: Add it to the list with a new ID and run `just seed`
 // To remove a category: Remove it from the list and run `just seed` (auto-deleted from DB)
 // To update a category: Edit the fields and run `just seed`
+//
+// Seeding overwrites edits made on the admin categories page and deletes
+// categories created there, so it is meant for development databases.
 func SeedCategories(ctx context.Context, repo *store.CategoryRepository) error {
 	// Define seed data with fixed IDs
 	// compile-time safe - if NeedCategory type changes, this won't compile
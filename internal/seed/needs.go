@@ -227,7 +227,7 @@ func pickWeightedStatus(rng *rand.Rand) types.NeedStatus {
 func stepForStatus(status types.NeedStatus, rng *rand.Rand) types.NeedStep {
 	switch status {
 	case types.NeedStatusDraft:
-		steps := []types.NeedStep{types.NeedStepWelcome, types.NeedStepLocation, types.NeedStepCategories, types.NeedStepStory, types.NeedStepDocuments, types.NeedStepReview}
+		steps := []types.NeedStep{types.NeedStepWelcome, types.NeedStepLocation, types.NeedStepCategories, types.NeedStepStory, types.NeedStepBudget, types.NeedStepDocuments, types.NeedStepReview}
 		return steps[rng.Intn(len(steps))]
 	case types.NeedStatusSubmitted, types.NeedStatusUnderReview:
 		return types.NeedStepReview
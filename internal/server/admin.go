@@ -1,14 +1,239 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"christjesus/pkg/types"
 )
 
+const (
+	adminDashboardUnansweredLimit = 5
+	adminQueueAgeAlert            = 72 * time.Hour
+	adminUnansweredPreviewChars   = 140
+)
+
 func (s *Service) handleGetAdminDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	now := time.Now()
+
+	queueCount, err := s.needsRepo.ModerationQueueNeedsCount(ctx, "")
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count moderation queue for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	oldestSubmittedAt, err := s.needsRepo.OldestModerationQueueSubmittedAt(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch moderation queue age for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	statusCounts, err := s.needsRepo.AdminExplorerNeedsCountByStatus(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch need status counts for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	weekCount, weekCents, err := s.donationIntentRepo.DonationVolumeSince(ctx, now.AddDate(0, 0, -7))
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch 7 day donation volume for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	monthCount, monthCents, err := s.donationIntentRepo.DonationVolumeSince(ctx, now.AddDate(0, 0, -30))
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch 30 day donation volume for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	intentCounts, err := s.donationIntentRepo.IntentCountsByStatus(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch donation intent counts for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	emailSince := now.AddDate(0, 0, -30)
+	bounceCount, err := s.emailRepo.EmailEventCountSince(ctx, types.EmailEventTypeBounced, emailSince)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count email bounces for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	complaintCount, err := s.emailRepo.EmailEventCountSince(ctx, types.EmailEventTypeComplained, emailSince)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count email complaints for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	unansweredCount, err := s.needReviewMessageRepo.UnansweredMessagesCount(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count unanswered review messages for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	unanswered, err := s.needReviewMessageRepo.OldestUnansweredMessages(ctx, adminDashboardUnansweredLimit)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch unanswered review messages for admin dashboard")
+		s.internalServerError(w)
+		return
+	}
+
+	queueDetail := "Queue is empty"
+	queueAlert := false
+	if oldestSubmittedAt != nil {
+		waiting := now.Sub(*oldestSubmittedAt)
+		queueDetail = "Oldest waiting " + formatWaitDuration(waiting)
+		queueAlert = waiting >= adminQueueAgeAlert
+	}
+
+	secondApprovalCount := statusCounts[types.NeedStatusAwaitingSecondApproval]
+	queueTiles := []*types.AdminDashboardTile{
+		{
+			Label:   "Review Queue",
+			Value:   strconv.Itoa(queueCount),
+			Detail:  queueDetail,
+			Href:    s.route(RouteAdminNeeds),
+			IsAlert: queueAlert,
+		},
+		{
+			Label:   "Second Approval",
+			Value:   strconv.Itoa(secondApprovalCount),
+			Detail:  "Approved once, not yet published",
+			Href:    s.route(RouteAdminNeedsSecondApproval),
+			IsAlert: secondApprovalCount > 0,
+		},
+		{
+			Label:   "Unanswered Messages",
+			Value:   strconv.Itoa(unansweredCount),
+			Detail:  "Owner replied last",
+			Href:    "#unanswered-messages",
+			IsAlert: unansweredCount > 0,
+		},
+	}
+
+	statusTiles := make([]*types.AdminDashboardTile, 0, len(adminExplorerStatusOptions()))
+	for _, option := range adminExplorerStatusOptions() {
+		if option.Value == "" {
+			continue
+		}
+
+		v := url.Values{}
+		v.Set("status", option.Value)
+		statusTiles = append(statusTiles, &types.AdminDashboardTile{
+			Label: option.Label,
+			Value: strconv.Itoa(statusCounts[types.NeedStatus(option.Value)]),
+			Href:  s.routeWithQuery(RouteAdminNeedExplorer, v),
+		})
+	}
+
+	donationsHref := func(status, window string) string {
+		v := url.Values{}
+		if status != "" {
+			v.Set("status", status)
+		}
+		if window != "" {
+			v.Set("window", window)
+		}
+		return s.routeWithQuery(RouteAdminDonations, v)
+	}
+
+	pendingCount := intentCounts[types.DonationPaymentStatusPending]
+	failedCount := intentCounts[types.DonationPaymentStatusFailed]
+	donationTiles := []*types.AdminDashboardTile{
+		{
+			Label:  "Last 7 Days",
+			Value:  formatUSDFromCents(weekCents),
+			Detail: fmt.Sprintf("%d gifts", weekCount),
+			Href:   donationsHref(types.DonationPaymentStatusFinalized, "7"),
+		},
+		{
+			Label:  "Last 30 Days",
+			Value:  formatUSDFromCents(monthCents),
+			Detail: fmt.Sprintf("%d gifts", monthCount),
+			Href:   donationsHref(types.DonationPaymentStatusFinalized, "30"),
+		},
+		{
+			Label:  "Pending Intents",
+			Value:  strconv.Itoa(pendingCount),
+			Detail: "Awaiting payment confirmation",
+			Href:   donationsHref(types.DonationPaymentStatusPending, ""),
+		},
+		{
+			Label:   "Failed Intents",
+			Value:   strconv.Itoa(failedCount),
+			Detail:  "Payment did not complete",
+			Href:    donationsHref(types.DonationPaymentStatusFailed, ""),
+			IsAlert: failedCount > 0,
+		},
+	}
+
+	emailEventsHref := func(eventType string) string {
+		v := url.Values{}
+		v.Set("type", eventType)
+		v.Set("window", "30")
+		return s.routeWithQuery(RouteAdminEmailEvents, v)
+	}
+
+	emailTiles := []*types.AdminDashboardTile{
+		{
+			Label:   "Bounces",
+			Value:   strconv.Itoa(bounceCount),
+			Detail:  "Last 30 days",
+			Href:    emailEventsHref(types.EmailEventTypeBounced),
+			IsAlert: bounceCount > 0,
+		},
+		{
+			Label:   "Complaints",
+			Value:   strconv.Itoa(complaintCount),
+			Detail:  "Last 30 days",
+			Href:    emailEventsHref(types.EmailEventTypeComplained),
+			IsAlert: complaintCount > 0,
+		},
+	}
+
+	messages := make([]*types.AdminDashboardMessage, 0, len(unanswered))
+	for _, message := range unanswered {
+		if message == nil {
+			continue
+		}
+		messages = append(messages, &types.AdminDashboardMessage{
+			NeedID:     message.NeedID,
+			Preview:    truncateShareDescription(message.Body, adminUnansweredPreviewChars),
+			Waiting:    formatWaitDuration(now.Sub(message.CreatedAt)),
+			ReviewHref: s.route(RouteAdminNeedReview, Param("needID", message.NeedID)),
+		})
+	}
+
 	data := &types.AdminDashboardPageData{
-		BasePageData: types.BasePageData{Title: "Admin"},
+		BasePageData:        types.BasePageData{Title: "Admin"},
+		QueueTiles:          queueTiles,
+		StatusTiles:         statusTiles,
+		DonationTiles:       donationTiles,
+		EmailTiles:          emailTiles,
+		UnansweredMessages:  messages,
+		UnansweredCount:     unansweredCount,
+		CanViewUsers:        sessionCan(r, adminPermissionUsersView),
+		CanManageFunds:      sessionCan(r, adminPermissionFundsManage),
+		CanManageResponses:  sessionCan(r, adminPermissionNeedsModerate),
+		CanManageRoles:      sessionCan(r, adminPermissionRolesManage),
+		CanViewAudit:        sessionCan(r, adminPermissionAuditView),
+		CanViewEmails:       sessionCan(r, adminPermissionEmailsView),
+		CanManageCategories: sessionCan(r, adminPermissionCategoriesManage),
+		CanManageFeaturing:  sessionCan(r, adminPermissionNeedsModerate),
 	}
 
 	if err := s.renderTemplate(w, r, "page.admin.dashboard", data); err != nil {
@@ -17,3 +242,50 @@ func (s *Service) handleGetAdminDashboard(w http.ResponseWriter, r *http.Request
 		return
 	}
 }
+
+// formatWaitDuration renders how long something has been waiting in days and
+// hours, e.g. "3d 4h".
+func formatWaitDuration(d time.Duration) string {
+	if d < time.Hour {
+		return "under 1h"
+	}
+
+	hours := int(d / time.Hour)
+	days, hours := hours/24, hours%24
+	switch {
+	case days == 0:
+		return fmt.Sprintf("%dh", hours)
+	case hours == 0:
+		return fmt.Sprintf("%dd", days)
+	default:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+}
+
+// adminWindowSince resolves a window filter in days to its canonical value and
+// start time. Unknown or empty values mean all time.
+func adminWindowSince(raw string, now time.Time) (string, *time.Time) {
+	selected := strings.TrimSpace(raw)
+	for _, option := range adminWindowOptions() {
+		if option.Value == "" || option.Value != selected {
+			continue
+		}
+		days, err := strconv.Atoi(option.Value)
+		if err != nil {
+			break
+		}
+		since := now.AddDate(0, 0, -days)
+		return selected, &since
+	}
+
+	return "", nil
+}
+
+func adminWindowOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: "7", Label: "Last 7 days"},
+		{Value: "30", Label: "Last 30 days"},
+		{Value: "90", Label: "Last 90 days"},
+		{Value: "", Label: "All time"},
+	}
+}
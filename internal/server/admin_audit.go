@@ -0,0 +1,357 @@
+package server
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+)
+
+const adminAuditPageSize = 50
+
+// requestClientIP returns the caller's address. When header is configured the
+// edge proxy's value is trusted; otherwise the connection's remote address is
+// used.
+func requestClientIP(r *http.Request, header string) string {
+	if header = strings.TrimSpace(header); header != "" {
+		if value := strings.TrimSpace(r.Header.Get(header)); value != "" {
+			first, _, _ := strings.Cut(value, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return strings.TrimSpace(r.RemoteAddr)
+	}
+	return host
+}
+
+// adminAuditEvent builds an event for the signed-in actor of r. It returns
+// nil when the request carries no session.
+func (s *Service) adminAuditEvent(r *http.Request, action types.AdminAuditAction, targetType types.AdminAuditTargetType, targetID, detail string) *types.AdminAuditEvent {
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		return nil
+	}
+
+	event := &types.AdminAuditEvent{
+		ActorUserID: session.UserID,
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    targetID,
+	}
+	if ip := requestClientIP(r, s.config.ClientIPHeader); ip != "" {
+		event.IPAddress = &ip
+	}
+	if detail = strings.TrimSpace(detail); detail != "" {
+		event.Detail = &detail
+	}
+	return event
+}
+
+// recordAdminAudit writes an audit event outside any transaction. Failures
+// are logged rather than surfaced; the action itself has already happened.
+func (s *Service) recordAdminAudit(r *http.Request, action types.AdminAuditAction, targetType types.AdminAuditTargetType, targetID, detail string) {
+	event := s.adminAuditEvent(r, action, targetType, targetID, detail)
+	if event == nil {
+		s.logger.WithField("action", action).WithField("target_id", targetID).Error("admin audit event has no actor")
+		return
+	}
+
+	if err := s.adminAuditRepo.RecordEvent(r.Context(), event); err != nil {
+		s.logger.WithError(err).
+			WithField("action", action).
+			WithField("target_type", targetType).
+			WithField("target_id", targetID).
+			Error("failed to record admin audit event")
+	}
+}
+
+// AuditAdminRequest records a read of the record named by the targetParam path
+// value before the handler runs. If the read cannot be recorded the request is
+// refused, so no sensitive record is served without an audit row. It sits
+// under RequireAdmin so the actor is always known.
+func (s *Service) AuditAdminRequest(action types.AdminAuditAction, targetType types.AdminAuditTargetType, targetParam string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			targetID := strings.TrimSpace(r.PathValue(targetParam))
+			if targetID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			event := s.adminAuditEvent(r, action, targetType, targetID, r.URL.Path)
+			if event == nil {
+				s.logger.Error("session not found on context")
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			if err := s.adminAuditRepo.RecordEvent(r.Context(), event); err != nil {
+				s.logger.WithError(err).
+					WithField("action", action).
+					WithField("target_type", targetType).
+					WithField("target_id", targetID).
+					Error("failed to record admin audit event")
+				s.internalServerError(w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// parseAdminAuditFilter reads the audit page filters. Dates are whole days in
+// UTC; the "to" day is inclusive. Unknown actions and target types are
+// dropped so the form never shows a value it cannot select.
+func parseAdminAuditFilter(values url.Values) store.AdminAuditFilter {
+	filter := store.AdminAuditFilter{
+		ActorUserID: strings.TrimSpace(values.Get("actor")),
+		TargetID:    strings.TrimSpace(values.Get("target_id")),
+	}
+
+	if action := types.AdminAuditAction(strings.TrimSpace(values.Get("action"))); slices.Contains(types.AdminAuditActions, action) {
+		filter.Action = string(action)
+	}
+	if targetType := types.AdminAuditTargetType(strings.TrimSpace(values.Get("target_type"))); slices.Contains(types.AdminAuditTargetTypes, targetType) {
+		filter.TargetType = string(targetType)
+	}
+	if from, err := time.Parse(time.DateOnly, strings.TrimSpace(values.Get("from"))); err == nil {
+		filter.Since = &from
+	}
+	if to, err := time.Parse(time.DateOnly, strings.TrimSpace(values.Get("to"))); err == nil {
+		until := to.AddDate(0, 0, 1)
+		filter.Until = &until
+	}
+
+	return filter
+}
+
+// adminAuditFilterValues is the inverse of parseAdminAuditFilter, used to keep
+// filters across pagination and export links.
+func adminAuditFilterValues(filter store.AdminAuditFilter) url.Values {
+	v := url.Values{}
+	if filter.ActorUserID != "" {
+		v.Set("actor", filter.ActorUserID)
+	}
+	if filter.Action != "" {
+		v.Set("action", filter.Action)
+	}
+	if filter.TargetType != "" {
+		v.Set("target_type", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		v.Set("target_id", filter.TargetID)
+	}
+	if filter.Since != nil {
+		v.Set("from", filter.Since.Format(time.DateOnly))
+	}
+	if filter.Until != nil {
+		v.Set("to", filter.Until.AddDate(0, 0, -1).Format(time.DateOnly))
+	}
+	return v
+}
+
+func (s *Service) handleGetAdminAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter := parseAdminAuditFilter(r.URL.Query())
+	page := parsePositiveInt(r.URL.Query().Get("page"), 1)
+
+	totalEvents, err := s.adminAuditRepo.EventsCount(ctx, filter)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count admin audit events")
+		s.internalServerError(w)
+		return
+	}
+
+	totalPages := totalEvents / adminAuditPageSize
+	if totalEvents%adminAuditPageSize != 0 {
+		totalPages++
+	}
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page > totalPages {
+		page = totalPages
+	}
+
+	events, err := s.adminAuditRepo.EventsPage(ctx, filter, page, adminAuditPageSize)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch admin audit events")
+		s.internalServerError(w)
+		return
+	}
+
+	actorIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		actorIDs = append(actorIDs, event.ActorUserID)
+	}
+	actors, err := s.userRepo.UsersByIDs(ctx, actorIDs)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch actors for admin audit events")
+		s.internalServerError(w)
+		return
+	}
+	actorsByID := make(map[string]*types.User, len(actors))
+	for _, actor := range actors {
+		actorsByID[actor.ID] = actor
+	}
+
+	items := make([]*types.AdminAuditItem, 0, len(events))
+	for _, event := range events {
+		actorName := event.ActorUserID
+		if actor := actorsByID[event.ActorUserID]; actor != nil {
+			actorName = userDisplayName(actor)
+		}
+
+		items = append(items, &types.AdminAuditItem{
+			When:       event.CreatedAt.Format("2006-01-02 15:04:05"),
+			ActorID:    event.ActorUserID,
+			ActorName:  actorName,
+			ActorHref:  s.route(RouteAdminUserDetail, Param("userID", event.ActorUserID)),
+			Action:     string(event.Action),
+			TargetType: string(event.TargetType),
+			TargetID:   event.TargetID,
+			TargetHref: s.adminAuditTargetHref(event),
+			IPAddress:  formatOptionalString(event.IPAddress),
+			Detail:     derefString(event.Detail),
+		})
+	}
+
+	filterValues := adminAuditFilterValues(filter)
+	buildPageHref := func(p int) string {
+		v := adminAuditFilterValues(filter)
+		v.Set("page", strconv.Itoa(p))
+		return s.routeWithQuery(RouteAdminAudit, v)
+	}
+
+	prevHref := ""
+	if page > 1 {
+		prevHref = buildPageHref(page - 1)
+	}
+	nextHref := ""
+	if page < totalPages {
+		nextHref = buildPageHref(page + 1)
+	}
+
+	actionOptions := make([]types.AdminExplorerOption, 0, len(types.AdminAuditActions))
+	for _, action := range types.AdminAuditActions {
+		actionOptions = append(actionOptions, types.AdminExplorerOption{Value: string(action), Label: string(action)})
+	}
+	targetTypeOptions := make([]types.AdminExplorerOption, 0, len(types.AdminAuditTargetTypes))
+	for _, targetType := range types.AdminAuditTargetTypes {
+		targetTypeOptions = append(targetTypeOptions, types.AdminExplorerOption{Value: string(targetType), Label: string(targetType)})
+	}
+
+	data := &types.AdminAuditPageData{
+		BasePageData:       types.BasePageData{Title: "Admin Audit Log"},
+		Events:             items,
+		Page:               page,
+		TotalEvents:        totalEvents,
+		TotalPages:         totalPages,
+		PrevHref:           prevHref,
+		NextHref:           nextHref,
+		Actor:              filter.ActorUserID,
+		SelectedAction:     filter.Action,
+		SelectedTargetType: filter.TargetType,
+		TargetID:           filter.TargetID,
+		From:               filterValues.Get("from"),
+		To:                 filterValues.Get("to"),
+		ActionOptions:      actionOptions,
+		TargetTypeOptions:  targetTypeOptions,
+		FilterAction:       s.route(RouteAdminAudit),
+		ExportHref:         s.routeWithQuery(RouteAdminAuditExport, filterValues),
+		BackHref:           s.route(RouteAdmin),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.audit", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin audit page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+// handleGetAdminAuditExport streams the filtered audit log as CSV. The export
+// is itself audited before any rows are written.
+func (s *Service) handleGetAdminAuditExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter := parseAdminAuditFilter(r.URL.Query())
+
+	event := s.adminAuditEvent(r, types.AdminAuditActionAuditExported, types.AdminAuditTargetAuditLog, "export", adminAuditFilterValues(filter).Encode())
+	if event == nil {
+		s.logger.Error("session not found on context")
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	if err := s.adminAuditRepo.RecordEvent(ctx, event); err != nil {
+		s.logger.WithError(err).Error("failed to record admin audit export")
+		s.internalServerError(w)
+		return
+	}
+
+	filename := fmt.Sprintf("admin-audit-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"created_at", "actor_user_id", "action", "target_type", "target_id", "ip_address", "detail"}); err != nil {
+		s.logger.WithError(err).Warn("failed to write admin audit export header")
+		return
+	}
+
+	err := s.adminAuditRepo.EachEvent(ctx, filter, func(event *types.AdminAuditEvent) error {
+		return writer.Write([]string{
+			event.CreatedAt.UTC().Format(time.RFC3339),
+			csvSafeCell(event.ActorUserID),
+			string(event.Action),
+			string(event.TargetType),
+			csvSafeCell(event.TargetID),
+			csvSafeCell(derefString(event.IPAddress)),
+			csvSafeCell(derefString(event.Detail)),
+		})
+	})
+	writer.Flush()
+	if err == nil {
+		err = writer.Error()
+	}
+	if err != nil {
+		// Headers are already sent, so the download is simply cut short.
+		s.logger.WithError(err).Error("failed to stream admin audit export")
+	}
+}
+
+func (s *Service) adminAuditTargetHref(event *types.AdminAuditEvent) string {
+	switch event.TargetType {
+	case types.AdminAuditTargetNeed:
+		return s.route(RouteAdminNeedReview, Param("needID", event.TargetID))
+	case types.AdminAuditTargetUser:
+		return s.route(RouteAdminUserDetail, Param("userID", event.TargetID))
+	case types.AdminAuditTargetEmailMessage:
+		return s.route(RouteAdminEmailMessage, Param("messageID", event.TargetID))
+	default:
+		return ""
+	}
+}
+
+// csvSafeCell stops spreadsheet apps from evaluating a cell as a formula.
+// Reasons and details are free text typed by admins and users.
+func csvSafeCell(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
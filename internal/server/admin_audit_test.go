@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"christjesus/pkg/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRequestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.RemoteAddr = "10.0.0.7:51234"
+	req.Header.Set("CF-Connecting-IP", "203.0.113.9")
+	req.Header.Set("X-Forwarded-For", "198.51.100.4, 10.0.0.1")
+
+	if got := requestClientIP(req, ""); got != "10.0.0.7" {
+		t.Fatalf("requestClientIP(no header) = %q, want remote host", got)
+	}
+	if got := requestClientIP(req, "CF-Connecting-IP"); got != "203.0.113.9" {
+		t.Fatalf("requestClientIP(CF-Connecting-IP) = %q", got)
+	}
+	if got := requestClientIP(req, "X-Forwarded-For"); got != "198.51.100.4" {
+		t.Fatalf("requestClientIP(X-Forwarded-For) = %q, want first hop", got)
+	}
+	if got := requestClientIP(req, "X-Real-IP"); got != "10.0.0.7" {
+		t.Fatalf("requestClientIP(missing header) = %q, want remote host", got)
+	}
+}
+
+func TestParseAdminAuditFilter(t *testing.T) {
+	values := url.Values{
+		"actor":       {" user_1 "},
+		"action":      {"document.downloaded"},
+		"target_type": {"bogus"},
+		"target_id":   {"doc_9"},
+		"from":        {"2026-03-01"},
+		"to":          {"2026-03-05"},
+	}
+
+	filter := parseAdminAuditFilter(values)
+	if filter.ActorUserID != "user_1" || filter.Action != string(types.AdminAuditActionDocumentDownloaded) || filter.TargetID != "doc_9" {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+	if filter.TargetType != "" {
+		t.Fatalf("TargetType = %q, want unknown type dropped", filter.TargetType)
+	}
+	if filter.Until == nil || !filter.Until.Equal(time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Until = %v, want the day after the inclusive to date", filter.Until)
+	}
+
+	round := adminAuditFilterValues(filter)
+	if round.Get("from") != "2026-03-01" || round.Get("to") != "2026-03-05" || round.Has("target_type") {
+		t.Fatalf("adminAuditFilterValues() = %v", round)
+	}
+}
+
+func TestCSVSafeCell(t *testing.T) {
+	tests := map[string]string{
+		"":                "",
+		"plain":           "plain",
+		"=HYPERLINK(1)":   "'=HYPERLINK(1)",
+		"+1":              "'+1",
+		"@SUM(A1)":        "'@SUM(A1)",
+		"reason - spaced": "reason - spaced",
+	}
+	for in, want := range tests {
+		if got := csvSafeCell(in); got != want {
+			t.Fatalf("csvSafeCell(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAuditAdminRequest_RefusesUnrecordedReads(t *testing.T) {
+	t.Parallel()
+
+	// No session on the request, so no audit event can be written.
+	s := &Service{logger: logrus.New()}
+
+	served := false
+	h := s.AuditAdminRequest(types.AdminAuditActionUserViewed, types.AdminAuditTargetUser, "userID")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/user_1", nil)
+	req.SetPathValue("userID", "user_1")
+	rr := httptest.NewRecorder()
+
+	h.ServeHTTP(rr, req)
+
+	if served {
+		t.Fatal("handler ran without an audit row")
+	}
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusForbidden)
+	}
+}
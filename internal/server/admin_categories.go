@@ -0,0 +1,421 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	maxCategoryNameLength        = 80
+	maxCategoryDescriptionLength = 300
+)
+
+// categoryIconOptions are the icon identifiers the category cards know how to
+// draw. Other values fall back to a generic icon.
+var categoryIconOptions = []string{
+	"home",
+	"utensils",
+	"heart-pulse",
+	"lightbulb",
+	"car",
+	"briefcase",
+	"book",
+	"users",
+	"file-text",
+	"alert-circle",
+	"wallet",
+}
+
+func (s *Service) handleGetAdminCategories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	categories, err := s.categoryRepo.AllCategoriesUnfiltered(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories for admin")
+		s.internalServerError(w)
+		return
+	}
+
+	usage, err := s.categoryRepo.CategoryUsage(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch category usage for admin")
+		s.internalServerError(w)
+		return
+	}
+
+	items := make([]*types.AdminCategoryItem, 0, len(categories))
+	for index, category := range categories {
+		mergeTargets := make([]types.AdminExplorerOption, 0, len(categories))
+		for _, target := range categories {
+			if target.ID == category.ID || !target.IsActive {
+				continue
+			}
+			mergeTargets = append(mergeTargets, types.AdminExplorerOption{Value: target.ID, Label: target.Name})
+		}
+
+		slug := normalizedCategorySlug(category)
+		categoryUsage := usage[category.ID]
+		items = append(items, &types.AdminCategoryItem{
+			ID:                   category.ID,
+			Name:                 category.Name,
+			Slug:                 slug,
+			Description:          derefString(category.Description),
+			Icon:                 derefString(category.Icon),
+			DisplayOrder:         category.DisplayOrder,
+			IsActive:             category.IsActive,
+			NeedCount:            categoryUsage.NeedCount,
+			DonorPreferenceCount: categoryUsage.DonorPreferenceCount,
+			PublicHref:           s.route(RouteCategoryNeeds, Param("slug", slug)),
+			CanMoveUp:            index > 0,
+			CanMoveDown:          index < len(categories)-1,
+			MergeTargets:         mergeTargets,
+			UpdateAction:         s.route(RouteAdminCategoryUpdate, Param("categoryID", category.ID)),
+			ActiveAction:         s.route(RouteAdminCategoryActive, Param("categoryID", category.ID)),
+			MoveAction:           s.route(RouteAdminCategoryMove, Param("categoryID", category.ID)),
+			MergeAction:          s.route(RouteAdminCategoryMerge, Param("categoryID", category.ID)),
+		})
+	}
+
+	data := &types.AdminCategoriesPageData{
+		BasePageData: types.BasePageData{Title: "Categories"},
+		Categories:   items,
+		IconOptions:  categoryIconOptions,
+		CreateAction: s.route(RouteAdminCategoryCreate),
+		BackHref:     s.route(RouteAdmin),
+		Notice:       strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:        strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.categories", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin categories page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) handlePostAdminCategoryCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminCategoriesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	category, message := parseAdminCategoryForm(r)
+	if message != "" {
+		s.redirectAdminCategoriesWithError(w, r, message)
+		return
+	}
+
+	existing, err := s.categoryRepo.AllCategoriesUnfiltered(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories before create")
+		s.redirectAdminCategoriesWithError(w, r, "failed to add category")
+		return
+	}
+
+	// New categories go to the end of the list.
+	category.ID = utils.NanoID()
+	category.DisplayOrder = 1
+	if len(existing) > 0 {
+		category.DisplayOrder = existing[len(existing)-1].DisplayOrder + 1
+	}
+	category.IsActive = r.FormValue("active") == "1"
+	category.CreatedAt = time.Now()
+
+	if err := s.categoryRepo.CreateCategory(ctx, category); err != nil {
+		if errors.Is(err, types.ErrCategorySlugTaken) {
+			s.redirectAdminCategoriesWithError(w, r, "another category already uses that slug")
+			return
+		}
+		s.logger.WithError(err).Error("failed to create category")
+		s.redirectAdminCategoriesWithError(w, r, "failed to add category")
+		return
+	}
+
+	v := url.Values{}
+	if category.IsActive {
+		v.Set("notice", "Category \""+category.Name+"\" added")
+	} else {
+		v.Set("notice", "Category \""+category.Name+"\" added as inactive")
+	}
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminCategories, v), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminCategoryUpdate(w http.ResponseWriter, r *http.Request) {
+	categoryID := strings.TrimSpace(r.PathValue("categoryID"))
+	if categoryID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminCategoriesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	category, message := parseAdminCategoryForm(r)
+	if message != "" {
+		s.redirectAdminCategoriesWithError(w, r, message)
+		return
+	}
+	category.ID = categoryID
+
+	if err := s.categoryRepo.UpdateCategory(r.Context(), category); err != nil {
+		switch {
+		case errors.Is(err, types.ErrCategoryNotFound):
+			s.redirectAdminCategoriesWithError(w, r, "category not found")
+		case errors.Is(err, types.ErrCategorySlugTaken):
+			s.redirectAdminCategoriesWithError(w, r, "another category already uses that slug")
+		default:
+			s.logger.WithError(err).WithField("category_id", categoryID).Error("failed to update category")
+			s.redirectAdminCategoriesWithError(w, r, "failed to save category")
+		}
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Category \""+category.Name+"\" updated")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminCategories, v), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminCategoryActive(w http.ResponseWriter, r *http.Request) {
+	categoryID := strings.TrimSpace(r.PathValue("categoryID"))
+	if categoryID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminCategoriesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	active := r.FormValue("active") == "1"
+	if err := s.categoryRepo.SetCategoryActive(r.Context(), categoryID, active); err != nil {
+		if errors.Is(err, types.ErrCategoryNotFound) {
+			s.redirectAdminCategoriesWithError(w, r, "category not found")
+			return
+		}
+		s.logger.WithError(err).WithField("category_id", categoryID).Error("failed to set category active")
+		s.redirectAdminCategoriesWithError(w, r, "failed to update category")
+		return
+	}
+
+	v := url.Values{}
+	if active {
+		v.Set("notice", "Category activated")
+	} else {
+		v.Set("notice", "Category deactivated; existing needs keep it but new needs cannot pick it")
+	}
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminCategories, v), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminCategoryMove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	categoryID := strings.TrimSpace(r.PathValue("categoryID"))
+	if categoryID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminCategoriesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	categories, err := s.categoryRepo.AllCategoriesUnfiltered(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories before reorder")
+		s.redirectAdminCategoriesWithError(w, r, "failed to reorder categories")
+		return
+	}
+
+	ids := make([]string, 0, len(categories))
+	for _, category := range categories {
+		ids = append(ids, category.ID)
+	}
+
+	reordered, ok := moveCategoryID(ids, categoryID, r.FormValue("direction"))
+	if !ok {
+		s.redirectAdminCategoriesWithError(w, r, "category cannot move that way")
+		return
+	}
+
+	if err := store.WithTx(ctx, s.categoryRepo, func(tx pgx.Tx) error {
+		return s.categoryRepo.ReorderCategoriesTx(ctx, tx, reordered)
+	}); err != nil {
+		s.logger.WithError(err).WithField("category_id", categoryID).Error("failed to reorder categories")
+		s.redirectAdminCategoriesWithError(w, r, "failed to reorder categories")
+		return
+	}
+
+	http.Redirect(w, r, s.route(RouteAdminCategories), http.StatusSeeOther)
+}
+
+// handlePostAdminCategoryMerge folds one category into another. Needs, donor
+// preferences and line items move to the target in one transaction and the
+// source is left deactivated with nothing referencing it.
+func (s *Service) handlePostAdminCategoryMerge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sourceID := strings.TrimSpace(r.PathValue("categoryID"))
+	if sourceID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminCategoriesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	if _, ok := sessionFromRequest(r); !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminCategoriesWithError(w, r, "missing actor identity")
+		return
+	}
+
+	targetID := strings.TrimSpace(r.FormValue("target_id"))
+	if targetID == "" || targetID == sourceID {
+		s.redirectAdminCategoriesWithError(w, r, "choose a different category to merge into")
+		return
+	}
+	if r.FormValue("confirm") != "1" {
+		s.redirectAdminCategoriesWithError(w, r, "confirm the merge before continuing")
+		return
+	}
+
+	source, err := s.categoryRepo.CategoryByID(ctx, sourceID)
+	if err != nil {
+		s.logger.WithError(err).WithField("category_id", sourceID).Error("failed to fetch merge source category")
+		s.redirectAdminCategoriesWithError(w, r, "category not found")
+		return
+	}
+	target, err := s.categoryRepo.CategoryByID(ctx, targetID)
+	if err != nil {
+		s.logger.WithError(err).WithField("category_id", targetID).Error("failed to fetch merge target category")
+		s.redirectAdminCategoriesWithError(w, r, "merge target not found")
+		return
+	}
+	if !target.IsActive {
+		s.redirectAdminCategoriesWithError(w, r, "merge into an active category")
+		return
+	}
+
+	var result *types.CategoryMergeResult
+	if err := store.WithTx(ctx, s.categoryRepo, func(tx pgx.Tx) error {
+		var err error
+		result, err = s.categoryRepo.MergeCategoryTx(ctx, tx, source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.categoryRepo.SetCategoryActiveTx(ctx, tx, source.ID, false); err != nil {
+			return err
+		}
+
+		detail := fmt.Sprintf("merged %q into %q (%s): %d needs, %d donor preferences, %d line items, %d pending reallocations, %d feature slots moved",
+			source.Name, target.Name, target.ID, result.NeedsMoved, result.DonorPreferencesMoved, result.LineItemsMoved, result.ReallocationsMoved, result.FeatureSlotsMoved)
+		return s.adminAuditRepo.RecordEventTx(ctx, tx, s.adminAuditEvent(r, types.AdminAuditActionCategoryMerged, types.AdminAuditTargetCategory, source.ID, detail))
+	}); err != nil {
+		s.logger.WithError(err).WithField("source_id", source.ID).WithField("target_id", target.ID).Error("failed to merge categories")
+		s.redirectAdminCategoriesWithError(w, r, "failed to merge categories; nothing was changed")
+		return
+	}
+
+	s.logger.WithField("source_id", source.ID).WithField("target_id", target.ID).WithField("needs_moved", result.NeedsMoved).Info("categories merged")
+
+	v := url.Values{}
+	v.Set("notice", fmt.Sprintf("Merged %s into %s: %d needs and %d donor preferences moved", source.Name, target.Name, result.NeedsMoved, result.DonorPreferencesMoved))
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminCategories, v), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminCategoriesWithError(w http.ResponseWriter, r *http.Request, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminCategories, v), http.StatusSeeOther)
+}
+
+// parseAdminCategoryForm reads the editable fields of a category. The
+// returned message is non-empty when the form is invalid.
+func parseAdminCategoryForm(r *http.Request) (*types.NeedCategory, string) {
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		return nil, "name is required"
+	}
+	if len([]rune(name)) > maxCategoryNameLength {
+		return nil, "name is too long"
+	}
+
+	slug := normalizeCategorySlug(r.FormValue("slug"))
+	if slug == "" {
+		slug = normalizeCategorySlug(slugifyCategoryName(name))
+	}
+	if slug == "" {
+		return nil, "slug must contain letters or numbers"
+	}
+
+	category := &types.NeedCategory{Name: name, Slug: slug}
+
+	if description := strings.TrimSpace(r.FormValue("description")); description != "" {
+		if len([]rune(description)) > maxCategoryDescriptionLength {
+			return nil, "description is too long"
+		}
+		category.Description = &description
+	}
+	if icon := strings.TrimSpace(r.FormValue("icon")); icon != "" {
+		category.Icon = &icon
+	}
+
+	return category, ""
+}
+
+// normalizeCategorySlug lowercases raw and keeps only letters, digits and
+// single hyphens so the slug is safe in /category/:slug.
+func normalizeCategorySlug(raw string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(strings.TrimSpace(raw)) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case c == '-' || c == ' ' || c == '_':
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteByte('-')
+			}
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// moveCategoryID swaps id with its neighbour in direction "up" or "down". It
+// reports false when id is unknown or already at that end.
+func moveCategoryID(ids []string, id, direction string) ([]string, bool) {
+	index := slices.Index(ids, id)
+	if index < 0 {
+		return nil, false
+	}
+
+	neighbour := index - 1
+	if direction == "down" {
+		neighbour = index + 1
+	} else if direction != "up" {
+		return nil, false
+	}
+	if neighbour < 0 || neighbour >= len(ids) {
+		return nil, false
+	}
+
+	reordered := slices.Clone(ids)
+	reordered[index], reordered[neighbour] = reordered[neighbour], reordered[index]
+	return reordered, true
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeCategorySlug(t *testing.T) {
+	tests := map[string]string{
+		"housing-shelter":       "housing-shelter",
+		" Housing & Shelter ":   "housing-shelter",
+		"pets_and--animals":     "pets-and-animals",
+		"--edge--":              "edge",
+		"café 2":                "caf-2",
+		"!!!":                   "",
+		"Legal / Documentation": "legal-documentation",
+	}
+	for in, want := range tests {
+		if got := normalizeCategorySlug(in); got != want {
+			t.Fatalf("normalizeCategorySlug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMoveCategoryID(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+
+	if got, ok := moveCategoryID(ids, "b", "up"); !ok || !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Fatalf("move b up = %v %v", got, ok)
+	}
+	if got, ok := moveCategoryID(ids, "b", "down"); !ok || !slices.Equal(got, []string{"a", "c", "b"}) {
+		t.Fatalf("move b down = %v %v", got, ok)
+	}
+	if !slices.Equal(ids, []string{"a", "b", "c"}) {
+		t.Fatalf("moveCategoryID modified its input: %v", ids)
+	}
+	if _, ok := moveCategoryID(ids, "a", "up"); ok {
+		t.Fatal("moved the first category up")
+	}
+	if _, ok := moveCategoryID(ids, "c", "down"); ok {
+		t.Fatal("moved the last category down")
+	}
+	if _, ok := moveCategoryID(ids, "z", "up"); ok {
+		t.Fatal("moved an unknown category")
+	}
+	if _, ok := moveCategoryID(ids, "b", "sideways"); ok {
+		t.Fatal("accepted an unknown direction")
+	}
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+)
+
+const adminDonationsPageSize = 25
+
+func (s *Service) handleGetAdminDonations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	page := parsePositiveInt(r.URL.Query().Get("page"), 1)
+	selectedStatus := canonicalAdminDonationStatus(r.URL.Query().Get("status"))
+	selectedWindow, since := adminWindowSince(r.URL.Query().Get("window"), time.Now())
+
+	totalDonations, totalCents, err := s.donationIntentRepo.AdminDonationsCount(ctx, selectedStatus, since)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count donations for admin list")
+		s.internalServerError(w)
+		return
+	}
+
+	totalPages := totalDonations / adminDonationsPageSize
+	if totalDonations%adminDonationsPageSize != 0 {
+		totalPages++
+	}
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page > totalPages {
+		page = totalPages
+	}
+
+	intents, err := s.donationIntentRepo.AdminDonationsPage(ctx, page, adminDonationsPageSize, selectedStatus, since)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch donations for admin list")
+		s.internalServerError(w)
+		return
+	}
+
+	items := make([]*types.AdminDonationItem, 0, len(intents))
+	for _, intent := range intents {
+		if intent == nil {
+			continue
+		}
+
+		items = append(items, &types.AdminDonationItem{
+			IntentID:    intent.ID,
+			NeedID:      intent.NeedID,
+			NeedHref:    s.route(RouteAdminNeedReview, Param("needID", intent.NeedID)),
+			Amount:      formatUSDFromCents(intent.AmountCents),
+			Status:      formatDonationStatus(intent.PaymentStatus),
+			DonorUserID: formatOptionalString(intent.DonorUserID),
+			IsAnonymous: intent.IsAnonymous,
+			CreatedAt:   intent.CreatedAt.Format("2006-01-02 15:04"),
+		})
+	}
+
+	buildPageHref := func(nextPage int) string {
+		v := url.Values{}
+		v.Set("page", strconv.Itoa(nextPage))
+		if selectedStatus != "" {
+			v.Set("status", selectedStatus)
+		}
+		if selectedWindow != "" {
+			v.Set("window", selectedWindow)
+		}
+		return s.routeWithQuery(RouteAdminDonations, v)
+	}
+
+	prevHref := ""
+	if page > 1 {
+		prevHref = buildPageHref(page - 1)
+	}
+
+	nextHref := ""
+	if page < totalPages {
+		nextHref = buildPageHref(page + 1)
+	}
+
+	categories, err := s.categoryRepo.AllCategoriesUnfiltered(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to load categories for donation report")
+		s.internalServerError(w)
+		return
+	}
+	categoryOptions := make([]types.AdminExplorerOption, 0, len(categories)+1)
+	categoryOptions = append(categoryOptions, types.AdminExplorerOption{Value: "", Label: "All categories"})
+	for _, category := range categories {
+		categoryOptions = append(categoryOptions, types.AdminExplorerOption{Value: category.ID, Label: category.Name})
+	}
+
+	data := &types.AdminDonationsPageData{
+		BasePageData:    types.BasePageData{Title: "Admin Donations"},
+		Donations:       items,
+		TotalDonations:  totalDonations,
+		TotalAmount:     formatUSDFromCents(totalCents),
+		Page:            page,
+		PageSize:        adminDonationsPageSize,
+		TotalPages:      totalPages,
+		PrevHref:        prevHref,
+		NextHref:        nextHref,
+		SelectedStatus:  selectedStatus,
+		SelectedWindow:  selectedWindow,
+		StatusOptions:   adminDonationStatusOptions(),
+		WindowOptions:   adminWindowOptions(),
+		FilterAction:    s.route(RouteAdminDonations),
+		ReportAction:    s.route(RouteAdminDonationsExport),
+		CategoryOptions: categoryOptions,
+		BackHref:        s.route(RouteAdmin),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.donations", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin donations page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+// handleGetAdminDonationsExport downloads the donation report: every
+// donation created in the date range with the given status, for one need or
+// for needs in one category.
+func (s *Service) handleGetAdminDonationsExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	format := parseAdminExportFormat(query.Get("format"))
+
+	from, ok := parseAdminReportDate(query.Get("from"))
+	if !ok {
+		http.Error(w, "from must be a date like 2026-01-31", http.StatusBadRequest)
+		return
+	}
+	to, ok := parseAdminReportDate(query.Get("to"))
+	if !ok {
+		http.Error(w, "to must be a date like 2026-01-31", http.StatusBadRequest)
+		return
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		http.Error(w, "to must not be before from", http.StatusBadRequest)
+		return
+	}
+
+	filter := store.AdminDonationReportFilter{
+		From:       from,
+		Status:     canonicalAdminDonationStatus(query.Get("status")),
+		NeedID:     strings.TrimSpace(query.Get("need_id")),
+		CategoryID: strings.TrimSpace(query.Get("category_id")),
+	}
+	if to != nil {
+		// The form picks whole days, so include everything on the end date.
+		until := to.AddDate(0, 0, 1)
+		filter.Until = &until
+	}
+
+	filters := url.Values{}
+	for _, key := range []string{"from", "to"} {
+		if value := strings.TrimSpace(query.Get(key)); value != "" {
+			filters.Set(key, value)
+		}
+	}
+	if filter.Status != "" {
+		filters.Set("status", filter.Status)
+	}
+	if filter.NeedID != "" {
+		filters.Set("need_id", filter.NeedID)
+	}
+	if filter.CategoryID != "" {
+		filters.Set("category_id", filter.CategoryID)
+	}
+	filters.Set("format", string(format))
+	if !s.auditAdminExport(w, r, "donations", filters) {
+		return
+	}
+
+	writer, err := startAdminExport(w, format, "donations", []string{
+		"donation_id", "created_at", "status", "provider", "payment_intent_id", "amount",
+		"anonymous", "donor_user_id", "need_id", "need_description", "primary_category",
+	}, 5)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to start donation report export")
+		return
+	}
+
+	err = s.donationIntentRepo.EachAdminDonationReportRow(ctx, filter, func(row *store.AdminDonationReportRow) error {
+		return writer.Write([]string{
+			row.ID,
+			formatExportTime(&row.CreatedAt),
+			row.PaymentStatus,
+			row.PaymentProvider,
+			derefString(row.PaymentIntentID),
+			formatExportDollars(row.AmountCents),
+			strconv.FormatBool(row.IsAnonymous),
+			derefString(row.DonorUserID),
+			row.NeedID,
+			derefString(row.NeedDescription),
+			derefString(row.PrimaryCategoryName),
+		})
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Headers are already sent, so the download is simply cut short.
+		s.logger.WithError(err).Error("failed to stream donation report export")
+	}
+}
+
+// parseAdminReportDate reads a YYYY-MM-DD date as midnight UTC. A blank value
+// is no date; ok is false only when the value is malformed.
+func parseAdminReportDate(raw string) (*time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, true
+	}
+	parsed, err := time.ParseInLocation(time.DateOnly, raw, time.UTC)
+	if err != nil {
+		return nil, false
+	}
+	return &parsed, true
+}
+
+func canonicalAdminDonationStatus(raw string) string {
+	selected := strings.ToLower(strings.TrimSpace(raw))
+	for _, option := range adminDonationStatusOptions() {
+		if option.Value == selected {
+			return selected
+		}
+	}
+	return ""
+}
+
+func adminDonationStatusOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: "", Label: "All statuses"},
+		{Value: types.DonationPaymentStatusFinalized, Label: "Finalized"},
+		{Value: types.DonationPaymentStatusPending, Label: "Pending"},
+		{Value: types.DonationPaymentStatusFailed, Label: "Failed"},
+		{Value: types.DonationPaymentStatusCanceled, Label: "Canceled"},
+	}
+}
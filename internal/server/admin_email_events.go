@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+const adminEmailEventsLimit = 200
+
+func (s *Service) handleGetAdminEmailEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	selectedType := canonicalAdminEmailEventType(r.URL.Query().Get("type"))
+	selectedWindow, since := adminWindowSince(r.URL.Query().Get("window"), time.Now())
+
+	var sinceValue time.Time
+	if since != nil {
+		sinceValue = *since
+	}
+
+	events, err := s.emailRepo.EmailEventsSince(ctx, selectedType, sinceValue, adminEmailEventsLimit)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch email events for admin list")
+		s.internalServerError(w)
+		return
+	}
+
+	items := make([]*types.AdminEmailEventItem, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		items = append(items, &types.AdminEmailEventItem{
+			When:      event.CreatedAt.Format("2006-01-02 15:04"),
+			EventType: adminEmailEventTypeLabel(event.EventType),
+			Recipient: formatOptionalString(event.Recipient),
+			Subject:   formatOptionalString(event.Subject),
+			EmailType: formatOptionalString(event.MessageEmailType),
+		})
+	}
+
+	data := &types.AdminEmailEventsPageData{
+		BasePageData:   types.BasePageData{Title: "Admin Email Events"},
+		Events:         items,
+		Limit:          adminEmailEventsLimit,
+		SelectedType:   selectedType,
+		SelectedWindow: selectedWindow,
+		TypeOptions:    adminEmailEventTypeOptions(),
+		WindowOptions:  adminWindowOptions(),
+		FilterAction:   s.route(RouteAdminEmailEvents),
+		BackHref:       s.route(RouteAdmin),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.email.events", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin email events page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func canonicalAdminEmailEventType(raw string) string {
+	selected := strings.TrimSpace(raw)
+	for _, option := range adminEmailEventTypeOptions() {
+		if option.Value == selected {
+			return selected
+		}
+	}
+	return ""
+}
+
+func adminEmailEventTypeLabel(eventType string) string {
+	for _, option := range adminEmailEventTypeOptions() {
+		if option.Value != "" && option.Value == eventType {
+			return option.Label
+		}
+	}
+	return eventType
+}
+
+func adminEmailEventTypeOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: "", Label: "All events"},
+		{Value: types.EmailEventTypeBounced, Label: "Bounced"},
+		{Value: types.EmailEventTypeComplained, Label: "Complained"},
+		{Value: types.EmailEventTypeDelivered, Label: "Delivered"},
+	}
+}
@@ -0,0 +1,609 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	internalemail "christjesus/internal/email"
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	adminEmailsPageSize       = 50
+	adminSuppressionsPageSize = 50
+
+	maxSuppressionRemoveReasonLength = 1000
+)
+
+func (s *Service) handleGetAdminEmails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	page := parsePositiveInt(r.URL.Query().Get("page"), 1)
+	filter := parseAdminEmailMessageFilter(r.URL.Query())
+
+	totalMessages, err := s.emailRepo.EmailMessagesCount(ctx, filter)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count email messages for admin list")
+		s.internalServerError(w)
+		return
+	}
+
+	totalPages := totalMessages / adminEmailsPageSize
+	if totalMessages%adminEmailsPageSize != 0 {
+		totalPages++
+	}
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page > totalPages {
+		page = totalPages
+	}
+
+	messages, err := s.emailRepo.EmailMessagesPage(ctx, filter, page, adminEmailsPageSize)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list email messages for admin")
+		s.internalServerError(w)
+		return
+	}
+
+	items := make([]*types.AdminEmailMessageItem, 0, len(messages))
+	for _, msg := range messages {
+		if msg == nil {
+			continue
+		}
+		items = append(items, s.adminEmailMessageItem(msg))
+	}
+
+	buildPageHref := func(p int) string {
+		v := adminEmailMessageFilterValues(filter)
+		v.Set("page", strconv.Itoa(p))
+		return s.routeWithQuery(RouteAdminEmails, v)
+	}
+
+	prevHref := ""
+	if page > 1 {
+		prevHref = buildPageHref(page - 1)
+	}
+	nextHref := ""
+	if page < totalPages {
+		nextHref = buildPageHref(page + 1)
+	}
+
+	data := &types.AdminEmailsPageData{
+		BasePageData:     types.BasePageData{Title: "Admin Emails"},
+		Messages:         items,
+		Page:             page,
+		PageSize:         adminEmailsPageSize,
+		TotalMessages:    totalMessages,
+		TotalPages:       totalPages,
+		PrevHref:         prevHref,
+		NextHref:         nextHref,
+		Recipient:        filter.Recipient,
+		SelectedType:     filter.EmailType,
+		SelectedStatus:   filter.Status,
+		TypeOptions:      adminEmailTypeOptions(),
+		StatusOptions:    adminEmailStatusOptions(),
+		FilterAction:     s.route(RouteAdminEmails),
+		EventsHref:       s.route(RouteAdminEmailEvents),
+		SuppressionsHref: s.route(RouteAdminEmailSuppressions),
+		BackHref:         s.route(RouteAdmin),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.emails", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin emails page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) handleGetAdminEmailMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	messageID := strings.TrimSpace(r.PathValue("messageID"))
+	if messageID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	msg, err := s.emailRepo.EmailMessageByID(ctx, messageID)
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", messageID).Error("failed to fetch email message for admin")
+		s.internalServerError(w)
+		return
+	}
+	if msg == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	events, err := s.emailRepo.EmailEventsByMessage(ctx, msg.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", msg.ID).Error("failed to fetch email events for admin message")
+		s.internalServerError(w)
+		return
+	}
+
+	resends, err := s.emailRepo.EmailMessagesResentFrom(ctx, msg.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", msg.ID).Error("failed to fetch resends for admin message")
+		s.internalServerError(w)
+		return
+	}
+
+	suppression, err := s.emailRepo.ActiveEmailSuppression(ctx, msg.Recipient)
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", msg.ID).Error("failed to fetch recipient suppression for admin message")
+		s.internalServerError(w)
+		return
+	}
+
+	resendItems := make([]*types.AdminEmailMessageItem, 0, len(resends))
+	for _, resend := range resends {
+		resendItems = append(resendItems, s.adminEmailMessageItem(resend))
+	}
+
+	canManage := sessionCan(r, adminPermissionEmailsManage)
+	data := &types.AdminEmailMessagePageData{
+		BasePageData:     types.BasePageData{Title: "Admin Email"},
+		Message:          s.adminEmailMessageItem(msg),
+		FromAddress:      formatOptionalString(msg.FromAddress),
+		Provider:         msg.Provider,
+		ProviderID:       formatOptionalString(msg.ProviderMessageID),
+		UpdatedAt:        msg.UpdatedAt.Format("2006-01-02 15:04"),
+		Resends:          resendItems,
+		Timeline:         adminEmailTimeline(msg, events),
+		CanManage:        canManage,
+		ResendAction:     s.route(RouteAdminEmailMessageResend, Param("messageID", msg.ID)),
+		ResendBlocked:    adminEmailResendBlocked(msg, suppression),
+		SuppressionsHref: s.route(RouteAdminEmailSuppressions),
+		BackHref:         s.route(RouteAdminEmails),
+		Notice:           strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:            strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+	if msg.HTMLBody != nil {
+		data.HTMLBody = *msg.HTMLBody
+	}
+	if msg.TextBody != nil {
+		data.TextBody = *msg.TextBody
+	}
+	if msg.ResentFromMessageID != nil {
+		data.ResentFromHref = s.route(RouteAdminEmailMessage, Param("messageID", *msg.ResentFromMessageID))
+	}
+	if suppression != nil {
+		data.Suppression = s.adminEmailSuppressionItem(suppression, nil, canManage)
+		data.SuppressionsHref = s.routeWithQuery(RouteAdminEmailSuppressions, url.Values{"search": {suppression.EmailAddress}})
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.email.message", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin email message page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+// handlePostAdminEmailMessageResend sends a stored message again to the same
+// recipient. The new message links back to the original so both show up in
+// the console.
+func (s *Service) handlePostAdminEmailMessageResend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	messageID := strings.TrimSpace(r.PathValue("messageID"))
+	if messageID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	msg, err := s.emailRepo.EmailMessageByID(ctx, messageID)
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", messageID).Error("failed to fetch email message to resend")
+		s.internalServerError(w)
+		return
+	}
+	if msg == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	suppression, err := s.emailRepo.ActiveEmailSuppression(ctx, msg.Recipient)
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", msg.ID).Error("failed to check suppression before resend")
+		s.redirectAdminEmailMessageWithError(w, r, msg.ID, "failed to resend message")
+		return
+	}
+	if blocked := adminEmailResendBlocked(msg, suppression); blocked != "" {
+		s.redirectAdminEmailMessageWithError(w, r, msg.ID, blocked)
+		return
+	}
+
+	resent, err := s.sendEmail(ctx, internalemail.Message{
+		From:     *msg.FromAddress,
+		To:       msg.Recipient,
+		Subject:  msg.Subject,
+		HTMLBody: derefString(msg.HTMLBody),
+		TextBody: derefString(msg.TextBody),
+	}, msg.EmailType, withResentFrom(msg.ID))
+	if err != nil {
+		s.logger.WithError(err).WithField("email_message_id", msg.ID).Error("failed to resend email message")
+		s.redirectAdminEmailMessageWithError(w, r, msg.ID, "the email provider rejected the resend; try again later")
+		return
+	}
+	if resent == nil {
+		// The recipient was suppressed between the check above and the send.
+		s.redirectAdminEmailMessageWithError(w, r, msg.ID, "recipient is suppressed; remove the suppression before resending")
+		return
+	}
+
+	s.recordAdminAudit(r, types.AdminAuditActionEmailResent, types.AdminAuditTargetEmailMessage, msg.ID,
+		fmt.Sprintf("resent %s to %s as message %s", msg.EmailType, msg.Recipient, resent.ID))
+
+	s.logger.WithField("email_message_id", msg.ID).WithField("resent_message_id", resent.ID).Info("email message resent")
+
+	s.redirectAdminEmailMessageWithNotice(w, r, resent.ID, "Message resent")
+}
+
+func (s *Service) handleGetAdminEmailSuppressions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	page := parsePositiveInt(r.URL.Query().Get("page"), 1)
+	filter := store.EmailSuppressionFilter{
+		EmailAddress:   strings.TrimSpace(r.URL.Query().Get("search")),
+		IncludeRemoved: r.URL.Query().Get("removed") == "1",
+	}
+
+	totalSuppressions, err := s.emailRepo.EmailSuppressionsCount(ctx, filter)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count email suppressions for admin list")
+		s.internalServerError(w)
+		return
+	}
+
+	totalPages := totalSuppressions / adminSuppressionsPageSize
+	if totalSuppressions%adminSuppressionsPageSize != 0 {
+		totalPages++
+	}
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page > totalPages {
+		page = totalPages
+	}
+
+	suppressions, err := s.emailRepo.EmailSuppressionsPage(ctx, filter, page, adminSuppressionsPageSize)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list email suppressions for admin")
+		s.internalServerError(w)
+		return
+	}
+
+	actorIDs := make([]string, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		if suppression.RemovedByUserID != nil {
+			actorIDs = append(actorIDs, *suppression.RemovedByUserID)
+		}
+	}
+	actorNames := make(map[string]string)
+	if len(actorIDs) > 0 {
+		actors, err := s.userRepo.UsersByIDs(ctx, uniqueSortedStrings(actorIDs))
+		if err != nil {
+			s.logger.WithError(err).Warn("failed to fetch suppression actors for admin list")
+		}
+		for _, actor := range actors {
+			actorNames[actor.ID] = userDisplayName(actor)
+		}
+	}
+
+	canManage := sessionCan(r, adminPermissionEmailsManage)
+	items := make([]*types.AdminEmailSuppressionItem, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		if suppression == nil {
+			continue
+		}
+		items = append(items, s.adminEmailSuppressionItem(suppression, actorNames, canManage))
+	}
+
+	buildPageHref := func(p int) string {
+		v := url.Values{}
+		v.Set("page", strconv.Itoa(p))
+		if filter.EmailAddress != "" {
+			v.Set("search", filter.EmailAddress)
+		}
+		if filter.IncludeRemoved {
+			v.Set("removed", "1")
+		}
+		return s.routeWithQuery(RouteAdminEmailSuppressions, v)
+	}
+
+	prevHref := ""
+	if page > 1 {
+		prevHref = buildPageHref(page - 1)
+	}
+	nextHref := ""
+	if page < totalPages {
+		nextHref = buildPageHref(page + 1)
+	}
+
+	data := &types.AdminEmailSuppressionsPageData{
+		BasePageData:      types.BasePageData{Title: "Admin Email Suppressions"},
+		Suppressions:      items,
+		Page:              page,
+		PageSize:          adminSuppressionsPageSize,
+		TotalSuppressions: totalSuppressions,
+		TotalPages:        totalPages,
+		PrevHref:          prevHref,
+		NextHref:          nextHref,
+		Search:            filter.EmailAddress,
+		IncludeRemoved:    filter.IncludeRemoved,
+		CanManage:         canManage,
+		FilterAction:      s.route(RouteAdminEmailSuppressions),
+		MessagesHref:      s.route(RouteAdminEmails),
+		BackHref:          s.route(RouteAdmin),
+		Notice:            strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:             strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.email.suppressions", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin email suppressions page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+// handlePostAdminEmailSuppressionRemove lifts a suppression so mail to the
+// address is sent again. The row is kept with removed_at set.
+func (s *Service) handlePostAdminEmailSuppressionRemove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	suppressionID := strings.TrimSpace(r.PathValue("suppressionID"))
+	if suppressionID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminEmailSuppressionsWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminEmailSuppressionsWithError(w, r, "missing actor identity")
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		s.redirectAdminEmailSuppressionsWithError(w, r, "a reason is required to remove a suppression")
+		return
+	}
+	if len([]rune(reason)) > maxSuppressionRemoveReasonLength {
+		s.redirectAdminEmailSuppressionsWithError(w, r, "reason is too long")
+		return
+	}
+
+	var removed *types.EmailSuppression
+	err := store.WithTx(ctx, s.emailRepo, func(tx pgx.Tx) error {
+		var err error
+		removed, err = s.emailRepo.RemoveEmailSuppressionTx(ctx, tx, suppressionID, session.UserID, reason)
+		if err != nil {
+			return err
+		}
+
+		detail := fmt.Sprintf("removed %s suppression for %s: %s", removed.Reason, removed.EmailAddress, reason)
+		return s.adminAuditRepo.RecordEventTx(ctx, tx, s.adminAuditEvent(r, types.AdminAuditActionSuppressionRemoved, types.AdminAuditTargetEmailSuppression, removed.ID, detail))
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrEmailSuppressionNotFound) {
+			s.redirectAdminEmailSuppressionsWithError(w, r, "suppression not found or already removed")
+			return
+		}
+		s.logger.WithError(err).WithField("suppression_id", suppressionID).Error("failed to remove email suppression")
+		s.redirectAdminEmailSuppressionsWithError(w, r, "failed to remove suppression; nothing was changed")
+		return
+	}
+
+	s.logger.WithField("suppression_id", removed.ID).Info("email suppression removed")
+
+	s.redirectAdminEmailSuppressionsWithNotice(w, r, fmt.Sprintf("Suppression removed; email to %s will be sent again", removed.EmailAddress))
+}
+
+// adminEmailResendBlocked returns why a message cannot be resent, or an empty
+// string when it can.
+func adminEmailResendBlocked(msg *types.EmailMessage, suppression *types.EmailSuppression) string {
+	if !msg.Resendable() {
+		return "this message was sent before bodies were stored and cannot be resent"
+	}
+	if suppression != nil {
+		return "recipient is suppressed; remove the suppression before resending"
+	}
+	return ""
+}
+
+// adminEmailTimeline lists the send followed by every webhook event for the
+// message, oldest first.
+func adminEmailTimeline(msg *types.EmailMessage, events []*types.EmailEvent) []*types.AdminEmailTimelineItem {
+	items := make([]*types.AdminEmailTimelineItem, 0, len(events)+1)
+
+	sent := &types.AdminEmailTimelineItem{
+		When:  msg.CreatedAt.Format("2006-01-02 15:04:05"),
+		Label: "Queued",
+	}
+	if msg.ProviderMessageID != nil {
+		sent.Label = "Accepted by provider"
+		sent.Detail = *msg.ProviderMessageID
+	}
+	items = append(items, sent)
+
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		items = append(items, &types.AdminEmailTimelineItem{
+			When:    event.CreatedAt.Format("2006-01-02 15:04:05"),
+			Label:   adminEmailEventTypeLabel(event.EventType),
+			Detail:  resendEventDetail(event.Payload),
+			IsAlert: event.EventType == types.EmailEventTypeBounced || event.EventType == types.EmailEventTypeComplained,
+		})
+	}
+
+	return items
+}
+
+// resendEventDetail pulls the human-readable bounce explanation out of a
+// stored Resend webhook body. Other events carry nothing worth showing.
+func resendEventDetail(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var body struct {
+		Data struct {
+			Bounce struct {
+				Type    string `json:"type"`
+				SubType string `json:"subType"`
+				Message string `json:"message"`
+			} `json:"bounce"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return ""
+	}
+
+	bounce := body.Data.Bounce
+	parts := make([]string, 0, 2)
+	if kind := strings.TrimSpace(bounce.Type + " " + bounce.SubType); kind != "" {
+		parts = append(parts, kind)
+	}
+	if message := strings.TrimSpace(bounce.Message); message != "" {
+		parts = append(parts, message)
+	}
+	return strings.Join(parts, ": ")
+}
+
+func (s *Service) adminEmailMessageItem(msg *types.EmailMessage) *types.AdminEmailMessageItem {
+	return &types.AdminEmailMessageItem{
+		MessageID:  msg.ID,
+		When:       msg.CreatedAt.Format("2006-01-02 15:04"),
+		Recipient:  msg.Recipient,
+		Subject:    msg.Subject,
+		EmailType:  msg.EmailType,
+		Status:     msg.Status,
+		IsResend:   msg.ResentFromMessageID != nil,
+		DetailHref: s.route(RouteAdminEmailMessage, Param("messageID", msg.ID)),
+	}
+}
+
+func (s *Service) adminEmailSuppressionItem(suppression *types.EmailSuppression, actorNames map[string]string, canManage bool) *types.AdminEmailSuppressionItem {
+	messages := url.Values{}
+	messages.Set("recipient", suppression.EmailAddress)
+
+	item := &types.AdminEmailSuppressionItem{
+		SuppressionID: suppression.ID,
+		EmailAddress:  suppression.EmailAddress,
+		Reason:        suppression.Reason,
+		CreatedAt:     suppression.CreatedAt.Format("2006-01-02 15:04"),
+		IsActive:      suppression.RemovedAt == nil,
+		MessagesHref:  s.routeWithQuery(RouteAdminEmails, messages),
+	}
+	if suppression.RemovedAt != nil {
+		item.RemovedAt = suppression.RemovedAt.Format("2006-01-02 15:04")
+		item.RemoveReason = formatOptionalString(suppression.RemoveReason)
+		item.RemovedBy = "-"
+		if suppression.RemovedByUserID != nil {
+			item.RemovedBy = *suppression.RemovedByUserID
+			if name, ok := actorNames[*suppression.RemovedByUserID]; ok {
+				item.RemovedBy = name
+			}
+		}
+	}
+	if item.IsActive && canManage {
+		item.RemoveAction = s.route(RouteAdminEmailSuppressionRemove, Param("suppressionID", suppression.ID))
+	}
+	return item
+}
+
+// parseAdminEmailMessageFilter reads the message search form. Unknown types
+// and statuses are dropped so the form never shows a value it cannot select.
+func parseAdminEmailMessageFilter(values url.Values) store.EmailMessageFilter {
+	filter := store.EmailMessageFilter{
+		Recipient: strings.TrimSpace(values.Get("recipient")),
+	}
+
+	if emailType := strings.TrimSpace(values.Get("type")); emailType != "" {
+		for _, option := range adminEmailTypeOptions() {
+			if option.Value == emailType {
+				filter.EmailType = emailType
+			}
+		}
+	}
+	if status := strings.TrimSpace(values.Get("status")); status != "" {
+		for _, option := range adminEmailStatusOptions() {
+			if option.Value == status {
+				filter.Status = status
+			}
+		}
+	}
+
+	return filter
+}
+
+func adminEmailMessageFilterValues(filter store.EmailMessageFilter) url.Values {
+	v := url.Values{}
+	if filter.Recipient != "" {
+		v.Set("recipient", filter.Recipient)
+	}
+	if filter.EmailType != "" {
+		v.Set("type", filter.EmailType)
+	}
+	if filter.Status != "" {
+		v.Set("status", filter.Status)
+	}
+	return v
+}
+
+func adminEmailTypeOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: "", Label: "All types"},
+		{Value: types.EmailTypeDonationReceipt, Label: "Donation receipt"},
+		{Value: types.EmailTypeNeedGoalChange, Label: "Goal change"},
+		{Value: types.EmailTypeFundReallocation, Label: "Fund reallocation"},
+	}
+}
+
+func adminEmailStatusOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: "", Label: "All statuses"},
+		{Value: types.EmailStatusQueued, Label: "Queued"},
+		{Value: types.EmailStatusSent, Label: "Sent"},
+		{Value: types.EmailStatusDelivered, Label: "Delivered"},
+		{Value: types.EmailStatusBounced, Label: "Bounced"},
+		{Value: types.EmailStatusComplained, Label: "Complained"},
+	}
+}
+
+func (s *Service) redirectAdminEmailMessageWithError(w http.ResponseWriter, r *http.Request, messageID, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminEmailMessage, v, Param("messageID", messageID)), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminEmailMessageWithNotice(w http.ResponseWriter, r *http.Request, messageID, notice string) {
+	v := url.Values{}
+	v.Set("notice", notice)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminEmailMessage, v, Param("messageID", messageID)), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminEmailSuppressionsWithError(w http.ResponseWriter, r *http.Request, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminEmailSuppressions, v), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminEmailSuppressionsWithNotice(w http.ResponseWriter, r *http.Request, notice string) {
+	v := url.Values{}
+	v.Set("notice", notice)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminEmailSuppressions, v), http.StatusSeeOther)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+func TestResendEventDetail(t *testing.T) {
+	bounced := []byte(`{"type":"email.bounced","data":{"email_id":"re_1","bounce":{"type":"Permanent","subType":"General","message":"The recipient's mailbox does not exist."}}}`)
+	if got := resendEventDetail(bounced); got != "Permanent General: The recipient's mailbox does not exist." {
+		t.Fatalf("resendEventDetail(bounced) = %q", got)
+	}
+
+	delivered := []byte(`{"type":"email.delivered","data":{"email_id":"re_1"}}`)
+	if got := resendEventDetail(delivered); got != "" {
+		t.Fatalf("resendEventDetail(delivered) = %q, want empty", got)
+	}
+	if got := resendEventDetail([]byte("not json")); got != "" {
+		t.Fatalf("resendEventDetail(invalid) = %q, want empty", got)
+	}
+}
+
+func TestAdminEmailResendBlocked(t *testing.T) {
+	from := "noreply@christjesus.app"
+	body := "Thank you"
+	stored := &types.EmailMessage{FromAddress: &from, TextBody: &body}
+
+	if got := adminEmailResendBlocked(stored, nil); got != "" {
+		t.Fatalf("adminEmailResendBlocked(stored) = %q, want resendable", got)
+	}
+	if got := adminEmailResendBlocked(&types.EmailMessage{}, nil); got == "" {
+		t.Fatal("message without a stored body should not be resendable")
+	}
+	if got := adminEmailResendBlocked(stored, &types.EmailSuppression{}); got == "" {
+		t.Fatal("message to a suppressed recipient should not be resendable")
+	}
+}
+
+func TestAdminEmailTimeline(t *testing.T) {
+	providerID := "re_1"
+	created := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	msg := &types.EmailMessage{ProviderMessageID: &providerID, CreatedAt: created}
+	events := []*types.EmailEvent{
+		{EventType: types.EmailEventTypeDelivered, CreatedAt: created.Add(time.Minute)},
+		{EventType: types.EmailEventTypeComplained, CreatedAt: created.Add(time.Hour)},
+	}
+
+	timeline := adminEmailTimeline(msg, events)
+	if len(timeline) != 3 {
+		t.Fatalf("len(timeline) = %d, want send plus two events", len(timeline))
+	}
+	if timeline[0].Label != "Accepted by provider" || timeline[0].Detail != providerID {
+		t.Fatalf("first entry = %+v, want the provider acceptance", timeline[0])
+	}
+	if timeline[1].IsAlert || !timeline[2].IsAlert {
+		t.Fatalf("only the complaint should be flagged: %+v, %+v", timeline[1], timeline[2])
+	}
+}
+
+func TestParseAdminEmailMessageFilter(t *testing.T) {
+	filter := parseAdminEmailMessageFilter(url.Values{
+		"recipient": {" donor@example.com "},
+		"type":      {types.EmailTypeDonationReceipt},
+		"status":    {"lost"},
+	})
+	if filter.Recipient != "donor@example.com" || filter.EmailType != types.EmailTypeDonationReceipt {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+	if filter.Status != "" {
+		t.Fatalf("Status = %q, want unknown status dropped", filter.Status)
+	}
+
+	round := adminEmailMessageFilterValues(filter)
+	if round.Get("recipient") != "donor@example.com" || round.Has("status") {
+		t.Fatalf("adminEmailMessageFilterValues() = %v", round)
+	}
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"christjesus/internal/xlsx"
+	"christjesus/pkg/types"
+)
+
+// adminExportFormat is the file type of an admin export.
+type adminExportFormat string
+
+const (
+	adminExportCSV  adminExportFormat = "csv"
+	adminExportXLSX adminExportFormat = "xlsx"
+)
+
+// parseAdminExportFormat reads the format query parameter. Anything other
+// than xlsx exports CSV.
+func parseAdminExportFormat(raw string) adminExportFormat {
+	if strings.EqualFold(strings.TrimSpace(raw), string(adminExportXLSX)) {
+		return adminExportXLSX
+	}
+	return adminExportCSV
+}
+
+// adminExportWriter writes an export one row at a time in either format.
+type adminExportWriter interface {
+	Write(record []string) error
+	Close() error
+}
+
+// csvExportWriter guards every cell against formula injection, since
+// exported descriptions, names and reasons are typed by users.
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvExportWriter) Write(record []string) error {
+	safe := make([]string, len(record))
+	for i, value := range record {
+		safe[i] = csvSafeCell(value)
+	}
+	return c.w.Write(safe)
+}
+
+func (c *csvExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// adminExportFilename names a download after the export and the time it was
+// taken, for example needs-20260301-120000.xlsx.
+func adminExportFilename(name string, format adminExportFormat, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", name, now.UTC().Format("20060102-150405"), format)
+}
+
+// startAdminExport sends download headers and the header row. CSV rows go
+// straight to the client; workbooks are sent on Close. numericColumns are
+// stored as numbers in workbooks.
+func startAdminExport(w http.ResponseWriter, format adminExportFormat, name string, header []string, numericColumns ...int) (adminExportWriter, error) {
+	contentType := "text/csv; charset=utf-8"
+	if format == adminExportXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", adminExportFilename(name, format, time.Now())))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	var writer adminExportWriter
+	if format == adminExportXLSX {
+		xw, err := xlsx.NewWriter(w, name)
+		if err != nil {
+			return nil, err
+		}
+		xw.SetNumericColumns(numericColumns...)
+		writer = xw
+	} else {
+		writer = &csvExportWriter{w: csv.NewWriter(w)}
+	}
+
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// adminExportHrefs links to an export in both formats with the list's current
+// filters.
+func (s *Service) adminExportHrefs(name RouteName, filters url.Values) (string, string) {
+	csvFilters := url.Values{}
+	xlsxFilters := url.Values{}
+	for key, values := range filters {
+		csvFilters[key] = values
+		xlsxFilters[key] = values
+	}
+	csvFilters.Set("format", string(adminExportCSV))
+	xlsxFilters.Set("format", string(adminExportXLSX))
+	return s.routeWithQuery(name, csvFilters), s.routeWithQuery(name, xlsxFilters)
+}
+
+// auditAdminExport records who downloaded which export with which filters
+// before any rows are sent. It writes the error response and returns false
+// when the export must not go ahead.
+func (s *Service) auditAdminExport(w http.ResponseWriter, r *http.Request, name string, filters url.Values) bool {
+	event := s.adminAuditEvent(r, types.AdminAuditActionDataExported, types.AdminAuditTargetExport, name, filters.Encode())
+	if event == nil {
+		s.logger.Error("session not found on context")
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return false
+	}
+	if err := s.adminAuditRepo.RecordEvent(r.Context(), event); err != nil {
+		s.logger.WithError(err).WithField("export", name).Error("failed to record admin export")
+		s.internalServerError(w)
+		return false
+	}
+	return true
+}
+
+// formatExportTime renders a timestamp for exports, leaving unset ones blank.
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatExportDollars renders cents as a plain decimal that spreadsheets can
+// sum, without currency symbols or separators.
+func formatExportDollars(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
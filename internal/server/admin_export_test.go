@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAdminExportFormat(t *testing.T) {
+	cases := map[string]adminExportFormat{
+		"":        adminExportCSV,
+		"csv":     adminExportCSV,
+		" XLSX ":  adminExportXLSX,
+		"xlsx":    adminExportXLSX,
+		"numbers": adminExportCSV,
+	}
+	for in, want := range cases {
+		if got := parseAdminExportFormat(in); got != want {
+			t.Fatalf("parseAdminExportFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFormatExportDollars(t *testing.T) {
+	cases := map[int]string{
+		0:      "0.00",
+		5:      "0.05",
+		1250:   "12.50",
+		-1999:  "-19.99",
+		100000: "1000.00",
+	}
+	for in, want := range cases {
+		if got := formatExportDollars(in); got != want {
+			t.Fatalf("formatExportDollars(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseAdminReportDate(t *testing.T) {
+	if got, ok := parseAdminReportDate(" "); !ok || got != nil {
+		t.Fatalf("blank date = %v, %v", got, ok)
+	}
+	got, ok := parseAdminReportDate("2026-03-01")
+	if !ok || got == nil || !got.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("valid date = %v, %v", got, ok)
+	}
+	if _, ok := parseAdminReportDate("03/01/2026"); ok {
+		t.Fatal("expected malformed date to be rejected")
+	}
+}
+
+func TestStartAdminExport_CSVSanitizesCells(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writer, err := startAdminExport(rec, adminExportCSV, "needs", []string{"id", "description"})
+	if err != nil {
+		t.Fatalf("startAdminExport: %v", err)
+	}
+	if err := writer.Write([]string{"n1", "=HYPERLINK(\"x\")"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Fatalf("content type = %q", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, "needs-") || !strings.Contains(got, ".csv") {
+		t.Fatalf("content disposition = %q", got)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "id,description\n") {
+		t.Fatalf("missing header row: %q", body)
+	}
+	if strings.Contains(body, "\n=") || strings.Contains(body, ",=") {
+		t.Fatalf("formula cell was not neutralized: %q", body)
+	}
+}
+
+func TestStartAdminExport_XLSX(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writer, err := startAdminExport(rec, adminExportXLSX, "donations", []string{"id", "amount"}, 1)
+	if err != nil {
+		t.Fatalf("startAdminExport: %v", err)
+	}
+	if err := writer.Write([]string{"d1", "12.50"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); !strings.Contains(got, "spreadsheetml") {
+		t.Fatalf("content type = %q", got)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "PK") {
+		t.Fatal("expected a zip archive")
+	}
+}
@@ -0,0 +1,456 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// featureSlotTimeLayout is what datetime-local inputs submit. Windows are
+	// entered and shown in UTC.
+	featureSlotTimeLayout = "2006-01-02T15:04"
+
+	maxFeatureSlotPosition     = 99
+	maxFeatureEndReasonLength  = 500
+	adminFeaturingPastLimit    = 20
+	homeFeaturedNeedsLimit     = 5
+	categoryFeaturedNeedsLimit = 3
+)
+
+var errFeatureNeedNotInCategory = errors.New("need is not in that category")
+
+func (s *Service) handleGetAdminFeaturing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	now := time.Now()
+
+	openSlots, err := s.needFeatureRepo.OpenSlots(ctx, now)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch open feature slots")
+		s.internalServerError(w)
+		return
+	}
+
+	pastSlots, err := s.needFeatureRepo.PastSlots(ctx, now, adminFeaturingPastLimit)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch past feature slots")
+		s.internalServerError(w)
+		return
+	}
+
+	categories, err := s.categoryRepo.AllCategoriesUnfiltered(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories for featuring")
+		s.internalServerError(w)
+		return
+	}
+	categoryNames := make(map[string]string, len(categories))
+	categoryOptions := make([]types.AdminExplorerOption, 0, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+		if category.IsActive {
+			categoryOptions = append(categoryOptions, types.AdminExplorerOption{Value: category.ID, Label: category.Name})
+		}
+	}
+
+	needIDs := make([]string, 0, len(openSlots)+len(pastSlots))
+	for _, slot := range slices.Concat(openSlots, pastSlots) {
+		needIDs = append(needIDs, slot.NeedID)
+	}
+	needs, err := s.needsRepo.NeedsByIDs(ctx, uniqueSortedStrings(needIDs))
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch needs for featuring")
+		s.internalServerError(w)
+		return
+	}
+	needsByID := make(map[string]*types.Need, len(needs))
+	for _, need := range needs {
+		needsByID[need.ID] = need
+	}
+
+	homeSlots := make([]*types.AdminFeatureSlotItem, 0)
+	categorySlots := make([]*types.AdminFeatureSlotItem, 0)
+	for _, slot := range openSlots {
+		item := s.adminFeatureSlotItem(slot, needsByID[slot.NeedID], categoryNames, now)
+		if slot.Placement == types.NeedFeaturePlacementCategory {
+			categorySlots = append(categorySlots, item)
+		} else {
+			homeSlots = append(homeSlots, item)
+		}
+	}
+
+	pastItems := make([]*types.AdminFeatureSlotItem, 0, len(pastSlots))
+	for _, slot := range pastSlots {
+		pastItems = append(pastItems, s.adminFeatureSlotItem(slot, needsByID[slot.NeedID], categoryNames, now))
+	}
+
+	data := &types.AdminFeaturingPageData{
+		BasePageData: types.BasePageData{Title: "Featuring"},
+		SlotGroups: []*types.AdminFeatureSlotGroup{
+			{Title: "Homepage", EmptyText: "Nothing scheduled. The homepage shows the latest needs.", Slots: homeSlots},
+			{Title: "Category pages", EmptyText: "Nothing scheduled.", Slots: categorySlots},
+		},
+		PastSlots:       pastItems,
+		CategoryOptions: categoryOptions,
+		DefaultStartsAt: now.UTC().Format(featureSlotTimeLayout),
+		CreateAction:    s.route(RouteAdminFeaturingCreate),
+		BackHref:        s.route(RouteAdmin),
+		Notice:          strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:           strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.featuring", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin featuring page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) adminFeatureSlotItem(slot *types.NeedFeatureSlot, need *types.Need, categoryNames map[string]string, now time.Time) *types.AdminFeatureSlotItem {
+	item := &types.AdminFeatureSlotItem{
+		ID:             slot.ID,
+		NeedID:         slot.NeedID,
+		NeedTitle:      slot.NeedID,
+		NeedHref:       s.route(RouteAdminNeedReview, Param("needID", slot.NeedID)),
+		Placement:      featureSlotPlacementLabel(slot, categoryNames),
+		Position:       slot.Position,
+		StartsAt:       slot.StartsAt.UTC().Format("2006-01-02 15:04"),
+		EndsAt:         "No end",
+		IsLive:         slot.LiveAt(now),
+		IsUpcoming:     slot.OpenAt(now) && now.Before(slot.StartsAt),
+		EndReason:      derefString(slot.EndReason),
+		PositionAction: s.route(RouteAdminFeaturingPosition, Param("slotID", slot.ID)),
+		EndAction:      s.route(RouteAdminFeaturingEnd, Param("slotID", slot.ID)),
+	}
+	if slot.EndsAt != nil {
+		item.EndsAt = slot.EndsAt.UTC().Format("2006-01-02 15:04")
+	}
+	if slot.EndedAt != nil {
+		item.EndedAt = slot.EndedAt.UTC().Format("2006-01-02 15:04")
+	}
+	if need != nil {
+		item.NeedStatus = string(need.Status)
+		if title := strings.TrimSpace(derefString(need.ShortDescription)); title != "" {
+			item.NeedTitle = title
+		}
+		// A live slot only shows while its need is publicly visible.
+		item.IsBlocked = need.Status != types.NeedStatusActive || need.DeletedAt != nil || need.HiddenAt != nil
+	}
+	return item
+}
+
+func (s *Service) handlePostAdminFeaturingCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminFeaturingWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminFeaturingWithError(w, r, "missing actor identity")
+		return
+	}
+
+	slot, message := parseNeedFeatureSlotForm(r, time.Now())
+	if message != "" {
+		s.redirectAdminFeaturingWithError(w, r, message)
+		return
+	}
+	slot.CreatedByUserID = &session.UserID
+
+	need, err := s.needsRepo.Need(ctx, slot.NeedID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			s.redirectAdminFeaturingWithError(w, r, "need not found")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", slot.NeedID).Error("failed to fetch need for featuring")
+		s.redirectAdminFeaturingWithError(w, r, "failed to schedule featuring")
+		return
+	}
+	if need.DeletedAt != nil || need.HiddenAt != nil || need.Status != types.NeedStatusActive {
+		s.redirectAdminFeaturingWithError(w, r, "only active, visible needs can be featured")
+		return
+	}
+
+	categoryName := ""
+	if slot.Placement == types.NeedFeaturePlacementCategory {
+		categoryName, err = s.featureSlotCategoryName(ctx, need.ID, *slot.CategoryID)
+		if err != nil {
+			switch {
+			case errors.Is(err, types.ErrCategoryNotFound):
+				s.redirectAdminFeaturingWithError(w, r, "category not found or inactive")
+			case errors.Is(err, errFeatureNeedNotInCategory):
+				s.redirectAdminFeaturingWithError(w, r, err.Error())
+			default:
+				s.logger.WithError(err).WithField("need_id", need.ID).Error("failed to check need category for featuring")
+				s.redirectAdminFeaturingWithError(w, r, "failed to schedule featuring")
+			}
+			return
+		}
+	}
+
+	note := describeNeedFeatureSlot(slot, categoryName)
+	err = store.WithTx(ctx, s.needFeatureRepo, func(tx pgx.Tx) error {
+		if err := s.needFeatureRepo.CreateSlotTx(ctx, tx, slot); err != nil {
+			return err
+		}
+		if err := s.needsRepo.SetNeedFeaturedTx(ctx, tx, need.ID, true); err != nil {
+			return err
+		}
+		_, err := s.progressRepo.RecordModerationActionEventTx(ctx, tx, need.ID, types.NeedModerationActionTypeFeatured, session.UserID, nil, &note, nil)
+		return err
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", need.ID).Error("failed to schedule featuring")
+		s.redirectAdminFeaturingWithError(w, r, "failed to schedule featuring")
+		return
+	}
+
+	s.redirectAdminFeaturingWithNotice(w, r, "Featuring scheduled: "+note)
+}
+
+// featureSlotCategoryName checks a need can be featured on a category page
+// and returns the category name for the timeline note.
+func (s *Service) featureSlotCategoryName(ctx context.Context, needID, categoryID string) (string, error) {
+	category, err := s.categoryRepo.CategoryByID(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", types.ErrCategoryNotFound
+		}
+		return "", err
+	}
+	if category == nil || !category.IsActive {
+		return "", types.ErrCategoryNotFound
+	}
+
+	assignments, err := s.needCategoryAssignmentsRepo.GetAssignmentsByNeedID(ctx, needID)
+	if err != nil {
+		return "", err
+	}
+	for _, assignment := range assignments {
+		if assignment.CategoryID == categoryID {
+			return category.Name, nil
+		}
+	}
+	return "", errFeatureNeedNotInCategory
+}
+
+func (s *Service) handlePostAdminFeaturingPosition(w http.ResponseWriter, r *http.Request) {
+	slotID := strings.TrimSpace(r.PathValue("slotID"))
+	if slotID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminFeaturingWithError(w, r, "invalid form submission")
+		return
+	}
+
+	position, ok := parseFeatureSlotPosition(r.FormValue("position"))
+	if !ok {
+		s.redirectAdminFeaturingWithError(w, r, fmt.Sprintf("slot must be between 1 and %d", maxFeatureSlotPosition))
+		return
+	}
+
+	if err := s.needFeatureRepo.SetSlotPosition(r.Context(), slotID, position); err != nil {
+		if errors.Is(err, types.ErrNeedFeatureSlotNotFound) {
+			s.redirectAdminFeaturingWithError(w, r, "slot not found or already ended")
+			return
+		}
+		s.logger.WithError(err).WithField("slot_id", slotID).Error("failed to move feature slot")
+		s.redirectAdminFeaturingWithError(w, r, "failed to move slot")
+		return
+	}
+
+	s.redirectAdminFeaturingWithNotice(w, r, fmt.Sprintf("Moved to slot %d", position))
+}
+
+func (s *Service) handlePostAdminFeaturingEnd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	slotID := strings.TrimSpace(r.PathValue("slotID"))
+	if slotID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminFeaturingWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminFeaturingWithError(w, r, "missing actor identity")
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		reason = "ended early"
+	}
+	if len(reason) > maxFeatureEndReasonLength {
+		s.redirectAdminFeaturingWithError(w, r, fmt.Sprintf("reason must be %d characters or fewer", maxFeatureEndReasonLength))
+		return
+	}
+
+	err := store.WithTx(ctx, s.needFeatureRepo, func(tx pgx.Tx) error {
+		slot, err := s.needFeatureRepo.SlotForUpdateTx(ctx, tx, slotID)
+		if err != nil {
+			return err
+		}
+		if err := s.needFeatureRepo.EndSlotTx(ctx, tx, slot.ID, &session.UserID, reason); err != nil {
+			return err
+		}
+		return s.unfeatureIfNoOpenSlotsTx(ctx, tx, slot.NeedID, session.UserID, reason)
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrNeedFeatureSlotNotFound) {
+			s.redirectAdminFeaturingWithError(w, r, "slot not found or already ended")
+			return
+		}
+		s.logger.WithError(err).WithField("slot_id", slotID).Error("failed to end feature slot")
+		s.redirectAdminFeaturingWithError(w, r, "failed to end slot")
+		return
+	}
+
+	s.redirectAdminFeaturingWithNotice(w, r, "Featuring slot ended")
+}
+
+// unfeatureIfNoOpenSlotsTx clears the featured flag once the last live or
+// upcoming slot of a need has ended, and notes it on the need's timeline.
+func (s *Service) unfeatureIfNoOpenSlotsTx(ctx context.Context, tx pgx.Tx, needID, actorUserID, reason string) error {
+	remaining, err := s.needFeatureRepo.CountOpenSlotsByNeedTx(ctx, tx, needID)
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return nil
+	}
+	if err := s.needsRepo.UnfeatureNeedTx(ctx, tx, needID); err != nil {
+		return err
+	}
+	_, err = s.progressRepo.RecordModerationActionEventTx(ctx, tx, needID, types.NeedModerationActionTypeUnfeatured, actorUserID, &reason, nil, nil)
+	return err
+}
+
+func (s *Service) redirectAdminFeaturingWithError(w http.ResponseWriter, r *http.Request, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminFeaturing, v), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminFeaturingWithNotice(w http.ResponseWriter, r *http.Request, notice string) {
+	v := url.Values{}
+	v.Set("notice", notice)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminFeaturing, v), http.StatusSeeOther)
+}
+
+// parseNeedFeatureSlotForm reads a scheduling form. A blank start means now.
+// The returned message is non-empty when the form is invalid.
+func parseNeedFeatureSlotForm(r *http.Request, now time.Time) (*types.NeedFeatureSlot, string) {
+	slot := &types.NeedFeatureSlot{
+		NeedID:   strings.TrimSpace(r.FormValue("need_id")),
+		StartsAt: now,
+	}
+	if slot.NeedID == "" {
+		return nil, "need id is required"
+	}
+
+	switch types.NeedFeaturePlacement(strings.TrimSpace(r.FormValue("placement"))) {
+	case types.NeedFeaturePlacementHome:
+		slot.Placement = types.NeedFeaturePlacementHome
+	case types.NeedFeaturePlacementCategory:
+		categoryID := strings.TrimSpace(r.FormValue("category_id"))
+		if categoryID == "" {
+			return nil, "choose a category for a category page placement"
+		}
+		slot.Placement = types.NeedFeaturePlacementCategory
+		slot.CategoryID = &categoryID
+	default:
+		return nil, "choose where to feature the need"
+	}
+
+	position, ok := parseFeatureSlotPosition(r.FormValue("position"))
+	if !ok {
+		return nil, fmt.Sprintf("slot must be between 1 and %d", maxFeatureSlotPosition)
+	}
+	slot.Position = position
+
+	if raw := strings.TrimSpace(r.FormValue("starts_at")); raw != "" {
+		startsAt, err := time.ParseInLocation(featureSlotTimeLayout, raw, time.UTC)
+		if err != nil {
+			return nil, "start time is invalid"
+		}
+		slot.StartsAt = startsAt
+	}
+
+	if raw := strings.TrimSpace(r.FormValue("ends_at")); raw != "" {
+		endsAt, err := time.ParseInLocation(featureSlotTimeLayout, raw, time.UTC)
+		if err != nil {
+			return nil, "end time is invalid"
+		}
+		if !endsAt.After(slot.StartsAt) {
+			return nil, "end time must be after the start time"
+		}
+		if !endsAt.After(now) {
+			return nil, "end time must be in the future"
+		}
+		slot.EndsAt = &endsAt
+	}
+
+	return slot, ""
+}
+
+// parseFeatureSlotPosition reads a slot number, defaulting to the first slot.
+func parseFeatureSlotPosition(raw string) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 1, true
+	}
+	position, err := strconv.Atoi(raw)
+	if err != nil || position < 1 || position > maxFeatureSlotPosition {
+		return 0, false
+	}
+	return position, true
+}
+
+// describeNeedFeatureSlot summarises a slot for the need's timeline and the
+// admin notice.
+func describeNeedFeatureSlot(slot *types.NeedFeatureSlot, categoryName string) string {
+	where := "the homepage"
+	if slot.Placement == types.NeedFeaturePlacementCategory {
+		where = "the " + categoryName + " category page"
+	}
+	until := "with no end"
+	if slot.EndsAt != nil {
+		until = "until " + slot.EndsAt.UTC().Format("2006-01-02 15:04") + " UTC"
+	}
+	return fmt.Sprintf("%s in slot %d from %s UTC %s", where, slot.Position, slot.StartsAt.UTC().Format("2006-01-02 15:04"), until)
+}
+
+func featureSlotPlacementLabel(slot *types.NeedFeatureSlot, categoryNames map[string]string) string {
+	if slot.Placement != types.NeedFeaturePlacementCategory {
+		return "Homepage"
+	}
+	name := categoryNames[derefString(slot.CategoryID)]
+	if name == "" {
+		name = derefString(slot.CategoryID)
+	}
+	return "Category: " + name
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+func TestParseNeedFeatureSlotForm(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	parse := func(values url.Values) (*types.NeedFeatureSlot, string) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/featuring/slots", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return parseNeedFeatureSlotForm(req, now)
+	}
+
+	slot, message := parse(url.Values{"need_id": {" n1 "}, "placement": {"home"}})
+	if message != "" {
+		t.Fatalf("home message = %q", message)
+	}
+	if slot.NeedID != "n1" || slot.Placement != types.NeedFeaturePlacementHome || slot.CategoryID != nil {
+		t.Fatalf("home slot = %+v", slot)
+	}
+	if slot.Position != 1 || !slot.StartsAt.Equal(now) || slot.EndsAt != nil {
+		t.Fatalf("home defaults = %+v", slot)
+	}
+
+	slot, message = parse(url.Values{
+		"need_id":     {"n1"},
+		"placement":   {"category"},
+		"category_id": {"c1"},
+		"position":    {"3"},
+		"starts_at":   {"2026-03-02T09:00"},
+		"ends_at":     {"2026-03-09T09:00"},
+	})
+	if message != "" {
+		t.Fatalf("category message = %q", message)
+	}
+	if slot.CategoryID == nil || *slot.CategoryID != "c1" || slot.Position != 3 {
+		t.Fatalf("category slot = %+v", slot)
+	}
+	if !slot.StartsAt.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) || slot.EndsAt == nil || !slot.EndsAt.Equal(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("category window = %v to %v", slot.StartsAt, slot.EndsAt)
+	}
+
+	invalid := []url.Values{
+		{"placement": {"home"}},
+		{"need_id": {"n1"}},
+		{"need_id": {"n1"}, "placement": {"category"}},
+		{"need_id": {"n1"}, "placement": {"home"}, "position": {"0"}},
+		{"need_id": {"n1"}, "placement": {"home"}, "position": {"100"}},
+		{"need_id": {"n1"}, "placement": {"home"}, "starts_at": {"tomorrow"}},
+		{"need_id": {"n1"}, "placement": {"home"}, "starts_at": {"2026-03-05T00:00"}, "ends_at": {"2026-03-04T00:00"}},
+		{"need_id": {"n1"}, "placement": {"home"}, "starts_at": {"2026-02-01T00:00"}, "ends_at": {"2026-02-02T00:00"}},
+	}
+	for _, values := range invalid {
+		if _, message := parse(values); message == "" {
+			t.Fatalf("expected %v to be rejected", values)
+		}
+	}
+}
+
+func TestNeedFeatureSlotLiveAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name string
+		slot *types.NeedFeatureSlot
+		live bool
+		open bool
+	}{
+		{"open ended", &types.NeedFeatureSlot{StartsAt: past}, true, true},
+		{"within window", &types.NeedFeatureSlot{StartsAt: past, EndsAt: &future}, true, true},
+		{"upcoming", &types.NeedFeatureSlot{StartsAt: future}, false, true},
+		{"window closed", &types.NeedFeatureSlot{StartsAt: past.Add(-time.Hour), EndsAt: &past}, false, false},
+		{"ended early", &types.NeedFeatureSlot{StartsAt: past, EndedAt: &past}, false, false},
+	}
+	for _, tc := range cases {
+		if got := tc.slot.LiveAt(now); got != tc.live {
+			t.Fatalf("%s: LiveAt = %v, want %v", tc.name, got, tc.live)
+		}
+		if got := tc.slot.OpenAt(now); got != tc.open {
+			t.Fatalf("%s: OpenAt = %v, want %v", tc.name, got, tc.open)
+		}
+	}
+}
+
+func TestMergeFeaturedNeeds(t *testing.T) {
+	need := func(id string) *types.Need { return &types.Need{ID: id} }
+
+	merged := mergeFeaturedNeeds(
+		[]*types.Need{need("s1"), need("s2")},
+		[]*types.Need{need("l1"), need("s2"), need("l2"), need("l3")},
+		4,
+	)
+
+	ids := make([]string, 0, len(merged))
+	for _, n := range merged {
+		ids = append(ids, n.ID)
+	}
+	if got, want := strings.Join(ids, ","), "s1,s2,l1,l2"; got != want {
+		t.Fatalf("merged = %s, want %s", got, want)
+	}
+
+	if merged := mergeFeaturedNeeds(nil, []*types.Need{need("l1")}, 4); len(merged) != 1 || merged[0].ID != "l1" {
+		t.Fatalf("fallback only = %+v", merged)
+	}
+}
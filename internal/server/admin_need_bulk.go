@@ -0,0 +1,436 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// adminNeedBulkMaxNeeds caps one bulk submission. It is a few explorer pages,
+// which is enough to clear a spam wave without holding locks for long.
+const adminNeedBulkMaxNeeds = 100
+
+type adminNeedBulkAction string
+
+const (
+	adminNeedBulkSetUrgency adminNeedBulkAction = "set_urgency"
+	adminNeedBulkFeature    adminNeedBulkAction = "feature"
+	adminNeedBulkUnfeature  adminNeedBulkAction = "unfeature"
+	adminNeedBulkDelete     adminNeedBulkAction = "delete"
+	adminNeedBulkRestore    adminNeedBulkAction = "restore"
+	adminNeedBulkAssign     adminNeedBulkAction = "assign"
+)
+
+// adminNeedBulkActions lists the bulk actions in the order they are offered.
+var adminNeedBulkActions = []adminNeedBulkAction{
+	adminNeedBulkSetUrgency,
+	adminNeedBulkFeature,
+	adminNeedBulkUnfeature,
+	adminNeedBulkAssign,
+	adminNeedBulkDelete,
+	adminNeedBulkRestore,
+}
+
+var (
+	errAdminNeedBulkNotActive       = errors.New("only active needs can be featured")
+	errAdminNeedBulkNotInQueue      = errors.New("only needs waiting in the review queue can be assigned")
+	errAdminNeedBulkAlreadyAssigned = errors.New("already assigned to that reviewer")
+)
+
+// adminNeedBulkRequest is a validated bulk submission.
+type adminNeedBulkRequest struct {
+	action     adminNeedBulkAction
+	needIDs    []string
+	urgency    types.NeedUrgency
+	reason     string
+	reviewer   *types.User
+	actorUser  string
+	needsByID  map[string]*types.Need
+	actionType types.NeedModerationActionType
+	// audit is copied per need for deletes and restores, which also go to
+	// the admin audit log.
+	audit *types.AdminAuditEvent
+}
+
+func (s *Service) handlePostAdminNeedExplorerBulk(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNeedExplorerBulk(w, r, "", "invalid form submission", nil)
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedExplorerBulk(w, r, "", "missing actor identity", nil)
+		return
+	}
+
+	action, ok := parseAdminNeedBulkAction(r.FormValue("action"))
+	if !ok {
+		s.redirectAdminNeedExplorerBulk(w, r, "", "choose a bulk action", nil)
+		return
+	}
+
+	if !session.Can(adminNeedBulkPermission(action)) {
+		s.redirectAdminNeedExplorerBulk(w, r, "", "you do not have permission for that bulk action", nil)
+		return
+	}
+
+	needIDs := uniqueNeedIDs(r.Form["need_id"])
+	if len(needIDs) == 0 {
+		s.redirectAdminNeedExplorerBulk(w, r, "", "select at least one need", nil)
+		return
+	}
+	if len(needIDs) > adminNeedBulkMaxNeeds {
+		s.redirectAdminNeedExplorerBulk(w, r, "", fmt.Sprintf("select at most %d needs at a time", adminNeedBulkMaxNeeds), nil)
+		return
+	}
+
+	req := &adminNeedBulkRequest{
+		action:    action,
+		needIDs:   needIDs,
+		actorUser: session.UserID,
+	}
+
+	switch action {
+	case adminNeedBulkSetUrgency:
+		urgency, ok := parseNeedUrgency(r.FormValue("urgency"))
+		if !ok {
+			s.redirectAdminNeedExplorerBulk(w, r, "", "invalid urgency value", nil)
+			return
+		}
+		req.urgency = urgency
+		req.actionType = types.NeedModerationActionTypeUrgencyChanged
+	case adminNeedBulkFeature:
+		req.actionType = types.NeedModerationActionTypeFeatured
+	case adminNeedBulkUnfeature:
+		req.actionType = types.NeedModerationActionTypeUnfeatured
+	case adminNeedBulkDelete, adminNeedBulkRestore:
+		req.reason = strings.TrimSpace(r.FormValue("reason"))
+		if req.reason == "" {
+			s.redirectAdminNeedExplorerBulk(w, r, "", "a shared reason is required to "+string(action)+" needs", nil)
+			return
+		}
+		req.actionType = types.NeedModerationActionTypeSoftDeleted
+		auditAction := types.AdminAuditActionNeedDeleted
+		if action == adminNeedBulkRestore {
+			req.actionType = types.NeedModerationActionTypeRestored
+			auditAction = types.AdminAuditActionNeedRestored
+		}
+		req.audit = s.adminAuditEvent(r, auditAction, types.AdminAuditTargetNeed, "", "bulk: "+req.reason)
+	case adminNeedBulkAssign:
+		reviewerUserID := strings.TrimSpace(r.FormValue("reviewer_user_id"))
+		if reviewerUserID == "" {
+			s.redirectAdminNeedExplorerBulk(w, r, "", "reviewer user id is required", nil)
+			return
+		}
+		reviewer, err := s.userRepo.User(r.Context(), reviewerUserID)
+		if err != nil {
+			if errors.Is(err, types.ErrUserNotFound) {
+				s.redirectAdminNeedExplorerBulk(w, r, "", "reviewer not found", nil)
+				return
+			}
+			s.logger.WithError(err).Error("failed to fetch reviewer for bulk assignment")
+			s.internalServerError(w)
+			return
+		}
+		req.reviewer = reviewer
+		req.actionType = types.NeedModerationActionTypeReviewReassigned
+	}
+
+	needs, err := s.needsRepo.NeedsByIDs(r.Context(), needIDs)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch needs for bulk action")
+		s.internalServerError(w)
+		return
+	}
+
+	req.needsByID = make(map[string]*types.Need, len(needs))
+	for _, need := range needs {
+		req.needsByID[need.ID] = need
+	}
+
+	failures := make([]string, 0)
+	applied := 0
+	err = store.WithTx(r.Context(), s.needsRepo, func(tx pgx.Tx) error {
+		for _, needID := range needIDs {
+			// Each need gets its own savepoint so one failure is reported
+			// without undoing the needs that succeeded.
+			savepoint, err := tx.Begin(r.Context())
+			if err != nil {
+				return err
+			}
+
+			if err := s.applyAdminNeedBulkAction(r.Context(), savepoint, req, needID); err != nil {
+				if rbErr := savepoint.Rollback(r.Context()); rbErr != nil {
+					return rbErr
+				}
+				failures = append(failures, needID+": "+s.adminNeedBulkFailureMessage(needID, err))
+				continue
+			}
+
+			if err := savepoint.Commit(r.Context()); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("action", action).Error("failed to apply bulk need action")
+		s.redirectAdminNeedExplorerBulk(w, r, "", "bulk action failed; no needs were changed", nil)
+		return
+	}
+
+	s.logger.WithField("action", action).WithField("applied", applied).WithField("failed", len(failures)).WithField("actor_user_id", req.actorUser).Info("bulk need action applied")
+
+	notice := fmt.Sprintf("%s applied to %d of %d needs", adminNeedBulkActionLabel(action), applied, len(needIDs))
+	s.redirectAdminNeedExplorerBulk(w, r, notice, "", failures)
+}
+
+// applyAdminNeedBulkAction changes one need and records the moderation action
+// inside tx.
+func (s *Service) applyAdminNeedBulkAction(ctx context.Context, tx pgx.Tx, req *adminNeedBulkRequest, needID string) error {
+	need := req.needsByID[needID]
+	if need == nil {
+		return types.ErrNeedNotFound
+	}
+
+	var reason, note *string
+	switch req.action {
+	case adminNeedBulkSetUrgency:
+		if need.DeletedAt != nil {
+			return types.ErrNeedAlreadyDeleted
+		}
+		if _, err := s.needsRepo.NeedReviewerForUpdateTx(ctx, tx, needID); err != nil {
+			return err
+		}
+		if err := s.needsRepo.SetNeedUrgencyTx(ctx, tx, needID, req.urgency); err != nil {
+			return err
+		}
+		change := fmt.Sprintf("urgency changed from %s to %s", need.Urgency, req.urgency)
+		note = &change
+	case adminNeedBulkFeature:
+		if need.DeletedAt != nil {
+			return types.ErrNeedAlreadyDeleted
+		}
+		if need.Status != types.NeedStatusActive {
+			return errAdminNeedBulkNotActive
+		}
+		if err := s.needsRepo.SetNeedFeaturedTx(ctx, tx, needID, true); err != nil {
+			return err
+		}
+		// Needs featured in bulk without a schedule go on the homepage until
+		// they are unfeatured.
+		open, err := s.needFeatureRepo.CountOpenSlotsByNeedTx(ctx, tx, needID)
+		if err != nil {
+			return err
+		}
+		if open == 0 {
+			slot := &types.NeedFeatureSlot{
+				NeedID:          needID,
+				Placement:       types.NeedFeaturePlacementHome,
+				Position:        1,
+				StartsAt:        time.Now(),
+				CreatedByUserID: &req.actorUser,
+			}
+			if err := s.needFeatureRepo.CreateSlotTx(ctx, tx, slot); err != nil {
+				return err
+			}
+		}
+	case adminNeedBulkUnfeature:
+		if err := s.needsRepo.SetNeedFeaturedTx(ctx, tx, needID, false); err != nil {
+			return err
+		}
+		if _, err := s.needFeatureRepo.EndSlotsForNeedTx(ctx, tx, needID, &req.actorUser, "unfeatured in bulk"); err != nil {
+			return err
+		}
+	case adminNeedBulkDelete:
+		if err := s.needsRepo.SoftDeleteNeedTx(ctx, tx, needID, req.actorUser, req.reason); err != nil {
+			return err
+		}
+		reason = &req.reason
+	case adminNeedBulkRestore:
+		if err := s.needsRepo.RestoreNeedTx(ctx, tx, needID); err != nil {
+			return err
+		}
+		reason = &req.reason
+	case adminNeedBulkAssign:
+		if need.DeletedAt != nil || !isReviewQueueStatus(need.Status) {
+			return errAdminNeedBulkNotInQueue
+		}
+		current, err := s.needsRepo.NeedReviewerForUpdateTx(ctx, tx, needID)
+		if err != nil {
+			return err
+		}
+		if current != nil && *current == req.reviewer.ID {
+			return errAdminNeedBulkAlreadyAssigned
+		}
+		if err := s.needsRepo.SetNeedReviewerTx(ctx, tx, needID, &req.reviewer.ID); err != nil {
+			return err
+		}
+		change := "assigned to " + req.reviewer.ID
+		if current != nil {
+			change = fmt.Sprintf("reassigned from %s to %s", *current, req.reviewer.ID)
+		}
+		note = &change
+	}
+
+	if _, err := s.progressRepo.RecordModerationActionEventTx(ctx, tx, needID, req.actionType, req.actorUser, reason, note, nil); err != nil {
+		return err
+	}
+
+	if req.audit != nil {
+		event := *req.audit
+		event.TargetID = needID
+		return s.adminAuditRepo.RecordEventTx(ctx, tx, &event)
+	}
+	return nil
+}
+
+func (s *Service) adminNeedBulkFailureMessage(needID string, err error) string {
+	switch {
+	case errors.Is(err, types.ErrNeedNotFound):
+		return "not found or deleted"
+	case errors.Is(err, types.ErrNeedAlreadyDeleted):
+		return "already deleted"
+	case errors.Is(err, types.ErrNeedNotDeleted):
+		return "not deleted"
+	case errors.Is(err, errAdminNeedBulkNotActive),
+		errors.Is(err, errAdminNeedBulkNotInQueue),
+		errors.Is(err, errAdminNeedBulkAlreadyAssigned):
+		return err.Error()
+	default:
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to apply bulk action to need")
+		return "unexpected error"
+	}
+}
+
+// redirectAdminNeedExplorerBulk returns to the explorer view the bulk form was
+// submitted from, carrying the outcome in the query string.
+func (s *Service) redirectAdminNeedExplorerBulk(w http.ResponseWriter, r *http.Request, notice, message string, failures []string) {
+	v := url.Values{}
+	if status, _ := canonicalAdminExplorerStatus(r.FormValue("status")); status != "" {
+		v.Set("status", status)
+	}
+	v.Set("sort", canonicalAdminExplorerSort(r.FormValue("sort")))
+	if page := parsePositiveInt(r.FormValue("page"), 1); page > 1 {
+		v.Set("page", strconv.Itoa(page))
+	}
+	if r.FormValue("deleted") == "1" {
+		v.Set("deleted", "1")
+	}
+	if notice != "" {
+		v.Set("notice", notice)
+	}
+	if message != "" {
+		v.Set("error", message)
+	}
+	for _, failure := range failures {
+		v.Add("failed", failure)
+	}
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedExplorer, v), http.StatusSeeOther)
+}
+
+func parseAdminNeedBulkAction(raw string) (adminNeedBulkAction, bool) {
+	action := adminNeedBulkAction(strings.ToLower(strings.TrimSpace(raw)))
+	for _, known := range adminNeedBulkActions {
+		if action == known {
+			return action, true
+		}
+	}
+	return "", false
+}
+
+// adminNeedBulkPermission is the permission a bulk action needs; it matches
+// the permission guarding the single-need equivalent.
+func adminNeedBulkPermission(action adminNeedBulkAction) adminPermission {
+	switch action {
+	case adminNeedBulkDelete, adminNeedBulkRestore:
+		return adminPermissionNeedsDelete
+	case adminNeedBulkAssign:
+		return adminPermissionNeedsAssign
+	default:
+		return adminPermissionNeedsModerate
+	}
+}
+
+func adminNeedBulkActionLabel(action adminNeedBulkAction) string {
+	switch action {
+	case adminNeedBulkSetUrgency:
+		return "Set urgency"
+	case adminNeedBulkFeature:
+		return "Feature"
+	case adminNeedBulkUnfeature:
+		return "Unfeature"
+	case adminNeedBulkDelete:
+		return "Delete"
+	case adminNeedBulkRestore:
+		return "Restore"
+	case adminNeedBulkAssign:
+		return "Assign reviewer"
+	default:
+		return string(action)
+	}
+}
+
+// adminNeedBulkOptions lists the bulk actions the session may run. Restore is
+// only offered on the deleted view and the others only on the live view.
+func adminNeedBulkOptions(session *AuthSession, showDeleted bool) []types.AdminExplorerOption {
+	options := make([]types.AdminExplorerOption, 0, len(adminNeedBulkActions))
+	for _, action := range adminNeedBulkActions {
+		if (action == adminNeedBulkRestore) != showDeleted {
+			continue
+		}
+		if !session.Can(adminNeedBulkPermission(action)) {
+			continue
+		}
+		options = append(options, types.AdminExplorerOption{Value: string(action), Label: adminNeedBulkActionLabel(action)})
+	}
+	return options
+}
+
+func adminNeedUrgencyOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: string(types.NeedUrgencyLow), Label: "Low"},
+		{Value: string(types.NeedUrgencyMedium), Label: "Medium"},
+		{Value: string(types.NeedUrgencyHigh), Label: "High"},
+		{Value: string(types.NeedUrgencyUrgent), Label: "Urgent"},
+	}
+}
+
+func parseNeedUrgency(raw string) (types.NeedUrgency, bool) {
+	urgency := types.NeedUrgency(strings.ToLower(strings.TrimSpace(raw)))
+	switch urgency {
+	case types.NeedUrgencyLow, types.NeedUrgencyMedium, types.NeedUrgencyHigh, types.NeedUrgencyUrgent:
+		return urgency, true
+	default:
+		return "", false
+	}
+}
+
+// uniqueNeedIDs trims the submitted IDs and drops blanks and repeats while
+// keeping the submitted order.
+func uniqueNeedIDs(raw []string) []string {
+	seen := make(map[string]bool, len(raw))
+	ids := make([]string, 0, len(raw))
+	for _, id := range raw {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
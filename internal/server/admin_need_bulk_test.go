@@ -0,0 +1,51 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestUniqueNeedIDs(t *testing.T) {
+	got := uniqueNeedIDs([]string{" need_b ", "need_a", "", "need_b", "  ", "need_c"})
+	want := []string{"need_b", "need_a", "need_c"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("uniqueNeedIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestParseAdminNeedBulkAction(t *testing.T) {
+	if action, ok := parseAdminNeedBulkAction(" Delete "); !ok || action != adminNeedBulkDelete {
+		t.Fatalf("parseAdminNeedBulkAction(Delete) = %q %v, want delete true", action, ok)
+	}
+	if _, ok := parseAdminNeedBulkAction("approve"); ok {
+		t.Fatal("parseAdminNeedBulkAction(approve) accepted an unknown action")
+	}
+}
+
+func TestAdminNeedBulkOptions(t *testing.T) {
+	optionValues := func(options []types.AdminExplorerOption) []string {
+		values := make([]string, 0, len(options))
+		for _, option := range options {
+			values = append(values, option.Value)
+		}
+		return values
+	}
+
+	reviewer := &AuthSession{IsAdmin: true, AdminRoles: []types.AdminRole{types.AdminRoleReviewer}}
+	if got, want := optionValues(adminNeedBulkOptions(reviewer, false)), []string{"set_urgency", "feature", "unfeature"}; !slices.Equal(got, want) {
+		t.Fatalf("reviewer live options = %v, want %v", got, want)
+	}
+	if got := adminNeedBulkOptions(reviewer, true); len(got) != 0 {
+		t.Fatalf("reviewer deleted options = %v, want none", got)
+	}
+
+	superadmin := &AuthSession{IsAdmin: true, AdminRoles: []types.AdminRole{types.AdminRoleSuperadmin}}
+	if got, want := optionValues(adminNeedBulkOptions(superadmin, false)), []string{"set_urgency", "feature", "unfeature", "assign", "delete"}; !slices.Equal(got, want) {
+		t.Fatalf("superadmin live options = %v, want %v", got, want)
+	}
+	if got, want := optionValues(adminNeedBulkOptions(superadmin, true)), []string{"restore"}; !slices.Equal(got, want) {
+		t.Fatalf("superadmin deleted options = %v, want %v", got, want)
+	}
+}
@@ -74,8 +74,11 @@ func (s *Service) handlePostAdminNeedDeleteOrRestore(w http.ResponseWriter, r *h
 				return err
 			}
 
-			_, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeSoftDeleted, actorUserID, &reasonPtr, nil, nil)
-			return err
+			if _, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeSoftDeleted, actorUserID, &reasonPtr, nil, nil); err != nil {
+				return err
+			}
+
+			return s.adminAuditRepo.RecordEventTx(r.Context(), tx, s.adminAuditEvent(r, types.AdminAuditActionNeedDeleted, types.AdminAuditTargetNeed, needID, reason))
 		}); err != nil {
 			if errors.Is(err, types.ErrNeedAlreadyDeleted) {
 				s.redirectAdminNeedReviewWithError(w, r, needID, "need is already deleted")
@@ -104,8 +107,11 @@ func (s *Service) handlePostAdminNeedDeleteOrRestore(w http.ResponseWriter, r *h
 			return err
 		}
 
-		_, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeRestored, actorUserID, &reasonPtr, nil, nil)
-		return err
+		if _, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeRestored, actorUserID, &reasonPtr, nil, nil); err != nil {
+			return err
+		}
+
+		return s.adminAuditRepo.RecordEventTx(r.Context(), tx, s.adminAuditEvent(r, types.AdminAuditActionNeedRestored, types.AdminAuditTargetNeed, needID, reason))
 	}); err != nil {
 		if errors.Is(err, types.ErrNeedNotDeleted) {
 			s.redirectAdminNeedReviewWithError(w, r, needID, "need is not deleted")
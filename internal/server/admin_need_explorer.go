@@ -20,6 +20,7 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 	page := parsePositiveInt(r.URL.Query().Get("page"), 1)
 	selectedStatus, statusFilter := canonicalAdminExplorerStatus(r.URL.Query().Get("status"))
 	selectedSort := canonicalAdminExplorerSort(r.URL.Query().Get("sort"))
+	showDeleted := r.URL.Query().Get("deleted") == "1"
 	statusCounts, err := s.needsRepo.AdminExplorerNeedsCountByStatus(ctx)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to fetch grouped status counts for admin explorer")
@@ -27,7 +28,7 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	totalNeeds, err := s.needsRepo.AdminExplorerNeedsCount(ctx, statusFilter)
+	totalNeeds, err := s.needsRepo.AdminExplorerNeedsCount(ctx, statusFilter, showDeleted)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to count needs for admin explorer")
 		s.internalServerError(w)
@@ -45,7 +46,7 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 		page = totalPages
 	}
 
-	needs, err := s.needsRepo.AdminExplorerNeedsPage(ctx, page, adminNeedExplorerPageSize, statusFilter, selectedSort)
+	needs, err := s.needsRepo.AdminExplorerNeedsPage(ctx, page, adminNeedExplorerPageSize, statusFilter, selectedSort, showDeleted)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to fetch needs for admin explorer")
 		s.internalServerError(w)
@@ -74,6 +75,9 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 			Status:            need.Status,
 			AmountRaisedCents: need.AmountRaisedCents,
 			AmountNeededCents: need.AmountNeededCents,
+			Urgency:           need.Urgency,
+			IsFeatured:        need.IsFeatured,
+			IsDeleted:         need.DeletedAt != nil,
 			FundingPercent:    fundingPercent,
 			ActivityLabel:     fundingActivityLabel(fundingPercent),
 			UpdatedAt:         need.UpdatedAt.Format(time.DateOnly),
@@ -93,6 +97,9 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 		if selectedSort != "" {
 			v.Set("sort", selectedSort)
 		}
+		if showDeleted {
+			v.Set("deleted", "1")
+		}
 		return s.routeWithQuery(RouteAdminNeedExplorer, v)
 	}
 
@@ -131,6 +138,27 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 		})
 	}
 
+	toggleDeleted := url.Values{}
+	if selectedStatus != "" {
+		toggleDeleted.Set("status", selectedStatus)
+	}
+	toggleDeleted.Set("sort", selectedSort)
+	if !showDeleted {
+		toggleDeleted.Set("deleted", "1")
+	}
+
+	exportFilters := url.Values{}
+	if selectedStatus != "" {
+		exportFilters.Set("status", selectedStatus)
+	}
+	exportFilters.Set("sort", selectedSort)
+	if showDeleted {
+		exportFilters.Set("deleted", "1")
+	}
+	exportCSVHref, exportXLSXHref := s.adminExportHrefs(RouteAdminNeedExplorerExport, exportFilters)
+
+	session, _ := sessionFromRequest(r)
+
 	data := &types.AdminNeedExplorerPageData{
 		BasePageData:      types.BasePageData{Title: "Admin Need Explorer"},
 		Needs:             items,
@@ -149,6 +177,16 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 		BackHref:          s.route(RouteAdmin),
 		QueueHref:         s.route(RouteAdminNeeds),
 		CurrentStatusText: adminExplorerStatusLabelByValue(selectedStatus),
+		ShowDeleted:       showDeleted,
+		ToggleDeletedHref: s.routeWithQuery(RouteAdminNeedExplorer, toggleDeleted),
+		ExportCSVHref:     exportCSVHref,
+		ExportXLSXHref:    exportXLSXHref,
+		BulkAction:        s.route(RouteAdminNeedExplorerBulk),
+		BulkOptions:       adminNeedBulkOptions(session, showDeleted),
+		UrgencyOptions:    adminNeedUrgencyOptions(),
+		Notice:            strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:             strings.TrimSpace(r.URL.Query().Get("error")),
+		Failures:          r.URL.Query()["failed"],
 	}
 
 	if err := s.renderTemplate(w, r, "page.admin.need.explorer", data); err != nil {
@@ -158,6 +196,70 @@ func (s *Service) handleGetAdminNeedExplorer(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// handleGetAdminNeedExplorerExport downloads every need the explorer lists
+// for the current status filter, sort and deleted toggle.
+func (s *Service) handleGetAdminNeedExplorerExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	selectedStatus, statusFilter := canonicalAdminExplorerStatus(query.Get("status"))
+	selectedSort := canonicalAdminExplorerSort(query.Get("sort"))
+	showDeleted := query.Get("deleted") == "1"
+	format := parseAdminExportFormat(query.Get("format"))
+
+	filters := url.Values{}
+	if selectedStatus != "" {
+		filters.Set("status", selectedStatus)
+	}
+	filters.Set("sort", selectedSort)
+	if showDeleted {
+		filters.Set("deleted", "1")
+	}
+	filters.Set("format", string(format))
+	if !s.auditAdminExport(w, r, "needs", filters) {
+		return
+	}
+
+	writer, err := startAdminExport(w, format, "needs", []string{
+		"need_id", "status", "urgency", "short_description", "owner_user_id",
+		"amount_needed", "amount_raised", "funding_percent", "featured", "hidden",
+		"submitted_at", "published_at", "closed_at", "created_at", "updated_at",
+		"deleted_at", "delete_reason",
+	}, 5, 6, 7)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to start need explorer export")
+		return
+	}
+
+	err = s.needsRepo.EachAdminExplorerNeed(ctx, statusFilter, selectedSort, showDeleted, func(need *types.Need) error {
+		return writer.Write([]string{
+			need.ID,
+			string(need.Status),
+			string(need.Urgency),
+			derefString(need.ShortDescription),
+			need.UserID,
+			formatExportDollars(need.AmountNeededCents),
+			formatExportDollars(need.AmountRaisedCents),
+			strconv.Itoa(fundingPercentFromCents(need.AmountRaisedCents, need.AmountNeededCents)),
+			strconv.FormatBool(need.IsFeatured),
+			strconv.FormatBool(need.HiddenAt != nil),
+			formatExportTime(need.SubmittedAt),
+			formatExportTime(need.PublishedAt),
+			formatExportTime(need.ClosedAt),
+			formatExportTime(&need.CreatedAt),
+			formatExportTime(&need.UpdatedAt),
+			formatExportTime(need.DeletedAt),
+			derefString(need.DeleteReason),
+		})
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Headers are already sent, so the download is simply cut short.
+		s.logger.WithError(err).Error("failed to stream need explorer export")
+	}
+}
+
 func adminExplorerStatusFilter(raw string) *types.NeedStatus {
 	switch strings.ToUpper(strings.TrimSpace(raw)) {
 	case string(types.NeedStatusDraft):
@@ -172,6 +274,9 @@ func adminExplorerStatusFilter(raw string) *types.NeedStatus {
 	case string(types.NeedStatusUnderReview):
 		status := types.NeedStatusUnderReview
 		return &status
+	case string(types.NeedStatusAwaitingSecondApproval):
+		status := types.NeedStatusAwaitingSecondApproval
+		return &status
 	case string(types.NeedStatusChangesRequested):
 		status := types.NeedStatusChangesRequested
 		return &status
@@ -184,6 +289,9 @@ func adminExplorerStatusFilter(raw string) *types.NeedStatus {
 	case string(types.NeedStatusFunded):
 		status := types.NeedStatusFunded
 		return &status
+	case string(types.NeedStatusClosed):
+		status := types.NeedStatusClosed
+		return &status
 	default:
 		return nil
 	}
@@ -230,10 +338,12 @@ func adminExplorerStatusOptions() []types.AdminExplorerOption {
 		{Value: string(types.NeedStatusSubmitted), Label: "Submitted"},
 		{Value: string(types.NeedStatusReadyForReview), Label: "Ready For Review"},
 		{Value: string(types.NeedStatusUnderReview), Label: "Under Review"},
+		{Value: string(types.NeedStatusAwaitingSecondApproval), Label: "Awaiting Second Approval"},
 		{Value: string(types.NeedStatusChangesRequested), Label: "Changes Requested"},
 		{Value: string(types.NeedStatusRejected), Label: "Rejected"},
 		{Value: string(types.NeedStatusActive), Label: "Active"},
 		{Value: string(types.NeedStatusFunded), Label: "Funded"},
+		{Value: string(types.NeedStatusClosed), Label: "Closed"},
 	}
 }
 
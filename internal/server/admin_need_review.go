@@ -111,7 +111,7 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	_, _, cityState := browseCityStateParts(selectedAddress)
+	_, _, cityState := addressCityStateParts(selectedAddress)
 
 	documents, err := s.documentRepo.DocumentsByNeedID(ctx, needID)
 	if err != nil {
@@ -141,6 +141,11 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 			mimeType = "application/octet-stream"
 		}
 
+		previewHref := ""
+		if sessionCan(r, adminPermissionDocumentsView) {
+			previewHref = s.route(RouteAdminNeedDocument, Param("needID", needID), Param("documentID", document.ID))
+		}
+
 		reviewDocuments = append(reviewDocuments, &types.AdminNeedReviewDocument{
 			ID:          document.ID,
 			FileName:    document.FileName,
@@ -149,7 +154,7 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 			Status:      status,
 			MimeType:    mimeType,
 			FileSize:    formatFileSize(document.FileSizeBytes),
-			PreviewHref: s.route(RouteAdminNeedDocument, Param("needID", needID), Param("documentID", document.ID)),
+			PreviewHref: previewHref,
 		})
 	}
 
@@ -167,6 +172,92 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	revisions, err := s.needRevisionRepo.RevisionsByNeed(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need revisions for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	revisionDiff, err := buildAdminNeedRevisionDiff(revisions)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to build need revision diff for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	flags, err := s.needFlagRepo.FlagsByNeed(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need flags for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	flagViews, openFlagCount := s.buildAdminNeedFlagViews(needID, flags)
+
+	goalChangeRequests, err := s.needGoalChangeRepo.RequestsByNeed(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch goal change requests for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	itemized, err := s.needIsItemized(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need line items for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	reallocations, err := s.fundReallocationRepo.ReallocationsByNeed(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch fund reallocations for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	canManageFunds := sessionCan(r, adminPermissionFundsManage)
+	reallocationViews, err := s.buildAdminFundReallocationViews(ctx, reallocations, canManageFunds)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to build fund reallocations for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	unqueuedBalanceCents, _ := unqueuedReallocationBalanceCents(need, reallocations)
+	if unqueuedBalanceCents > 0 {
+		intents, err := s.donationIntentRepo.FinalizedIntentsByNeedID(ctx, needID)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch gifts for admin review")
+			s.internalServerError(w)
+			return
+		}
+		allocated, err := s.fundReallocationRepo.AllocatedCentsByIntent(ctx, needID)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch reallocated gift shares for admin review")
+			s.internalServerError(w)
+			return
+		}
+		unqueuedBalanceCents = min(unqueuedBalanceCents, attributableReallocationCents(intents, allocated, reallocations))
+	}
+
+	var reallocationCategories []*types.NeedCategory
+	if len(reallocations) > 0 {
+		reallocationCategories, err = s.categoryRepo.Categories(ctx)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch categories for admin review")
+			s.internalServerError(w)
+			return
+		}
+	}
+
+	lineItems, err := s.loadNeedLineItemViews(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need line items for admin review")
+		s.internalServerError(w)
+		return
+	}
+
 	session, ok := sessionFromRequest(r)
 	if !ok {
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need review messages for admin review")
@@ -175,6 +266,9 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 	}
 
 	viewerUserID := session.UserID
+	canModerate := session.Can(adminPermissionNeedsModerate)
+
+	goalChangeViews, pendingGoalChangeCount := s.buildNeedGoalChangeViews(needID, goalChangeRequests, true, itemized, viewerUserID)
 
 	timeline := make([]*types.AdminNeedTimelineItem, 0, len(moderationTimeline))
 	for _, item := range moderationTimeline {
@@ -189,8 +283,8 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 		if event.ActorUserID != nil && strings.TrimSpace(*event.ActorUserID) != "" {
 			actor = *event.ActorUserID
 		}
-		if action != nil && strings.TrimSpace(action.ActorUserID) != "" {
-			actor = action.ActorUserID
+		if action != nil && action.ActorUserID != nil && strings.TrimSpace(*action.ActorUserID) != "" {
+			actor = *action.ActorUserID
 		}
 
 		actionType := "-"
@@ -226,31 +320,111 @@ func (s *Service) handleGetAdminNeedReview(w http.ResponseWriter, r *http.Reques
 		timeline[left], timeline[right] = timeline[right], timeline[left]
 	}
 
+	assignment, err := s.buildAdminNeedAssignmentView(ctx, need, session)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to build reviewer assignment for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	var responseOptions []*types.AdminReviewResponseOption
+	if canModerate {
+		templates, err := s.reviewResponseRepo.Templates(ctx, true)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch review response templates for admin review")
+			s.internalServerError(w)
+			return
+		}
+
+		responseOptions = make([]*types.AdminReviewResponseOption, 0, len(templates))
+		for _, template := range templates {
+			action := reviewResponseFormAction(template.ActionType)
+			responseOptions = append(responseOptions, &types.AdminReviewResponseOption{
+				ID:     template.ID,
+				Action: action,
+				Label:  reviewResponseActionLabel(action) + ": " + template.Title,
+				Reason: template.Reason,
+				Note:   strings.TrimSpace(derefString(template.Note)),
+			})
+		}
+	}
+
+	notes, err := s.adminNoteRepo.NotesByNeed(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch admin notes for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	adminNotes, err := s.buildAdminNotesView(ctx, r, notes, s.route(RouteAdminNeedNoteCreate, Param("needID", needID)))
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to build admin notes for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	secondApproval, err := s.buildAdminNeedSecondApprovalView(ctx, need, viewerUserID, canModerate)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to build second approval for admin review")
+		s.internalServerError(w)
+		return
+	}
+
+	secondApprovalThreshold := s.secondApprovalThresholdCents()
+
+	neededBy := ""
+	if need.NeededBy != nil {
+		neededBy = need.NeededBy.Format(time.DateOnly)
+	}
+
 	data := &types.AdminNeedReviewPageData{
-		BasePageData:        types.BasePageData{Title: "Admin Need Review"},
-		Need:                need,
-		Story:               story,
-		PrimaryCategory:     primaryCategory,
-		SecondaryCategories: secondaryCategories,
-		SelectedAddress:     selectedAddress,
-		CityState:           cityState,
-		Documents:           reviewDocuments,
-		Timeline:            timeline,
-		BackHref:            s.route(RouteAdminNeeds),
-		ModerateAction:      s.route(RouteAdminNeedModerate, Param("needID", needID)),
-		AcceptReviewAction:  s.route(RouteAdminNeedModerate, Param("needID", needID)),
-		CanAcceptReview:     need.Status == types.NeedStatusReadyForReview,
-		CanSubmitModeration: need.Status == types.NeedStatusUnderReview,
-		DeleteAction:        s.route(RouteAdminNeedDelete, Param("needID", needID)),
-		RestoreAction:       s.route(RouteAdminNeedRestore, Param("needID", needID)),
-		IsDeleted:           need.DeletedAt != nil,
-		DeletedAt:           formatOptionalDateTime(need.DeletedAt),
-		DeletedByUserID:     formatOptionalString(need.DeletedByUserID),
-		DeleteReason:        formatOptionalString(need.DeleteReason),
-		Messages:            buildNeedReviewMessageViews(messages, strings.TrimSpace(viewerUserID)),
-		MessageAction:       s.route(RouteAdminNeedMessage, Param("needID", needID)),
-		Notice:              strings.TrimSpace(r.URL.Query().Get("notice")),
-		Error:               strings.TrimSpace(r.URL.Query().Get("error")),
+		BasePageData:            types.BasePageData{Title: "Admin Need Review"},
+		Need:                    need,
+		Story:                   story,
+		PrimaryCategory:         primaryCategory,
+		SecondaryCategories:     secondaryCategories,
+		SelectedAddress:         selectedAddress,
+		CityState:               cityState,
+		NeededBy:                neededBy,
+		Documents:               reviewDocuments,
+		LineItems:               lineItems,
+		Timeline:                timeline,
+		Assignment:              assignment,
+		SecondApproval:          secondApproval,
+		RequiresSecondApproval:  need.Status == types.NeedStatusUnderReview && requiresSecondApproval(need, secondApprovalThreshold),
+		SecondApprovalThreshold: formatUSDFromCents(secondApprovalThreshold),
+		BackHref:                s.route(RouteAdminNeeds),
+		ModerateAction:          s.route(RouteAdminNeedModerate, Param("needID", needID)),
+		AcceptReviewAction:      s.route(RouteAdminNeedModerate, Param("needID", needID)),
+		CanAcceptReview:         canModerate && need.Status == types.NeedStatusReadyForReview,
+		CanSubmitModeration:     (canModerate && need.Status == types.NeedStatusUnderReview) || canGiveSecondApproval(need, viewerUserID, canModerate),
+		CanModerate:             canModerate,
+		CanDelete:               session.Can(adminPermissionNeedsDelete),
+		DeleteAction:            s.route(RouteAdminNeedDelete, Param("needID", needID)),
+		RestoreAction:           s.route(RouteAdminNeedRestore, Param("needID", needID)),
+		IsDeleted:               need.DeletedAt != nil,
+		DeletedAt:               formatOptionalDateTime(need.DeletedAt),
+		DeletedByUserID:         formatOptionalString(need.DeletedByUserID),
+		DeleteReason:            formatOptionalString(need.DeleteReason),
+		Messages:                buildNeedReviewMessageViews(messages, strings.TrimSpace(viewerUserID)),
+		RevisionDiff:            revisionDiff,
+		RevisionCount:           len(revisions),
+		Flags:                   flagViews,
+		OpenFlagCount:           openFlagCount,
+		GoalChanges:             goalChangeViews,
+		PendingGoalChangeCount:  pendingGoalChangeCount,
+		Reallocations:           reallocationViews,
+		UnqueuedBalance:         formatUSDFromCents(unqueuedBalanceCents),
+		CanQueueReallocation:    canManageFunds && unqueuedBalanceCents > 0,
+		ReallocationQueueAction: s.route(RouteAdminNeedReallocationQueue, Param("needID", needID)),
+		ReallocationCategories:  reallocationCategories,
+		MessageAction:           s.route(RouteAdminNeedMessage, Param("needID", needID)),
+		ResponseTemplates:       responseOptions,
+		ChangeRequestOptions:    needChangeRequestChecklistOptions(documents),
+		ManageResponsesHref:     s.route(RouteAdminReviewResponses),
+		AdminNotes:              adminNotes,
+		Notice:                  strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:                   strings.TrimSpace(r.URL.Query().Get("error")),
 	}
 
 	if err := s.renderTemplate(w, r, "page.admin.need.review", data); err != nil {
@@ -311,42 +485,74 @@ func (s *Service) handlePostAdminNeedModerate(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if action == "set_needed_by" {
+		var neededBy *time.Time
+		if raw := strings.TrimSpace(r.FormValue("needed_by")); raw != "" {
+			parsed, err := time.ParseInLocation(time.DateOnly, raw, time.Local)
+			if err != nil {
+				s.redirectAdminNeedReviewWithError(w, r, needID, "invalid needed by date")
+				return
+			}
+			neededBy = &parsed
+		}
+		if err := s.needsRepo.SetNeedNeededBy(r.Context(), needID, neededBy); err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to update need needed by date")
+			s.redirectAdminNeedReviewWithError(w, r, needID, "failed to update needed by date")
+			return
+		}
+		v := url.Values{}
+		v.Set("notice", "Needed by date updated")
+		http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+		return
+	}
+
 	var newStatus *types.NeedStatus
 	var actionType types.NeedModerationActionType
 	var moderationDocumentID *string
 	var notice string
 
+	// A need awaiting second approval is decided by whichever admin picks it
+	// up, so the reviewer claim does not apply.
+	isSecondApproval := need.Status == types.NeedStatusAwaitingSecondApproval
+
 	switch action {
 	case "accept_review":
-		if need.Status != types.NeedStatusReadyForReview {
-			s.redirectAdminNeedReviewWithError(w, r, needID, "need must be ready for review before accepting")
-			return
-		}
 		status := types.NeedStatusUnderReview
 		newStatus = &status
 		actionType = types.NeedModerationActionTypeReviewStarted
 		notice = "Review accepted"
 	case "approve":
-		if need.Status != types.NeedStatusUnderReview {
-			s.redirectAdminNeedReviewWithError(w, r, needID, "need must be under review before approval")
+		// Restricting a user hides their live needs, so one of their queued
+		// needs must not be published while the restriction stands.
+		restriction, err := s.restrictionCheck.ActiveRestrictionByUser(r.Context(), need.UserID)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to check owner restriction before approval")
+			s.internalServerError(w)
 			return
 		}
+		if restriction != nil {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "the owner of this need is suspended or banned; it cannot be approved until the restriction is lifted")
+			return
+		}
+
+		status := types.NeedStatusActive
+		newStatus = &status
 		actionType = types.NeedModerationActionTypeReviewApproved
 		notice = "Need approved and published"
-	case "reject":
-		if need.Status != types.NeedStatusUnderReview {
-			s.redirectAdminNeedReviewWithError(w, r, needID, "need must be under review before rejection")
-			return
+		switch {
+		case isSecondApproval:
+			actionType = types.NeedModerationActionTypeReviewSecondApproved
+			notice = "Second approval recorded; need published"
+		case requiresSecondApproval(need, s.secondApprovalThresholdCents()):
+			status = types.NeedStatusAwaitingSecondApproval
+			notice = "Need approved; a second admin must approve it before it is published"
 		}
+	case "reject":
 		status := types.NeedStatusRejected
 		newStatus = &status
 		actionType = types.NeedModerationActionTypeReviewRejected
 		notice = "Need rejected"
 	case "request_changes":
-		if need.Status != types.NeedStatusUnderReview {
-			s.redirectAdminNeedReviewWithError(w, r, needID, "need must be under review before requesting changes")
-			return
-		}
 		status := types.NeedStatusChangesRequested
 		newStatus = &status
 		actionType = types.NeedModerationActionTypeChangesRequested
@@ -394,6 +600,50 @@ func (s *Service) handlePostAdminNeedModerate(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if responseID := strings.TrimSpace(r.FormValue("response_id")); responseID != "" {
+		template, err := s.reviewResponseRepo.Template(r.Context(), responseID)
+		if err != nil && !errors.Is(err, types.ErrReviewResponseTemplateNotFound) {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch review response template")
+			s.internalServerError(w)
+			return
+		}
+		if template == nil || template.ArchivedAt != nil || reviewResponseFormAction(template.ActionType) != action {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "that saved response does not apply to this action")
+			return
+		}
+
+		// The reviewer's own wording wins over the saved response.
+		if reason == "" {
+			reason = template.Reason
+		}
+		if note == "" && template.Note != nil {
+			note = strings.TrimSpace(*template.Note)
+		}
+	}
+
+	var changeRequestItems []*types.NeedChangeRequestItem
+	if action == "request_changes" {
+		documents, err := s.documentRepo.DocumentsByNeedID(r.Context(), needID)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch documents for change request checklist")
+			s.internalServerError(w)
+			return
+		}
+
+		documentIDs := make(map[string]bool, len(documents))
+		for _, doc := range documents {
+			documentIDs[doc.ID] = true
+		}
+
+		changeRequestItems, err = parseNeedChangeRequestChecklist(r.Form["checklist"], func(value string) string {
+			return r.FormValue("checklist_note_" + value)
+		}, documentIDs)
+		if err != nil {
+			s.redirectAdminNeedReviewWithError(w, r, needID, err.Error())
+			return
+		}
+	}
+
 	session, ok := sessionFromRequest(r)
 	if !ok {
 		s.logger.Error("session not found on context")
@@ -414,10 +664,29 @@ func (s *Service) handlePostAdminNeedModerate(w http.ResponseWriter, r *http.Req
 	}
 
 	if err := store.WithTx(r.Context(), s.needsRepo, func(tx pgx.Tx) error {
-		if action == "approve" {
-			if err := s.needsRepo.PublishNeedTx(r.Context(), tx, needID); err != nil {
+		assignedUserID, err := s.needsRepo.NeedReviewerForUpdateTx(r.Context(), tx, needID)
+		if err != nil {
+			return err
+		}
+		if assignedUserID != nil && *assignedUserID != actorUserID && !isSecondApproval {
+			return types.ErrNeedClaimedByOther
+		}
+		if assignedUserID == nil && action == "accept_review" {
+			if err := s.needsRepo.SetNeedReviewerTx(r.Context(), tx, needID, &actorUserID); err != nil {
+				return err
+			}
+			if _, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeReviewClaimed, actorUserID, nil, nil, nil); err != nil {
+				return err
+			}
+		}
+
+		if newStatus != nil {
+			if err := s.needsRepo.TransitionNeedStatusTx(r.Context(), tx, needID, *newStatus, types.NeedProgressEventSourceAdmin, actorUserID); err != nil {
 				return err
 			}
+		}
+
+		if action == "approve" && !isSecondApproval {
 			urgency := types.NeedUrgencyMedium
 			switch urgencyInput {
 			case "low":
@@ -430,20 +699,33 @@ func (s *Service) handlePostAdminNeedModerate(w http.ResponseWriter, r *http.Req
 			if err := s.needsRepo.SetNeedUrgencyTx(r.Context(), tx, needID, urgency); err != nil {
 				return err
 			}
-		} else if newStatus != nil {
-			if err := s.needsRepo.SetNeedStatusTx(r.Context(), tx, needID, *newStatus); err != nil {
-				return err
-			}
 		}
 
-		_, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, actionType, actorUserID, reasonPtr, notePtr, moderationDocumentID)
-		return err
+		moderationAction, err := s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, actionType, actorUserID, reasonPtr, notePtr, moderationDocumentID)
+		if err != nil {
+			return err
+		}
+
+		return s.progressRepo.CreateChangeRequestItemsTx(r.Context(), tx, needID, moderationAction.ID, changeRequestItems)
 	}); err != nil {
+		if errors.Is(err, types.ErrNeedClaimedByOther) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "need is claimed by another reviewer; ask a review lead to reassign it")
+			return
+		}
+		if message, ok := needTransitionErrorMessage(err); ok {
+			s.redirectAdminNeedReviewWithError(w, r, needID, message)
+			return
+		}
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to atomically apply moderation action")
 		s.redirectAdminNeedReviewWithError(w, r, needID, "moderation action failed")
 		return
 	}
 
+	if action == "request_changes" {
+		// Baseline for the field-level diff shown once the owner resubmits.
+		s.recordNeedRevision(r.Context(), needID, actorUserID, types.NeedRevisionSourceChangesRequested)
+	}
+
 	v := url.Values{}
 	v.Set("notice", notice)
 	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+const defaultSecondApprovalThresholdCents = 500000
+
+// secondApprovalThresholdCents is the requested amount above which a need
+// needs two admins to approve it. Zero turns two-person approval off.
+func (s *Service) secondApprovalThresholdCents() int {
+	if s.config == nil {
+		return defaultSecondApprovalThresholdCents
+	}
+	return s.config.SecondApprovalThresholdCents
+}
+
+// requiresSecondApproval reports whether approving need under review should
+// hold it for a second approver instead of publishing it.
+func requiresSecondApproval(need *types.Need, thresholdCents int) bool {
+	return thresholdCents > 0 && need.AmountNeededCents > thresholdCents
+}
+
+// canGiveSecondApproval reports whether the viewer may give the second
+// approval: anyone allowed to moderate except the admin who gave the first.
+func canGiveSecondApproval(need *types.Need, viewerUserID string, canModerate bool) bool {
+	if !canModerate || need.Status != types.NeedStatusAwaitingSecondApproval {
+		return false
+	}
+	return need.FirstApprovedByUserID == nil || *need.FirstApprovedByUserID != viewerUserID
+}
+
+func (s *Service) buildAdminNeedSecondApprovalView(ctx context.Context, need *types.Need, viewerUserID string, canModerate bool) (*types.AdminNeedSecondApprovalView, error) {
+	if need.Status != types.NeedStatusAwaitingSecondApproval {
+		return nil, nil
+	}
+
+	view := &types.AdminNeedSecondApprovalView{
+		FirstApprover:   "former admin",
+		FirstApprovedAt: formatOptionalDateTime(need.FirstApprovedAt),
+		IsFirstApprover: need.FirstApprovedByUserID != nil && *need.FirstApprovedByUserID == viewerUserID,
+		CanApprove:      canGiveSecondApproval(need, viewerUserID, canModerate),
+	}
+
+	if need.FirstApprovedByUserID != nil {
+		view.FirstApprover = *need.FirstApprovedByUserID
+		approver, err := s.userRepo.User(ctx, *need.FirstApprovedByUserID)
+		if err != nil && !errors.Is(err, types.ErrUserNotFound) {
+			return nil, err
+		}
+		if approver != nil {
+			view.FirstApprover = userDisplayName(approver)
+		}
+	}
+
+	return view, nil
+}
+
+func (s *Service) handleGetAdminNeedsSecondApproval(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.internalServerError(w)
+		return
+	}
+
+	needs, err := s.needsRepo.SecondApprovalQueueNeeds(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch second approval queue")
+		s.internalServerError(w)
+		return
+	}
+
+	approverIDs := make([]string, 0, len(needs))
+	for _, need := range needs {
+		if need.FirstApprovedByUserID != nil {
+			approverIDs = append(approverIDs, *need.FirstApprovedByUserID)
+		}
+	}
+
+	approverNames := make(map[string]string, len(approverIDs))
+	if len(approverIDs) > 0 {
+		approvers, err := s.userRepo.UsersByIDs(ctx, uniqueSortedStrings(approverIDs))
+		if err != nil {
+			s.logger.WithError(err).Error("failed to fetch first approvers for second approval queue")
+			s.internalServerError(w)
+			return
+		}
+		for _, approver := range approvers {
+			approverNames[approver.ID] = userDisplayName(approver)
+		}
+	}
+
+	now := time.Now()
+	canModerate := session.Can(adminPermissionNeedsModerate)
+
+	items := make([]*types.AdminNeedSecondApprovalItem, 0, len(needs))
+	for _, need := range needs {
+		item := &types.AdminNeedSecondApprovalItem{
+			NeedID:          need.ID,
+			Amount:          formatUSDFromCents(need.AmountNeededCents),
+			FirstApprover:   "former admin",
+			FirstApprovedAt: formatOptionalDateTime(need.FirstApprovedAt),
+			ReviewHref:      s.route(RouteAdminNeedReview, Param("needID", need.ID)),
+			CanApprove:      canGiveSecondApproval(need, session.UserID, canModerate),
+		}
+		if need.FirstApprovedByUserID != nil {
+			item.FirstApprover = approverNames[*need.FirstApprovedByUserID]
+			if item.FirstApprover == "" {
+				item.FirstApprover = *need.FirstApprovedByUserID
+			}
+			item.IsFirstApprover = *need.FirstApprovedByUserID == session.UserID
+		}
+		if need.FirstApprovedAt != nil {
+			item.Waiting = formatWaitDuration(now.Sub(*need.FirstApprovedAt))
+		}
+		items = append(items, item)
+	}
+
+	data := &types.AdminNeedsSecondApprovalPageData{
+		BasePageData:   types.BasePageData{Title: "Second Approval"},
+		Needs:          items,
+		ThresholdLabel: formatUSDFromCents(s.secondApprovalThresholdCents()),
+		IsEnabled:      s.secondApprovalThresholdCents() > 0,
+		QueueHref:      s.route(RouteAdminNeeds),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.needs.second_approval", data); err != nil {
+		s.logger.WithError(err).Error("failed to render second approval queue")
+		s.internalServerError(w)
+		return
+	}
+}
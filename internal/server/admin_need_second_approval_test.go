@@ -0,0 +1,48 @@
+package server
+
+import (
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestRequiresSecondApproval(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int
+		threshold int
+		want      bool
+	}{
+		{name: "above threshold", amount: 500001, threshold: 500000, want: true},
+		{name: "at threshold", amount: 500000, threshold: 500000, want: false},
+		{name: "below threshold", amount: 1000, threshold: 500000, want: false},
+		{name: "disabled", amount: 9000000, threshold: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		need := &types.Need{AmountNeededCents: tt.amount}
+		if got := requiresSecondApproval(need, tt.threshold); got != tt.want {
+			t.Fatalf("%s: requiresSecondApproval() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCanGiveSecondApproval(t *testing.T) {
+	first := "usr_first"
+	awaiting := &types.Need{Status: types.NeedStatusAwaitingSecondApproval, FirstApprovedByUserID: &first}
+
+	if canGiveSecondApproval(awaiting, first, true) {
+		t.Fatal("first approver must not give the second approval")
+	}
+	if !canGiveSecondApproval(awaiting, "usr_second", true) {
+		t.Fatal("a different moderator should be able to give the second approval")
+	}
+	if canGiveSecondApproval(awaiting, "usr_second", false) {
+		t.Fatal("admins without moderate permission cannot approve")
+	}
+
+	underReview := &types.Need{Status: types.NeedStatusUnderReview}
+	if canGiveSecondApproval(underReview, "usr_second", true) {
+		t.Fatal("second approval only applies to needs awaiting it")
+	}
+}
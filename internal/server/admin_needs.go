@@ -16,13 +16,33 @@ func (s *Service) handleGetAdminNeeds(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	page := parsePositiveInt(r.URL.Query().Get("page"), 1)
 
-	totalNeeds, err := s.needsRepo.ModerationQueueNeedsCount(ctx)
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.internalServerError(w)
+		return
+	}
+
+	viewerUserID := session.UserID
+	assignedToMe := strings.TrimSpace(r.URL.Query().Get("assigned")) == "me"
+
+	reviewerFilter := ""
+	if assignedToMe {
+		reviewerFilter = viewerUserID
+	}
+
+	candidates, err := s.needsRepo.ModerationQueueCandidates(ctx, reviewerFilter)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to count needs for admin queue")
+		s.logger.WithError(err).Error("failed to fetch needs for admin queue")
 		s.internalServerError(w)
 		return
 	}
 
+	now := time.Now()
+	weights := s.queuePriorityWeights()
+	scored := prioritizeQueueCandidates(candidates, weights, now)
+
+	totalNeeds := len(scored)
 	totalPages := totalNeeds / adminNeedsPageSize
 	if totalNeeds%adminNeedsPageSize != 0 {
 		totalPages++
@@ -34,18 +54,35 @@ func (s *Service) handleGetAdminNeeds(w http.ResponseWriter, r *http.Request) {
 		page = totalPages
 	}
 
-	needs, err := s.needsRepo.ModerationQueueNeedsPage(ctx, page, adminNeedsPageSize)
-	if err != nil {
-		s.logger.WithError(err).Error("failed to fetch needs for admin queue")
-		s.internalServerError(w)
-		return
+	start := min((page-1)*adminNeedsPageSize, totalNeeds)
+	end := min(start+adminNeedsPageSize, totalNeeds)
+	scored = scored[start:end]
+
+	reviewerIDs := make([]string, 0, len(scored))
+	for _, entry := range scored {
+		if entry.candidate.ReviewerUserID != nil {
+			reviewerIDs = append(reviewerIDs, *entry.candidate.ReviewerUserID)
+		}
 	}
 
-	items := make([]*types.AdminNeedQueueItem, 0, len(needs))
-	for _, need := range needs {
-		if need == nil {
-			continue
+	reviewerNames := make(map[string]string, len(reviewerIDs))
+	if len(reviewerIDs) > 0 {
+		reviewers, err := s.userRepo.UsersByIDs(ctx, reviewerIDs)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to fetch assigned reviewers for admin queue")
+			s.internalServerError(w)
+			return
+		}
+		for _, reviewer := range reviewers {
+			reviewerNames[reviewer.ID] = userDisplayName(reviewer)
 		}
+	}
+
+	sla := s.reviewSLA()
+
+	items := make([]*types.AdminNeedQueueItem, 0, len(scored))
+	for _, entry := range scored {
+		need := &entry.candidate.Need
 
 		needID := strings.TrimSpace(need.ID)
 		if needID == "" {
@@ -58,29 +95,64 @@ func (s *Service) handleGetAdminNeeds(w http.ResponseWriter, r *http.Request) {
 			submittedAt = need.SubmittedAt.Format(time.DateOnly)
 		}
 
+		assignedTo := ""
+		claimAction := s.route(RouteAdminNeedClaim, Param("needID", needID))
+		if need.ReviewerUserID != nil {
+			assignedTo = reviewerNames[*need.ReviewerUserID]
+			if assignedTo == "" {
+				assignedTo = *need.ReviewerUserID
+			}
+			claimAction = ""
+		}
+
+		slaLabel, isOverdue := reviewSLAStatus(need.SubmittedAt, sla, now)
+
+		neededBy := ""
+		if need.NeededBy != nil {
+			neededBy = need.NeededBy.Format(time.DateOnly)
+		}
+
 		items = append(items, &types.AdminNeedQueueItem{
 			NeedID:      needID,
 			Status:      need.Status,
 			CreatedAt:   need.CreatedAt.Format(time.DateOnly),
 			SubmittedAt: submittedAt,
 			ReviewHref:  s.route(RouteAdminNeedReview, Param("needID", needID)),
+			AssignedTo:  assignedTo,
+			IsMine:      need.ReviewerUserID != nil && *need.ReviewerUserID == viewerUserID,
+			SLALabel:    slaLabel,
+			IsOverdue:   isOverdue,
+			ClaimAction: claimAction,
+			Urgency:     need.Urgency,
+			NeededBy:    neededBy,
+
+			PriorityScore:   entry.score,
+			PriorityFactors: entry.factors,
 		})
 	}
 
+	pageHref := func(target int) string {
+		v := url.Values{}
+		v.Set("page", strconv.Itoa(target))
+		if assignedToMe {
+			v.Set("assigned", "me")
+		}
+		return s.routeWithQuery(RouteAdminNeeds, v)
+	}
+
 	prevHref := ""
 	if page > 1 {
-		v := url.Values{}
-		v.Set("page", strconv.Itoa(page-1))
-		prevHref = s.routeWithQuery(RouteAdminNeeds, v)
+		prevHref = pageHref(page - 1)
 	}
 
 	nextHref := ""
 	if page < totalPages {
-		v := url.Values{}
-		v.Set("page", strconv.Itoa(page+1))
-		nextHref = s.routeWithQuery(RouteAdminNeeds, v)
+		nextHref = pageHref(page + 1)
 	}
 
+	mineQuery := url.Values{}
+	mineQuery.Set("assigned", "me")
+
 	data := &types.AdminNeedsPageData{
 		BasePageData: types.BasePageData{Title: "Admin Needs"},
 		Needs:        items,
@@ -90,6 +162,12 @@ func (s *Service) handleGetAdminNeeds(w http.ResponseWriter, r *http.Request) {
 		TotalPages:   totalPages,
 		PrevHref:     prevHref,
 		NextHref:     nextHref,
+		AssignedToMe: assignedToMe,
+		AllHref:      s.route(RouteAdminNeeds),
+		MineHref:     s.routeWithQuery(RouteAdminNeeds, mineQuery),
+		SLAHours:     int(sla / time.Hour),
+
+		PriorityWeights: weights.describe(),
 	}
 
 	if err := s.renderTemplate(w, r, "page.admin.needs", data); err != nil {
@@ -0,0 +1,377 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const maxAdminNoteLength = 4000
+
+// parseAdminNoteBody reads the note body from a create or edit form. The
+// returned message is non-empty when the body is invalid.
+func parseAdminNoteBody(r *http.Request) (string, string) {
+	body := strings.TrimSpace(r.FormValue("body"))
+	if body == "" {
+		return "", "note cannot be empty"
+	}
+	if len([]rune(body)) > maxAdminNoteLength {
+		return "", "note is too long"
+	}
+	return body, ""
+}
+
+func (s *Service) handlePostAdminNeedNoteCreate(w http.ResponseWriter, r *http.Request) {
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	if needID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if _, err := s.needsRepo.Need(r.Context(), needID); err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need for admin note")
+		s.internalServerError(w)
+		return
+	}
+
+	s.createAdminNote(w, r, &types.AdminNote{NeedID: &needID})
+}
+
+func (s *Service) handlePostAdminUserNoteCreate(w http.ResponseWriter, r *http.Request) {
+	userID := strings.TrimSpace(r.PathValue("userID"))
+	if userID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if _, err := s.userRepo.User(r.Context(), userID); err != nil {
+		if errors.Is(err, types.ErrUserNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to fetch user for admin note")
+		s.internalServerError(w)
+		return
+	}
+
+	s.createAdminNote(w, r, &types.AdminNote{UserID: &userID})
+}
+
+// createAdminNote saves a note about the need or user already set on note and
+// returns the admin to the page it is shown on.
+func (s *Service) createAdminNote(w http.ResponseWriter, r *http.Request, note *types.AdminNote) {
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNoteTarget(w, r, note, "error", "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNoteTarget(w, r, note, "error", "missing actor identity")
+		return
+	}
+
+	body, message := parseAdminNoteBody(r)
+	if message != "" {
+		s.redirectAdminNoteTarget(w, r, note, "error", message)
+		return
+	}
+
+	authorUserID := session.UserID
+	note.AuthorUserID = &authorUserID
+	note.Body = body
+
+	if err := s.adminNoteRepo.CreateNote(r.Context(), note); err != nil {
+		s.logger.WithError(err).Error("failed to create admin note")
+		s.redirectAdminNoteTarget(w, r, note, "error", "failed to save note")
+		return
+	}
+
+	s.redirectAdminNoteTarget(w, r, note, "notice", "Note added")
+}
+
+func (s *Service) handlePostAdminNoteUpdate(w http.ResponseWriter, r *http.Request) {
+	note, ok := s.adminNoteForRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNoteTarget(w, r, note, "error", "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNoteTarget(w, r, note, "error", "missing actor identity")
+		return
+	}
+
+	if !adminNoteEditableBy(note, session) {
+		s.redirectAdminNoteTarget(w, r, note, "error", "only the author or a superadmin can edit this note")
+		return
+	}
+
+	body, message := parseAdminNoteBody(r)
+	if message != "" {
+		s.redirectAdminNoteTarget(w, r, note, "error", message)
+		return
+	}
+
+	ctx := r.Context()
+	err := store.WithTx(ctx, s.adminNoteRepo, func(tx pgx.Tx) error {
+		_, err := s.adminNoteRepo.UpdateNoteBodyTx(ctx, tx, note.ID, body, session.UserID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrAdminNoteNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("note_id", note.ID).Error("failed to update admin note")
+		s.redirectAdminNoteTarget(w, r, note, "error", "failed to save note")
+		return
+	}
+
+	s.redirectAdminNoteTarget(w, r, note, "notice", "Note updated")
+}
+
+func (s *Service) handlePostAdminNotePin(w http.ResponseWriter, r *http.Request) {
+	note, ok := s.adminNoteForRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNoteTarget(w, r, note, "error", "invalid form submission")
+		return
+	}
+
+	if _, ok := sessionFromRequest(r); !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNoteTarget(w, r, note, "error", "missing actor identity")
+		return
+	}
+
+	pinned := r.FormValue("pinned") != "0"
+	action := types.AdminAuditActionNoteUnpinned
+	if pinned {
+		action = types.AdminAuditActionNotePinned
+	}
+
+	ctx := r.Context()
+	err := store.WithTx(ctx, s.adminNoteRepo, func(tx pgx.Tx) error {
+		if err := s.adminNoteRepo.SetNotePinnedTx(ctx, tx, note.ID, pinned); err != nil {
+			return err
+		}
+		return s.adminAuditRepo.RecordEventTx(ctx, tx, s.adminAuditEvent(r, action, types.AdminAuditTargetAdminNote, note.ID, adminNoteAuditDetail(note)))
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrAdminNoteNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("note_id", note.ID).Error("failed to pin admin note")
+		s.redirectAdminNoteTarget(w, r, note, "error", "failed to update note")
+		return
+	}
+
+	notice := "Note unpinned"
+	if pinned {
+		notice = "Note pinned"
+	}
+	s.redirectAdminNoteTarget(w, r, note, "notice", notice)
+}
+
+// adminNoteEditableBy reports whether session may change note's body. Any
+// admin with notes.write may pin a note, but only its author or a superadmin
+// may reword it.
+func adminNoteEditableBy(note *types.AdminNote, session *AuthSession) bool {
+	if session == nil {
+		return false
+	}
+	if note.AuthorUserID != nil && *note.AuthorUserID == session.UserID {
+		return true
+	}
+	return slices.Contains(session.AdminRoles, types.AdminRoleSuperadmin)
+}
+
+// adminNoteAuditDetail names the need or user a note is about.
+func adminNoteAuditDetail(note *types.AdminNote) string {
+	if note.NeedID != nil {
+		return "note on need " + *note.NeedID
+	}
+	return "note on user " + derefString(note.UserID)
+}
+
+// adminNoteForRequest loads the note named in the path. Editing a note also
+// requires being allowed to view the page it is shown on, so a reviewer
+// cannot reach user notes by ID.
+func (s *Service) adminNoteForRequest(w http.ResponseWriter, r *http.Request) (*types.AdminNote, bool) {
+	noteID := strings.TrimSpace(r.PathValue("noteID"))
+	if noteID == "" {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	note, err := s.adminNoteRepo.Note(r.Context(), noteID)
+	if err != nil {
+		if errors.Is(err, types.ErrAdminNoteNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		s.logger.WithError(err).WithField("note_id", noteID).Error("failed to fetch admin note")
+		s.internalServerError(w)
+		return nil, false
+	}
+
+	if !sessionCan(r, adminNoteViewPermission(note)) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return nil, false
+	}
+
+	return note, true
+}
+
+// adminNoteViewPermission is the permission needed to see the page a note is
+// shown on.
+func adminNoteViewPermission(note *types.AdminNote) adminPermission {
+	if note.NeedID != nil {
+		return adminPermissionNeedsView
+	}
+	return adminPermissionUsersView
+}
+
+// redirectAdminNoteTarget returns to the need review or user detail page the
+// note belongs to with a notice or error message.
+func (s *Service) redirectAdminNoteTarget(w http.ResponseWriter, r *http.Request, note *types.AdminNote, key, message string) {
+	v := url.Values{}
+	v.Set(key, message)
+
+	target := s.routeWithQuery(RouteAdminUserDetail, v, Param("userID", derefString(note.UserID)))
+	if note.NeedID != nil {
+		target = s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", *note.NeedID))
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// populateAdminUserNotes fills the internal notes panel of the user detail
+// page.
+func (s *Service) populateAdminUserNotes(ctx context.Context, r *http.Request, data *types.AdminUserDetailPageData, userID string) {
+	notes, err := s.adminNoteRepo.NotesByUser(ctx, userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to fetch notes for admin user detail")
+		return
+	}
+
+	view, err := s.buildAdminNotesView(ctx, r, notes, s.route(RouteAdminUserNoteCreate, Param("userID", userID)))
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to build notes for admin user detail")
+		return
+	}
+	data.AdminNotes = view
+}
+
+// buildAdminNotesView renders notes for the panel, resolving authors and
+// editors to display names.
+func (s *Service) buildAdminNotesView(ctx context.Context, r *http.Request, notes []*types.AdminNote, createAction string) (*types.AdminNotesView, error) {
+	noteIDs := make([]string, 0, len(notes))
+	for _, note := range notes {
+		noteIDs = append(noteIDs, note.ID)
+	}
+
+	revisions, err := s.adminNoteRepo.RevisionsByNotes(ctx, noteIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	actorIDs := make([]string, 0, len(notes)*2)
+	for _, note := range notes {
+		if note.AuthorUserID != nil {
+			actorIDs = append(actorIDs, *note.AuthorUserID)
+		}
+		if note.UpdatedByUserID != nil {
+			actorIDs = append(actorIDs, *note.UpdatedByUserID)
+		}
+		for _, revision := range revisions[note.ID] {
+			if revision.EditedByUserID != nil {
+				actorIDs = append(actorIDs, *revision.EditedByUserID)
+			}
+		}
+	}
+
+	actorNames := make(map[string]string)
+	if len(actorIDs) > 0 {
+		actors, err := s.userRepo.UsersByIDs(ctx, uniqueSortedStrings(actorIDs))
+		if err != nil {
+			s.logger.WithError(err).Warn("failed to fetch admin note authors")
+		}
+		for _, actor := range actors {
+			actorNames[actor.ID] = userDisplayName(actor)
+		}
+	}
+	actorName := func(id *string) string {
+		if id == nil {
+			return "former admin"
+		}
+		if name, ok := actorNames[*id]; ok {
+			return name
+		}
+		return *id
+	}
+
+	session, _ := sessionFromRequest(r)
+	canWrite := sessionCan(r, adminPermissionNotesWrite)
+	items := make([]*types.AdminNoteItem, 0, len(notes))
+	for _, note := range notes {
+		item := &types.AdminNoteItem{
+			ID:        note.ID,
+			Body:      note.Body,
+			Author:    actorName(note.AuthorUserID),
+			CreatedAt: note.CreatedAt.Format(time.DateTime),
+			IsPinned:  note.PinnedAt != nil,
+		}
+
+		history := revisions[note.ID]
+		if len(history) > 0 {
+			item.EditedAt = note.UpdatedAt.Format(time.DateTime)
+			item.EditedBy = actorName(note.UpdatedByUserID)
+		}
+		for _, revision := range history {
+			item.History = append(item.History, &types.AdminNoteRevisionItem{
+				Body:     revision.Body,
+				EditedBy: actorName(revision.EditedByUserID),
+				EditedAt: revision.CreatedAt.Format(time.DateTime),
+			})
+		}
+
+		if canWrite {
+			item.PinAction = s.route(RouteAdminNotePin, Param("noteID", note.ID))
+			if adminNoteEditableBy(note, session) {
+				item.UpdateAction = s.route(RouteAdminNoteUpdate, Param("noteID", note.ID))
+			}
+		}
+		items = append(items, item)
+	}
+
+	return &types.AdminNotesView{
+		Notes:        items,
+		CanWrite:     canWrite,
+		CreateAction: createAction,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestParseAdminNoteBody(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		form := url.Values{"body": {body}}
+		req := httptest.NewRequest(http.MethodPost, "/admin/notes", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	body, message := parseAdminNoteBody(newRequest("  Verified by Pastor X  "))
+	if message != "" || body != "Verified by Pastor X" {
+		t.Fatalf("parseAdminNoteBody() = %q, %q", body, message)
+	}
+	if _, message := parseAdminNoteBody(newRequest("   ")); message == "" {
+		t.Fatal("blank note should be rejected")
+	}
+	if _, message := parseAdminNoteBody(newRequest(strings.Repeat("a", maxAdminNoteLength+1))); message == "" {
+		t.Fatal("overlong note should be rejected")
+	}
+}
+
+func TestAdminNoteViewPermission(t *testing.T) {
+	needID := "need_1"
+	userID := "user_1"
+
+	if got := adminNoteViewPermission(&types.AdminNote{NeedID: &needID}); got != adminPermissionNeedsView {
+		t.Fatalf("need note permission = %q", got)
+	}
+	if got := adminNoteViewPermission(&types.AdminNote{UserID: &userID}); got != adminPermissionUsersView {
+		t.Fatalf("user note permission = %q", got)
+	}
+
+	// Reviewers can write notes but only reach the ones on needs.
+	reviewer := []types.AdminRole{types.AdminRoleReviewer}
+	if !adminRolesAllow(reviewer, adminPermissionNotesWrite) || adminRolesAllow(reviewer, adminPermissionUsersView) {
+		t.Fatal("reviewer should write need notes but not see user notes")
+	}
+	if adminRolesAllow([]types.AdminRole{types.AdminRoleFinance}, adminPermissionNotesWrite) {
+		t.Fatal("finance should not write notes")
+	}
+}
+
+func TestAdminNoteEditableBy(t *testing.T) {
+	authorID := "user_author"
+	note := &types.AdminNote{AuthorUserID: &authorID}
+
+	author := &AuthSession{UserID: authorID, IsAdmin: true, AdminRoles: []types.AdminRole{types.AdminRoleReviewer}}
+	if !adminNoteEditableBy(note, author) {
+		t.Fatal("author should be able to edit their note")
+	}
+
+	other := &AuthSession{UserID: "user_other", IsAdmin: true, AdminRoles: []types.AdminRole{types.AdminRoleSupport}}
+	if adminNoteEditableBy(note, other) {
+		t.Fatal("another admin should not edit someone else's note")
+	}
+
+	superadmin := &AuthSession{UserID: "user_super", IsAdmin: true, AdminRoles: []types.AdminRole{types.AdminRoleSuperadmin}}
+	if !adminNoteEditableBy(note, superadmin) {
+		t.Fatal("superadmin should be able to edit any note")
+	}
+	if !adminNoteEditableBy(&types.AdminNote{}, superadmin) {
+		t.Fatal("superadmin should be able to edit a note whose author was removed")
+	}
+	if adminNoteEditableBy(&types.AdminNote{}, other) || adminNoteEditableBy(note, nil) {
+		t.Fatal("orphaned notes and missing sessions should not be editable")
+	}
+}
@@ -0,0 +1,313 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+// adminPermission names one capability inside the admin area. Route groups and
+// individual actions check permissions rather than roles so a role can be
+// widened without touching handlers.
+type adminPermission string
+
+const (
+	adminPermissionNeedsView        adminPermission = "needs.view"
+	adminPermissionNeedsModerate    adminPermission = "needs.moderate"
+	adminPermissionNeedsAssign      adminPermission = "needs.assign"
+	adminPermissionNeedsDelete      adminPermission = "needs.delete"
+	adminPermissionDocumentsView    adminPermission = "documents.view"
+	adminPermissionFundsManage      adminPermission = "funds.manage"
+	adminPermissionDonationsView    adminPermission = "donations.view"
+	adminPermissionUsersView        adminPermission = "users.view"
+	adminPermissionUsersManage      adminPermission = "users.manage"
+	adminPermissionEmailsView       adminPermission = "emails.view"
+	adminPermissionEmailsManage     adminPermission = "emails.manage"
+	adminPermissionRolesManage      adminPermission = "roles.manage"
+	adminPermissionAuditView        adminPermission = "audit.view"
+	adminPermissionCategoriesManage adminPermission = "categories.manage"
+	adminPermissionNotesWrite       adminPermission = "notes.write"
+)
+
+// adminRolePermissions is what each role may do. Superadmins may do
+// everything and are not listed.
+var adminRolePermissions = map[types.AdminRole][]adminPermission{
+	types.AdminRoleReviewer: {
+		adminPermissionNeedsView,
+		adminPermissionNeedsModerate,
+		adminPermissionDocumentsView,
+		adminPermissionNotesWrite,
+	},
+	types.AdminRoleFinance: {
+		adminPermissionNeedsView,
+		adminPermissionFundsManage,
+		adminPermissionDonationsView,
+	},
+	types.AdminRoleSupport: {
+		adminPermissionNeedsView,
+		adminPermissionDonationsView,
+		adminPermissionUsersView,
+		adminPermissionEmailsView,
+		adminPermissionEmailsManage,
+		adminPermissionNotesWrite,
+	},
+}
+
+// adminRolesAllow reports whether any of roles grants permission.
+func adminRolesAllow(roles []types.AdminRole, permission adminPermission) bool {
+	for _, role := range roles {
+		if role == types.AdminRoleSuperadmin {
+			return true
+		}
+		if slices.Contains(adminRolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the signed-in admin holds permission.
+func (a *AuthSession) Can(permission adminPermission) bool {
+	if a == nil || !a.IsAdmin {
+		return false
+	}
+	return adminRolesAllow(a.AdminRoles, permission)
+}
+
+// sessionCan reports whether the request's session holds permission.
+func sessionCan(r *http.Request, permission adminPermission) bool {
+	session, ok := sessionFromRequest(r)
+	return ok && session.Can(permission)
+}
+
+// RequirePermission restricts a route group to admins holding permission. It
+// is layered under RequireAdmin, which has already handled signed-out users.
+func (s *Service) RequirePermission(permission adminPermission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !sessionCan(r, permission) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (s *Service) handleGetAdminRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	grants, err := s.adminRoleRepo.ActiveGrants(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch admin role grants")
+		s.internalServerError(w)
+		return
+	}
+
+	userIDs := make([]string, 0, len(grants)*2)
+	for _, grant := range grants {
+		userIDs = append(userIDs, grant.UserID)
+		if grant.GrantedByUserID != nil {
+			userIDs = append(userIDs, *grant.GrantedByUserID)
+		}
+	}
+
+	users, err := s.userRepo.UsersByIDs(ctx, userIDs)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch users for admin role grants")
+		s.internalServerError(w)
+		return
+	}
+
+	usersByID := make(map[string]*types.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	items := make([]*types.AdminRoleGrantItem, 0, len(grants))
+	for _, grant := range grants {
+		user := usersByID[grant.UserID]
+
+		email := "-"
+		if user != nil && user.Email != nil && strings.TrimSpace(*user.Email) != "" {
+			email = *user.Email
+		}
+
+		grantedBy := "CLI bootstrap"
+		if grant.GrantedByUserID != nil {
+			grantedBy = *grant.GrantedByUserID
+			if granter := usersByID[*grant.GrantedByUserID]; granter != nil {
+				grantedBy = userDisplayName(granter)
+			}
+		}
+
+		items = append(items, &types.AdminRoleGrantItem{
+			GrantID:      grant.ID,
+			UserID:       grant.UserID,
+			UserName:     userDisplayName(user),
+			Email:        email,
+			Role:         adminRoleLabel(grant.Role),
+			GrantedBy:    grantedBy,
+			GrantedAt:    grant.CreatedAt.Format(time.DateOnly),
+			UserHref:     s.route(RouteAdminUserDetail, Param("userID", grant.UserID)),
+			RevokeAction: s.route(RouteAdminRoleRevoke, Param("grantID", grant.ID)),
+		})
+	}
+
+	roleOptions := make([]types.AdminExplorerOption, 0, len(types.AdminRoles))
+	for _, role := range types.AdminRoles {
+		roleOptions = append(roleOptions, types.AdminExplorerOption{Value: string(role), Label: adminRoleLabel(role)})
+	}
+
+	data := &types.AdminRolesPageData{
+		BasePageData: types.BasePageData{Title: "Admin Roles"},
+		Grants:       items,
+		RoleOptions:  roleOptions,
+		GrantAction:  s.route(RouteAdminRoleGrant),
+		BackHref:     s.route(RouteAdmin),
+		Notice:       strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:        strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.roles", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin roles page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) handlePostAdminRoleGrant(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminRolesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	role, ok := parseAdminRole(r.FormValue("role"))
+	if !ok {
+		s.redirectAdminRolesWithError(w, r, "choose a valid role")
+		return
+	}
+
+	identifier := strings.TrimSpace(r.FormValue("user"))
+	if identifier == "" {
+		s.redirectAdminRolesWithError(w, r, "user id or email is required")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminRolesWithError(w, r, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	var user *types.User
+	var err error
+	if strings.Contains(identifier, "@") {
+		user, err = s.userRepo.UserByEmail(r.Context(), identifier)
+	} else {
+		user, err = s.userRepo.User(r.Context(), identifier)
+	}
+	if err != nil {
+		if errors.Is(err, types.ErrUserNotFound) {
+			s.redirectAdminRolesWithError(w, r, "user not found")
+			return
+		}
+		s.logger.WithError(err).Error("failed to fetch user for admin role grant")
+		s.internalServerError(w)
+		return
+	}
+
+	grant, err := s.adminRoleRepo.GrantRole(r.Context(), user.ID, role, &actorUserID)
+	if err != nil {
+		if errors.Is(err, types.ErrAdminRoleAlreadyHeld) {
+			s.redirectAdminRolesWithError(w, r, "user already holds that role")
+			return
+		}
+		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to grant admin role")
+		s.redirectAdminRolesWithError(w, r, "failed to grant role")
+		return
+	}
+
+	s.logger.WithField("user_id", user.ID).WithField("role", role).WithField("actor_user_id", actorUserID).Info("admin role granted")
+	s.recordAdminAudit(r, types.AdminAuditActionRoleGranted, types.AdminAuditTargetRoleGrant, grant.ID, string(role)+" granted to user "+user.ID)
+
+	v := url.Values{}
+	v.Set("notice", adminRoleLabel(role)+" role granted to "+userDisplayName(user))
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminRoles, v), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminRoleRevoke(w http.ResponseWriter, r *http.Request) {
+	grantID := strings.TrimSpace(r.PathValue("grantID"))
+	if grantID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminRolesWithError(w, r, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	grant, err := s.adminRoleRepo.RevokeGrant(r.Context(), grantID, actorUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrAdminRoleGrantNotFound):
+			s.redirectAdminRolesWithError(w, r, "role grant not found or already revoked")
+		case errors.Is(err, types.ErrLastSuperadmin):
+			s.redirectAdminRolesWithError(w, r, "cannot revoke the last superadmin")
+		default:
+			s.logger.WithError(err).WithField("grant_id", grantID).Error("failed to revoke admin role")
+			s.redirectAdminRolesWithError(w, r, "failed to revoke role")
+		}
+		return
+	}
+
+	s.logger.WithField("user_id", grant.UserID).WithField("role", grant.Role).WithField("actor_user_id", actorUserID).Info("admin role revoked")
+	s.recordAdminAudit(r, types.AdminAuditActionRoleRevoked, types.AdminAuditTargetRoleGrant, grant.ID, string(grant.Role)+" revoked from user "+grant.UserID)
+
+	v := url.Values{}
+	v.Set("notice", adminRoleLabel(grant.Role)+" role revoked")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminRoles, v), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminRolesWithError(w http.ResponseWriter, r *http.Request, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminRoles, v), http.StatusSeeOther)
+}
+
+func parseAdminRole(raw string) (types.AdminRole, bool) {
+	role := types.AdminRole(strings.ToLower(strings.TrimSpace(raw)))
+	if !slices.Contains(types.AdminRoles, role) {
+		return "", false
+	}
+	return role, true
+}
+
+func adminRoleLabel(role types.AdminRole) string {
+	switch role {
+	case types.AdminRoleReviewer:
+		return "Reviewer"
+	case types.AdminRoleFinance:
+		return "Finance"
+	case types.AdminRoleSupport:
+		return "Support"
+	case types.AdminRoleSuperadmin:
+		return "Superadmin"
+	default:
+		return string(role)
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestAdminRolesAllow(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []types.AdminRole
+		permission adminPermission
+		want       bool
+	}{
+		{name: "no roles", roles: nil, permission: adminPermissionNeedsView, want: false},
+		{name: "reviewer moderates", roles: []types.AdminRole{types.AdminRoleReviewer}, permission: adminPermissionNeedsModerate, want: true},
+		{name: "reviewer cannot delete", roles: []types.AdminRole{types.AdminRoleReviewer}, permission: adminPermissionNeedsDelete, want: false},
+		{name: "finance manages funds", roles: []types.AdminRole{types.AdminRoleFinance}, permission: adminPermissionFundsManage, want: true},
+		{name: "support cannot see documents", roles: []types.AdminRole{types.AdminRoleSupport}, permission: adminPermissionDocumentsView, want: false},
+		{name: "roles combine", roles: []types.AdminRole{types.AdminRoleSupport, types.AdminRoleReviewer}, permission: adminPermissionDocumentsView, want: true},
+		{name: "superadmin does everything", roles: []types.AdminRole{types.AdminRoleSuperadmin}, permission: adminPermissionRolesManage, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := adminRolesAllow(tt.roles, tt.permission); got != tt.want {
+			t.Fatalf("%s: adminRolesAllow() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseAdminRole(t *testing.T) {
+	if role, ok := parseAdminRole(" Finance "); !ok || role != types.AdminRoleFinance {
+		t.Fatalf("parseAdminRole(Finance) = %q %v", role, ok)
+	}
+	if _, ok := parseAdminRole("admin"); ok {
+		t.Fatal("expected unknown role to be rejected")
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatWaitDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{20 * time.Minute, "under 1h"},
+		{5*time.Hour + 10*time.Minute, "5h"},
+		{48 * time.Hour, "2d"},
+		{76 * time.Hour, "3d 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := formatWaitDuration(tt.in); got != tt.want {
+			t.Fatalf("formatWaitDuration(%s) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAdminWindowSince(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	selected, since := adminWindowSince(" 30 ", now)
+	if selected != "30" || since == nil || !since.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected 30 day window %q %v", selected, since)
+	}
+
+	for _, raw := range []string{"", "14", "all"} {
+		if selected, since := adminWindowSince(raw, now); selected != "" || since != nil {
+			t.Fatalf("expected %q to mean all time, got %q %v", raw, selected, since)
+		}
+	}
+}
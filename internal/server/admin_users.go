@@ -100,19 +100,30 @@ func (s *Service) handleGetAdminUsers(w http.ResponseWriter, r *http.Request) {
 		nextHref = buildPageHref(page + 1)
 	}
 
+	exportFilters := url.Values{}
+	if search != "" {
+		exportFilters.Set("search", search)
+	}
+	if selectedType != "" {
+		exportFilters.Set("type", selectedType)
+	}
+	exportCSVHref, exportXLSXHref := s.adminExportHrefs(RouteAdminUsersExport, exportFilters)
+
 	data := &types.AdminUsersPageData{
-		BasePageData: types.BasePageData{Title: "Admin Users"},
-		Users:        items,
-		Page:         page,
-		PageSize:     adminUsersPageSize,
-		TotalUsers:   totalUsers,
-		TotalPages:   totalPages,
-		PrevHref:     prevHref,
-		NextHref:     nextHref,
-		Search:       search,
-		SelectedType: selectedType,
-		FilterAction: s.route(RouteAdminUsers),
-		BackHref:     s.route(RouteAdmin),
+		BasePageData:   types.BasePageData{Title: "Admin Users"},
+		Users:          items,
+		Page:           page,
+		PageSize:       adminUsersPageSize,
+		TotalUsers:     totalUsers,
+		TotalPages:     totalPages,
+		PrevHref:       prevHref,
+		NextHref:       nextHref,
+		Search:         search,
+		SelectedType:   selectedType,
+		FilterAction:   s.route(RouteAdminUsers),
+		ExportCSVHref:  exportCSVHref,
+		ExportXLSXHref: exportXLSXHref,
+		BackHref:       s.route(RouteAdmin),
 	}
 
 	if err := s.renderTemplate(w, r, "page.admin.users", data); err != nil {
@@ -122,6 +133,54 @@ func (s *Service) handleGetAdminUsers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleGetAdminUsersExport downloads every user matching the list's search
+// and type filters.
+func (s *Service) handleGetAdminUsersExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	search := strings.TrimSpace(r.URL.Query().Get("search"))
+	selectedType := strings.TrimSpace(r.URL.Query().Get("type"))
+	format := parseAdminExportFormat(r.URL.Query().Get("format"))
+
+	filters := url.Values{}
+	if search != "" {
+		filters.Set("search", search)
+	}
+	if selectedType != "" {
+		filters.Set("type", selectedType)
+	}
+	filters.Set("format", string(format))
+	if !s.auditAdminExport(w, r, "users", filters) {
+		return
+	}
+
+	writer, err := startAdminExport(w, format, "users", []string{
+		"user_id", "email", "given_name", "family_name", "user_type", "created_at", "updated_at",
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to start users export")
+		return
+	}
+
+	err = s.userRepo.EachUser(ctx, search, selectedType, func(user *types.User) error {
+		return writer.Write([]string{
+			user.ID,
+			derefString(user.Email),
+			derefString(user.GivenName),
+			derefString(user.FamilyName),
+			derefString(user.UserType),
+			formatExportTime(&user.CreatedAt),
+			formatExportTime(&user.UpdatedAt),
+		})
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Headers are already sent, so the download is simply cut short.
+		s.logger.WithError(err).Error("failed to stream users export")
+	}
+}
+
 func (s *Service) handleGetAdminUserDetail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := strings.TrimSpace(r.PathValue("userID"))
@@ -169,8 +228,21 @@ func (s *Service) handleGetAdminUserDetail(w http.ResponseWriter, r *http.Reques
 		CreatedAt:    user.CreatedAt.Format(time.DateTime),
 		UpdatedAt:    user.UpdatedAt.Format(time.DateTime),
 		BackHref:     s.route(RouteAdminUsers),
+		Notice:       r.URL.Query().Get("notice"),
+		Error:        r.URL.Query().Get("error"),
+
+		CanManageRestrictions: sessionCan(r, adminPermissionUsersManage),
+		SuspensionDayOptions:  suspensionDayOptions,
+		RestrictAction:        s.route(RouteAdminUserRestrict, Param("userID", user.ID)),
+		LiftAction:            s.route(RouteAdminUserRestrictionLift, Param("userID", user.ID)),
+	}
+	if session, ok := sessionFromRequest(r); ok {
+		data.IsSelf = session.UserID == user.ID
 	}
 
+	s.populateAdminUserRestrictions(ctx, data, userID)
+	s.populateAdminUserNotes(ctx, r, data, userID)
+
 	if userType == string(types.UserTypeRecipient) {
 		s.populateAdminRecipientData(ctx, data, userID)
 	} else if userType == string(types.UserTypeDonor) {
@@ -225,6 +297,7 @@ func (s *Service) populateAdminRecipientData(ctx context.Context, data *types.Ad
 			FundingPercent:   fundingPercent,
 			CreatedAt:        need.CreatedAt.Format(time.DateOnly),
 			ReviewHref:       s.route(RouteAdminNeedReview, Param("needID", need.ID)),
+			IsHidden:         need.HiddenAt != nil,
 		})
 	}
 
@@ -139,6 +139,20 @@ func (s *Service) handleGetAuthCallback(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	restriction, err := s.restrictionCheck.ActiveRestrictionByUser(ctx, userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to check user restriction in auth callback")
+		s.internalServerError(w)
+		return
+	}
+	if restriction != nil {
+		s.logger.WithField("user_id", userID).WithField("restriction_id", restriction.ID).Info("blocked sign-in for restricted user")
+		s.clearAuthFlowCookies(w)
+		s.clearRedirectCookie(w)
+		s.redirectRestrictedUser(w, r, restriction)
+		return
+	}
+
 	var userType string
 	user, err := s.authIdentityRepo.User(ctx, userID)
 	if err == nil && user != nil && user.UserType != nil {
@@ -175,7 +189,6 @@ func (s *Service) handleGetAuthCallback(w http.ResponseWriter, r *http.Request)
 		FamilyName:  strings.TrimSpace(claims.FamilyName),
 		DisplayName: strings.TrimSpace(claims.DisplayName),
 		UserType:    userType,
-		IsAdmin:     claims.IsAdmin,
 	}, expiresIn)
 
 	s.clearAuthFlowCookies(w)
@@ -42,6 +42,14 @@ func (m *mockAuthIdentityRepo) User(ctx context.Context, userID string) (*types.
 	return m.userFn(ctx, userID)
 }
 
+type mockRestrictionChecker struct {
+	restriction *types.UserRestriction
+}
+
+func (m *mockRestrictionChecker) ActiveRestrictionByUser(ctx context.Context, userID string) (*types.UserRestriction, error) {
+	return m.restriction, nil
+}
+
 func TestSubtleCompare_CallbackSecrets(t *testing.T) {
 	t.Parallel()
 
@@ -199,6 +207,56 @@ func TestHandleGetAuthCallback_Success(t *testing.T) {
 	assertCookieCleared(t, cookies, internal.COOKIE_AUTH_NONCE)
 }
 
+// Exercises sign-in for a banned user.
+// Benefit: ensures a valid Auth0 login still issues no session while an admin restriction is in force.
+func TestHandleGetAuthCallback_RestrictedUserBlocked(t *testing.T) {
+	t.Parallel()
+
+	state := "state-123"
+	nonce := "nonce-123"
+	issuer := "https://issuer.example/"
+	clientID := "client_123"
+
+	idToken, jwksURL := buildSignedIDTokenAndJWKS(t, issuer, clientID, nonce)
+
+	auth0 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id_token":   idToken,
+			"expires_in": 3600,
+		})
+	}))
+	t.Cleanup(auth0.Close)
+
+	s := newAuthCallbackTestService(t, auth0.URL, issuer, clientID, jwksURL)
+	s.restrictionCheck = &mockRestrictionChecker{restriction: &types.UserRestriction{
+		ID:     "restriction_123",
+		UserID: "user_123",
+		Kind:   types.UserRestrictionBanned,
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/callback?state="+state+"&code=abc123", nil)
+	addEncodedCookie(t, s, req, internal.COOKIE_AUTH_STATE, state)
+	addEncodedCookie(t, s, req, internal.COOKIE_AUTH_NONCE, nonce)
+	rr := httptest.NewRecorder()
+
+	s.handleGetAuthCallback(rr, req)
+
+	if rr.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusSeeOther)
+	}
+	if got := rr.Header().Get("Location"); !strings.HasPrefix(got, "/?error=") {
+		t.Fatalf("Location = %q, want home with error", got)
+	}
+
+	for _, cookie := range rr.Result().Cookies() {
+		if cookie.Name == internal.COOKIE_ACCESS_TOKEN_NAME && cookie.MaxAge >= 0 {
+			t.Fatal("access token cookie issued for banned user")
+		}
+	}
+	assertCookieCleared(t, rr.Result().Cookies(), internal.COOKIE_AUTH_STATE)
+}
+
 // Verifies callback rejects when query state and cookie state do not match.
 // Benefit: protects against CSRF/state-fixation attacks in the auth callback.
 func TestHandleGetAuthCallback_StateMismatch(t *testing.T) {
@@ -341,6 +399,7 @@ func newAuthCallbackTestService(t *testing.T, auth0Domain, issuer, clientID, jwk
 				return &types.User{ID: userID}, nil
 			},
 		},
+		restrictionCheck: &mockRestrictionChecker{},
 	}
 }
 
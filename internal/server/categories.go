@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 func (s *Service) handleCategories(w http.ResponseWriter, r *http.Request) {
@@ -94,7 +95,7 @@ func (s *Service) handleCategoryNeeds(w http.ResponseWriter, r *http.Request) {
 		s.internalServerError(w)
 		return
 	}
-	if category == nil {
+	if category == nil || !category.IsActive {
 		http.NotFound(w, r)
 		return
 	}
@@ -109,6 +110,14 @@ func (s *Service) handleCategoryNeeds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var featuredNeeds []*types.BrowseNeedCard
+	scheduledNeeds, err := s.needFeatureRepo.LiveFeaturedNeeds(ctx, types.NeedFeaturePlacementCategory, category.ID, time.Now(), categoryFeaturedNeedsLimit)
+	if err != nil {
+		s.logger.WithError(err).WithField("category_id", category.ID).Warn("failed to fetch scheduled featured needs for category page")
+	} else if len(scheduledNeeds) > 0 {
+		featuredNeeds = s.buildNeedCards(ctx, scheduledNeeds, "category featured needs")
+	}
+
 	city := strings.TrimSpace(r.URL.Query().Get("city"))
 	cityQuery := ""
 	if city != "" {
@@ -123,11 +132,12 @@ func (s *Service) handleCategoryNeeds(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := &types.CategoryNeedsPageData{
-		BasePageData: types.BasePageData{Title: fmt.Sprintf("%s Needs", category.Name)},
-		Category:     category,
-		Needs:        browseData.Needs,
-		BackHref:     backHref,
-		BrowseHref:   browseHref,
+		BasePageData:  types.BasePageData{Title: fmt.Sprintf("%s Needs", category.Name)},
+		Category:      category,
+		Needs:         browseData.Needs,
+		FeaturedNeeds: featuredNeeds,
+		BackHref:      backHref,
+		BrowseHref:    browseHref,
 	}
 
 	if err := s.renderTemplate(w, r, "page.category.needs", data); err != nil {
@@ -64,7 +64,9 @@ func (s *Service) handleGetNeedDonate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	needID := r.PathValue("needID")
 
-	data, err := s.buildNeedDonatePageData(ctx, needID, &types.NeedDonatePageData{})
+	data, err := s.buildNeedDonatePageData(ctx, needID, &types.NeedDonatePageData{
+		LineItemID: strings.TrimSpace(r.URL.Query().Get("line_item")),
+	})
 	if err != nil {
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to build donate page data")
 		s.internalServerError(w)
@@ -92,12 +94,14 @@ func (s *Service) handlePostNeedDonate(w http.ResponseWriter, r *http.Request) {
 	customAmount := strings.TrimSpace(r.FormValue("custom_amount"))
 	privateMessage := strings.TrimSpace(r.FormValue("private_message"))
 	isAnonymous := r.FormValue("is_anonymous") == "on"
+	lineItemID := strings.TrimSpace(r.FormValue("line_item_id"))
 
 	data := &types.NeedDonatePageData{
 		SelectedPreset: selectedPreset,
 		CustomAmount:   customAmount,
 		PrivateMessage: privateMessage,
 		IsAnonymous:    isAnonymous,
+		LineItemID:     lineItemID,
 	}
 
 	data, err := s.buildNeedDonatePageData(ctx, needID, data)
@@ -140,6 +144,15 @@ func (s *Service) handlePostNeedDonate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if lineItemID != "" && needLineItemViewByID(data.LineItems, lineItemID) == nil {
+		data.Error = "Select one of this need's line items or give where it's needed most."
+		if renderErr := s.renderTemplate(w, r, "page.need-donate", data); renderErr != nil {
+			s.logger.WithError(renderErr).Error("failed to render need donate page with validation error")
+			s.internalServerError(w)
+		}
+		return
+	}
+
 	var donorUserID string
 	if session, ok := sessionFromRequest(r); ok {
 		donorUserID = session.UserID
@@ -163,6 +176,13 @@ func (s *Service) handlePostNeedDonate(w http.ResponseWriter, r *http.Request) {
 	if privateMessage != "" {
 		intent.PrivateMessage = &privateMessage
 	}
+	if lineItemID != "" {
+		intent.LineItemID = &lineItemID
+	}
+	if referral := s.shareReferralForDonation(ctx, r, needID, donorUserID); referral != nil {
+		intent.ShareLinkID = utils.StringPtr(referral.ID)
+		intent.ReferrerUserID = utils.StringPtr(referral.UserID)
+	}
 
 	if err := s.donationIntentRepo.Create(ctx, intent); err != nil {
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to create donation intent")
@@ -431,6 +451,11 @@ func (s *Service) buildNeedDonatePageData(ctx context.Context, needID string, da
 		data.PresetAmounts, data.RemainingPreset = smartPresetAmounts(need.AmountNeededCents, need.AmountRaisedCents)
 	}
 
+	data.LineItems, err = s.loadNeedLineItemViews(ctx, need.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return data, nil
 }
 
@@ -439,7 +464,9 @@ func (s *Service) loadNeedDonateSummary(ctx context.Context, needID string) (*ty
 	if err != nil {
 		return nil, "", "", "", err
 	}
-	if need.DeletedAt != nil {
+	// Needs closed early have released their remaining funds for reallocation
+	// and must not take new gifts. Hidden needs belong to a restricted owner.
+	if need.DeletedAt != nil || need.HiddenAt != nil || need.Status == types.NeedStatusClosed {
 		return nil, "", "", "", types.ErrNeedNotFound
 	}
 
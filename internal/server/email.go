@@ -15,15 +15,30 @@ import (
 type sendEmailOption func(*sendEmailConfig)
 
 // sendEmailConfig holds optional parameters for sendEmail.
-type sendEmailConfig struct{}
+type sendEmailConfig struct {
+	resentFromMessageID *string
+}
+
+// withResentFrom links the new message to the earlier one an admin resent.
+func withResentFrom(messageID string) sendEmailOption {
+	return func(c *sendEmailConfig) {
+		c.resentFromMessageID = &messageID
+	}
+}
 
 // sendEmail is the generic email dispatch helper.
 //
-// It checks the suppression list, inserts an email_messages record (queued),
-// calls the configured Sender, then updates the status to "sent".
+// It checks the suppression list, inserts an email_messages record (queued)
+// with the rendered bodies so support can resend it, calls the configured
+// Sender, then updates the status to "sent".
 // Callers are responsible for creating any association records (e.g.
 // donation_intent_emails, user_emails) using the returned EmailMessage.
 func (s *Service) sendEmail(ctx context.Context, msg internalemail.Message, emailType string, opts ...sendEmailOption) (*types.EmailMessage, error) {
+	var cfg sendEmailConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	suppressed, err := s.emailRepo.IsEmailSuppressed(ctx, msg.To)
 	if err != nil {
 		return nil, fmt.Errorf("check email suppression: %w", err)
@@ -37,12 +52,20 @@ func (s *Service) sendEmail(ctx context.Context, msg internalemail.Message, emai
 	}
 
 	record := &types.EmailMessage{
-		ID:        utils.NanoID(),
-		Recipient: msg.To,
-		EmailType: emailType,
-		Subject:   msg.Subject,
-		Provider:  "resend",
-		Status:    types.EmailStatusQueued,
+		ID:                  utils.NanoID(),
+		Recipient:           msg.To,
+		EmailType:           emailType,
+		Subject:             msg.Subject,
+		Provider:            "resend",
+		Status:              types.EmailStatusQueued,
+		FromAddress:         &msg.From,
+		ResentFromMessageID: cfg.resentFromMessageID,
+	}
+	if msg.HTMLBody != "" {
+		record.HTMLBody = &msg.HTMLBody
+	}
+	if msg.TextBody != "" {
+		record.TextBody = &msg.TextBody
 	}
 	if err := s.emailRepo.InsertEmailMessage(ctx, record); err != nil {
 		return nil, fmt.Errorf("insert email message: %w", err)
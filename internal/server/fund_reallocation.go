@@ -0,0 +1,756 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	internalemail "christjesus/internal/email"
+	"christjesus/internal/store"
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultReallocationConsentDays = 14
+	maxReallocationConsentDays     = 60
+)
+
+// errNoReallocationBalance rolls back queueing when nothing is left to queue.
+var errNoReallocationBalance = errors.New("no reallocation balance")
+
+// unqueuedReallocationBalanceCents returns how much of a need's raised money
+// is no longer required and not yet part of a pending reallocation. Every
+// dollar of a deleted need is eligible; otherwise only what was raised beyond
+// the goal is.
+func unqueuedReallocationBalanceCents(need *types.Need, reallocations []*types.FundReallocation) (int, types.FundReallocationSource) {
+	committedCents := 0
+	for _, reallocation := range reallocations {
+		if reallocation == nil || reallocation.Status == types.FundReallocationStatusCompleted {
+			continue
+		}
+		committedCents += reallocation.AmountCents
+	}
+
+	if need.DeletedAt != nil {
+		return max(need.AmountRaisedCents-committedCents, 0), types.FundReallocationSourceDeleted
+	}
+
+	return max(need.AmountRaisedCents-need.AmountNeededCents-committedCents, 0), types.FundReallocationSourceOverfunded
+}
+
+// attributableReallocationCents returns how much of a need's money can still
+// be traced to its own finalized gifts: what those gifts gave, less the
+// shares earlier reallocations already cover and less reallocations still
+// waiting for a destination. Money that reached the need through an earlier
+// redirect has no gift on the need, so it cannot be offered to donors for
+// consent and is never queued.
+func attributableReallocationCents(intents []*types.DonationIntent, allocated map[string]int, reallocations []*types.FundReallocation) int {
+	attributableCents := 0
+	for _, intent := range intents {
+		if intent == nil {
+			continue
+		}
+		attributableCents += max(intent.AmountCents-allocated[intent.ID], 0)
+	}
+
+	for _, reallocation := range reallocations {
+		if reallocation != nil && reallocation.Status == types.FundReallocationStatusQueued {
+			attributableCents -= reallocation.AmountCents
+		}
+	}
+
+	return max(attributableCents, 0)
+}
+
+// reallocatableCentsTx caps balanceCents at what the need's gifts can still
+// cover. reallocations must have been read in tx with the need row locked,
+// so two admins cannot queue the same money.
+func (s *Service) reallocatableCentsTx(ctx context.Context, tx pgx.Tx, needID string, intents []*types.DonationIntent, reallocations []*types.FundReallocation, balanceCents int) (int, error) {
+	allocated, err := s.fundReallocationRepo.AllocatedCentsByIntentTx(ctx, tx, needID)
+	if err != nil {
+		return 0, err
+	}
+
+	return min(balanceCents, attributableReallocationCents(intents, allocated, reallocations)), nil
+}
+
+// allocateDonationReallocation spreads amountCents across a need's finalized
+// gifts, newest first, skipping whatever share of each gift an earlier
+// reallocation already covers. intents must be ordered newest first.
+func allocateDonationReallocation(reallocationID string, intents []*types.DonationIntent, allocated map[string]int, amountCents int) []*types.DonationReallocation {
+	allocations := make([]*types.DonationReallocation, 0)
+	remaining := amountCents
+	for _, intent := range intents {
+		if remaining <= 0 {
+			break
+		}
+		if intent == nil {
+			continue
+		}
+
+		available := intent.AmountCents - allocated[intent.ID]
+		if available <= 0 {
+			continue
+		}
+
+		share := min(available, remaining)
+		allocations = append(allocations, &types.DonationReallocation{
+			FundReallocationID: reallocationID,
+			DonationIntentID:   intent.ID,
+			AmountCents:        share,
+		})
+		remaining -= share
+	}
+
+	return allocations
+}
+
+func fundReallocationSourceLabel(source types.FundReallocationSource) string {
+	switch source {
+	case types.FundReallocationSourceEarlyClose:
+		return "Closed Early"
+	case types.FundReallocationSourceGoalReduced:
+		return "Goal Reduced"
+	case types.FundReallocationSourceDeleted:
+		return "Need Deleted"
+	case types.FundReallocationSourceOverfunded:
+		return "Overfunded"
+	default:
+		return string(source)
+	}
+}
+
+func fundReallocationStatusLabel(status types.FundReallocationStatus) string {
+	switch status {
+	case types.FundReallocationStatusQueued:
+		return "Awaiting Destination"
+	case types.FundReallocationStatusProposed:
+		return "Awaiting Donor Consent"
+	case types.FundReallocationStatusCompleted:
+		return "Completed"
+	default:
+		return string(status)
+	}
+}
+
+// reallocationDestinations holds display labels for the needs and category
+// funds reallocations point at.
+type reallocationDestinations struct {
+	needLabels     map[string]string
+	categoryLabels map[string]string
+}
+
+func (d reallocationDestinations) label(targetNeedID, targetCategoryID *string) string {
+	if targetNeedID != nil {
+		if label, ok := d.needLabels[*targetNeedID]; ok {
+			return label
+		}
+		return "another need"
+	}
+	if targetCategoryID != nil {
+		if label, ok := d.categoryLabels[*targetCategoryID]; ok {
+			return label
+		}
+		return "a category fund"
+	}
+	return ""
+}
+
+func (s *Service) loadReallocationDestinations(ctx context.Context, targetNeedIDs, targetCategoryIDs []string) (reallocationDestinations, error) {
+	destinations := reallocationDestinations{
+		needLabels:     make(map[string]string),
+		categoryLabels: make(map[string]string),
+	}
+
+	if len(targetNeedIDs) > 0 {
+		needs, err := s.needsRepo.NeedsByIDs(ctx, targetNeedIDs)
+		if err != nil {
+			return destinations, fmt.Errorf("fetch reallocation target needs: %w", err)
+		}
+		for _, need := range needs {
+			if need == nil {
+				continue
+			}
+			label := strings.TrimSpace(derefString(need.ShortDescription))
+			if label == "" {
+				label = "another need"
+			}
+			destinations.needLabels[need.ID] = label
+		}
+	}
+
+	if len(targetCategoryIDs) > 0 {
+		categories, err := s.categoryRepo.CategoriesByIDs(ctx, targetCategoryIDs)
+		if err != nil {
+			return destinations, fmt.Errorf("fetch reallocation target categories: %w", err)
+		}
+		for _, category := range categories {
+			if category == nil {
+				continue
+			}
+			destinations.categoryLabels[category.ID] = "the " + category.Name + " fund"
+		}
+	}
+
+	return destinations, nil
+}
+
+// buildAdminFundReallocationViews renders reallocations for the need review
+// page and the admin queue. Propose links are only set when withPropose is
+// true and the entry still needs a destination.
+func (s *Service) buildAdminFundReallocationViews(ctx context.Context, reallocations []*types.FundReallocation, withPropose bool) ([]*types.AdminFundReallocationView, error) {
+	targetNeedIDs := make([]string, 0)
+	targetCategoryIDs := make([]string, 0)
+	for _, reallocation := range reallocations {
+		if reallocation == nil {
+			continue
+		}
+		if reallocation.TargetNeedID != nil {
+			targetNeedIDs = append(targetNeedIDs, *reallocation.TargetNeedID)
+		}
+		if reallocation.TargetCategoryID != nil {
+			targetCategoryIDs = append(targetCategoryIDs, *reallocation.TargetCategoryID)
+		}
+	}
+
+	destinations, err := s.loadReallocationDestinations(ctx, targetNeedIDs, targetCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*types.AdminFundReallocationView, 0, len(reallocations))
+	for _, reallocation := range reallocations {
+		if reallocation == nil {
+			continue
+		}
+
+		isQueued := reallocation.Status == types.FundReallocationStatusQueued
+		destination := destinations.label(reallocation.TargetNeedID, reallocation.TargetCategoryID)
+		if destination == "" {
+			destination = "-"
+		}
+
+		view := &types.AdminFundReallocationView{
+			ID:              reallocation.ID,
+			NeedID:          reallocation.NeedID,
+			NeedHref:        s.route(RouteAdminNeedReview, Param("needID", reallocation.NeedID)),
+			Amount:          formatUSDFromCents(reallocation.AmountCents),
+			SourceLabel:     fundReallocationSourceLabel(reallocation.Source),
+			StatusLabel:     fundReallocationStatusLabel(reallocation.Status),
+			IsQueued:        isQueued,
+			Destination:     destination,
+			ConsentDeadline: formatOptionalDateTime(reallocation.ConsentDeadline),
+			CreatedAt:       reallocation.CreatedAt.Format("2006-01-02 15:04"),
+		}
+		if withPropose && isQueued {
+			view.ProposeAction = s.route(RouteAdminNeedReallocationPropose, Param("needID", reallocation.NeedID), Param("reallocationID", reallocation.ID))
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+func (s *Service) handleGetAdminReallocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reallocations, err := s.fundReallocationRepo.ReallocationsByStatus(ctx, []types.FundReallocationStatus{
+		types.FundReallocationStatusQueued,
+		types.FundReallocationStatusProposed,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch fund reallocation queue")
+		s.internalServerError(w)
+		return
+	}
+
+	views, err := s.buildAdminFundReallocationViews(ctx, reallocations, false)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to build fund reallocation queue")
+		s.internalServerError(w)
+		return
+	}
+
+	refundsOwed, err := s.fundReallocationRepo.RefundsOwed(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch refunds owed")
+		s.internalServerError(w)
+		return
+	}
+
+	recentRefunds, err := s.fundReallocationRepo.RecentRefunds(ctx, recentReallocationRefundsLimit)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch recent refunds")
+		s.internalServerError(w)
+		return
+	}
+
+	refundViews, err := s.buildAdminDonationRefundViews(ctx, append(refundsOwed, recentRefunds...))
+	if err != nil {
+		s.logger.WithError(err).Error("failed to build refund queue")
+		s.internalServerError(w)
+		return
+	}
+
+	data := &types.AdminReallocationsPageData{
+		BasePageData:  types.BasePageData{Title: "Fund Reallocations"},
+		Queued:        make([]*types.AdminFundReallocationView, 0),
+		Proposed:      make([]*types.AdminFundReallocationView, 0),
+		RefundsOwed:   refundViews[:len(refundsOwed)],
+		RecentRefunds: refundViews[len(refundsOwed):],
+		BackHref:      s.route(RouteAdmin),
+		Notice:        strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:         strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+	for _, view := range views {
+		if view.IsQueued {
+			data.Queued = append(data.Queued, view)
+			continue
+		}
+		data.Proposed = append(data.Proposed, view)
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.reallocations", data); err != nil {
+		s.logger.WithError(err).Error("failed to render admin reallocations page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) handlePostAdminNeedReallocationQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	if needID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	intents, err := s.donationIntentRepo.FinalizedIntentsByNeedID(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch gifts before queueing reallocation")
+		s.internalServerError(w)
+		return
+	}
+
+	if err := store.WithTx(ctx, s.fundReallocationRepo, func(tx pgx.Tx) error {
+		// The need row lock serializes queueing, so the balance below cannot
+		// be queued twice by a double submit or a second admin.
+		need, err := s.needsRepo.NeedForUpdateTx(ctx, tx, needID)
+		if err != nil {
+			return err
+		}
+
+		reallocations, err := s.fundReallocationRepo.ReallocationsByNeedTx(ctx, tx, needID)
+		if err != nil {
+			return err
+		}
+
+		balanceCents, source := unqueuedReallocationBalanceCents(need, reallocations)
+		balanceCents, err = s.reallocatableCentsTx(ctx, tx, needID, intents, reallocations, balanceCents)
+		if err != nil {
+			return err
+		}
+		if balanceCents <= 0 {
+			return errNoReallocationBalance
+		}
+
+		if err := s.fundReallocationRepo.QueueReallocationTx(ctx, tx, &types.FundReallocation{
+			NeedID:      needID,
+			AmountCents: balanceCents,
+			Source:      source,
+		}); err != nil {
+			return err
+		}
+
+		summary := fmt.Sprintf("%s queued for reallocation (%s)", formatUSDFromCents(balanceCents), strings.ToLower(fundReallocationSourceLabel(source)))
+		_, err = s.progressRepo.RecordModerationActionEventTx(ctx, tx, needID, types.NeedModerationActionTypeReallocationQueued, actorUserID, nil, &summary, nil)
+		return err
+	}); err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if errors.Is(err, errNoReallocationBalance) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "this need has no unspent funds from its own gifts to reallocate")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to queue fund reallocation")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "failed to queue fund reallocation")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Funds queued for reallocation")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminNeedReallocationPropose(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	reallocationID := strings.TrimSpace(r.PathValue("reallocationID"))
+	if needID == "" || reallocationID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "invalid form submission")
+		return
+	}
+
+	need, err := s.needsRepo.Need(ctx, needID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need before proposing reallocation")
+		s.internalServerError(w)
+		return
+	}
+
+	var targetNeedID, targetCategoryID *string
+	var destination string
+	switch strings.TrimSpace(r.FormValue("target_type")) {
+	case "need":
+		candidateID := strings.TrimSpace(r.FormValue("target_need_id"))
+		if candidateID == "" {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "target need id is required")
+			return
+		}
+		if candidateID == needID {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "funds cannot be reallocated to the same need")
+			return
+		}
+		target, err := s.needsRepo.Need(ctx, candidateID)
+		if err != nil {
+			if errors.Is(err, types.ErrNeedNotFound) {
+				s.redirectAdminNeedReviewWithError(w, r, needID, "target need not found")
+				return
+			}
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch reallocation target need")
+			s.internalServerError(w)
+			return
+		}
+		if target.Status != types.NeedStatusActive || target.DeletedAt != nil || target.HiddenAt != nil {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "target need must be active")
+			return
+		}
+		targetNeedID = &target.ID
+		destination = strings.TrimSpace(derefString(target.ShortDescription))
+		if destination == "" {
+			destination = "another need"
+		}
+	case "category":
+		candidateID := strings.TrimSpace(r.FormValue("target_category_id"))
+		if candidateID == "" {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "target category is required")
+			return
+		}
+		categories, err := s.categoryRepo.CategoriesByIDs(ctx, []string{candidateID})
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch reallocation target category")
+			s.internalServerError(w)
+			return
+		}
+		if len(categories) == 0 || !categories[0].IsActive {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "target category must be active")
+			return
+		}
+		category := categories[0]
+		targetCategoryID = &category.ID
+		destination = "the " + category.Name + " fund"
+	default:
+		s.redirectAdminNeedReviewWithError(w, r, needID, "choose a need or category fund as the destination")
+		return
+	}
+
+	windowDays := parsePositiveInt(r.FormValue("window_days"), defaultReallocationConsentDays)
+	if windowDays > maxReallocationConsentDays {
+		s.redirectAdminNeedReviewWithError(w, r, needID, fmt.Sprintf("consent window cannot exceed %d days", maxReallocationConsentDays))
+		return
+	}
+	consentDeadline := time.Now().AddDate(0, 0, windowDays)
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	intents, err := s.donationIntentRepo.FinalizedIntentsByNeedID(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch gifts before proposing reallocation")
+		s.internalServerError(w)
+		return
+	}
+
+	var reallocation *types.FundReallocation
+	var allocations []*types.DonationReallocation
+	if err := store.WithTx(ctx, s.fundReallocationRepo, func(tx pgx.Tx) error {
+		var err error
+		reallocation, err = s.fundReallocationRepo.ProposeReallocationTx(ctx, tx, needID, reallocationID, targetNeedID, targetCategoryID, actorUserID, consentDeadline)
+		if err != nil {
+			return err
+		}
+
+		allocated, err := s.fundReallocationRepo.AllocatedCentsByIntentTx(ctx, tx, needID)
+		if err != nil {
+			return err
+		}
+
+		allocations = allocateDonationReallocation(reallocation.ID, intents, allocated, reallocation.AmountCents)
+		if err := s.fundReallocationRepo.CreateDonationReallocationsTx(ctx, tx, allocations); err != nil {
+			return err
+		}
+
+		summary := fmt.Sprintf("%s proposed for %s; donors may opt out until %s", formatUSDFromCents(reallocation.AmountCents), destination, consentDeadline.Format("Jan 2, 2006"))
+		_, err = s.progressRepo.RecordModerationActionEventTx(ctx, tx, needID, types.NeedModerationActionTypeReallocationProposed, actorUserID, nil, &summary, nil)
+		return err
+	}); err != nil {
+		if errors.Is(err, types.ErrFundReallocationNotFound) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "reallocation not found or already proposed")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).WithField("reallocation_id", reallocationID).Error("failed to propose fund reallocation")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "failed to propose fund reallocation")
+		return
+	}
+
+	s.notifyDonorsOfReallocation(ctx, need, reallocation, intents, allocations, destination)
+
+	v := url.Values{}
+	v.Set("notice", "Reallocation proposed and donors notified")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostProfileDonationReallocationOptOut(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	intentID := strings.TrimSpace(r.PathValue("intentID"))
+	allocationID := strings.TrimSpace(r.PathValue("allocationID"))
+	if intentID == "" || allocationID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	userID, err := s.userIDFromContext(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("user id not found in context")
+		s.internalServerError(w)
+		return
+	}
+
+	intent, err := s.donationIntentRepo.ByID(ctx, intentID)
+	if err != nil {
+		s.logger.WithError(err).WithField("intent_id", intentID).Error("failed to fetch donation before reallocation opt out")
+		s.internalServerError(w)
+		return
+	}
+	if intent == nil || intent.DonorUserID == nil || *intent.DonorUserID != userID {
+		s.redirectProfileWithError(w, r, "Donation not found.")
+		return
+	}
+
+	if err := s.fundReallocationRepo.OptOutDonationReallocation(ctx, intentID, allocationID); err != nil {
+		if errors.Is(err, types.ErrFundReallocationNotFound) {
+			s.redirectProfileWithError(w, r, "This redirect can no longer be changed.")
+			return
+		}
+		s.logger.WithError(err).WithField("intent_id", intentID).Error("failed to opt out of donation reallocation")
+		s.internalServerError(w)
+		return
+	}
+
+	s.redirectProfileWithNotice(w, r, "You opted out. That part of your gift will be refunded instead of redirected.")
+}
+
+// buildDonationRedirects describes every reallocation touching the donor's
+// gifts, keyed by donation intent ID.
+func (s *Service) buildDonationRedirects(ctx context.Context, intentIDs []string) (map[string][]types.ProfileDonationRedirect, error) {
+	details, err := s.fundReallocationRepo.DonationReallocationsByIntentIDs(ctx, intentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetch donation reallocations: %w", err)
+	}
+
+	targetNeedIDs := make([]string, 0)
+	targetCategoryIDs := make([]string, 0)
+	for _, detail := range details {
+		if detail.TargetNeedID != nil {
+			targetNeedIDs = append(targetNeedIDs, *detail.TargetNeedID)
+		}
+		if detail.TargetCategoryID != nil {
+			targetCategoryIDs = append(targetCategoryIDs, *detail.TargetCategoryID)
+		}
+	}
+
+	destinations, err := s.loadReallocationDestinations(ctx, targetNeedIDs, targetCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	redirects := make(map[string][]types.ProfileDonationRedirect)
+	for _, detail := range details {
+		destination := destinations.label(detail.TargetNeedID, detail.TargetCategoryID)
+		amount := formatUSDFromCents(detail.AmountCents)
+
+		var redirect types.ProfileDonationRedirect
+		switch detail.Status {
+		case types.DonationReallocationStatusRedirected:
+			redirect.Message = fmt.Sprintf("%s of this gift was redirected to %s.", amount, destination)
+		case types.DonationReallocationStatusOptedOut:
+			redirect.Message = fmt.Sprintf("You opted out of redirecting %s of this gift; a refund has been requested.", amount)
+		case types.DonationReallocationStatusRefunded:
+			redirect.Message = fmt.Sprintf("%s of this gift was refunded to you.", amount)
+		case types.DonationReallocationStatusPending:
+			deadline := "soon"
+			if detail.ConsentDeadline != nil {
+				deadline = "on " + detail.ConsentDeadline.Format("Jan 2, 2006")
+			}
+			redirect.Message = fmt.Sprintf("%s of this gift will be redirected to %s %s unless you opt out.", amount, destination, deadline)
+			if detail.ConsentDeadline == nil || time.Now().Before(*detail.ConsentDeadline) {
+				redirect.OptOutAction = s.route(RouteProfileDonationReallocationOptOut, Param("intentID", detail.DonationIntentID), Param("allocationID", detail.ID))
+			}
+		default:
+			continue
+		}
+
+		redirects[detail.DonationIntentID] = append(redirects[detail.DonationIntentID], redirect)
+	}
+
+	return redirects, nil
+}
+
+type fundReallocationEmailTemplateData struct {
+	DonorName       string
+	NeedTitle       string
+	Amount          string
+	Destination     string
+	ConsentDeadline string
+	ProfileURL      string
+}
+
+// notifyDonorsOfReallocation emails each signed-in donor whose gift is part
+// of a proposed reallocation, once per donor with their combined share.
+// Failures are logged; the proposal has already been committed.
+func (s *Service) notifyDonorsOfReallocation(ctx context.Context, need *types.Need, reallocation *types.FundReallocation, intents []*types.DonationIntent, allocations []*types.DonationReallocation, destination string) {
+	logger := s.logger.WithField("need_id", need.ID).WithField("reallocation_id", reallocation.ID)
+
+	donorByIntentID := make(map[string]string, len(intents))
+	for _, intent := range intents {
+		if intent != nil && intent.DonorUserID != nil {
+			donorByIntentID[intent.ID] = *intent.DonorUserID
+		}
+	}
+
+	centsByDonor := make(map[string]int)
+	donorIDs := make([]string, 0)
+	for _, allocation := range allocations {
+		donorID, ok := donorByIntentID[allocation.DonationIntentID]
+		if !ok {
+			continue
+		}
+		if _, seen := centsByDonor[donorID]; !seen {
+			donorIDs = append(donorIDs, donorID)
+		}
+		centsByDonor[donorID] += allocation.AmountCents
+	}
+	if len(donorIDs) == 0 {
+		return
+	}
+
+	donors, err := s.userRepo.UsersByIDs(ctx, donorIDs)
+	if err != nil {
+		logger.WithError(err).Error("failed to load donor users for reallocation notification")
+		return
+	}
+
+	from := strings.TrimSpace(s.config.EmailFromAddress)
+	if from == "" {
+		from = "noreply@christjesus.app"
+	}
+
+	needTitle := "a need you supported"
+	if need.ShortDescription != nil && strings.TrimSpace(*need.ShortDescription) != "" {
+		needTitle = strings.TrimSpace(*need.ShortDescription)
+	}
+
+	deadline := ""
+	if reallocation.ConsentDeadline != nil {
+		deadline = reallocation.ConsentDeadline.Format("Jan 2, 2006")
+	}
+
+	profileURL := s.absoluteRoute(RouteProfile, nil)
+
+	for _, donor := range donors {
+		if donor == nil || donor.Email == nil || strings.TrimSpace(*donor.Email) == "" {
+			continue
+		}
+
+		donorName := ""
+		if donor.GivenName != nil {
+			donorName = *donor.GivenName
+		}
+
+		amount := formatUSDFromCents(centsByDonor[donor.ID])
+
+		var htmlBuf bytes.Buffer
+		if err := s.templates.ExecuteTemplate(&htmlBuf, "email.fund-reallocation", fundReallocationEmailTemplateData{
+			DonorName:       donorName,
+			NeedTitle:       needTitle,
+			Amount:          amount,
+			Destination:     destination,
+			ConsentDeadline: deadline,
+			ProfileURL:      profileURL,
+		}); err != nil {
+			logger.WithError(err).Error("failed to render fund reallocation email template")
+			return
+		}
+
+		textBody := fmt.Sprintf("%s of your gift to %s is no longer needed there. We plan to redirect it to %s on %s.\n\nIf you would rather have it refunded, opt out from your profile before then: %s\n\nChristJesus.app", amount, needTitle, destination, deadline, profileURL)
+
+		record, err := s.sendEmail(ctx, internalemail.Message{
+			From:     from,
+			To:       *donor.Email,
+			Subject:  "Where should the rest of your gift go?",
+			HTMLBody: htmlBuf.String(),
+			TextBody: textBody,
+		}, types.EmailTypeFundReallocation)
+		if err != nil {
+			logger.WithError(err).WithField("user_id", donor.ID).Error("failed to send fund reallocation email")
+			continue
+		}
+		if record == nil {
+			continue
+		}
+
+		if err := s.emailRepo.InsertUserEmail(ctx, &types.UserEmail{
+			ID:             utils.NanoID(),
+			UserID:         donor.ID,
+			EmailMessageID: record.ID,
+			EmailType:      types.EmailTypeFundReallocation,
+		}); err != nil {
+			logger.WithError(err).WithField("user_id", donor.ID).Warn("failed to link fund reallocation email to user")
+		}
+	}
+}
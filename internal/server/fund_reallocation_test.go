@@ -0,0 +1,86 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+func TestUnqueuedReallocationBalanceCents(t *testing.T) {
+	reallocations := []*types.FundReallocation{
+		{AmountCents: 5000, Status: types.FundReallocationStatusQueued},
+		{AmountCents: 2000, Status: types.FundReallocationStatusProposed},
+		{AmountCents: 9000, Status: types.FundReallocationStatusCompleted},
+	}
+
+	need := &types.Need{AmountNeededCents: 100000, AmountRaisedCents: 110000}
+	balance, source := unqueuedReallocationBalanceCents(need, reallocations)
+	if balance != 3000 || source != types.FundReallocationSourceOverfunded {
+		t.Fatalf("expected 3000 overfunded, got %d %s", balance, source)
+	}
+
+	need = &types.Need{AmountNeededCents: 100000, AmountRaisedCents: 50000}
+	if balance, _ := unqueuedReallocationBalanceCents(need, nil); balance != 0 {
+		t.Fatalf("expected no balance for an underfunded need, got %d", balance)
+	}
+
+	deletedAt := time.Now()
+	need = &types.Need{AmountNeededCents: 100000, AmountRaisedCents: 50000, DeletedAt: &deletedAt}
+	balance, source = unqueuedReallocationBalanceCents(need, reallocations)
+	if balance != 43000 || source != types.FundReallocationSourceDeleted {
+		t.Fatalf("expected 43000 deleted, got %d %s", balance, source)
+	}
+}
+
+func TestAllocateDonationReallocation(t *testing.T) {
+	intents := []*types.DonationIntent{
+		{ID: "newest", AmountCents: 2000},
+		{ID: "middle", AmountCents: 5000},
+		{ID: "oldest", AmountCents: 10000},
+	}
+	allocated := map[string]int{"newest": 2000, "middle": 1000}
+
+	allocations := allocateDonationReallocation("realloc-1", intents, allocated, 6000)
+	if len(allocations) != 2 {
+		t.Fatalf("expected 2 allocations, got %d", len(allocations))
+	}
+	if allocations[0].DonationIntentID != "middle" || allocations[0].AmountCents != 4000 {
+		t.Fatalf("unexpected first allocation %+v", allocations[0])
+	}
+	if allocations[1].DonationIntentID != "oldest" || allocations[1].AmountCents != 2000 {
+		t.Fatalf("unexpected second allocation %+v", allocations[1])
+	}
+	for _, allocation := range allocations {
+		if allocation.FundReallocationID != "realloc-1" {
+			t.Fatalf("expected allocation to reference realloc-1, got %q", allocation.FundReallocationID)
+		}
+	}
+
+	if allocations := allocateDonationReallocation("realloc-2", intents, nil, 50000); len(allocations) != 3 {
+		t.Fatalf("expected every gift to be used when the amount exceeds them, got %d", len(allocations))
+	}
+}
+
+func TestAttributableReallocationCents(t *testing.T) {
+	intents := []*types.DonationIntent{
+		{ID: "newest", AmountCents: 2000},
+		{ID: "oldest", AmountCents: 10000},
+	}
+	allocated := map[string]int{"newest": 2500, "oldest": 3000}
+	reallocations := []*types.FundReallocation{
+		{AmountCents: 4000, Status: types.FundReallocationStatusQueued},
+		{AmountCents: 3000, Status: types.FundReallocationStatusProposed},
+	}
+
+	// 10000 - 3000 left on the oldest gift, less the 4000 queued; the
+	// proposed reallocation is already counted in allocated.
+	if got := attributableReallocationCents(intents, allocated, reallocations); got != 3000 {
+		t.Fatalf("expected 3000 attributable, got %d", got)
+	}
+
+	// A need funded only by an earlier redirect has no gifts to draw on.
+	if got := attributableReallocationCents(nil, nil, nil); got != 0 {
+		t.Fatalf("expected nothing attributable without gifts, got %d", got)
+	}
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"christjesus/pkg/types"
+	"context"
+	"strings"
+)
+
+// normalizeAddressPrivacy maps a stored privacy_display value to one of the
+// supported levels. Unknown and legacy values fall back to city.
+func normalizeAddressPrivacy(value *string) string {
+	switch strings.ToLower(strings.TrimSpace(derefString(value))) {
+	case types.AddressPrivacyCounty:
+		return types.AddressPrivacyCounty
+	case types.AddressPrivacyRegion:
+		return types.AddressPrivacyRegion
+	default:
+		return types.AddressPrivacyCity
+	}
+}
+
+func addressPrivacyLabel(value *string) string {
+	switch normalizeAddressPrivacy(value) {
+	case types.AddressPrivacyCounty:
+		return "County only"
+	case types.AddressPrivacyRegion:
+		return "Region only"
+	default:
+		return "City only"
+	}
+}
+
+// browseCityStateParts returns the city, state and combined label to show
+// publicly for an address, honoring its privacy_display. County privacy needs
+// the county name for the address ZIP; without one it degrades to region.
+func browseCityStateParts(address *types.UserAddress, countyName string) (string, string, string) {
+	city, state, cityState := addressCityStateParts(address)
+	if address == nil {
+		return city, state, cityState
+	}
+
+	switch normalizeAddressPrivacy(address.PrivacyDisplay) {
+	case types.AddressPrivacyCounty:
+		if countyName = strings.TrimSpace(countyName); countyName != "" && state != "N/A" {
+			return "N/A", state, countyName + ", " + state
+		}
+		return "N/A", state, regionLabel(state)
+	case types.AddressPrivacyRegion:
+		return "N/A", state, regionLabel(state)
+	default:
+		return city, state, cityState
+	}
+}
+
+// addressCityStateParts returns the exact city and state of an address. Use
+// browseCityStateParts for anything shown outside the admin area.
+func addressCityStateParts(address *types.UserAddress) (string, string, string) {
+	city := "N/A"
+	state := "N/A"
+
+	if address != nil {
+		if address.City != nil {
+			trimmed := strings.TrimSpace(*address.City)
+			if trimmed != "" {
+				city = trimmed
+			}
+		}
+		if address.State != nil {
+			trimmed := strings.TrimSpace(*address.State)
+			if trimmed != "" {
+				state = strings.ToUpper(trimmed)
+			}
+		}
+	}
+
+	if city == "N/A" || state == "N/A" {
+		return city, state, "N/A"
+	}
+
+	return city, state, city + ", " + state
+}
+
+func regionLabel(state string) string {
+	if name, ok := usStateNames[state]; ok {
+		return name
+	}
+	if state == "" || state == "N/A" {
+		return "N/A"
+	}
+	return state
+}
+
+// publicNeedDistanceMiles returns the distance to show for a need. The store
+// has already fuzzed it; needs with county or region privacy never show one.
+func publicNeedDistanceMiles(privacy string, miles *float64) *float64 {
+	if privacy != types.AddressPrivacyCity {
+		return nil
+	}
+	return miles
+}
+
+// countyNamesForAddresses looks up county names for the ZIPs of addresses that
+// chose county privacy. Lookup failures degrade those needs to region display.
+func (s *Service) countyNamesForAddresses(ctx context.Context, addresses ...*types.UserAddress) map[string]string {
+	zipCodes := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address == nil || normalizeAddressPrivacy(address.PrivacyDisplay) != types.AddressPrivacyCounty {
+			continue
+		}
+		if zipCode := derefString(address.ZipCode); zipCode != "" {
+			zipCodes = append(zipCodes, zipCode)
+		}
+	}
+	if len(zipCodes) == 0 {
+		return map[string]string{}
+	}
+
+	countyByZip, err := s.needsRepo.CountyNamesByZipCodes(ctx, uniqueSortedStrings(zipCodes))
+	if err != nil {
+		s.logger.WithError(err).Warn("failed to look up county names for address privacy")
+		return map[string]string{}
+	}
+
+	return countyByZip
+}
+
+// publicLocation resolves the privacy-respecting location label for one
+// address.
+func (s *Service) publicLocation(ctx context.Context, address *types.UserAddress) (string, string, string) {
+	countyByZip := s.countyNamesForAddresses(ctx, address)
+	countyName := ""
+	if address != nil {
+		countyName = countyByZip[derefString(address.ZipCode)]
+	}
+	return browseCityStateParts(address, countyName)
+}
+
+var usStateNames = map[string]string{
+	"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas", "CA": "California",
+	"CO": "Colorado", "CT": "Connecticut", "DE": "Delaware", "DC": "District of Columbia", "FL": "Florida",
+	"GA": "Georgia", "HI": "Hawaii", "ID": "Idaho", "IL": "Illinois", "IN": "Indiana",
+	"IA": "Iowa", "KS": "Kansas", "KY": "Kentucky", "LA": "Louisiana", "ME": "Maine",
+	"MD": "Maryland", "MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota", "MS": "Mississippi",
+	"MO": "Missouri", "MT": "Montana", "NE": "Nebraska", "NV": "Nevada", "NH": "New Hampshire",
+	"NJ": "New Jersey", "NM": "New Mexico", "NY": "New York", "NC": "North Carolina", "ND": "North Dakota",
+	"OH": "Ohio", "OK": "Oklahoma", "OR": "Oregon", "PA": "Pennsylvania", "RI": "Rhode Island",
+	"SC": "South Carolina", "SD": "South Dakota", "TN": "Tennessee", "TX": "Texas", "UT": "Utah",
+	"VT": "Vermont", "VA": "Virginia", "WA": "Washington", "WV": "West Virginia", "WI": "Wisconsin",
+	"WY": "Wyoming", "PR": "Puerto Rico", "GU": "Guam", "VI": "U.S. Virgin Islands",
+}
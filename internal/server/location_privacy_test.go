@@ -0,0 +1,52 @@
+package server
+
+import (
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func testAddress(privacy string) *types.UserAddress {
+	city, state, zip := "Charlotte", "nc", "28202"
+	return &types.UserAddress{City: &city, State: &state, ZipCode: &zip, PrivacyDisplay: &privacy}
+}
+
+func TestBrowseCityStateParts_HonorsPrivacy(t *testing.T) {
+	cases := []struct {
+		privacy    string
+		countyName string
+		wantCity   string
+		wantLabel  string
+	}{
+		{privacy: "city", wantCity: "Charlotte", wantLabel: "Charlotte, NC"},
+		{privacy: "neighborhood", wantCity: "Charlotte", wantLabel: "Charlotte, NC"},
+		{privacy: "county", countyName: "Mecklenburg County", wantCity: "N/A", wantLabel: "Mecklenburg County, NC"},
+		{privacy: "county", wantCity: "N/A", wantLabel: "North Carolina"},
+		{privacy: "region", countyName: "Mecklenburg County", wantCity: "N/A", wantLabel: "North Carolina"},
+	}
+
+	for _, tc := range cases {
+		city, state, label := browseCityStateParts(testAddress(tc.privacy), tc.countyName)
+		if city != tc.wantCity || state != "NC" || label != tc.wantLabel {
+			t.Fatalf("%s/%q: got (%q, %q, %q)", tc.privacy, tc.countyName, city, state, label)
+		}
+	}
+
+	if _, _, label := browseCityStateParts(nil, ""); label != "N/A" {
+		t.Fatalf("expected N/A for missing address, got %q", label)
+	}
+}
+
+func TestPublicNeedDistanceMiles(t *testing.T) {
+	miles := 15.0
+
+	if got := publicNeedDistanceMiles(types.AddressPrivacyCounty, &miles); got != nil {
+		t.Fatalf("expected no distance for county privacy, got %v", *got)
+	}
+	if got := publicNeedDistanceMiles(types.AddressPrivacyRegion, &miles); got != nil {
+		t.Fatalf("expected no distance for region privacy, got %v", *got)
+	}
+	if got := publicNeedDistanceMiles(types.AddressPrivacyCity, &miles); got == nil || *got != miles {
+		t.Fatalf("expected city privacy to keep the store distance, got %v", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"christjesus/internal"
+	"christjesus/pkg/types"
 
 	"github.com/gorilla/csrf"
 	"github.com/lestrrat-go/jwx/v3/jwt"
@@ -19,7 +20,8 @@ import (
 type contextKey string
 
 const (
-	contextKeySession contextKey = "session"
+	contextKeySession     contextKey = "session"
+	contextKeyRestriction contextKey = "restriction"
 )
 
 type AuthSession struct {
@@ -31,6 +33,7 @@ type AuthSession struct {
 	DisplayName string
 	UserType    string
 	IsAdmin     bool
+	AdminRoles  []types.AdminRole
 }
 
 func sessionFromRequest(r *http.Request) (*AuthSession, bool) {
@@ -42,6 +45,13 @@ func sessionFromContext(ctx context.Context) (*AuthSession, bool) {
 	return s, ok && s != nil
 }
 
+// restrictionFromRequest returns the suspension or ban that signed the
+// requester out, if AttachAuthContext found one on this request.
+func restrictionFromRequest(r *http.Request) (*types.UserRestriction, bool) {
+	restriction, ok := r.Context().Value(contextKeyRestriction).(*types.UserRestriction)
+	return restriction, ok && restriction != nil
+}
+
 type authUserState struct {
 	UserID      string
 	AuthSubject string
@@ -50,7 +60,6 @@ type authUserState struct {
 	FamilyName  string
 	DisplayName string
 	UserType    string
-	IsAdmin     bool
 }
 
 type responseWriter struct {
@@ -65,7 +74,6 @@ type AuthClaims struct {
 	FamilyName  string
 	DisplayName string
 	Nonce       string
-	IsAdmin     bool
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -164,6 +172,32 @@ func (s *Service) AttachAuthContext(next http.Handler) http.Handler {
 
 		ctx := r.Context()
 
+		// Suspended and banned users are signed out on their next request.
+		// Without a session every RequireAuth route turns them away, which
+		// covers new needs, donations and review messages. If the check
+		// cannot run the request is refused rather than trusted.
+		restriction, err := s.restrictionCheck.ActiveRestrictionByUser(ctx, userID)
+		if err != nil {
+			s.logger.WithError(err).WithField("user_id", userID).Error("failed to load user restriction")
+			s.internalServerError(w)
+			return
+		}
+		if restriction != nil {
+			s.clearAccessTokenCookie(w)
+			s.clearAuthUserStateCookie(w)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, contextKeyRestriction, restriction)))
+			return
+		}
+
+		// Admin access comes from roles granted in our database rather than
+		// the identity provider, so a revoked role takes effect on the next
+		// request instead of the next login.
+		adminRoles, err := s.adminRoleRepo.ActiveRolesByUser(ctx, userID)
+		if err != nil {
+			s.logger.WithError(err).WithField("user_id", userID).Error("failed to load admin roles")
+			adminRoles = nil
+		}
+
 		ctx = context.WithValue(ctx, contextKeySession, &AuthSession{
 			UserID:      userID,
 			AuthSubject: state.AuthSubject,
@@ -172,7 +206,8 @@ func (s *Service) AttachAuthContext(next http.Handler) http.Handler {
 			FamilyName:  familyName,
 			DisplayName: displayName,
 			UserType:    strings.TrimSpace(state.UserType),
-			IsAdmin:     claims.IsAdmin,
+			IsAdmin:     len(adminRoles) > 0,
+			AdminRoles:  adminRoles,
 		})
 
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -275,20 +310,6 @@ func (s *Service) authClaimsFromToken(ctx context.Context, tokenString string) (
 	}
 	nonce = strings.TrimSpace(nonce)
 
-	isAdmin := false
-	adminClaim := strings.TrimSpace(internal.AuthAdminClaim)
-	adminValue := strings.TrimSpace(internal.AuthAdminValue)
-	if adminClaim != "" {
-		if groups, ok := tokenStringSliceClaim(token, adminClaim); ok {
-			for _, group := range groups {
-				if adminValue != "" && strings.EqualFold(strings.TrimSpace(group), adminValue) {
-					isAdmin = true
-					break
-				}
-			}
-		}
-	}
-
 	return &AuthClaims{
 		Subject:     subject,
 		Email:       email,
@@ -296,53 +317,17 @@ func (s *Service) authClaimsFromToken(ctx context.Context, tokenString string) (
 		FamilyName:  familyName,
 		DisplayName: displayName,
 		Nonce:       nonce,
-		IsAdmin:     isAdmin,
 	}, nil
 }
 
-func tokenStringSliceClaim(token jwt.Token, claim string) ([]string, bool) {
-	var raw any
-	if err := token.Get(claim, &raw); err != nil {
-		return nil, false
-	}
-
-	switch v := raw.(type) {
-	case []string:
-		if len(v) == 0 {
-			return nil, false
-		}
-		return v, true
-	case []any:
-		out := make([]string, 0, len(v))
-		for _, item := range v {
-			s, ok := item.(string)
-			if !ok {
-				continue
-			}
-			trimmed := strings.TrimSpace(s)
-			if trimmed == "" {
-				continue
-			}
-			out = append(out, trimmed)
-		}
-		if len(out) == 0 {
-			return nil, false
-		}
-		return out, true
-	case string:
-		trimmed := strings.TrimSpace(v)
-		if trimmed == "" {
-			return nil, false
-		}
-		return []string{trimmed}, true
-	default:
-		return nil, false
-	}
-}
-
 // RequireAuth middleware checks for valid access token and adds user to context
 func (s *Service) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if restriction, ok := restrictionFromRequest(r); ok {
+			s.redirectRestrictedUser(w, r, restriction)
+			return
+		}
+
 		session, ok := sessionFromRequest(r)
 		if !ok || session.UserID == "" {
 			s.setRedirectCookie(w, r.URL.Path, time.Minute*5)
@@ -372,6 +357,11 @@ func (s *Service) RequireAuth(next http.Handler) http.Handler {
 // RequireAdmin middleware enforces authenticated admin access.
 func (s *Service) RequireAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if restriction, ok := restrictionFromRequest(r); ok {
+			s.redirectRestrictedUser(w, r, restriction)
+			return
+		}
+
 		session, ok := sessionFromRequest(r)
 		if !ok || session.UserID == "" {
 			s.setRedirectCookie(w, r.URL.Path, time.Minute*5)
@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"christjesus/pkg/types"
+
 	"github.com/gorilla/securecookie"
 )
 
@@ -87,3 +89,53 @@ func TestRequireAdmin_AdminPasses(t *testing.T) {
 		t.Fatalf("status = %d, want %d", rr.Code, http.StatusNoContent)
 	}
 }
+
+func TestRequirePermission_MissingPermissionForbidden(t *testing.T) {
+	t.Parallel()
+
+	s := &Service{}
+
+	h := s.RequirePermission(adminPermissionNeedsDelete)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/needs/need_123/delete", nil)
+	req = req.WithContext(context.WithValue(req.Context(), contextKeySession, &AuthSession{
+		UserID:     "reviewer_123",
+		IsAdmin:    true,
+		AdminRoles: []types.AdminRole{types.AdminRoleReviewer},
+	}))
+	rr := httptest.NewRecorder()
+
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequirePermission_SuperadminPasses(t *testing.T) {
+	t.Parallel()
+
+	s := &Service{}
+
+	called := false
+	h := s.RequirePermission(adminPermissionRolesManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/roles", nil)
+	req = req.WithContext(context.WithValue(req.Context(), contextKeySession, &AuthSession{
+		UserID:     "admin_123",
+		IsAdmin:    true,
+		AdminRoles: []types.AdminRole{types.AdminRoleSuperadmin},
+	}))
+	rr := httptest.NewRecorder()
+
+	h.ServeHTTP(rr, req)
+
+	if !called {
+		t.Fatal("expected next handler to be called")
+	}
+}
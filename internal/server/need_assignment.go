@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// needAssignmentDecision is the outcome of an assignment request: who the need
+// should be assigned to next (nil releases it) and how to record the change.
+type needAssignmentDecision struct {
+	reviewerUserID *string
+	actionType     types.NeedModerationActionType
+	note           *string
+}
+
+func (s *Service) handlePostAdminNeedClaim(w http.ResponseWriter, r *http.Request) {
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	if needID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	if !s.needIsInReviewQueue(w, r, needID) {
+		return
+	}
+
+	err := s.updateNeedAssignment(r.Context(), needID, actorUserID, func(current *string) (*needAssignmentDecision, error) {
+		if current != nil && *current != actorUserID {
+			return nil, types.ErrNeedClaimedByOther
+		}
+		if current != nil {
+			return nil, nil
+		}
+		return &needAssignmentDecision{
+			reviewerUserID: &actorUserID,
+			actionType:     types.NeedModerationActionTypeReviewClaimed,
+		}, nil
+	})
+	if err != nil {
+		s.redirectAdminNeedAssignmentError(w, r, needID, err, "failed to claim need")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Need claimed")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminNeedRelease(w http.ResponseWriter, r *http.Request) {
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	if needID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+	isLead := session.Can(adminPermissionNeedsAssign)
+
+	err := s.updateNeedAssignment(r.Context(), needID, actorUserID, func(current *string) (*needAssignmentDecision, error) {
+		if !canReleaseReviewClaim(actorUserID, current, isLead) {
+			return nil, types.ErrNeedNotClaimed
+		}
+
+		decision := &needAssignmentDecision{actionType: types.NeedModerationActionTypeReviewReleased}
+		if *current != actorUserID {
+			note := "released claim held by " + *current
+			decision.note = &note
+		}
+		return decision, nil
+	})
+	if err != nil {
+		s.redirectAdminNeedAssignmentError(w, r, needID, err, "failed to release need")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Need released back to the queue")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminNeedReassign(w http.ResponseWriter, r *http.Request) {
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	if needID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	reviewerUserID := strings.TrimSpace(r.FormValue("reviewer_user_id"))
+	if reviewerUserID == "" {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "reviewer user id is required")
+		return
+	}
+
+	reviewer, err := s.userRepo.User(r.Context(), reviewerUserID)
+	if err != nil {
+		if errors.Is(err, types.ErrUserNotFound) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "reviewer not found")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch reviewer for reassignment")
+		s.internalServerError(w)
+		return
+	}
+
+	if !s.needIsInReviewQueue(w, r, needID) {
+		return
+	}
+
+	err = s.updateNeedAssignment(r.Context(), needID, actorUserID, func(current *string) (*needAssignmentDecision, error) {
+		if current != nil && *current == reviewer.ID {
+			return nil, nil
+		}
+
+		note := "assigned to " + reviewer.ID
+		if current != nil {
+			note = fmt.Sprintf("reassigned from %s to %s", *current, reviewer.ID)
+		}
+		return &needAssignmentDecision{
+			reviewerUserID: &reviewer.ID,
+			actionType:     types.NeedModerationActionTypeReviewReassigned,
+			note:           &note,
+		}, nil
+	})
+	if err != nil {
+		s.redirectAdminNeedAssignmentError(w, r, needID, err, "failed to reassign need")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Need reassigned to "+userDisplayName(reviewer))
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
+
+// buildAdminNeedAssignmentView describes who holds the review and what the
+// viewer may do about it. Needs outside the review queue have no assignment
+// panel.
+func (s *Service) buildAdminNeedAssignmentView(ctx context.Context, need *types.Need, session *AuthSession) (*types.AdminNeedAssignmentView, error) {
+	if need.DeletedAt != nil || !isReviewQueueStatus(need.Status) {
+		return nil, nil
+	}
+
+	viewerUserID := session.UserID
+	canModerate := session.Can(adminPermissionNeedsModerate)
+	isLead := session.Can(adminPermissionNeedsAssign)
+	slaLabel, isOverdue := reviewSLAStatus(need.SubmittedAt, s.reviewSLA(), time.Now())
+
+	view := &types.AdminNeedAssignmentView{
+		AssignedAt:     formatOptionalDateTime(need.AssignedAt),
+		IsClaimed:      need.ReviewerUserID != nil,
+		IsMine:         need.ReviewerUserID != nil && *need.ReviewerUserID == viewerUserID,
+		SLALabel:       slaLabel,
+		IsOverdue:      isOverdue,
+		CanClaim:       canModerate && need.ReviewerUserID == nil,
+		CanRelease:     canModerate && canReleaseReviewClaim(viewerUserID, need.ReviewerUserID, isLead),
+		CanReassign:    isLead,
+		ClaimAction:    s.route(RouteAdminNeedClaim, Param("needID", need.ID)),
+		ReleaseAction:  s.route(RouteAdminNeedRelease, Param("needID", need.ID)),
+		ReassignAction: s.route(RouteAdminNeedReassign, Param("needID", need.ID)),
+	}
+
+	if need.ReviewerUserID != nil {
+		view.AssignedTo = *need.ReviewerUserID
+		reviewer, err := s.userRepo.User(ctx, *need.ReviewerUserID)
+		if err != nil && !errors.Is(err, types.ErrUserNotFound) {
+			return nil, err
+		}
+		if reviewer != nil {
+			view.AssignedTo = userDisplayName(reviewer)
+		}
+	}
+
+	return view, nil
+}
+
+// updateNeedAssignment locks the need, lets decide inspect the current
+// reviewer, and applies and records the resulting change in one transaction.
+// A nil decision leaves the assignment untouched.
+func (s *Service) updateNeedAssignment(ctx context.Context, needID, actorUserID string, decide func(current *string) (*needAssignmentDecision, error)) error {
+	return store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		current, err := s.needsRepo.NeedReviewerForUpdateTx(ctx, tx, needID)
+		if err != nil {
+			return err
+		}
+
+		decision, err := decide(current)
+		if err != nil || decision == nil {
+			return err
+		}
+
+		if err := s.needsRepo.SetNeedReviewerTx(ctx, tx, needID, decision.reviewerUserID); err != nil {
+			return err
+		}
+
+		_, err = s.progressRepo.RecordModerationActionEventTx(ctx, tx, needID, decision.actionType, actorUserID, nil, decision.note, nil)
+		return err
+	})
+}
+
+// needIsInReviewQueue reports whether the need can be claimed, redirecting
+// with an error when it is not.
+func (s *Service) needIsInReviewQueue(w http.ResponseWriter, r *http.Request, needID string) bool {
+	need, err := s.needsRepo.Need(r.Context(), needID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return false
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need before assignment change")
+		s.internalServerError(w)
+		return false
+	}
+
+	if need.DeletedAt != nil || !isReviewQueueStatus(need.Status) {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "only needs waiting in the review queue can be assigned")
+		return false
+	}
+
+	return true
+}
+
+func (s *Service) redirectAdminNeedAssignmentError(w http.ResponseWriter, r *http.Request, needID string, err error, fallback string) {
+	switch {
+	case errors.Is(err, types.ErrNeedClaimedByOther):
+		s.redirectAdminNeedReviewWithError(w, r, needID, "need is claimed by another reviewer; ask a review lead to reassign it")
+	case errors.Is(err, types.ErrNeedNotClaimed):
+		s.redirectAdminNeedReviewWithError(w, r, needID, "only the assigned reviewer or a review lead can release this need")
+	case errors.Is(err, types.ErrNeedNotFound):
+		s.redirectAdminNeedReviewWithError(w, r, needID, "need not found or deleted")
+	default:
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to update need assignment")
+		s.redirectAdminNeedReviewWithError(w, r, needID, fallback)
+	}
+}
+
+func (s *Service) reviewSLA() time.Duration {
+	if s.config == nil || s.config.ReviewSLAHours <= 0 {
+		return 48 * time.Hour
+	}
+	return time.Duration(s.config.ReviewSLAHours) * time.Hour
+}
+
+// canReleaseReviewClaim reports whether actor may drop the current claim:
+// reviewers may release their own claims, leads may release anyone's.
+func canReleaseReviewClaim(actorUserID string, assignedUserID *string, isLead bool) bool {
+	if assignedUserID == nil {
+		return false
+	}
+	return *assignedUserID == actorUserID || isLead
+}
+
+func isReviewQueueStatus(status types.NeedStatus) bool {
+	return status == types.NeedStatusReadyForReview || status == types.NeedStatusUnderReview
+}
+
+// reviewSLAStatus describes how a need stands against the review SLA counted
+// from submission, e.g. "12h left" or "overdue 1d 4h".
+func reviewSLAStatus(submittedAt *time.Time, sla time.Duration, now time.Time) (string, bool) {
+	if submittedAt == nil {
+		return "-", false
+	}
+
+	remaining := submittedAt.Add(sla).Sub(now)
+	if remaining < 0 {
+		return "overdue " + formatWaitDuration(-remaining), true
+	}
+	return formatWaitDuration(remaining) + " left", false
+}
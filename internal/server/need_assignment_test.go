@@ -0,0 +1,47 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewSLAStatus(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	sla := 48 * time.Hour
+
+	if label, overdue := reviewSLAStatus(nil, sla, now); label != "-" || overdue {
+		t.Fatalf("unsubmitted need = %q %v, want \"-\" false", label, overdue)
+	}
+
+	submitted := now.Add(-36 * time.Hour)
+	if label, overdue := reviewSLAStatus(&submitted, sla, now); label != "12h left" || overdue {
+		t.Fatalf("within SLA = %q %v, want \"12h left\" false", label, overdue)
+	}
+
+	submitted = now.Add(-76 * time.Hour)
+	if label, overdue := reviewSLAStatus(&submitted, sla, now); label != "overdue 1d 4h" || !overdue {
+		t.Fatalf("past SLA = %q %v, want \"overdue 1d 4h\" true", label, overdue)
+	}
+}
+
+func TestCanReleaseReviewClaim(t *testing.T) {
+	owner := "usr_owner"
+	tests := []struct {
+		name     string
+		actor    string
+		assigned *string
+		isLead   bool
+		want     bool
+	}{
+		{name: "unclaimed", actor: owner, assigned: nil, isLead: true, want: false},
+		{name: "own claim", actor: owner, assigned: &owner, want: true},
+		{name: "someone else's claim", actor: "usr_other", assigned: &owner, want: false},
+		{name: "lead releases any claim", actor: "usr_lead", assigned: &owner, isLead: true, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := canReleaseReviewClaim(tt.actor, tt.assigned, tt.isLead); got != tt.want {
+			t.Fatalf("%s: canReleaseReviewClaim() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
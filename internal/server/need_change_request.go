@@ -0,0 +1,173 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"christjesus/pkg/types"
+)
+
+// needChangeRequestDocumentPrefix marks a checklist value that points at one
+// document, e.g. "document:abc123".
+const needChangeRequestDocumentPrefix = string(types.NeedChangeRequestFieldDocument) + ":"
+
+// maxNeedChangeRequestNoteLength bounds the per-item note a reviewer may add.
+const maxNeedChangeRequestNoteLength = 500
+
+func needChangeRequestFieldLabel(field types.NeedChangeRequestField) string {
+	switch field {
+	case types.NeedChangeRequestFieldAddress:
+		return "Address"
+	case types.NeedChangeRequestFieldContact:
+		return "Privacy and contact preferences"
+	case types.NeedChangeRequestFieldCategories:
+		return "Categories"
+	case types.NeedChangeRequestFieldAmount:
+		return "Amount needed"
+	case types.NeedChangeRequestFieldStory:
+		return "Story"
+	case types.NeedChangeRequestFieldDocuments:
+		return "Supporting documents"
+	case types.NeedChangeRequestFieldDocument:
+		return "Document"
+	default:
+		return string(field)
+	}
+}
+
+// needChangeRequestEditRoute is the profile edit step where the owner fixes
+// field.
+func needChangeRequestEditRoute(field types.NeedChangeRequestField) RouteName {
+	switch field {
+	case types.NeedChangeRequestFieldAddress, types.NeedChangeRequestFieldContact:
+		return RouteProfileNeedEditLocation
+	case types.NeedChangeRequestFieldCategories:
+		return RouteProfileNeedEditCategories
+	case types.NeedChangeRequestFieldAmount, types.NeedChangeRequestFieldStory:
+		return RouteProfileNeedEditStory
+	case types.NeedChangeRequestFieldDocuments, types.NeedChangeRequestFieldDocument:
+		return RouteProfileNeedEditDocs
+	default:
+		return RouteProfileNeedEdit
+	}
+}
+
+// parseNeedChangeRequestChecklist turns the ticked checklist values into items.
+// noteFor returns the reviewer's note for a value. Document values must name
+// one of the need's documents.
+func parseNeedChangeRequestChecklist(values []string, noteFor func(value string) string, documentIDs map[string]bool) ([]*types.NeedChangeRequestItem, error) {
+	items := make([]*types.NeedChangeRequestItem, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+
+		item := &types.NeedChangeRequestItem{}
+		if documentID, ok := strings.CutPrefix(value, needChangeRequestDocumentPrefix); ok {
+			if !documentIDs[documentID] {
+				return nil, fmt.Errorf("invalid document in change request checklist")
+			}
+			item.Field = types.NeedChangeRequestFieldDocument
+			item.DocumentID = &documentID
+		} else {
+			field := types.NeedChangeRequestField(value)
+			if !slices.Contains(types.NeedChangeRequestFields, field) {
+				return nil, fmt.Errorf("unknown change request checklist item")
+			}
+			item.Field = field
+		}
+
+		if note := strings.TrimSpace(noteFor(value)); note != "" {
+			if len([]rune(note)) > maxNeedChangeRequestNoteLength {
+				return nil, fmt.Errorf("checklist notes must be %d characters or fewer", maxNeedChangeRequestNoteLength)
+			}
+			item.Note = &note
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// needChangeRequestChecklistOptions lists what a reviewer can tick: the
+// need-level fields followed by each of the need's documents.
+func needChangeRequestChecklistOptions(documents []types.NeedDocument) []types.AdminExplorerOption {
+	options := make([]types.AdminExplorerOption, 0, len(types.NeedChangeRequestFields)+len(documents))
+	for _, field := range types.NeedChangeRequestFields {
+		options = append(options, types.AdminExplorerOption{Value: string(field), Label: needChangeRequestFieldLabel(field)})
+	}
+	for _, doc := range documents {
+		options = append(options, types.AdminExplorerOption{
+			Value: needChangeRequestDocumentPrefix + doc.ID,
+			Label: "Document: " + doc.FileName,
+		})
+	}
+	return options
+}
+
+// latestChangeRequestActionID returns the changes_requested action the owner
+// is currently responding to. actions are newest first; a later rejection
+// replaces the change request.
+func latestChangeRequestActionID(actions []*types.NeedModerationAction) string {
+	for _, action := range actions {
+		if action == nil {
+			continue
+		}
+		switch action.ActionType {
+		case types.NeedModerationActionTypeChangesRequested:
+			return action.ID
+		case types.NeedModerationActionTypeReviewRejected, types.NeedModerationActionTypeReviewApproved:
+			return ""
+		}
+	}
+	return ""
+}
+
+// buildNeedChangeRequestViews renders the checklist for the owner in the
+// catalogue order, linking each item to its edit step when edits are open.
+func (s *Service) buildNeedChangeRequestViews(needID string, items []*types.NeedChangeRequestItem, documents []types.NeedDocument, canEdit bool) []*types.NeedChangeRequestItemView {
+	fileNames := make(map[string]string, len(documents))
+	for _, doc := range documents {
+		fileNames[doc.ID] = doc.FileName
+	}
+
+	order := func(item *types.NeedChangeRequestItem) int {
+		if index := slices.Index(types.NeedChangeRequestFields, item.Field); index >= 0 {
+			return index
+		}
+		return len(types.NeedChangeRequestFields)
+	}
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b *types.NeedChangeRequestItem) int {
+		return order(a) - order(b)
+	})
+
+	views := make([]*types.NeedChangeRequestItemView, 0, len(sorted))
+	for _, item := range sorted {
+		label := needChangeRequestFieldLabel(item.Field)
+		if item.Field == types.NeedChangeRequestFieldDocument {
+			label = "Document (removed)"
+			if item.DocumentID != nil {
+				if fileName, ok := fileNames[*item.DocumentID]; ok {
+					label = "Document: " + fileName
+				}
+			}
+		}
+
+		view := &types.NeedChangeRequestItemView{Label: label}
+		if item.Note != nil {
+			view.Note = strings.TrimSpace(*item.Note)
+		}
+		if canEdit {
+			view.EditHref = s.route(needChangeRequestEditRoute(item.Field), Param("needID", needID))
+		}
+		views = append(views, view)
+	}
+
+	return views
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestParseNeedChangeRequestChecklist(t *testing.T) {
+	documentIDs := map[string]bool{"doc_1": true}
+	notes := map[string]string{"story": "  Explain how the funds will be used. ", "document:doc_1": ""}
+	noteFor := func(value string) string { return notes[value] }
+
+	items, err := parseNeedChangeRequestChecklist([]string{"story", "document:doc_1", "story", " "}, noteFor, documentIDs)
+	if err != nil {
+		t.Fatalf("parseNeedChangeRequestChecklist() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("parseNeedChangeRequestChecklist() returned %d items, want 2", len(items))
+	}
+	if items[0].Field != types.NeedChangeRequestFieldStory || items[0].Note == nil || *items[0].Note != "Explain how the funds will be used." {
+		t.Fatalf("story item = %+v, want trimmed note", items[0])
+	}
+	if items[1].Field != types.NeedChangeRequestFieldDocument || items[1].DocumentID == nil || *items[1].DocumentID != "doc_1" || items[1].Note != nil {
+		t.Fatalf("document item = %+v, want doc_1 without note", items[1])
+	}
+
+	if _, err := parseNeedChangeRequestChecklist([]string{"document:doc_other"}, noteFor, documentIDs); err == nil {
+		t.Fatal("expected error for a document that is not on the need")
+	}
+	if _, err := parseNeedChangeRequestChecklist([]string{"title"}, noteFor, documentIDs); err == nil {
+		t.Fatal("expected error for an unknown field")
+	}
+}
+
+func TestLatestChangeRequestActionID(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []*types.NeedModerationAction
+		want    string
+	}{
+		{
+			name: "latest decision is a change request",
+			actions: []*types.NeedModerationAction{
+				{ID: "act_3", ActionType: types.NeedModerationActionTypeDocumentRejected},
+				{ID: "act_2", ActionType: types.NeedModerationActionTypeChangesRequested},
+				{ID: "act_1", ActionType: types.NeedModerationActionTypeChangesRequested},
+			},
+			want: "act_2",
+		},
+		{
+			name: "rejection replaces the change request",
+			actions: []*types.NeedModerationAction{
+				{ID: "act_2", ActionType: types.NeedModerationActionTypeReviewRejected},
+				{ID: "act_1", ActionType: types.NeedModerationActionTypeChangesRequested},
+			},
+			want: "",
+		},
+		{name: "no decisions", actions: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := latestChangeRequestActionID(tt.actions); got != tt.want {
+			t.Fatalf("%s: latestChangeRequestActionID() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
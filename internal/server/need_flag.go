@@ -0,0 +1,417 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// storyFlagThreshold is the minimum shingle overlap (0-100) before two
+	// stories are flagged as near-duplicates.
+	storyFlagThreshold = 60
+	// storyFlagMinWords skips fuzzy matching for stories too short to compare
+	// meaningfully.
+	storyFlagMinWords = 12
+	// storyFlagCandidateLimit bounds how many recent stories are compared on
+	// each submission.
+	storyFlagCandidateLimit = 500
+	storyShingleSize        = 3
+)
+
+// detectNeedFlags runs the duplicate and fraud checks for a submitted need.
+// The checks are advisory, so a lookup that fails is logged and skipped and
+// the submission goes through with fewer signals for the reviewer.
+func (s *Service) detectNeedFlags(ctx context.Context, need *types.Need) {
+	if need == nil {
+		return
+	}
+
+	logger := s.logger.WithField("need_id", need.ID)
+
+	core, err := s.loadNeedReviewCoreData(ctx, need.ID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to load need for duplicate detection")
+		return
+	}
+
+	flags := make([]*types.NeedFlag, 0)
+
+	documentFlags, err := s.detectDocumentHashFlags(ctx, need)
+	if err != nil {
+		logger.WithError(err).Warn("failed to run document hash duplicate check")
+	}
+	flags = append(flags, documentFlags...)
+
+	addressFlags, err := s.detectAddressFlags(ctx, need, core.SelectedAddress)
+	if err != nil {
+		logger.WithError(err).Warn("failed to run address duplicate check")
+	}
+	flags = append(flags, addressFlags...)
+
+	storyFlags, err := s.detectStoryFlags(ctx, need, core.Story)
+	if err != nil {
+		logger.WithError(err).Warn("failed to run story duplicate check")
+	}
+	flags = append(flags, storyFlags...)
+
+	if err := s.needFlagRepo.CreateFlags(ctx, flags); err != nil {
+		logger.WithError(err).Warn("failed to record need flags")
+	}
+}
+
+func (s *Service) detectDocumentHashFlags(ctx context.Context, need *types.Need) ([]*types.NeedFlag, error) {
+	documents, err := s.documentRepo.DocumentsByNeedID(ctx, need.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNameByHash := make(map[string]string)
+	hashes := make([]string, 0, len(documents))
+	for _, document := range documents {
+		if document.ContentSHA256 == nil || strings.TrimSpace(*document.ContentSHA256) == "" {
+			continue
+		}
+		hash := *document.ContentSHA256
+		if _, ok := fileNameByHash[hash]; ok {
+			continue
+		}
+		fileNameByHash[hash] = document.FileName
+		hashes = append(hashes, hash)
+	}
+
+	matches, err := s.documentRepo.DocumentsByContentHashes(ctx, need.ID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNamesByNeed := make(map[string][]string)
+	matchedNeedIDs := make([]string, 0)
+	for _, match := range matches {
+		if match.ContentSHA256 == nil {
+			continue
+		}
+		if _, ok := fileNamesByNeed[match.NeedID]; !ok {
+			matchedNeedIDs = append(matchedNeedIDs, match.NeedID)
+		}
+		fileNamesByNeed[match.NeedID] = append(fileNamesByNeed[match.NeedID], fileNameByHash[*match.ContentSHA256])
+	}
+
+	flags := make([]*types.NeedFlag, 0, len(matchedNeedIDs))
+	for _, matchedNeedID := range matchedNeedIDs {
+		detail := fmt.Sprintf("Identical file uploaded to another need: %s", strings.Join(uniqueSortedStrings(fileNamesByNeed[matchedNeedID]), ", "))
+		flags = append(flags, &types.NeedFlag{
+			NeedID:        need.ID,
+			MatchedNeedID: matchedNeedID,
+			Signal:        types.NeedFlagSignalDocumentHash,
+			Detail:        &detail,
+			Similarity:    100,
+		})
+	}
+
+	return flags, nil
+}
+
+func (s *Service) detectAddressFlags(ctx context.Context, need *types.Need, address *types.UserAddress) ([]*types.NeedFlag, error) {
+	if address == nil {
+		return nil, nil
+	}
+
+	key := normalizeAddressKey(derefString(address.Address), derefString(address.AddressExt), derefString(address.City), derefString(address.State), derefString(address.ZipCode))
+	if key == "" {
+		return nil, nil
+	}
+
+	candidates, err := s.needFlagRepo.AddressCandidates(ctx, need.UserID, addressZip5(derefString(address.ZipCode)))
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]*types.NeedFlag, 0)
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.NeedID == need.ID {
+			continue
+		}
+
+		candidateKey := normalizeAddressKey(derefString(candidate.Address), derefString(candidate.AddressExt), derefString(candidate.City), derefString(candidate.State), derefString(candidate.ZipCode))
+		if candidateKey != key {
+			continue
+		}
+
+		detail := fmt.Sprintf("Same address as a need from another account (user %s)", candidate.UserID)
+		flags = append(flags, &types.NeedFlag{
+			NeedID:        need.ID,
+			MatchedNeedID: candidate.NeedID,
+			Signal:        types.NeedFlagSignalAddress,
+			Detail:        &detail,
+			Similarity:    100,
+		})
+	}
+
+	return flags, nil
+}
+
+func (s *Service) detectStoryFlags(ctx context.Context, need *types.Need, story *types.NeedStory) ([]*types.NeedFlag, error) {
+	shingles := storyShingles(storyComparisonText(story))
+	if shingles == nil {
+		return nil, nil
+	}
+
+	candidates, err := s.needFlagRepo.StoryCandidates(ctx, need.UserID, storyFlagCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]*types.NeedFlag, 0)
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.NeedID == need.ID {
+			continue
+		}
+
+		similarity := shingleSimilarity(shingles, storyShingles(storyComparisonText(candidate)))
+		if similarity < storyFlagThreshold {
+			continue
+		}
+
+		detail := fmt.Sprintf("Story text is %d%% similar to a need from another account", similarity)
+		flags = append(flags, &types.NeedFlag{
+			NeedID:        need.ID,
+			MatchedNeedID: candidate.NeedID,
+			Signal:        types.NeedFlagSignalStory,
+			Detail:        &detail,
+			Similarity:    similarity,
+		})
+	}
+
+	return flags, nil
+}
+
+var addressTokenAbbreviations = map[string]string{
+	"STREET":    "ST",
+	"AVENUE":    "AVE",
+	"ROAD":      "RD",
+	"DRIVE":     "DR",
+	"BOULEVARD": "BLVD",
+	"LANE":      "LN",
+	"COURT":     "CT",
+	"PLACE":     "PL",
+	"CIRCLE":    "CIR",
+	"HIGHWAY":   "HWY",
+	"PARKWAY":   "PKWY",
+	"TERRACE":   "TER",
+	"APARTMENT": "APT",
+	"SUITE":     "STE",
+	"UNIT":      "UNIT",
+	"NORTH":     "N",
+	"SOUTH":     "S",
+	"EAST":      "E",
+	"WEST":      "W",
+}
+
+// normalizeAddressKey reduces an address to a comparison key. Addresses are
+// USPS-standardized on save when the API is configured; this also folds the
+// common spelling differences of rows saved without validation.
+func normalizeAddressKey(street, ext, city, state, zip string) string {
+	street = normalizeAddressPart(street)
+	zip5 := addressZip5(zip)
+	if street == "" || zip5 == "" {
+		return ""
+	}
+
+	return strings.Join([]string{
+		street,
+		normalizeAddressPart(ext),
+		normalizeAddressPart(city),
+		normalizeAddressPart(state),
+		zip5,
+	}, "|")
+}
+
+func normalizeAddressPart(value string) string {
+	value = strings.ToUpper(value)
+	value = strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return ' '
+	}, value)
+
+	tokens := strings.Fields(value)
+	for i, token := range tokens {
+		if abbreviation, ok := addressTokenAbbreviations[token]; ok {
+			tokens[i] = abbreviation
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+func addressZip5(zip string) string {
+	zip = strings.TrimSpace(zip)
+	if len(zip) < 5 {
+		return ""
+	}
+	return zip[:5]
+}
+
+func storyComparisonText(story *types.NeedStory) string {
+	if story == nil {
+		return ""
+	}
+	return strings.Join([]string{derefString(story.Current), derefString(story.Need), derefString(story.Outcome)}, " ")
+}
+
+// storyShingles splits text into overlapping word triples. It returns nil for
+// text with fewer than storyFlagMinWords words.
+func storyShingles(text string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '\''
+	})
+	if len(words) < storyFlagMinWords {
+		return nil
+	}
+
+	shingles := make(map[string]struct{}, len(words))
+	for i := 0; i+storyShingleSize <= len(words); i++ {
+		shingles[strings.Join(words[i:i+storyShingleSize], " ")] = struct{}{}
+	}
+
+	return shingles
+}
+
+// shingleSimilarity returns the Jaccard similarity of two shingle sets as a
+// whole percentage.
+func shingleSimilarity(left, right map[string]struct{}) int {
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for shingle := range left {
+		if _, ok := right[shingle]; ok {
+			shared++
+		}
+	}
+
+	union := len(left) + len(right) - shared
+	return shared * 100 / union
+}
+
+func uniqueSortedStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func needFlagSignalLabel(signal types.NeedFlagSignal) string {
+	switch signal {
+	case types.NeedFlagSignalDocumentHash:
+		return "Reused Document"
+	case types.NeedFlagSignalAddress:
+		return "Shared Address"
+	case types.NeedFlagSignalStory:
+		return "Similar Story"
+	default:
+		return string(signal)
+	}
+}
+
+func (s *Service) buildAdminNeedFlagViews(needID string, flags []*types.NeedFlag) ([]*types.AdminNeedFlagView, int) {
+	views := make([]*types.AdminNeedFlagView, 0, len(flags))
+	openCount := 0
+	for _, flag := range flags {
+		if flag == nil {
+			continue
+		}
+
+		if flag.DismissedAt == nil {
+			openCount++
+		}
+
+		views = append(views, &types.AdminNeedFlagView{
+			ID:              flag.ID,
+			SignalLabel:     needFlagSignalLabel(flag.Signal),
+			Detail:          formatOptionalString(flag.Detail),
+			Similarity:      flag.Similarity,
+			MatchedNeedID:   flag.MatchedNeedID,
+			MatchedNeedHref: s.route(RouteAdminNeedReview, Param("needID", flag.MatchedNeedID)),
+			CreatedAt:       flag.CreatedAt.Format("2006-01-02 15:04"),
+			IsDismissed:     flag.DismissedAt != nil,
+			DismissedAt:     formatOptionalDateTime(flag.DismissedAt),
+			DismissedBy:     formatOptionalString(flag.DismissedByUserID),
+			DismissReason:   formatOptionalString(flag.DismissReason),
+			DismissAction:   s.route(RouteAdminNeedFlagDismiss, Param("needID", needID), Param("flagID", flag.ID)),
+		})
+	}
+
+	return views, openCount
+}
+
+func (s *Service) handlePostAdminNeedFlagDismiss(w http.ResponseWriter, r *http.Request) {
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	flagID := strings.TrimSpace(r.PathValue("flagID"))
+	if needID == "" || flagID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "invalid form submission")
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "dismiss reason is required")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	if err := store.WithTx(r.Context(), s.needFlagRepo, func(tx pgx.Tx) error {
+		flag, err := s.needFlagRepo.DismissFlagTx(r.Context(), tx, needID, flagID, actorUserID, reason)
+		if err != nil {
+			return err
+		}
+
+		note := fmt.Sprintf("%s flag against need %s", needFlagSignalLabel(flag.Signal), flag.MatchedNeedID)
+		_, err = s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeFlagDismissed, actorUserID, &reason, &note, nil)
+		return err
+	}); err != nil {
+		if errors.Is(err, types.ErrNeedFlagNotFound) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "flag not found or already dismissed")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).WithField("flag_id", flagID).Error("failed to dismiss need flag")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "failed to dismiss flag")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Flag dismissed")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
@@ -0,0 +1,56 @@
+package server
+
+import "testing"
+
+func TestNormalizeAddressKey_FoldsSpellingDifferences(t *testing.T) {
+	left := normalizeAddressKey("123 North Main Street", "Apartment 4", "Austin", "tx", "78701-1234")
+	right := normalizeAddressKey("123 N. MAIN ST", "APT 4", "AUSTIN", "TX", "78701")
+
+	if left == "" {
+		t.Fatal("expected non-empty key")
+	}
+	if left != right {
+		t.Fatalf("expected keys to match, got %q and %q", left, right)
+	}
+
+	other := normalizeAddressKey("125 N Main St", "Apt 4", "Austin", "TX", "78701")
+	if other == left {
+		t.Fatalf("expected different house numbers to produce different keys")
+	}
+}
+
+func TestNormalizeAddressKey_RequiresStreetAndZip(t *testing.T) {
+	if key := normalizeAddressKey("", "", "Austin", "TX", "78701"); key != "" {
+		t.Fatalf("expected empty key without street, got %q", key)
+	}
+	if key := normalizeAddressKey("123 Main St", "", "Austin", "TX", "787"); key != "" {
+		t.Fatalf("expected empty key without a full zip, got %q", key)
+	}
+}
+
+func TestStoryShingles_SkipsShortText(t *testing.T) {
+	if shingles := storyShingles("Need help with rent this month"); shingles != nil {
+		t.Fatalf("expected nil shingles for short text, got %d", len(shingles))
+	}
+}
+
+func TestShingleSimilarity(t *testing.T) {
+	base := "I lost my job last month and I am behind on rent for my apartment and my landlord filed an eviction notice"
+	reworded := "I lost my job last month and I am behind on rent for my apartment and my landlord just filed an eviction notice"
+	unrelated := "My car transmission failed on the highway and I need repairs so I can keep driving to my night shift job"
+
+	identical := shingleSimilarity(storyShingles(base), storyShingles(base))
+	if identical != 100 {
+		t.Fatalf("expected identical stories to score 100, got %d", identical)
+	}
+
+	near := shingleSimilarity(storyShingles(base), storyShingles(reworded))
+	if near < storyFlagThreshold {
+		t.Fatalf("expected reworded story to meet threshold, got %d", near)
+	}
+
+	far := shingleSimilarity(storyShingles(base), storyShingles(unrelated))
+	if far >= storyFlagThreshold {
+		t.Fatalf("expected unrelated story below threshold, got %d", far)
+	}
+}
@@ -0,0 +1,569 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	internalemail "christjesus/internal/email"
+	"christjesus/internal/store"
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const maxGoalChangeJustificationLen = 2000
+
+// errItemizedGoalChange rolls back approving a new goal for an itemized need.
+var errItemizedGoalChange = errors.New("goal change on itemized need")
+
+// itemizedGoalChangeReason explains why an itemized need cannot change its
+// goal: its line items were checked against the old goal and donors may have
+// given to specific items.
+const itemizedGoalChangeReason = "this need is itemized, so a new goal would leave its line items adding up to the old one; deny the request instead"
+
+// validateGoalChangeRequest checks an owner's goal change form against the
+// need's current goal. For a goal change amount is the new goal; for an early
+// close it is the amount the owner wants to keep, and may be left blank to
+// keep everything raised so far. Itemized needs may only close early.
+func validateGoalChangeRequest(need *types.Need, itemized bool, kind, amount, justification string) (*types.NeedGoalChangeRequest, error) {
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return nil, fmt.Errorf("justification cannot be empty")
+	}
+	if utf8.RuneCountInString(justification) > maxGoalChangeJustificationLen {
+		return nil, fmt.Errorf("justification cannot exceed %d characters", maxGoalChangeJustificationLen)
+	}
+
+	request := &types.NeedGoalChangeRequest{
+		NeedID:            need.ID,
+		PreviousGoalCents: need.AmountNeededCents,
+		Justification:     justification,
+	}
+
+	switch types.NeedGoalChangeKind(strings.TrimSpace(kind)) {
+	case types.NeedGoalChangeKindGoal:
+		if itemized {
+			return nil, fmt.Errorf("itemized needs cannot change their goal; close early instead")
+		}
+		goalCents, err := parseDonationAmountCents(amount)
+		if err != nil {
+			return nil, fmt.Errorf("new goal must be in whole dollars")
+		}
+		if goalCents == need.AmountNeededCents {
+			return nil, fmt.Errorf("new goal is the same as the current goal")
+		}
+		request.Kind = types.NeedGoalChangeKindGoal
+		request.RequestedGoalCents = goalCents
+	case types.NeedGoalChangeKindEarlyClose:
+		keepCents := need.AmountRaisedCents
+		if strings.TrimSpace(amount) != "" {
+			parsed, err := parseDonationAmountCents(amount)
+			if err != nil {
+				return nil, fmt.Errorf("amount to keep must be in whole dollars")
+			}
+			keepCents = parsed
+		}
+		if keepCents > need.AmountNeededCents {
+			return nil, fmt.Errorf("amount to keep cannot exceed the current goal")
+		}
+		request.Kind = types.NeedGoalChangeKindEarlyClose
+		request.RequestedGoalCents = keepCents
+	default:
+		return nil, fmt.Errorf("unknown goal change type")
+	}
+
+	return request, nil
+}
+
+// goalChangeOutcome works out what an approved request does to a need that
+// has raised raisedCents. excessCents is money the need no longer requires and
+// funded reports whether a new goal has already been met.
+func goalChangeOutcome(request *types.NeedGoalChangeRequest, raisedCents int) (excessCents int, funded bool) {
+	switch request.Kind {
+	case types.NeedGoalChangeKindGoal:
+		if raisedCents >= request.RequestedGoalCents {
+			return raisedCents - request.RequestedGoalCents, true
+		}
+		return 0, false
+	case types.NeedGoalChangeKindEarlyClose:
+		return max(raisedCents-request.RequestedGoalCents, 0), false
+	default:
+		return 0, false
+	}
+}
+
+// goalChangeNeedsSecondApproval reports whether request raises a need's goal
+// above the second approval threshold, which takes two admins just as
+// publishing a need that large does.
+func goalChangeNeedsSecondApproval(request *types.NeedGoalChangeRequest, thresholdCents int) bool {
+	return request.Kind == types.NeedGoalChangeKindGoal &&
+		thresholdCents > 0 &&
+		request.RequestedGoalCents > request.PreviousGoalCents &&
+		request.RequestedGoalCents > thresholdCents
+}
+
+// checkGoalChangeApproval decides what approving request does when actor
+// approves it. firstApproval is true when the approval should only be
+// recorded and the request held for a second admin; the admin who gave the
+// first approval cannot give the second.
+func checkGoalChangeApproval(request *types.NeedGoalChangeRequest, thresholdCents int, actorUserID string) (firstApproval bool, err error) {
+	if !goalChangeNeedsSecondApproval(request, thresholdCents) {
+		return false, nil
+	}
+	if request.FirstApprovedByUserID == nil {
+		return true, nil
+	}
+	if *request.FirstApprovedByUserID == actorUserID {
+		return false, types.ErrSecondApproverRequired
+	}
+	return false, nil
+}
+
+func goalChangeKindLabel(kind types.NeedGoalChangeKind) string {
+	switch kind {
+	case types.NeedGoalChangeKindGoal:
+		return "Goal Change"
+	case types.NeedGoalChangeKindEarlyClose:
+		return "Early Close"
+	default:
+		return string(kind)
+	}
+}
+
+func goalChangeSummary(request *types.NeedGoalChangeRequest) string {
+	switch request.Kind {
+	case types.NeedGoalChangeKindGoal:
+		return fmt.Sprintf("Goal from %s to %s", formatUSDFromCents(request.PreviousGoalCents), formatUSDFromCents(request.RequestedGoalCents))
+	case types.NeedGoalChangeKindEarlyClose:
+		return fmt.Sprintf("Close early and keep %s of the %s goal", formatUSDFromCents(request.RequestedGoalCents), formatUSDFromCents(request.PreviousGoalCents))
+	default:
+		return ""
+	}
+}
+
+func goalChangeStatusLabel(status types.NeedGoalChangeStatus) string {
+	switch status {
+	case types.NeedGoalChangeStatusPending:
+		return "Pending"
+	case types.NeedGoalChangeStatusApproved:
+		return "Approved"
+	case types.NeedGoalChangeStatusDenied:
+		return "Denied"
+	default:
+		return string(status)
+	}
+}
+
+// buildNeedGoalChangeViews renders goal change requests for the owner portal
+// and the admin review page. Decide links are only set for admins, pending
+// goal changes on itemized needs are flagged so they are denied rather than
+// approved, and the admin who gave a first approval is not offered the
+// second.
+func (s *Service) buildNeedGoalChangeViews(needID string, requests []*types.NeedGoalChangeRequest, forAdmin, itemized bool, viewerUserID string) ([]*types.NeedGoalChangeView, int) {
+	views := make([]*types.NeedGoalChangeView, 0, len(requests))
+	pendingCount := 0
+	for _, request := range requests {
+		if request == nil {
+			continue
+		}
+
+		isPending := request.Status == types.NeedGoalChangeStatusPending
+		if isPending {
+			pendingCount++
+		}
+
+		view := &types.NeedGoalChangeView{
+			ID:            request.ID,
+			KindLabel:     goalChangeKindLabel(request.Kind),
+			Summary:       goalChangeSummary(request),
+			Justification: request.Justification,
+			StatusLabel:   goalChangeStatusLabel(request.Status),
+			IsPending:     isPending,
+			CreatedAt:     request.CreatedAt.Format("2006-01-02 15:04"),
+			DecidedAt:     formatOptionalDateTime(request.DecidedAt),
+			DecidedBy:     formatOptionalString(request.DecidedByUserID),
+			DecisionNote:  formatOptionalString(request.DecisionNote),
+		}
+		if forAdmin && isPending {
+			view.DecideAction = s.route(RouteAdminNeedGoalChangeDecide, Param("needID", needID), Param("requestID", request.ID))
+			if itemized && request.Kind == types.NeedGoalChangeKindGoal {
+				view.BlockedReason = itemizedGoalChangeReason
+			}
+			if request.FirstApprovedByUserID != nil {
+				view.FirstApprovedBy = *request.FirstApprovedByUserID
+				view.FirstApprovedAt = formatOptionalDateTime(request.FirstApprovedAt)
+				view.IsFirstApprover = *request.FirstApprovedByUserID == viewerUserID
+			}
+		}
+
+		views = append(views, view)
+	}
+
+	return views, pendingCount
+}
+
+func (s *Service) handlePostProfileNeedGoalChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	if needID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	userID, err := s.userIDFromContext(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("user id not found in context")
+		s.internalServerError(w)
+		return
+	}
+
+	need, err := s.needsRepo.Need(ctx, needID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need before goal change request")
+		s.internalServerError(w)
+		return
+	}
+
+	if need.UserID != userID {
+		http.NotFound(w, r)
+		return
+	}
+
+	if need.Status != types.NeedStatusActive || need.DeletedAt != nil {
+		s.redirectProfileNeedReviewWithError(w, r, needID, "Goal changes can only be requested while a need is active.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectProfileNeedReviewWithError(w, r, needID, "Invalid form submission.")
+		return
+	}
+
+	itemized, err := s.needIsItemized(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch line items before goal change request")
+		s.internalServerError(w)
+		return
+	}
+
+	request, validationErr := validateGoalChangeRequest(need, itemized, r.FormValue("kind"), r.FormValue("amount"), r.FormValue("justification"))
+	if validationErr != nil {
+		s.redirectProfileNeedReviewWithError(w, r, needID, validationErr.Error())
+		return
+	}
+	request.RequestedByUserID = userID
+
+	if err := s.needGoalChangeRepo.CreateRequest(ctx, request); err != nil {
+		if errors.Is(err, types.ErrGoalChangeRequestPending) {
+			s.redirectProfileNeedReviewWithError(w, r, needID, "You already have a goal change request waiting for review.")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to create goal change request")
+		s.internalServerError(w)
+		return
+	}
+
+	s.redirectProfileNeedReviewWithNotice(w, r, needID, "Your request was sent to admin reviewers.")
+}
+
+func (s *Service) handlePostAdminNeedGoalChangeDecide(w http.ResponseWriter, r *http.Request) {
+	needID := strings.TrimSpace(r.PathValue("needID"))
+	requestID := strings.TrimSpace(r.PathValue("requestID"))
+	if needID == "" || requestID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "invalid form submission")
+		return
+	}
+
+	decision := strings.TrimSpace(r.FormValue("decision"))
+	note := strings.TrimSpace(r.FormValue("note"))
+
+	var status types.NeedGoalChangeStatus
+	switch decision {
+	case "approve":
+		status = types.NeedGoalChangeStatusApproved
+	case "deny":
+		if note == "" {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "a note is required when denying a request")
+			return
+		}
+		status = types.NeedGoalChangeStatusDenied
+	default:
+		s.redirectAdminNeedReviewWithError(w, r, needID, "unknown goal change decision")
+		return
+	}
+
+	need, err := s.needsRepo.Need(r.Context(), needID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need before goal change decision")
+		s.internalServerError(w)
+		return
+	}
+	if status == types.NeedGoalChangeStatusApproved && (need.Status != types.NeedStatusActive || need.DeletedAt != nil) {
+		s.redirectAdminNeedReviewWithError(w, r, needID, "goal changes can only be approved while the need is active")
+		return
+	}
+
+	itemized, err := s.needIsItemized(r.Context(), needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch line items before goal change decision")
+		s.internalServerError(w)
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "missing actor identity")
+		return
+	}
+
+	actorUserID := session.UserID
+
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+
+	intents, err := s.donationIntentRepo.FinalizedIntentsByNeedID(r.Context(), needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch gifts before goal change decision")
+		s.internalServerError(w)
+		return
+	}
+
+	var request *types.NeedGoalChangeRequest
+	var excessCents int
+	var firstApproval bool
+	if err := store.WithTx(r.Context(), s.needGoalChangeRepo, func(tx pgx.Tx) error {
+		if status == types.NeedGoalChangeStatusApproved {
+			pending, err := s.needGoalChangeRepo.PendingRequestForUpdateTx(r.Context(), tx, needID, requestID)
+			if err != nil {
+				return err
+			}
+			firstApproval, err = checkGoalChangeApproval(pending, s.secondApprovalThresholdCents(), actorUserID)
+			if err != nil {
+				return err
+			}
+			if firstApproval {
+				if err := s.needGoalChangeRepo.RecordFirstApprovalTx(r.Context(), tx, needID, requestID, actorUserID); err != nil {
+					return err
+				}
+				summary := goalChangeSummary(pending)
+				_, err = s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeGoalChangeFirstApproved, actorUserID, notePtr, &summary, nil)
+				return err
+			}
+		}
+
+		var err error
+		request, err = s.needGoalChangeRepo.DecideRequestTx(r.Context(), tx, needID, requestID, status, actorUserID, notePtr)
+		if err != nil {
+			return err
+		}
+
+		summary := goalChangeSummary(request)
+		if status == types.NeedGoalChangeStatusDenied {
+			_, err = s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeGoalChangeDenied, actorUserID, notePtr, &summary, nil)
+			return err
+		}
+
+		var raisedCents int
+		var funded bool
+		switch request.Kind {
+		case types.NeedGoalChangeKindGoal:
+			if itemized {
+				return errItemizedGoalChange
+			}
+			raisedCents, err = s.needsRepo.SetNeedGoalTx(r.Context(), tx, needID, request.RequestedGoalCents)
+			if err != nil {
+				return err
+			}
+			excessCents, funded = goalChangeOutcome(request, raisedCents)
+			if funded {
+				if err := s.needsRepo.TransitionNeedStatusTx(r.Context(), tx, needID, types.NeedStatusFunded, types.NeedProgressEventSourceSystem, ""); err != nil {
+					return err
+				}
+			}
+		case types.NeedGoalChangeKindEarlyClose:
+			raisedCents, err = s.needsRepo.AmountRaisedForUpdateTx(r.Context(), tx, needID)
+			if err != nil {
+				return err
+			}
+			excessCents, _ = goalChangeOutcome(request, raisedCents)
+			if err := s.needsRepo.TransitionNeedStatusTx(r.Context(), tx, needID, types.NeedStatusClosed, types.NeedProgressEventSourceAdmin, actorUserID); err != nil {
+				return err
+			}
+		}
+
+		if excessCents > 0 {
+			reallocations, err := s.fundReallocationRepo.ReallocationsByNeedTx(r.Context(), tx, needID)
+			if err != nil {
+				return err
+			}
+			excessCents, err = s.reallocatableCentsTx(r.Context(), tx, needID, intents, reallocations, excessCents)
+			if err != nil {
+				return err
+			}
+		}
+
+		if excessCents > 0 {
+			source := types.FundReallocationSourceGoalReduced
+			if request.Kind == types.NeedGoalChangeKindEarlyClose {
+				source = types.FundReallocationSourceEarlyClose
+			}
+			if err := s.fundReallocationRepo.QueueReallocationTx(r.Context(), tx, &types.FundReallocation{
+				NeedID:              needID,
+				AmountCents:         excessCents,
+				Source:              source,
+				GoalChangeRequestID: &request.ID,
+			}); err != nil {
+				return err
+			}
+			summary = fmt.Sprintf("%s; %s queued for reallocation", summary, formatUSDFromCents(excessCents))
+		}
+
+		_, err = s.progressRepo.RecordModerationActionEventTx(r.Context(), tx, needID, types.NeedModerationActionTypeGoalChangeApproved, actorUserID, notePtr, &summary, nil)
+		return err
+	}); err != nil {
+		if errors.Is(err, types.ErrGoalChangeRequestNotFound) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, "goal change request not found or already decided")
+			return
+		}
+		if errors.Is(err, errItemizedGoalChange) {
+			s.redirectAdminNeedReviewWithError(w, r, needID, itemizedGoalChangeReason)
+			return
+		}
+		if message, ok := needTransitionErrorMessage(err); ok {
+			s.redirectAdminNeedReviewWithError(w, r, needID, message)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).WithField("request_id", requestID).Error("failed to decide goal change request")
+		s.redirectAdminNeedReviewWithError(w, r, needID, "failed to decide goal change request")
+		return
+	}
+
+	notice := "Goal change request denied"
+	if firstApproval {
+		notice = "Goal change approved; a second admin must approve it before the new goal takes effect"
+	} else if status == types.NeedGoalChangeStatusApproved {
+		notice = "Goal change request approved"
+		s.notifyDonorsOfGoalChange(r.Context(), need, request)
+	}
+
+	v := url.Values{}
+	v.Set("notice", notice)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminNeedReview, v, Param("needID", needID)), http.StatusSeeOther)
+}
+
+type needGoalChangeEmailTemplateData struct {
+	DonorName string
+	NeedTitle string
+	Summary   string
+	NeedURL   string
+}
+
+// notifyDonorsOfGoalChange emails every signed-in donor to the need about an
+// approved goal change. Failures are logged; the decision has already been
+// committed.
+func (s *Service) notifyDonorsOfGoalChange(ctx context.Context, need *types.Need, request *types.NeedGoalChangeRequest) {
+	logger := s.logger.WithField("need_id", need.ID).WithField("request_id", request.ID)
+
+	donorIDs, err := s.donationIntentRepo.DonorUserIDsByNeedID(ctx, need.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to load donors for goal change notification")
+		return
+	}
+	if len(donorIDs) == 0 {
+		return
+	}
+
+	donors, err := s.userRepo.UsersByIDs(ctx, donorIDs)
+	if err != nil {
+		logger.WithError(err).Error("failed to load donor users for goal change notification")
+		return
+	}
+
+	from := strings.TrimSpace(s.config.EmailFromAddress)
+	if from == "" {
+		from = "noreply@christjesus.app"
+	}
+
+	needTitle := "a need you supported"
+	if need.ShortDescription != nil && strings.TrimSpace(*need.ShortDescription) != "" {
+		needTitle = strings.TrimSpace(*need.ShortDescription)
+	}
+
+	summary := "The recipient's goal has changed from " + formatUSDFromCents(request.PreviousGoalCents) + " to " + formatUSDFromCents(request.RequestedGoalCents) + "."
+	if request.Kind == types.NeedGoalChangeKindEarlyClose {
+		summary = "The recipient no longer needs the full amount and this need has closed early. Any funds beyond what they still need will be redirected to another need."
+	}
+
+	needURL := s.absoluteRoute(RouteNeedDetail, nil, Param("needID", need.ID))
+
+	for _, donor := range donors {
+		if donor == nil || donor.Email == nil || strings.TrimSpace(*donor.Email) == "" {
+			continue
+		}
+
+		donorName := ""
+		if donor.GivenName != nil {
+			donorName = *donor.GivenName
+		}
+
+		var htmlBuf bytes.Buffer
+		if err := s.templates.ExecuteTemplate(&htmlBuf, "email.need-goal-change", needGoalChangeEmailTemplateData{
+			DonorName: donorName,
+			NeedTitle: needTitle,
+			Summary:   summary,
+			NeedURL:   needURL,
+		}); err != nil {
+			logger.WithError(err).Error("failed to render goal change email template")
+			return
+		}
+
+		textBody := fmt.Sprintf("An update on %s.\n\n%s\n\nView the need: %s\n\nChristJesus.app", needTitle, summary, needURL)
+
+		record, err := s.sendEmail(ctx, internalemail.Message{
+			From:     from,
+			To:       *donor.Email,
+			Subject:  "An update on a need you supported",
+			HTMLBody: htmlBuf.String(),
+			TextBody: textBody,
+		}, types.EmailTypeNeedGoalChange)
+		if err != nil {
+			logger.WithError(err).WithField("user_id", donor.ID).Error("failed to send goal change email")
+			continue
+		}
+		if record == nil {
+			continue
+		}
+
+		if err := s.emailRepo.InsertUserEmail(ctx, &types.UserEmail{
+			ID:             utils.NanoID(),
+			UserID:         donor.ID,
+			EmailMessageID: record.ID,
+			EmailType:      types.EmailTypeNeedGoalChange,
+		}); err != nil {
+			logger.WithError(err).WithField("user_id", donor.ID).Warn("failed to link goal change email to user")
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestValidateGoalChangeRequest(t *testing.T) {
+	need := &types.Need{ID: "need-1", AmountNeededCents: 100000, AmountRaisedCents: 40000}
+
+	request, err := validateGoalChangeRequest(need, false, "goal_change", "$1,500", "  Rent went up  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if request.Kind != types.NeedGoalChangeKindGoal || request.RequestedGoalCents != 150000 || request.PreviousGoalCents != 100000 {
+		t.Fatalf("unexpected goal change request %+v", request)
+	}
+	if request.Justification != "Rent went up" {
+		t.Fatalf("expected trimmed justification, got %q", request.Justification)
+	}
+
+	request, err = validateGoalChangeRequest(need, false, "early_close", "", "Found other help")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if request.Kind != types.NeedGoalChangeKindEarlyClose || request.RequestedGoalCents != 40000 {
+		t.Fatalf("expected blank early close amount to keep what was raised, got %+v", request)
+	}
+
+	invalid := []struct {
+		name          string
+		kind          string
+		amount        string
+		justification string
+	}{
+		{"missing justification", "goal_change", "1500", " "},
+		{"same goal", "goal_change", "1000", "no change"},
+		{"bad goal", "goal_change", "12.50", "cents"},
+		{"keep above goal", "early_close", "1200", "too much"},
+		{"unknown kind", "cancel", "100", "what"},
+	}
+	for _, tc := range invalid {
+		if _, err := validateGoalChangeRequest(need, false, tc.kind, tc.amount, tc.justification); err == nil {
+			t.Fatalf("%s: expected validation error", tc.name)
+		}
+	}
+
+	if _, err := validateGoalChangeRequest(need, true, "goal_change", "1500", "Rent went up"); err == nil {
+		t.Fatalf("expected itemized need to reject a goal change")
+	}
+	if _, err := validateGoalChangeRequest(need, true, "early_close", "", "Found other help"); err != nil {
+		t.Fatalf("expected itemized need to allow an early close, got %v", err)
+	}
+}
+
+func TestGoalChangeOutcome(t *testing.T) {
+	cases := []struct {
+		name       string
+		request    *types.NeedGoalChangeRequest
+		raised     int
+		wantExcess int
+		wantFunded bool
+	}{
+		{"goal raised", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, RequestedGoalCents: 150000}, 40000, 0, false},
+		{"goal lowered to raised", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, RequestedGoalCents: 40000}, 40000, 0, true},
+		{"goal lowered below raised", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, RequestedGoalCents: 30000}, 40000, 10000, true},
+		{"early close keeps part", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindEarlyClose, RequestedGoalCents: 25000}, 40000, 15000, false},
+		{"early close keeps more than raised", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindEarlyClose, RequestedGoalCents: 50000}, 40000, 0, false},
+	}
+
+	for _, tc := range cases {
+		excess, funded := goalChangeOutcome(tc.request, tc.raised)
+		if excess != tc.wantExcess || funded != tc.wantFunded {
+			t.Fatalf("%s: got excess=%d funded=%v, want excess=%d funded=%v", tc.name, excess, funded, tc.wantExcess, tc.wantFunded)
+		}
+	}
+}
+
+func TestCheckGoalChangeApproval_SingleAdminCannotRaiseLiveNeedOverThreshold(t *testing.T) {
+	const threshold = 500000
+	raise := &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, PreviousGoalCents: 400000, RequestedGoalCents: 600000}
+
+	firstApproval, err := checkGoalChangeApproval(raise, threshold, "admin_a")
+	if err != nil || !firstApproval {
+		t.Fatalf("first approval: got firstApproval=%v err=%v, want the request held for a second admin", firstApproval, err)
+	}
+
+	firstApprover := "admin_a"
+	raise.FirstApprovedByUserID = &firstApprover
+
+	if _, err := checkGoalChangeApproval(raise, threshold, "admin_a"); !errors.Is(err, types.ErrSecondApproverRequired) {
+		t.Fatalf("same admin approving again: err=%v, want ErrSecondApproverRequired", err)
+	}
+
+	firstApproval, err = checkGoalChangeApproval(raise, threshold, "admin_b")
+	if err != nil || firstApproval {
+		t.Fatalf("second admin: got firstApproval=%v err=%v, want the goal applied", firstApproval, err)
+	}
+}
+
+func TestGoalChangeNeedsSecondApproval(t *testing.T) {
+	cases := []struct {
+		name      string
+		request   *types.NeedGoalChangeRequest
+		threshold int
+		want      bool
+	}{
+		{"raised over threshold", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, PreviousGoalCents: 400000, RequestedGoalCents: 600000}, 500000, true},
+		{"raised to threshold", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, PreviousGoalCents: 400000, RequestedGoalCents: 500000}, 500000, false},
+		{"lowered above threshold", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, PreviousGoalCents: 800000, RequestedGoalCents: 600000}, 500000, false},
+		{"threshold disabled", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindGoal, PreviousGoalCents: 400000, RequestedGoalCents: 600000}, 0, false},
+		{"early close", &types.NeedGoalChangeRequest{Kind: types.NeedGoalChangeKindEarlyClose, PreviousGoalCents: 400000, RequestedGoalCents: 600000}, 500000, false},
+	}
+
+	for _, tc := range cases {
+		if got := goalChangeNeedsSecondApproval(tc.request, tc.threshold); got != tc.want {
+			t.Fatalf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
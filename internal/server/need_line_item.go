@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"christjesus/pkg/types"
+)
+
+// loadNeedLineItemViews returns a need's line items with finalized donation
+// progress and category names resolved for display.
+func (s *Service) loadNeedLineItemViews(ctx context.Context, needID string) ([]*types.NeedLineItemView, error) {
+	items, err := s.needLineItemRepo.LineItemsByNeedID(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	raisedByLineItemID, err := s.donationIntentRepo.FinalizedAmountsByLineItem(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.CategoryID != nil && strings.TrimSpace(*item.CategoryID) != "" {
+			categoryIDs = append(categoryIDs, *item.CategoryID)
+		}
+	}
+
+	categoryNameByID := make(map[string]string)
+	if len(categoryIDs) > 0 {
+		categories, err := s.categoryRepo.CategoriesByIDs(ctx, uniqueSortedStrings(categoryIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			if category == nil {
+				continue
+			}
+			categoryNameByID[category.ID] = category.Name
+		}
+	}
+
+	return buildNeedLineItemViews(items, raisedByLineItemID, categoryNameByID), nil
+}
+
+// needIsItemized reports whether the need's goal is broken into line items.
+func (s *Service) needIsItemized(ctx context.Context, needID string) (bool, error) {
+	items, err := s.needLineItemRepo.LineItemsByNeedID(ctx, needID)
+	if err != nil {
+		return false, err
+	}
+	return len(items) > 0, nil
+}
+
+func buildNeedLineItemViews(items []*types.NeedLineItem, raisedByLineItemID map[string]int, categoryNameByID map[string]string) []*types.NeedLineItemView {
+	views := make([]*types.NeedLineItemView, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+
+		view := &types.NeedLineItemView{
+			ID:             item.ID,
+			Label:          item.Label,
+			AmountCents:    item.AmountCents,
+			RaisedCents:    raisedByLineItemID[item.ID],
+			FundingPercent: fundingPercentFromCents(raisedByLineItemID[item.ID], item.AmountCents),
+		}
+		if item.CategoryID != nil {
+			view.CategoryName = categoryNameByID[*item.CategoryID]
+		}
+		views = append(views, view)
+	}
+
+	return views
+}
+
+func needLineItemViewByID(views []*types.NeedLineItemView, lineItemID string) *types.NeedLineItemView {
+	for _, view := range views {
+		if view != nil && view.ID == lineItemID {
+			return view
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+// queuePriorityWeights are the points each signal contributes to a need's
+// place in the moderation queue. See the QueueWeight settings in
+// types.Config for what each one means.
+type queuePriorityWeights struct {
+	urgency           int
+	evictionNotice    int
+	medicalRecord     int
+	deadline          int
+	deadlineWindow    time.Duration
+	amount            int
+	amountCapCents    int
+	verifiedRecipient int
+	waitingPerDay     int
+}
+
+var defaultQueuePriorityWeights = queuePriorityWeights{
+	urgency:           10,
+	evictionNotice:    30,
+	medicalRecord:     20,
+	deadline:          40,
+	deadlineWindow:    14 * 24 * time.Hour,
+	amount:            10,
+	amountCapCents:    500000,
+	verifiedRecipient: 10,
+	waitingPerDay:     5,
+}
+
+func (s *Service) queuePriorityWeights() queuePriorityWeights {
+	if s.config == nil {
+		return defaultQueuePriorityWeights
+	}
+
+	weights := queuePriorityWeights{
+		urgency:           s.config.QueueWeightUrgency,
+		evictionNotice:    s.config.QueueWeightEvictionNotice,
+		medicalRecord:     s.config.QueueWeightMedicalRecord,
+		deadline:          s.config.QueueWeightDeadline,
+		deadlineWindow:    time.Duration(s.config.QueueDeadlineWindowDays) * 24 * time.Hour,
+		amount:            s.config.QueueWeightAmount,
+		amountCapCents:    s.config.QueueAmountCapCents,
+		verifiedRecipient: s.config.QueueWeightVerifiedRecipient,
+		waitingPerDay:     s.config.QueueWeightWaitingPerDay,
+	}
+	if weights.deadlineWindow <= 0 {
+		weights.deadlineWindow = defaultQueuePriorityWeights.deadlineWindow
+	}
+	if weights.amountCapCents <= 0 {
+		weights.amountCapCents = defaultQueuePriorityWeights.amountCapCents
+	}
+	return weights
+}
+
+// describe lists the weights for the queue page so reviewers can see how
+// scores are put together.
+func (w queuePriorityWeights) describe() []*types.AdminQueuePriorityFactor {
+	windowDays := int(w.deadlineWindow / (24 * time.Hour))
+	return []*types.AdminQueuePriorityFactor{
+		newQueuePriorityFactor("Urgency, per level above low", w.urgency),
+		newQueuePriorityFactor("Eviction notice uploaded", w.evictionNotice),
+		newQueuePriorityFactor("Medical record uploaded", w.medicalRecord),
+		newQueuePriorityFactor(fmt.Sprintf("Needed within %dd, less further out", windowDays), w.deadline),
+		newQueuePriorityFactor(fmt.Sprintf("Requested amount, scaled up to %s", formatUSDFromCents(w.amountCapCents)), w.amount),
+		newQueuePriorityFactor("Recipient verified before", w.verifiedRecipient),
+		newQueuePriorityFactor("Each day waiting", w.waitingPerDay),
+	}
+}
+
+func newQueuePriorityFactor(label string, points int) *types.AdminQueuePriorityFactor {
+	return &types.AdminQueuePriorityFactor{
+		Label:       label,
+		Points:      points,
+		PointsLabel: fmt.Sprintf("%+d", points),
+	}
+}
+
+// queueUrgencyLevel ranks urgency from low (0) to urgent (3).
+func queueUrgencyLevel(urgency types.NeedUrgency) int {
+	switch urgency {
+	case types.NeedUrgencyMedium:
+		return 1
+	case types.NeedUrgencyHigh:
+		return 2
+	case types.NeedUrgencyUrgent:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// queueWaitingSince is when a need joined the queue, falling back to its
+// creation for rows that predate submitted_at.
+func queueWaitingSince(need *types.Need) time.Time {
+	if need.SubmittedAt != nil {
+		return *need.SubmittedAt
+	}
+	return need.CreatedAt
+}
+
+// scoreQueueCandidate adds up a need's queue priority and returns the score
+// with the factors that contributed to it. Factors worth nothing are left out
+// of the breakdown.
+func scoreQueueCandidate(candidate *types.ModerationQueueCandidate, weights queuePriorityWeights, now time.Time) (int, []*types.AdminQueuePriorityFactor) {
+	need := &candidate.Need
+	factors := make([]*types.AdminQueuePriorityFactor, 0, 7)
+	add := func(label string, points int) {
+		if points != 0 {
+			factors = append(factors, newQueuePriorityFactor(label, points))
+		}
+	}
+
+	add("Urgency: "+string(need.Urgency), queueUrgencyLevel(need.Urgency)*weights.urgency)
+
+	if candidate.HasEvictionNotice {
+		add("Eviction notice", weights.evictionNotice)
+	}
+	if candidate.HasMedicalRecord {
+		add("Medical record", weights.medicalRecord)
+	}
+
+	if need.NeededBy != nil {
+		remaining := need.NeededBy.Sub(now)
+		points := weights.deadline
+		if remaining > weights.deadlineWindow {
+			// Beyond the window the weight fades out over a second window.
+			beyond := remaining - weights.deadlineWindow
+			points = int(float64(weights.deadline) * (1 - float64(beyond)/float64(weights.deadlineWindow)))
+			if beyond >= weights.deadlineWindow {
+				points = 0
+			}
+		}
+
+		label := "Needed by " + need.NeededBy.Format(time.DateOnly)
+		if remaining < 0 {
+			label += " (passed)"
+		}
+		add(label, points)
+	}
+
+	if need.AmountNeededCents > 0 {
+		amount := min(need.AmountNeededCents, weights.amountCapCents)
+		add("Requested "+formatUSDFromCents(need.AmountNeededCents), weights.amount*amount/weights.amountCapCents)
+	}
+
+	if candidate.RecipientPreviouslyVerified {
+		add("Recipient verified before", weights.verifiedRecipient)
+	}
+
+	if waiting := now.Sub(queueWaitingSince(need)); waiting > 0 {
+		days := int(waiting / (24 * time.Hour))
+		add(fmt.Sprintf("Waiting %dd", days), days*weights.waitingPerDay)
+	}
+
+	score := 0
+	for _, factor := range factors {
+		score += factor.Points
+	}
+	return score, factors
+}
+
+type scoredQueueCandidate struct {
+	candidate *types.ModerationQueueCandidate
+	score     int
+	factors   []*types.AdminQueuePriorityFactor
+}
+
+// prioritizeQueueCandidates scores the queue and sorts it highest score
+// first. Ties go to the need that has waited longest.
+func prioritizeQueueCandidates(candidates []*types.ModerationQueueCandidate, weights queuePriorityWeights, now time.Time) []*scoredQueueCandidate {
+	scored := make([]*scoredQueueCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate == nil {
+			continue
+		}
+		score, factors := scoreQueueCandidate(candidate, weights, now)
+		scored = append(scored, &scoredQueueCandidate{candidate: candidate, score: score, factors: factors})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		waitingI, waitingJ := queueWaitingSince(&scored[i].candidate.Need), queueWaitingSince(&scored[j].candidate.Need)
+		if !waitingI.Equal(waitingJ) {
+			return waitingI.Before(waitingJ)
+		}
+		return scored[i].candidate.ID < scored[j].candidate.ID
+	})
+
+	return scored
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+func TestScoreQueueCandidate(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	submitted := now.Add(-50 * time.Hour)
+	neededBy := now.Add(3 * 24 * time.Hour)
+
+	candidate := &types.ModerationQueueCandidate{
+		Need: types.Need{
+			ID:                "need_eviction",
+			Urgency:           types.NeedUrgencyUrgent,
+			NeededBy:          &neededBy,
+			AmountNeededCents: 250000,
+			SubmittedAt:       &submitted,
+		},
+		HasEvictionNotice:           true,
+		RecipientPreviouslyVerified: true,
+	}
+
+	score, factors := scoreQueueCandidate(candidate, defaultQueuePriorityWeights, now)
+
+	// urgent 30 + eviction 30 + deadline 40 + amount 5 + verified 10 + 2 days 10
+	if score != 125 {
+		t.Fatalf("score = %d, want 125", score)
+	}
+
+	total := 0
+	for _, factor := range factors {
+		total += factor.Points
+	}
+	if total != score {
+		t.Fatalf("factors add up to %d, want %d", total, score)
+	}
+	if len(factors) != 6 {
+		t.Fatalf("len(factors) = %d, want 6 (medical record earns nothing)", len(factors))
+	}
+}
+
+func TestScoreQueueCandidateDeadlineFades(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	weights := queuePriorityWeights{deadline: 40, deadlineWindow: 10 * 24 * time.Hour, amountCapCents: 1}
+
+	tests := []struct {
+		name string
+		days int
+		want int
+	}{
+		{name: "passed", days: -2, want: 40},
+		{name: "inside window", days: 10, want: 40},
+		{name: "halfway through fade", days: 15, want: 20},
+		{name: "beyond fade", days: 20, want: 0},
+	}
+
+	for _, tt := range tests {
+		neededBy := now.Add(time.Duration(tt.days) * 24 * time.Hour)
+		candidate := &types.ModerationQueueCandidate{Need: types.Need{NeededBy: &neededBy, CreatedAt: now}}
+		if score, _ := scoreQueueCandidate(candidate, weights, now); score != tt.want {
+			t.Fatalf("%s: score = %d, want %d", tt.name, score, tt.want)
+		}
+	}
+}
+
+func TestPrioritizeQueueCandidates(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	weekOld := now.Add(-7 * 24 * time.Hour)
+	today := now.Add(-time.Hour)
+	sameDay := now.Add(12 * time.Hour)
+
+	routine := &types.ModerationQueueCandidate{Need: types.Need{ID: "need_routine", Urgency: types.NeedUrgencyLow, SubmittedAt: &weekOld}}
+	eviction := &types.ModerationQueueCandidate{
+		Need:              types.Need{ID: "need_eviction", Urgency: types.NeedUrgencyUrgent, NeededBy: &sameDay, SubmittedAt: &today},
+		HasEvictionNotice: true,
+	}
+	tiedOlder := &types.ModerationQueueCandidate{Need: types.Need{ID: "need_tied_b", SubmittedAt: &today, CreatedAt: today}}
+	tiedNewer := &types.ModerationQueueCandidate{Need: types.Need{ID: "need_tied_a", SubmittedAt: &sameDay}}
+
+	scored := prioritizeQueueCandidates([]*types.ModerationQueueCandidate{tiedNewer, routine, nil, tiedOlder, eviction}, defaultQueuePriorityWeights, now)
+
+	got := make([]string, 0, len(scored))
+	for _, entry := range scored {
+		got = append(got, entry.candidate.ID)
+	}
+	want := []string{"need_eviction", "need_routine", "need_tied_b", "need_tied_a"}
+	if len(got) != len(want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"christjesus/internal/store"
 	"christjesus/pkg/types"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -93,27 +94,62 @@ func (s *Service) handleGetProfileNeedReview(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	goalChangeRequests, err := s.needGoalChangeRepo.RequestsByNeed(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch goal change requests for review portal")
+		s.internalServerError(w)
+		return
+	}
+
+	itemized, err := s.needIsItemized(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need line items for review portal")
+		s.internalServerError(w)
+		return
+	}
+
+	goalChangeViews, pendingGoalChangeCount := s.buildNeedGoalChangeViews(needID, goalChangeRequests, false, itemized, "")
+
+	canEditNeed := need.Status == types.NeedStatusSubmitted || need.Status == types.NeedStatusChangesRequested
+
+	var changeRequestItems []*types.NeedChangeRequestItemView
+	if actionID := latestChangeRequestActionID(actions); actionID != "" {
+		items, err := s.progressRepo.ChangeRequestItemsByAction(ctx, actionID)
+		if err != nil {
+			s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch change request checklist for review portal")
+			s.internalServerError(w)
+			return
+		}
+		changeRequestItems = s.buildNeedChangeRequestViews(needID, items, shared.Documents, canEditNeed)
+	}
+
 	data := &types.NeedReviewPortalPageData{
-		BasePageData:        types.BasePageData{Title: "Need Review Portal"},
-		Need:                need,
-		Story:               shared.Story,
-		PrimaryCategory:     shared.PrimaryCategory,
-		SecondaryCategories: shared.SecondaryCategories,
-		RejectionReason:     rejectionReason,
-		RejectionNote:       rejectionNote,
-		Documents:           docFeedback,
-		Messages:            buildNeedReviewMessageViews(messages, userID),
-		PostMessageAction:   s.route(RouteProfileNeedReviewPost, Param("needID", needID)),
-		SetReadyAction:      s.route(RouteProfileNeedReviewSetReady, Param("needID", needID)),
-		PullBackAction:      s.route(RouteProfileNeedReviewPullBack, Param("needID", needID)),
-		BackHref:            s.route(RouteProfile),
-		EditNeedHref:        s.route(RouteProfileNeedEdit, Param("needID", needID)),
-		CanEditNeed:         need.Status == types.NeedStatusSubmitted || need.Status == types.NeedStatusChangesRequested,
-		CanSetReady:         need.Status == types.NeedStatusSubmitted || need.Status == types.NeedStatusChangesRequested,
-		CanPullBack:         need.Status == types.NeedStatusReadyForReview,
-		CanSendMessage:      isNeedOwnerMessagingAllowedStatus(need.Status),
-		Notice:              strings.TrimSpace(r.URL.Query().Get("notice")),
-		Error:               strings.TrimSpace(r.URL.Query().Get("error")),
+		BasePageData:         types.BasePageData{Title: "Need Review Portal"},
+		Need:                 need,
+		Story:                shared.Story,
+		PrimaryCategory:      shared.PrimaryCategory,
+		SecondaryCategories:  shared.SecondaryCategories,
+		RejectionReason:      rejectionReason,
+		RejectionNote:        rejectionNote,
+		Documents:            docFeedback,
+		Messages:             buildNeedReviewMessageViews(messages, userID),
+		PostMessageAction:    s.route(RouteProfileNeedReviewPost, Param("needID", needID)),
+		SetReadyAction:       s.route(RouteProfileNeedReviewSetReady, Param("needID", needID)),
+		PullBackAction:       s.route(RouteProfileNeedReviewPullBack, Param("needID", needID)),
+		BackHref:             s.route(RouteProfile),
+		EditNeedHref:         s.route(RouteProfileNeedEdit, Param("needID", needID)),
+		CanEditNeed:          canEditNeed,
+		CanSetReady:          store.CanTransitionNeedStatus(need.Status, types.NeedStatusReadyForReview, types.NeedProgressEventSourceUser),
+		CanPullBack:          store.CanTransitionNeedStatus(need.Status, types.NeedStatusSubmitted, types.NeedProgressEventSourceUser),
+		CanSendMessage:       isNeedOwnerMessagingAllowedStatus(need.Status),
+		CanRequestGoalChange: need.Status == types.NeedStatusActive && need.DeletedAt == nil && pendingGoalChangeCount == 0,
+		GoalChangeAction:     s.route(RouteProfileNeedGoalChange, Param("needID", needID)),
+		GoalChangeItemized:   itemized,
+		GoalChanges:          goalChangeViews,
+		HasPendingGoalChange: pendingGoalChangeCount > 0,
+		ChangeRequestItems:   changeRequestItems,
+		Notice:               strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:                strings.TrimSpace(r.URL.Query().Get("error")),
 	}
 
 	if err := s.renderTemplate(w, r, "page.profile.need.review", data); err != nil {
@@ -235,17 +271,18 @@ func (s *Service) handlePostProfileNeedReviewSetReady(w http.ResponseWriter, r *
 		return
 	}
 
-	if need.Status != types.NeedStatusSubmitted && need.Status != types.NeedStatusChangesRequested {
-		s.redirectProfileNeedReviewWithError(w, r, needID, "This need cannot be marked ready for review in its current status.")
-		return
-	}
-
-	if err := s.needsRepo.SetNeedStatus(ctx, needID, types.NeedStatusReadyForReview); err != nil {
+	if err := s.needsRepo.TransitionNeedStatus(ctx, needID, types.NeedStatusReadyForReview, types.NeedProgressEventSourceUser, userID); err != nil {
+		if _, ok := needTransitionErrorMessage(err); ok {
+			s.redirectProfileNeedReviewWithError(w, r, needID, "This need cannot be marked ready for review in its current status.")
+			return
+		}
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to set need status to ready for review")
 		s.internalServerError(w)
 		return
 	}
 
+	s.recordNeedRevision(ctx, needID, userID, types.NeedRevisionSourceResubmitted)
+	s.detectNeedFlags(ctx, need)
 	s.redirectProfileNeedReviewWithNotice(w, r, needID, "Need marked ready for review.")
 }
 
@@ -280,12 +317,11 @@ func (s *Service) handlePostProfileNeedReviewPullBack(w http.ResponseWriter, r *
 		return
 	}
 
-	if need.Status != types.NeedStatusReadyForReview {
-		s.redirectProfileNeedReviewWithError(w, r, needID, "This need cannot be pulled back in its current status.")
-		return
-	}
-
-	if err := s.needsRepo.SetNeedStatus(ctx, needID, types.NeedStatusSubmitted); err != nil {
+	if err := s.needsRepo.TransitionNeedStatus(ctx, needID, types.NeedStatusSubmitted, types.NeedProgressEventSourceUser, userID); err != nil {
+		if _, ok := needTransitionErrorMessage(err); ok {
+			s.redirectProfileNeedReviewWithError(w, r, needID, "This need cannot be pulled back in its current status.")
+			return
+		}
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to pull need back to submitted")
 		s.internalServerError(w)
 		return
@@ -296,7 +332,7 @@ func (s *Service) handlePostProfileNeedReviewPullBack(w http.ResponseWriter, r *
 
 func isNeedOwnerMessagingAllowedStatus(status types.NeedStatus) bool {
 	switch status {
-	case types.NeedStatusSubmitted, types.NeedStatusReadyForReview, types.NeedStatusUnderReview, types.NeedStatusChangesRequested, types.NeedStatusRejected:
+	case types.NeedStatusSubmitted, types.NeedStatusReadyForReview, types.NeedStatusUnderReview, types.NeedStatusAwaitingSecondApproval, types.NeedStatusChangesRequested, types.NeedStatusRejected:
 		return true
 	default:
 		return false
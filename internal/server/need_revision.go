@@ -0,0 +1,229 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"christjesus/pkg/types"
+)
+
+// recordNeedRevision snapshots the owner-editable content of a need after a
+// save. Errors are logged, not returned: the edit is already committed, and a
+// missing revision only leaves a gap in the reviewer diff.
+func (s *Service) recordNeedRevision(ctx context.Context, needID, actorUserID string, source types.NeedRevisionSource) {
+	snapshot, err := s.buildNeedRevisionSnapshot(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).
+			WithField("need_id", needID).
+			WithField("source", source).
+			Warn("failed to build need revision snapshot")
+		return
+	}
+
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		s.logger.WithError(err).
+			WithField("need_id", needID).
+			WithField("source", source).
+			Warn("failed to encode need revision snapshot")
+		return
+	}
+
+	err = s.needRevisionRepo.CreateRevision(ctx, &types.NeedRevision{
+		NeedID:      needID,
+		ActorUserID: actorUserID,
+		Source:      source,
+		Snapshot:    payload,
+	})
+	if err != nil {
+		s.logger.WithError(err).
+			WithField("need_id", needID).
+			WithField("source", source).
+			Warn("failed to record need revision")
+	}
+}
+
+func (s *Service) buildNeedRevisionSnapshot(ctx context.Context, needID string) (*types.NeedRevisionSnapshot, error) {
+	core, err := s.loadNeedReviewCoreData(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItems, err := s.needLineItemRepo.LineItemsByNeedID(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &types.NeedRevisionSnapshot{
+		AmountNeededCents:   core.Need.AmountNeededCents,
+		ShortDescription:    strings.TrimSpace(derefString(core.Need.ShortDescription)),
+		Location:            needRevisionLocationLabel(core.SelectedAddress),
+		SecondaryCategories: make([]string, 0, len(core.SecondaryCategories)),
+		Documents:           make([]string, 0, len(core.Documents)),
+		LineItems:           make([]types.NeedRevisionLineItem, 0, len(lineItems)),
+	}
+
+	if core.Story != nil {
+		snapshot.StoryCurrent = strings.TrimSpace(derefString(core.Story.Current))
+		snapshot.StoryNeed = strings.TrimSpace(derefString(core.Story.Need))
+		snapshot.StoryOutcome = strings.TrimSpace(derefString(core.Story.Outcome))
+	}
+
+	if core.PrimaryCategory != nil {
+		snapshot.PrimaryCategory = core.PrimaryCategory.Name
+	}
+	for _, category := range core.SecondaryCategories {
+		snapshot.SecondaryCategories = append(snapshot.SecondaryCategories, category.Name)
+	}
+
+	for _, document := range core.Documents {
+		snapshot.Documents = append(snapshot.Documents, fmt.Sprintf("%s (%s)", document.FileName, document.TypeLabel))
+	}
+
+	for _, item := range lineItems {
+		snapshot.LineItems = append(snapshot.LineItems, types.NeedRevisionLineItem{
+			Label:       item.Label,
+			AmountCents: item.AmountCents,
+			SortOrder:   item.SortOrder,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// needRevisionLineItemsLabel lists budget lines in their display order, so
+// relabelled, repriced, added, removed and reordered lines all show in a
+// diff.
+func needRevisionLineItemsLabel(items []types.NeedRevisionLineItem) string {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b types.NeedRevisionLineItem) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+
+	parts := make([]string, 0, len(sorted))
+	for _, item := range sorted {
+		parts = append(parts, fmt.Sprintf("%s %s", item.Label, formatUSDFromCents(item.AmountCents)))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func needRevisionLocationLabel(address *types.UserAddress) string {
+	if address == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, 4)
+	for _, value := range []*string{address.Address, address.City, address.State, address.ZipCode} {
+		if trimmed := strings.TrimSpace(derefString(value)); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// needRevisionDiffPair picks the snapshot taken when changes were last
+// requested and the newest snapshot recorded after it. revisions must be
+// ordered newest first. Either return value is nil when no diff applies.
+func needRevisionDiffPair(revisions []*types.NeedRevision) (*types.NeedRevision, *types.NeedRevision) {
+	var latest *types.NeedRevision
+	for _, revision := range revisions {
+		if revision == nil {
+			continue
+		}
+
+		if revision.Source == types.NeedRevisionSourceChangesRequested {
+			if latest == nil {
+				return revision, nil
+			}
+			return revision, latest
+		}
+
+		if latest == nil {
+			latest = revision
+		}
+	}
+
+	return nil, nil
+}
+
+func decodeNeedRevisionSnapshot(revision *types.NeedRevision) (*types.NeedRevisionSnapshot, error) {
+	snapshot := new(types.NeedRevisionSnapshot)
+	if err := json.Unmarshal(revision.Snapshot, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode need revision %s: %w", revision.ID, err)
+	}
+	return snapshot, nil
+}
+
+func diffNeedRevisionSnapshots(before, after *types.NeedRevisionSnapshot) []*types.NeedRevisionFieldChange {
+	if before == nil || after == nil {
+		return nil
+	}
+
+	changes := make([]*types.NeedRevisionFieldChange, 0)
+	add := func(field, beforeValue, afterValue string) {
+		if beforeValue == afterValue {
+			return
+		}
+		changes = append(changes, &types.NeedRevisionFieldChange{
+			Field:  field,
+			Before: needRevisionDisplayValue(beforeValue),
+			After:  needRevisionDisplayValue(afterValue),
+		})
+	}
+
+	add("Requested Amount", formatUSDFromCents(before.AmountNeededCents), formatUSDFromCents(after.AmountNeededCents))
+	add("Short Description", before.ShortDescription, after.ShortDescription)
+	add("Location", before.Location, after.Location)
+	add("Primary Category", before.PrimaryCategory, after.PrimaryCategory)
+	add("Secondary Categories", strings.Join(before.SecondaryCategories, ", "), strings.Join(after.SecondaryCategories, ", "))
+	add("Current Situation", before.StoryCurrent, after.StoryCurrent)
+	add("Need", before.StoryNeed, after.StoryNeed)
+	add("Expected Outcome", before.StoryOutcome, after.StoryOutcome)
+	add("Documents", strings.Join(before.Documents, ", "), strings.Join(after.Documents, ", "))
+	add("Line Items", needRevisionLineItemsLabel(before.LineItems), needRevisionLineItemsLabel(after.LineItems))
+
+	return changes
+}
+
+func needRevisionDisplayValue(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
+
+func buildAdminNeedRevisionDiff(revisions []*types.NeedRevision) (*types.AdminNeedRevisionDiff, error) {
+	baseline, latest := needRevisionDiffPair(revisions)
+	if baseline == nil {
+		return nil, nil
+	}
+
+	diff := &types.AdminNeedRevisionDiff{
+		ChangesRequestedAt: baseline.CreatedAt.Format("2006-01-02 15:04"),
+	}
+	if latest == nil {
+		return diff, nil
+	}
+
+	before, err := decodeNeedRevisionSnapshot(baseline)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeNeedRevisionSnapshot(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.ResubmittedAt = latest.CreatedAt.Format("2006-01-02 15:04")
+	diff.HasResubmission = true
+	diff.Changes = diffNeedRevisionSnapshots(before, after)
+
+	return diff, nil
+}
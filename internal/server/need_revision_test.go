@@ -0,0 +1,141 @@
+package server
+
+import (
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestNeedRevisionDiffPair(t *testing.T) {
+	t.Run("no changes requested baseline", func(t *testing.T) {
+		revisions := []*types.NeedRevision{
+			{ID: "rev-2", Source: types.NeedRevisionSourceStory},
+			{ID: "rev-1", Source: types.NeedRevisionSourceLocation},
+		}
+
+		baseline, latest := needRevisionDiffPair(revisions)
+		if baseline != nil || latest != nil {
+			t.Fatalf("expected no diff pair, got baseline=%v latest=%v", baseline, latest)
+		}
+	})
+
+	t.Run("baseline without later edits", func(t *testing.T) {
+		revisions := []*types.NeedRevision{
+			{ID: "rev-2", Source: types.NeedRevisionSourceChangesRequested},
+			{ID: "rev-1", Source: types.NeedRevisionSourceStory},
+		}
+
+		baseline, latest := needRevisionDiffPair(revisions)
+		if baseline == nil || baseline.ID != "rev-2" {
+			t.Fatalf("unexpected baseline: %v", baseline)
+		}
+		if latest != nil {
+			t.Fatalf("expected no latest revision, got %q", latest.ID)
+		}
+	})
+
+	t.Run("uses most recent baseline and newest edit", func(t *testing.T) {
+		revisions := []*types.NeedRevision{
+			{ID: "rev-5", Source: types.NeedRevisionSourceResubmitted},
+			{ID: "rev-4", Source: types.NeedRevisionSourceStory},
+			{ID: "rev-3", Source: types.NeedRevisionSourceChangesRequested},
+			{ID: "rev-2", Source: types.NeedRevisionSourceStory},
+			{ID: "rev-1", Source: types.NeedRevisionSourceChangesRequested},
+		}
+
+		baseline, latest := needRevisionDiffPair(revisions)
+		if baseline == nil || baseline.ID != "rev-3" {
+			t.Fatalf("unexpected baseline: %v", baseline)
+		}
+		if latest == nil || latest.ID != "rev-5" {
+			t.Fatalf("unexpected latest: %v", latest)
+		}
+	})
+}
+
+func TestDiffNeedRevisionSnapshots(t *testing.T) {
+	before := &types.NeedRevisionSnapshot{
+		AmountNeededCents:   50000,
+		Location:            "1 Main St, Austin, TX, 78701",
+		StoryCurrent:        "Behind on rent",
+		PrimaryCategory:     "Housing",
+		SecondaryCategories: []string{"Utilities"},
+		Documents:           []string{"lease.pdf (Other)"},
+	}
+	after := &types.NeedRevisionSnapshot{
+		AmountNeededCents:   65000,
+		Location:            "1 Main St, Austin, TX, 78701",
+		StoryCurrent:        "Behind on rent",
+		StoryOutcome:        "Stay housed",
+		PrimaryCategory:     "Housing",
+		SecondaryCategories: []string{"Utilities"},
+		Documents:           []string{"lease.pdf (Other)", "notice.pdf (Eviction Notice)"},
+	}
+
+	changes := diffNeedRevisionSnapshots(before, after)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d: %+v", len(changes), changes)
+	}
+
+	byField := make(map[string]*types.NeedRevisionFieldChange, len(changes))
+	for _, change := range changes {
+		byField[change.Field] = change
+	}
+
+	amount, ok := byField["Requested Amount"]
+	if !ok || amount.Before != "$500.00" || amount.After != "$650.00" {
+		t.Fatalf("unexpected amount change: %+v", amount)
+	}
+
+	outcome, ok := byField["Expected Outcome"]
+	if !ok || outcome.Before != "-" || outcome.After != "Stay housed" {
+		t.Fatalf("unexpected outcome change: %+v", outcome)
+	}
+
+	if _, ok := byField["Documents"]; !ok {
+		t.Fatal("expected documents change")
+	}
+	if _, ok := byField["Location"]; ok {
+		t.Fatal("did not expect unchanged location in diff")
+	}
+}
+
+func TestDiffNeedRevisionSnapshots_LineItems(t *testing.T) {
+	before := &types.NeedRevisionSnapshot{
+		AmountNeededCents: 80000,
+		LineItems: []types.NeedRevisionLineItem{
+			{Label: "Rent", AmountCents: 60000, SortOrder: 0},
+			{Label: "Electric", AmountCents: 20000, SortOrder: 1},
+		},
+	}
+
+	rewritten := &types.NeedRevisionSnapshot{
+		AmountNeededCents: 80000,
+		LineItems: []types.NeedRevisionLineItem{
+			{Label: "Car repair", AmountCents: 80000, SortOrder: 0},
+		},
+	}
+
+	changes := diffNeedRevisionSnapshots(before, rewritten)
+	if len(changes) != 1 || changes[0].Field != "Line Items" {
+		t.Fatalf("expected only a line item change, got %+v", changes)
+	}
+	if changes[0].Before != "Rent $600.00; Electric $200.00" || changes[0].After != "Car repair $800.00" {
+		t.Fatalf("unexpected line item change: %+v", changes[0])
+	}
+
+	reordered := &types.NeedRevisionSnapshot{
+		AmountNeededCents: 80000,
+		LineItems: []types.NeedRevisionLineItem{
+			{Label: "Rent", AmountCents: 60000, SortOrder: 1},
+			{Label: "Electric", AmountCents: 20000, SortOrder: 0},
+		},
+	}
+	if changes := diffNeedRevisionSnapshots(before, reordered); len(changes) != 1 {
+		t.Fatalf("expected a reorder to show as a change, got %+v", changes)
+	}
+
+	if changes := diffNeedRevisionSnapshots(before, before); len(changes) != 0 {
+		t.Fatalf("expected no changes for identical line items, got %+v", changes)
+	}
+}
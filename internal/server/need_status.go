@@ -0,0 +1,29 @@
+package server
+
+import (
+	"christjesus/pkg/types"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// needTransitionErrorMessage turns a status change rejected by the state
+// machine into a message for the person who attempted it. ok is false for any
+// other kind of error, which callers should treat as a server failure.
+func needTransitionErrorMessage(err error) (string, bool) {
+	var transitionErr *types.NeedTransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		from := strings.ToLower(adminExplorerStatusLabelByValue(string(transitionErr.From)))
+		to := strings.ToLower(adminExplorerStatusLabelByValue(string(transitionErr.To)))
+		return fmt.Sprintf("need cannot move from %s to %s", from, to), true
+	case errors.Is(err, types.ErrSecondApproverRequired):
+		return "a different admin must give the second approval", true
+	case errors.Is(err, types.ErrSecondApprovalRequired):
+		return "this need is now above the second approval threshold; approve it again to send it for a second approval", true
+	case errors.Is(err, types.ErrNeedDeleted):
+		return "cannot change the status of a deleted need; restore it first", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+)
+
+func TestCanTransitionNeedStatus(t *testing.T) {
+	cases := []struct {
+		from  types.NeedStatus
+		to    types.NeedStatus
+		actor types.NeedProgressEventSource
+		want  bool
+	}{
+		{types.NeedStatusDraft, types.NeedStatusSubmitted, types.NeedProgressEventSourceUser, true},
+		{types.NeedStatusSubmitted, types.NeedStatusReadyForReview, types.NeedProgressEventSourceUser, true},
+		{types.NeedStatusChangesRequested, types.NeedStatusReadyForReview, types.NeedProgressEventSourceUser, true},
+		{types.NeedStatusReadyForReview, types.NeedStatusSubmitted, types.NeedProgressEventSourceUser, true},
+		{types.NeedStatusReadyForReview, types.NeedStatusUnderReview, types.NeedProgressEventSourceAdmin, true},
+		{types.NeedStatusUnderReview, types.NeedStatusActive, types.NeedProgressEventSourceAdmin, true},
+		{types.NeedStatusUnderReview, types.NeedStatusAwaitingSecondApproval, types.NeedProgressEventSourceAdmin, true},
+		{types.NeedStatusAwaitingSecondApproval, types.NeedStatusActive, types.NeedProgressEventSourceAdmin, true},
+		{types.NeedStatusAwaitingSecondApproval, types.NeedStatusChangesRequested, types.NeedProgressEventSourceAdmin, true},
+		{types.NeedStatusActive, types.NeedStatusFunded, types.NeedProgressEventSourceSystem, true},
+		{types.NeedStatusActive, types.NeedStatusClosed, types.NeedProgressEventSourceAdmin, true},
+
+		{types.NeedStatusReadyForReview, types.NeedStatusUnderReview, types.NeedProgressEventSourceUser, false},
+		{types.NeedStatusUnderReview, types.NeedStatusActive, types.NeedProgressEventSourceUser, false},
+		{types.NeedStatusSubmitted, types.NeedStatusActive, types.NeedProgressEventSourceAdmin, false},
+		{types.NeedStatusReadyForReview, types.NeedStatusAwaitingSecondApproval, types.NeedProgressEventSourceAdmin, false},
+		{types.NeedStatusAwaitingSecondApproval, types.NeedStatusActive, types.NeedProgressEventSourceUser, false},
+		{types.NeedStatusRejected, types.NeedStatusReadyForReview, types.NeedProgressEventSourceUser, false},
+		{types.NeedStatusActive, types.NeedStatusDraft, types.NeedProgressEventSourceAdmin, false},
+		{types.NeedStatusActive, types.NeedStatusClosed, types.NeedProgressEventSourceUser, false},
+	}
+
+	for _, tc := range cases {
+		if got := store.CanTransitionNeedStatus(tc.from, tc.to, tc.actor); got != tc.want {
+			t.Fatalf("%s -> %s by %s: got %v want %v", tc.from, tc.to, tc.actor, got, tc.want)
+		}
+	}
+}
+
+func TestNeedTransitionErrorMessage(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &types.NeedTransitionError{
+		From:  types.NeedStatusSubmitted,
+		To:    types.NeedStatusActive,
+		Actor: types.NeedProgressEventSourceAdmin,
+		Err:   types.ErrIllegalNeedTransition,
+	})
+
+	message, ok := needTransitionErrorMessage(err)
+	if !ok || message != "need cannot move from submitted to active" {
+		t.Fatalf("unexpected message %q (ok=%v)", message, ok)
+	}
+
+	if _, ok := needTransitionErrorMessage(types.ErrNeedDeleted); !ok {
+		t.Fatal("expected deleted need error to map to a message")
+	}
+
+	if _, ok := needTransitionErrorMessage(types.ErrSecondApproverRequired); !ok {
+		t.Fatal("expected same-approver error to map to a message")
+	}
+
+	if _, ok := needTransitionErrorMessage(types.ErrSecondApprovalRequired); !ok {
+		t.Fatal("expected over-threshold approval error to map to a message")
+	}
+
+	if _, ok := needTransitionErrorMessage(fmt.Errorf("boom")); ok {
+		t.Fatal("expected unrelated error to be reported as a server failure")
+	}
+}
@@ -157,43 +157,41 @@ func (s *Service) handleCreateNeed(ctx context.Context, w http.ResponseWriter, r
 }
 
 func (s *Service) redirectNeedOnboarding(ctx context.Context, w http.ResponseWriter, r *http.Request, userID string) {
-	need, err := s.needsRepo.DraftNeedsByUser(ctx, userID)
+	needs, summaries, err := s.buildNeedSummaries(ctx, userID)
 	if err != nil {
-		if errors.Is(err, types.ErrNeedNotFound) {
-			s.handleCreateNeed(ctx, w, r)
-			return
-		}
-
-		s.logger.WithError(err).WithField("user_id", userID).Error("failed to load draft need")
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to load needs for onboarding")
 		s.internalServerError(w)
 		return
 	}
 
-	if need == nil {
+	// First-time recipients go straight into a fresh draft; everyone else
+	// picks a draft to resume, starts a new one, or clones a past need.
+	if len(needs) == 0 {
 		s.handleCreateNeed(ctx, w, r)
 		return
 	}
 
-	// Once a need leaves draft/onboarding workflow, send users to the need review portal.
-	if need.Status != types.NeedStatusDraft {
-		http.Redirect(w, r, s.route(RouteProfileNeedReview, Param("needID", need.ID)), http.StatusSeeOther)
-		return
-	}
+	s.renderOnboardingNeedPicker(ctx, w, r, userID, summaries)
+}
 
+// onboardingNeedStepRoute maps a draft's current step to the onboarding page
+// that resumes it.
+func onboardingNeedStepRoute(step types.NeedStep) RouteName {
 	nextRouteByStep := map[types.NeedStep]RouteName{
 		types.NeedStepWelcome:    RouteOnboardingNeedWelcome,
 		types.NeedStepLocation:   RouteOnboardingNeedLocation,
 		types.NeedStepCategories: RouteOnboardingNeedCategories,
 		types.NeedStepStory:      RouteOnboardingNeedStory,
+		types.NeedStepBudget:     RouteOnboardingNeedBudget,
 		types.NeedStepDocuments:  RouteOnboardingNeedDocuments,
 		types.NeedStepReview:     RouteOnboardingNeedReview,
 		types.NeedStepComplete:   RouteOnboardingNeedConfirmation,
 	}
-	nextRoute, ok := nextRouteByStep[need.CurrentStep]
+	nextRoute, ok := nextRouteByStep[step]
 	if !ok {
 		nextRoute = RouteOnboardingNeedWelcome
 	}
-	http.Redirect(w, r, s.route(nextRoute, Param("needID", need.ID)), http.StatusSeeOther)
+	return nextRoute
 }
 
 func (s *Service) setUserType(ctx context.Context, userType string) error {
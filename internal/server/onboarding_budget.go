@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	maxNeedLineItems        = 10
+	needLineItemBlankRows   = 3
+	maxNeedLineItemLabelLen = 80
+)
+
+func (s *Service) handleGetOnboardingNeedBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := r.PathValue("needID")
+
+	need, err := s.needsRepo.Need(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch need")
+		s.internalServerError(w)
+		return
+	}
+
+	if s.redirectIfNeedSubmitted(w, r, need) {
+		return
+	}
+
+	items, err := s.needLineItemRepo.LineItemsByNeedID(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need line items")
+		s.internalServerError(w)
+		return
+	}
+
+	s.renderOnboardingNeedBudget(w, r, need, needLineItemFormRows(items), "")
+}
+
+func (s *Service) handlePostOnboardingNeedBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := r.PathValue("needID")
+
+	if err := r.ParseForm(); err != nil {
+		s.logger.WithError(err).Error("failed to parse form")
+		s.internalServerError(w)
+		return
+	}
+
+	need, err := s.needsRepo.Need(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch need")
+		s.internalServerError(w)
+		return
+	}
+
+	if s.redirectIfNeedSubmitted(w, r, need) {
+		return
+	}
+
+	validCategoryIDs, err := s.needLineItemCategoryIDs(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories")
+		s.internalServerError(w)
+		return
+	}
+
+	rows := parseNeedLineItemRows(r.Form)
+	items, err := validateNeedLineItems(rows, need.AmountNeededCents, validCategoryIDs)
+	if err != nil {
+		s.renderOnboardingNeedBudget(w, r, need, rows, err.Error())
+		return
+	}
+
+	if err := store.WithTx(ctx, s.needLineItemRepo, func(tx pgx.Tx) error {
+		return s.needLineItemRepo.ReplaceLineItemsTx(ctx, tx, need.ID, items)
+	}); err != nil {
+		s.logger.WithError(err).WithField("need_id", need.ID).Error("failed to save need line items")
+		s.internalServerError(w)
+		return
+	}
+
+	need.CurrentStep = types.NeedStepBudget
+	err = s.needsRepo.UpdateNeed(ctx, need.ID, need)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to update need step")
+		s.internalServerError(w)
+		return
+	}
+
+	s.recordNeedProgress(ctx, need.ID, types.NeedStepBudget)
+
+	http.Redirect(w, r, s.route(RouteOnboardingNeedDocuments, Param("needID", need.ID)), http.StatusSeeOther)
+}
+
+func (s *Service) renderOnboardingNeedBudget(w http.ResponseWriter, r *http.Request, need *types.Need, rows []types.NeedLineItemFormRow, errorMessage string) {
+	s.renderNeedBudget(w, r, &types.NeedBudgetPageData{
+		BasePageData:      types.BasePageData{Title: "Itemize Your Need"},
+		ID:                need.ID,
+		AmountNeededCents: need.AmountNeededCents,
+		Rows:              rows,
+		FormAction:        s.route(RouteOnboardingNeedBudget, Param("needID", need.ID)),
+		BackHref:          s.route(RouteOnboardingNeedStory, Param("needID", need.ID)),
+		Error:             errorMessage,
+	})
+}
+
+// renderNeedBudget fills in the category choices and blank rows shared by the
+// onboarding and profile edit budget steps, then renders the page.
+func (s *Service) renderNeedBudget(w http.ResponseWriter, r *http.Request, data *types.NeedBudgetPageData) {
+	categories, err := s.categoryRepo.Categories(r.Context())
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories")
+		s.internalServerError(w)
+		return
+	}
+
+	data.Categories = categories
+	for i := 0; i < needLineItemBlankRows && len(data.Rows) < maxNeedLineItems; i++ {
+		data.Rows = append(data.Rows, types.NeedLineItemFormRow{})
+	}
+
+	if err := s.renderTemplate(w, r, "page.onboarding.need.budget", data); err != nil {
+		s.logger.WithError(err).Error("failed to render need budget page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) needLineItemCategoryIDs(ctx context.Context) (map[string]bool, error) {
+	categories, err := s.categoryRepo.Categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	validCategoryIDs := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		validCategoryIDs[category.ID] = true
+	}
+	return validCategoryIDs, nil
+}
+
+func needLineItemFormRows(items []*types.NeedLineItem) []types.NeedLineItemFormRow {
+	rows := make([]types.NeedLineItemFormRow, 0, len(items))
+	for _, item := range items {
+		row := types.NeedLineItemFormRow{
+			Label:  item.Label,
+			Amount: fmt.Sprintf("%d", item.AmountCents/100),
+		}
+		if item.CategoryID != nil {
+			row.CategoryID = *item.CategoryID
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ensureNeedLineItemsMatchGoal reports whether the need's line items, if any,
+// still add up to its goal. The amount can change on the story step after
+// the budget was saved, so this redirects back to the given review step with
+// an error.
+func (s *Service) ensureNeedLineItemsMatchGoal(w http.ResponseWriter, r *http.Request, need *types.Need, reviewRoute RouteName) bool {
+	items, err := s.needLineItemRepo.LineItemsByNeedID(r.Context(), need.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", need.ID).Error("failed to fetch need line items before submit")
+		s.internalServerError(w)
+		return false
+	}
+
+	if len(items) == 0 || needLineItemsTotalCents(items) == need.AmountNeededCents {
+		return true
+	}
+
+	q := url.Values{}
+	q.Set("error", "Your line items no longer add up to the amount requested. Update your line items before submitting.")
+	http.Redirect(w, r, s.routeWithQuery(reviewRoute, q, Param("needID", need.ID)), http.StatusSeeOther)
+	return false
+}
+
+func needLineItemsTotalCents(items []*types.NeedLineItem) int {
+	total := 0
+	for _, item := range items {
+		if item != nil {
+			total += item.AmountCents
+		}
+	}
+	return total
+}
+
+// parseNeedLineItemRows reads the parallel item_label, item_amount and
+// item_category form fields. Rows left completely blank are dropped.
+func parseNeedLineItemRows(form url.Values) []types.NeedLineItemFormRow {
+	labels := form["item_label"]
+	amounts := form["item_amount"]
+	categoryIDs := form["item_category"]
+
+	rowCount := max(len(labels), len(amounts))
+	rows := make([]types.NeedLineItemFormRow, 0, rowCount)
+	for i := 0; i < rowCount; i++ {
+		row := types.NeedLineItemFormRow{}
+		if i < len(labels) {
+			row.Label = strings.TrimSpace(labels[i])
+		}
+		if i < len(amounts) {
+			row.Amount = strings.TrimSpace(amounts[i])
+		}
+		if i < len(categoryIDs) {
+			row.CategoryID = strings.TrimSpace(categoryIDs[i])
+		}
+		if row.Label == "" && row.Amount == "" {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// validateNeedLineItems converts form rows into line items. Line items are
+// optional, but when any are given they must add up to the need's goal.
+func validateNeedLineItems(rows []types.NeedLineItemFormRow, amountNeededCents int, validCategoryIDs map[string]bool) ([]*types.NeedLineItem, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	if len(rows) > maxNeedLineItems {
+		return nil, fmt.Errorf("you can add up to %d line items", maxNeedLineItems)
+	}
+
+	items := make([]*types.NeedLineItem, 0, len(rows))
+	totalCents := 0
+	for i, row := range rows {
+		if row.Label == "" {
+			return nil, fmt.Errorf("line %d needs a description", i+1)
+		}
+		if len(row.Label) > maxNeedLineItemLabelLen {
+			return nil, fmt.Errorf("line %d description cannot exceed %d characters", i+1, maxNeedLineItemLabelLen)
+		}
+
+		amountCents, err := parseDonationAmountCents(row.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("line %d needs an amount in whole dollars", i+1)
+		}
+
+		item := &types.NeedLineItem{
+			Label:       row.Label,
+			AmountCents: amountCents,
+		}
+		if row.CategoryID != "" {
+			if !validCategoryIDs[row.CategoryID] {
+				return nil, fmt.Errorf("line %d has an unknown category", i+1)
+			}
+			categoryID := row.CategoryID
+			item.CategoryID = &categoryID
+		}
+
+		totalCents += amountCents
+		items = append(items, item)
+	}
+
+	if totalCents != amountNeededCents {
+		return nil, fmt.Errorf("line items add up to %s but your need is for %s", formatUSDFromCents(totalCents), formatUSDFromCents(amountNeededCents))
+	}
+
+	return items, nil
+}
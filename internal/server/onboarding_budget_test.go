@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestParseNeedLineItemRows_SkipsBlankRows(t *testing.T) {
+	form := url.Values{
+		"item_label":    {" Rent ", "", "Utilities"},
+		"item_amount":   {"800", "", "200"},
+		"item_category": {"cat-housing", "", ""},
+	}
+
+	rows := parseNeedLineItemRows(form)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].Label != "Rent" || rows[0].CategoryID != "cat-housing" {
+		t.Fatalf("unexpected first row: %+v", rows[0])
+	}
+	if rows[1].Label != "Utilities" || rows[1].Amount != "200" {
+		t.Fatalf("unexpected second row: %+v", rows[1])
+	}
+}
+
+func TestValidateNeedLineItems_RequiresMatchingTotal(t *testing.T) {
+	rows := []types.NeedLineItemFormRow{
+		{Label: "Rent", Amount: "800", CategoryID: "cat-housing"},
+		{Label: "Utilities", Amount: "200"},
+	}
+	valid := map[string]bool{"cat-housing": true}
+
+	items, err := validateNeedLineItems(rows, 100000, valid)
+	if err != nil {
+		t.Fatalf("expected valid line items, got %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].AmountCents != 80000 || items[0].CategoryID == nil || *items[0].CategoryID != "cat-housing" {
+		t.Fatalf("unexpected first item: %+v", items[0])
+	}
+	if items[1].CategoryID != nil {
+		t.Fatalf("expected no category on second item")
+	}
+
+	if _, err := validateNeedLineItems(rows, 120000, valid); err == nil {
+		t.Fatal("expected mismatched total to fail")
+	}
+}
+
+func TestValidateNeedLineItems_RejectsInvalidRows(t *testing.T) {
+	valid := map[string]bool{"cat-housing": true}
+
+	cases := map[string][]types.NeedLineItemFormRow{
+		"missing label":    {{Amount: "100"}},
+		"bad amount":       {{Label: "Rent", Amount: "12.50"}},
+		"unknown category": {{Label: "Rent", Amount: "100", CategoryID: "cat-unknown"}},
+	}
+
+	for name, rows := range cases {
+		if _, err := validateNeedLineItems(rows, 10000, valid); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestValidateNeedLineItems_AllowsNone(t *testing.T) {
+	items, err := validateNeedLineItems(nil, 50000, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if items != nil {
+		t.Fatalf("expected no items, got %d", len(items))
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"christjesus/internal/utils"
 	"christjesus/pkg/types"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -56,7 +59,7 @@ func (s *Service) handleGetOnboardingNeedDocuments(w http.ResponseWriter, r *htt
 		MetadataAction:      s.route(RouteOnboardingNeedDocumentsMeta, Param("needID", needID)),
 		UploadAction:        s.route(RouteOnboardingNeedDocumentsUpload, Param("needID", needID)),
 		ContinueAction:      s.route(RouteOnboardingNeedDocuments, Param("needID", needID)),
-		BackHref:            s.route(RouteOnboardingNeedStory, Param("needID", needID)),
+		BackHref:            s.route(RouteOnboardingNeedBudget, Param("needID", needID)),
 		DeleteActions:       s.needDocumentDeleteActions(RouteOnboardingNeedDocumentDelete, needID, needDocumentIDs(documents)),
 	}
 
@@ -161,6 +164,17 @@ func (s *Service) handleFile(ctx context.Context, needID, userID string, fileHea
 	}
 
 	defer file.Close()
+
+	// Hash before uploading so reused documents can be matched across needs.
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to hash uploaded file")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to rewind uploaded file")
+	}
+	contentHash := hex.EncodeToString(hasher.Sum(nil))
+
 	ext := filepath.Ext(fileHeader.Filename)
 
 	docID := utils.NanoID()
@@ -187,6 +201,7 @@ func (s *Service) handleFile(ctx context.Context, needID, userID string, fileHea
 		FileSizeBytes: fileHeader.Size,
 		MimeType:      contentType,
 		StorageKey:    storageKey,
+		ContentSHA256: &contentHash,
 		UploadedAt:    time.Now(),
 	}
 
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Service) renderOnboardingNeedPicker(ctx context.Context, w http.ResponseWriter, r *http.Request, userID string, summaries []types.ProfileNeedSummary) {
+	activeCount, err := s.needsRepo.ActiveNeedCountByUser(ctx, userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to count active needs for onboarding")
+		s.internalServerError(w)
+		return
+	}
+
+	drafts := make([]types.ProfileNeedSummary, 0)
+	pastNeeds := make([]types.ProfileNeedSummary, 0)
+	for _, summary := range summaries {
+		if summary.Status == types.NeedStatusDraft {
+			drafts = append(drafts, summary)
+			continue
+		}
+		pastNeeds = append(pastNeeds, summary)
+	}
+
+	maxActive := s.config.MaxActiveNeedsPerUser
+
+	data := &types.OnboardingNeedPickerPageData{
+		BasePageData:    types.BasePageData{Title: "Your Needs"},
+		Drafts:          drafts,
+		PastNeeds:       pastNeeds,
+		NewDraftAction:  s.route(RouteOnboardingNeedNew),
+		ActiveNeedCount: activeCount,
+		MaxActiveNeeds:  maxActive,
+		AtActiveCap:     activeNeedCapReached(activeCount, maxActive),
+		Notice:          strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:           strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+
+	if err := s.renderTemplate(w, r, "page.onboarding.need.picker", data); err != nil {
+		s.logger.WithError(err).Error("failed to render onboarding need picker")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) handlePostOnboardingNeedNew(w http.ResponseWriter, r *http.Request) {
+	s.handleCreateNeed(r.Context(), w, r)
+}
+
+// handlePostOnboardingNeedClone starts a new draft from a past need. Location,
+// categories and story carry over; documents and amounts do not, since those
+// are specific to each request.
+func (s *Service) handlePostOnboardingNeedClone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := s.userIDFromContext(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("user id not found in context")
+		s.internalServerError(w)
+		return
+	}
+
+	sourceID := strings.TrimSpace(r.PathValue("needID"))
+	source, err := s.needsRepo.Need(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			s.redirectOnboardingWithError(w, r, "Need not found.")
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", sourceID).Error("failed to load need to clone")
+		s.internalServerError(w)
+		return
+	}
+
+	if source.UserID != userID {
+		s.redirectOnboardingWithError(w, r, "Need not found.")
+		return
+	}
+
+	story, err := s.storyRepo.GetStoryByNeedID(ctx, source.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", source.ID).Error("failed to load story to clone")
+		s.internalServerError(w)
+		return
+	}
+
+	assignments, err := s.needCategoryAssignmentsRepo.GetAssignmentsByNeedID(ctx, source.ID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", source.ID).Error("failed to load categories to clone")
+		s.internalServerError(w)
+		return
+	}
+
+	draft := &types.Need{
+		UserID:                userID,
+		UserAddressID:         source.UserAddressID,
+		UsesNonPrimaryAddress: source.UsesNonPrimaryAddress,
+		Status:                types.NeedStatusDraft,
+		CurrentStep:           types.NeedStepWelcome,
+	}
+
+	if err := store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if err := s.needsRepo.CreateNeedTx(ctx, tx, draft); err != nil {
+			return err
+		}
+
+		clonedAssignments := make([]*types.NeedCategoryAssignment, 0, len(assignments))
+		for _, assignment := range assignments {
+			clonedAssignments = append(clonedAssignments, &types.NeedCategoryAssignment{
+				NeedID:     draft.ID,
+				CategoryID: assignment.CategoryID,
+				IsPrimary:  assignment.IsPrimary,
+			})
+		}
+		if err := s.needCategoryAssignmentsRepo.CreateAssignmentsTx(ctx, tx, clonedAssignments); err != nil {
+			return err
+		}
+
+		if story == nil {
+			return nil
+		}
+
+		return s.storyRepo.CreateStoryTx(ctx, tx, &types.NeedStory{
+			NeedID:  draft.ID,
+			Current: story.Current,
+			Need:    story.Need,
+			Outcome: story.Outcome,
+		})
+	}); err != nil {
+		s.logger.WithError(err).WithField("need_id", source.ID).Error("failed to clone need into new draft")
+		s.redirectOnboardingWithError(w, r, "We couldn't copy that need. Please try again.")
+		return
+	}
+
+	http.Redirect(w, r, s.route(RouteOnboardingNeedWelcome, Param("needID", draft.ID)), http.StatusSeeOther)
+}
+
+// ensureActiveNeedCapacity reports whether the user may submit another need.
+// When the cap is reached it redirects back to the review step with an error.
+func (s *Service) ensureActiveNeedCapacity(w http.ResponseWriter, r *http.Request, need *types.Need) bool {
+	maxActive := s.config.MaxActiveNeedsPerUser
+	if maxActive <= 0 {
+		return true
+	}
+
+	activeCount, err := s.needsRepo.ActiveNeedCountByUser(r.Context(), need.UserID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", need.UserID).Error("failed to count active needs before submit")
+		s.internalServerError(w)
+		return false
+	}
+
+	if !activeNeedCapReached(activeCount, maxActive) {
+		return true
+	}
+
+	q := url.Values{}
+	q.Set("error", fmt.Sprintf("You already have %d active needs, the most allowed at once. Your draft is saved; submit it once one of your needs is funded or closed.", activeCount))
+	http.Redirect(w, r, s.routeWithQuery(RouteOnboardingNeedReview, q, Param("needID", need.ID)), http.StatusSeeOther)
+	return false
+}
+
+func activeNeedCapReached(activeCount, maxActive int) bool {
+	return maxActive > 0 && activeCount >= maxActive
+}
+
+func (s *Service) redirectOnboardingWithError(w http.ResponseWriter, r *http.Request, message string) {
+	q := url.Values{}
+	q.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteOnboarding, q), http.StatusSeeOther)
+}
@@ -187,7 +187,7 @@ func (s *Service) handlePostOnboardingNeedLocation(w http.ResponseWriter, r *htt
 			City:                 location.City,
 			State:                location.State,
 			ZipCode:              location.ZipCode,
-			PrivacyDisplay:       location.PrivacyDisplay,
+			PrivacyDisplay:       utils.StringPtr(normalizeAddressPrivacy(location.PrivacyDisplay)),
 			ContactMethods:       location.ContactMethods,
 			PreferredContactTime: location.PreferredContactTime,
 			IsPrimary:            setNewAsPrimary,
@@ -1,10 +1,12 @@
 package server
 
 import (
+	"christjesus/internal/store"
 	"christjesus/pkg/types"
 	"net/http"
 	"net/url"
-	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s *Service) handleGetOnboardingNeedReview(w http.ResponseWriter, r *http.Request) {
@@ -38,9 +40,11 @@ func (s *Service) handleGetOnboardingNeedReview(w http.ResponseWriter, r *http.R
 		PrimaryCategory:     core.PrimaryCategory,
 		SecondaryCategories: core.SecondaryCategories,
 		Documents:           core.Documents,
+		LineItems:           core.LineItems,
 		EditLocationHref:    s.route(RouteOnboardingNeedLocation, Param("needID", needID)),
 		EditCategoriesHref:  s.route(RouteOnboardingNeedCategories, Param("needID", needID)),
 		EditStoryHref:       s.route(RouteOnboardingNeedStory, Param("needID", needID)),
+		EditBudgetHref:      s.route(RouteOnboardingNeedBudget, Param("needID", needID)),
 		EditDocumentsHref:   s.route(RouteOnboardingNeedDocuments, Param("needID", needID)),
 		SubmitAction:        s.route(RouteOnboardingNeedReview, Param("needID", needID)),
 		BackHref:            s.route(RouteOnboardingNeedDocuments, Param("needID", needID)),
@@ -101,18 +105,36 @@ func (s *Service) handlePostOnboardingNeedReview(w http.ResponseWriter, r *http.
 		return
 	}
 
-	now := time.Now()
+	if !s.ensureActiveNeedCapacity(w, r, need) {
+		return
+	}
+
+	if !s.ensureNeedLineItemsMatchGoal(w, r, need, RouteOnboardingNeedReview) {
+		return
+	}
+
 	need.CurrentStep = types.NeedStepReview
-	need.Status = types.NeedStatusSubmitted
-	need.SubmittedAt = &now
 
-	err = s.needsRepo.UpdateNeed(ctx, need.ID, need)
+	err = store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if err := s.needsRepo.UpdateNeedTx(ctx, tx, need.ID, need); err != nil {
+			return err
+		}
+		return s.needsRepo.TransitionNeedStatusTx(ctx, tx, need.ID, types.NeedStatusSubmitted, types.NeedProgressEventSourceUser, need.UserID)
+	})
 	if err != nil {
+		if message, ok := needTransitionErrorMessage(err); ok {
+			q := url.Values{}
+			q.Set("error", message)
+			http.Redirect(w, r, s.routeWithQuery(RouteOnboardingNeedReview, q, Param("needID", needID)), http.StatusSeeOther)
+			return
+		}
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to update need status on submit")
 		s.internalServerError(w)
 		return
 	}
+	need.Status = types.NeedStatusSubmitted
 
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepReview)
+	s.detectNeedFlags(ctx, need)
 	http.Redirect(w, r, s.route(RouteOnboardingNeedConfirmation, Param("needID", needID)), http.StatusSeeOther)
 }
@@ -139,5 +139,5 @@ func (s *Service) handlePostOnboardingNeedStory(w http.ResponseWriter, r *http.R
 	// Record progress
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepStory)
 
-	http.Redirect(w, r, s.route(RouteOnboardingNeedDocuments, Param("needID", need.ID)), http.StatusSeeOther)
+	http.Redirect(w, r, s.route(RouteOnboardingNeedBudget, Param("needID", need.ID)), http.StatusSeeOther)
 }
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"christjesus/internal/store"
 	"christjesus/pkg/types"
@@ -18,14 +20,21 @@ const browseDefaultPageSize = 18
 func (s *Service) handleHome(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	latestNeeds, err := s.needsRepo.LatestNeeds(ctx, 5)
+	latestNeeds, err := s.needsRepo.LatestNeeds(ctx, homeFeaturedNeedsLimit)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to fetch latest needs for home page")
 		s.internalServerError(w)
 		return
 	}
 
-	homeNeedCards := s.buildHomeNeedCards(ctx, latestNeeds)
+	// Scheduled needs lead the homepage; the latest needs fill any slots the
+	// schedule leaves empty.
+	scheduledNeeds, err := s.needFeatureRepo.LiveFeaturedNeeds(ctx, types.NeedFeaturePlacementHome, "", time.Now(), homeFeaturedNeedsLimit)
+	if err != nil {
+		s.logger.WithError(err).Warn("failed to fetch scheduled featured needs for home page")
+	}
+
+	homeNeedCards := s.buildHomeNeedCards(ctx, mergeFeaturedNeeds(scheduledNeeds, latestNeeds, homeFeaturedNeedsLimit))
 
 	var featuredNeed *types.BrowseNeedCard
 	featuredNeeds := make([]*types.BrowseNeedCard, 0, 4)
@@ -129,7 +138,7 @@ func (s *Service) buildRecommendedNeeds(ctx context.Context, r *http.Request, us
 
 	cards := s.buildNeedCards(ctx, needs, "home recommendations")
 	for _, card := range cards {
-		card.DistanceMiles = distanceByNeedID[card.ID]
+		card.DistanceMiles = publicNeedDistanceMiles(card.LocationPrivacy, distanceByNeedID[card.ID])
 	}
 
 	return cards
@@ -208,6 +217,24 @@ func (s *Service) buildHomeStats(ctx context.Context) types.StatsData {
 	return stats
 }
 
+// mergeFeaturedNeeds lists scheduled needs first, then fallback needs that
+// are not already listed, up to limit.
+func mergeFeaturedNeeds(scheduled, fallback []*types.Need, limit int) []*types.Need {
+	merged := make([]*types.Need, 0, limit)
+	seen := make(map[string]bool, limit)
+	for _, need := range slices.Concat(scheduled, fallback) {
+		if len(merged) == limit {
+			break
+		}
+		if need == nil || seen[need.ID] {
+			continue
+		}
+		seen[need.ID] = true
+		merged = append(merged, need)
+	}
+	return merged
+}
+
 func (s *Service) buildHomeNeedCards(ctx context.Context, needs []*types.Need) []*types.BrowseNeedCard {
 	return s.buildNeedCards(ctx, needs, "home featured needs")
 }
@@ -218,6 +245,7 @@ type needCardBuildContext struct {
 	primaryAddressesByUserID  map[string]*types.UserAddress
 	assignmentsByNeedID       map[string][]*types.NeedCategoryAssignment
 	categoryNamesByCategoryID map[string]string
+	countyNamesByZip          map[string]string
 }
 
 func (s *Service) buildNeedCards(ctx context.Context, needs []*types.Need, logContext string) []*types.BrowseNeedCard {
@@ -251,7 +279,13 @@ func (s *Service) buildNeedCards(ctx context.Context, needs []*types.Need, logCo
 			address = ctxData.primaryAddressesByUserID[need.UserID]
 		}
 
-		city, state, cityState := browseCityStateParts(address)
+		countyName := ""
+		locationPrivacy := types.AddressPrivacyCity
+		if address != nil {
+			countyName = ctxData.countyNamesByZip[derefString(address.ZipCode)]
+			locationPrivacy = normalizeAddressPrivacy(address.PrivacyDisplay)
+		}
+		city, state, cityState := browseCityStateParts(address, countyName)
 
 		primaryCategory := "General Need"
 		primaryCategoryID := ""
@@ -277,6 +311,7 @@ func (s *Service) buildNeedCards(ctx context.Context, needs []*types.Need, logCo
 			City:              city,
 			State:             state,
 			CityState:         cityState,
+			LocationPrivacy:   locationPrivacy,
 			UrgencyLabel:      urgencyLabel,
 			UrgencyDotClass:   urgencyDotClass,
 			UrgencyTextClass:  urgencyTextClass,
@@ -372,6 +407,15 @@ func (s *Service) loadNeedCardBuildContext(ctx context.Context, needs []*types.N
 		}
 	}
 
+	addresses := make([]*types.UserAddress, 0, len(ctxData.selectedAddressesByID)+len(ctxData.primaryAddressesByUserID))
+	for _, address := range ctxData.selectedAddressesByID {
+		addresses = append(addresses, address)
+	}
+	for _, address := range ctxData.primaryAddressesByUserID {
+		addresses = append(addresses, address)
+	}
+	ctxData.countyNamesByZip = s.countyNamesForAddresses(ctx, addresses...)
+
 	primaryCategoryIDs := make([]string, 0)
 	seenPrimaryCategoryIDs := make(map[string]bool)
 	assignments, err := s.needCategoryAssignmentsRepo.GetAssignmentsByNeedIDs(ctx, needIDs)
@@ -658,7 +702,7 @@ func (s *Service) buildBrowseResultsPageData(ctx context.Context, filters types.
 
 	cards := s.buildNeedCards(ctx, needs, "browse needs")
 	for _, card := range cards {
-		card.DistanceMiles = distanceByNeedID[card.ID]
+		card.DistanceMiles = publicNeedDistanceMiles(card.LocationPrivacy, distanceByNeedID[card.ID])
 	}
 
 	categories, err := s.categoryRepo.Categories(ctx)
@@ -738,7 +782,7 @@ func (s *Service) handleNeedDetail(w http.ResponseWriter, r *http.Request) {
 		s.internalServerError(w)
 		return
 	}
-	if need.DeletedAt != nil {
+	if need.DeletedAt != nil || need.HiddenAt != nil {
 		http.NotFound(w, r)
 		return
 	}
@@ -828,7 +872,11 @@ func (s *Service) handleNeedDetail(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	_, _, cityState := browseCityStateParts(selectedAddress)
+	_, _, cityState := s.publicLocation(ctx, selectedAddress)
+	var privacyDisplay *string
+	if selectedAddress != nil {
+		privacyDisplay = selectedAddress.PrivacyDisplay
+	}
 
 	urgencyLabel, urgencyDotClass, urgencyTextClass := browseUrgency(need.Urgency)
 
@@ -852,6 +900,13 @@ func (s *Service) handleNeedDetail(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	lineItems, err := s.loadNeedLineItemViews(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need line items")
+		s.internalServerError(w)
+		return
+	}
+
 	relatedNeeds := make([]*types.BrowseNeedCard, 0, 3)
 	if primaryCategory != nil && strings.TrimSpace(primaryCategory.ID) != "" {
 		relatedFilters := types.BrowseFilters{
@@ -893,7 +948,7 @@ func (s *Service) handleNeedDetail(w http.ResponseWriter, r *http.Request) {
 		ID:                  needID,
 		Need:                need,
 		OwnerName:           ownerName,
-		SelectedAddress:     selectedAddress,
+		LocationPrivacy:     addressPrivacyLabel(privacyDisplay),
 		CityState:           cityState,
 		UrgencyLabel:        urgencyLabel,
 		UrgencyDotClass:     urgencyDotClass,
@@ -903,11 +958,14 @@ func (s *Service) handleNeedDetail(w http.ResponseWriter, r *http.Request) {
 		PrimaryCategory:     primaryCategory,
 		SecondaryCategories: secondaryCategories,
 		Documents:           reviewDocs,
+		LineItems:           lineItems,
 		RelatedNeeds:        relatedNeeds,
 		IsSaved:             isSaved,
 		SaveNeedAction:      s.route(RouteNeedSave, Param("needID", needID)),
 		UnsaveNeedAction:    s.route(RouteNeedUnsave, Param("needID", needID)),
 	}
+	data.Meta = s.needPageMeta(need, ownerName, story)
+	s.buildNeedShareData(ctx, r, data)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := s.renderTemplate(w, r, "page.need-detail", data); err != nil {
@@ -973,32 +1031,6 @@ func userDisplayName(user *types.User) string {
 	return "Anonymous"
 }
 
-func browseCityStateParts(address *types.UserAddress) (string, string, string) {
-	city := "N/A"
-	state := "N/A"
-
-	if address != nil {
-		if address.City != nil {
-			trimmed := strings.TrimSpace(*address.City)
-			if trimmed != "" {
-				city = trimmed
-			}
-		}
-		if address.State != nil {
-			trimmed := strings.TrimSpace(*address.State)
-			if trimmed != "" {
-				state = strings.ToUpper(trimmed)
-			}
-		}
-	}
-
-	if city == "N/A" || state == "N/A" {
-		return city, state, "N/A"
-	}
-
-	return city, state, city + ", " + state
-}
-
 func browseUrgency(urgency types.NeedUrgency) (string, string, string) {
 	switch urgency {
 	case types.NeedUrgencyUrgent:
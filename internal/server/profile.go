@@ -73,6 +73,13 @@ func (s *Service) handleGetProfile(w http.ResponseWriter, r *http.Request) {
 		savedNeedSummaries = saved
 	}
 
+	shareSummaries, shareRaisedCents, err := s.buildShareSummaries(ctx, session.UserID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", session.UserID).Error("failed to build share summaries for profile")
+		s.internalServerError(w)
+		return
+	}
+
 	data := &types.ProfilePageData{
 		BasePageData:            types.BasePageData{Title: "My Profile"},
 		UserID:                  session.UserID,
@@ -92,9 +99,12 @@ func (s *Service) handleGetProfile(w http.ResponseWriter, r *http.Request) {
 		NeedSummaries:           needSummaries,
 		DonationSummaries:       donationSummaries,
 		SavedNeedSummaries:      savedNeedSummaries,
+		ShareSummaries:          shareSummaries,
+		ShareRaisedAmount:       formatUSDFromCents(shareRaisedCents),
 		HasNeeds:                len(myNeeds) > 0,
 		HasDonations:            len(donationSummaries) > 0,
 		HasSavedNeeds:           len(savedNeedSummaries) > 0,
+		HasShares:               len(shareSummaries) > 0,
 		SubmitNeedHref:          s.route(RouteOnboarding),
 	}
 
@@ -159,8 +169,13 @@ func (s *Service) buildNeedSummaries(ctx context.Context, userID string) ([]*typ
 	summaries := make([]types.ProfileNeedSummary, 0, len(needs))
 	for _, need := range needs {
 		reviewPortalHref := ""
-		if need.Status != types.NeedStatusDraft {
+		continueHref := ""
+		cloneAction := ""
+		if need.Status == types.NeedStatusDraft {
+			continueHref = s.route(onboardingNeedStepRoute(need.CurrentStep), Param("needID", need.ID))
+		} else {
 			reviewPortalHref = s.route(RouteProfileNeedReview, Param("needID", need.ID))
+			cloneAction = s.route(RouteOnboardingNeedClone, Param("needID", need.ID))
 		}
 
 		primaryCategoryName := "Uncategorized"
@@ -179,6 +194,8 @@ func (s *Service) buildNeedSummaries(ctx context.Context, userID string) ([]*typ
 			CanDelete:           need.Status == types.NeedStatusDraft,
 			NeedsAttention:      need.Status == types.NeedStatusChangesRequested || need.Status == types.NeedStatusRejected,
 			ReviewPortalHref:    reviewPortalHref,
+			ContinueHref:        continueHref,
+			CloneAction:         cloneAction,
 		})
 	}
 
@@ -227,6 +244,18 @@ func (s *Service) buildDonationSummaries(ctx context.Context, userID string) ([]
 		needLabelByID[needID] = needLabel
 	}
 
+	intentIDs := make([]string, 0, len(intents))
+	for _, intent := range intents {
+		if intent != nil {
+			intentIDs = append(intentIDs, intent.ID)
+		}
+	}
+
+	redirectsByIntentID, err := s.buildDonationRedirects(ctx, intentIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	summaries := make([]types.ProfileDonationSummary, 0, len(intents))
 	for _, intent := range intents {
 		if intent == nil {
@@ -250,6 +279,7 @@ func (s *Service) buildDonationSummaries(ctx context.Context, userID string) ([]
 			IsFinalized: isFinalized,
 			IsAnonymous: intent.IsAnonymous,
 			CreatedAt:   intent.CreatedAt.Format("Jan 2, 2006"),
+			Redirects:   redirectsByIntentID[intent.ID],
 		})
 	}
 
@@ -438,6 +468,9 @@ func (s *Service) handlePostProfileNeedDelete(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// The draft row is gone for good, so the audit log is its only trace.
+	s.recordAdminAudit(r, types.AdminAuditActionNeedDeleted, types.AdminAuditTargetNeed, needID, "owner deleted draft")
+
 	s.redirectProfileWithNotice(w, r, "Draft need deleted.")
 }
 
@@ -477,6 +510,8 @@ func formatNeedStepLabel(step types.NeedStep) string {
 		return "Categories"
 	case types.NeedStepStory:
 		return "Need Story"
+	case types.NeedStepBudget:
+		return "Budget"
 	case types.NeedStepDocuments:
 		return "Documents"
 	case types.NeedStepReview:
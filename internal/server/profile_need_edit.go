@@ -1,10 +1,16 @@
 package server
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"strings"
+
+	"christjesus/pkg/types"
 )
 
+const needEditConflictMessage = "This need changed since you opened it. Your changes below have not been saved yet; check them and save again."
+
 func (s *Service) handleGetProfileNeedEdit(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	needID := strings.TrimSpace(r.PathValue("needID"))
@@ -20,3 +26,27 @@ func (s *Service) handleGetProfileNeedEdit(w http.ResponseWriter, r *http.Reques
 
 	http.Redirect(w, r, s.route(RouteProfileNeedEditLocation, Param("needID", needID)), http.StatusSeeOther)
 }
+
+// needEditFormStale reports whether the need was saved by someone else after
+// the edit form was rendered. Forms carry the need version they were rendered
+// with; a form without one cannot prove it is current and is treated as stale.
+func needEditFormStale(r *http.Request, need *types.Need) bool {
+	version, err := strconv.Atoi(strings.TrimSpace(r.FormValue("version")))
+	if err != nil {
+		return true
+	}
+
+	return version != need.Version
+}
+
+// refreshNeedVersion picks up the stored version after a conflicting save so
+// the re-rendered form can be submitted again on top of the latest changes.
+func (s *Service) refreshNeedVersion(ctx context.Context, need *types.Need) error {
+	latest, err := s.needsRepo.Need(ctx, need.ID)
+	if err != nil {
+		return err
+	}
+
+	need.Version = latest.Version
+	return nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Service) handleGetProfileNeedEditBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+
+	need, err := s.profileEditableNeed(ctx, needID)
+	if err != nil {
+		s.handleProfileEditableNeedError(w, r, needID, err)
+		return
+	}
+
+	items, err := s.needLineItemRepo.LineItemsByNeedID(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need line items")
+		s.internalServerError(w)
+		return
+	}
+
+	s.renderProfileNeedEditBudget(w, r, need, needLineItemFormRows(items), "")
+}
+
+// renderProfileNeedEditBudget renders the budget step for need with the given
+// rows, which are the submitted ones when re-rendering after an error.
+func (s *Service) renderProfileNeedEditBudget(w http.ResponseWriter, r *http.Request, need *types.Need, rows []types.NeedLineItemFormRow, errorMessage string) {
+	s.renderNeedBudget(w, r, &types.NeedBudgetPageData{
+		BasePageData:      types.BasePageData{Title: "Edit Need Budget"},
+		ID:                need.ID,
+		AmountNeededCents: need.AmountNeededCents,
+		Rows:              rows,
+		NeedVersion:       need.Version,
+		FormAction:        s.route(RouteProfileNeedEditBudget, Param("needID", need.ID)),
+		BackHref:          s.route(RouteProfileNeedEditStory, Param("needID", need.ID)),
+		Error:             errorMessage,
+	})
+}
+
+func (s *Service) handlePostProfileNeedEditBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+
+	need, err := s.profileEditableNeed(ctx, needID)
+	if err != nil {
+		s.handleProfileEditableNeedError(w, r, needID, err)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.logger.WithError(err).Error("failed to parse form")
+		s.internalServerError(w)
+		return
+	}
+
+	rows := parseNeedLineItemRows(r.Form)
+
+	if needEditFormStale(r, need) {
+		s.renderProfileNeedEditBudget(w, r, need, rows, needEditConflictMessage)
+		return
+	}
+
+	validCategoryIDs, err := s.needLineItemCategoryIDs(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch categories")
+		s.internalServerError(w)
+		return
+	}
+
+	items, err := validateNeedLineItems(rows, need.AmountNeededCents, validCategoryIDs)
+	if err != nil {
+		s.renderProfileNeedEditBudget(w, r, need, rows, err.Error())
+		return
+	}
+
+	need.CurrentStep = types.NeedStepBudget
+	err = store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if err := s.needsRepo.UpdateNeedTx(ctx, tx, need.ID, need); err != nil {
+			return err
+		}
+		return s.needLineItemRepo.ReplaceLineItemsTx(ctx, tx, need.ID, items)
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrNeedVersionConflict) {
+			if err := s.refreshNeedVersion(ctx, need); err != nil {
+				s.logger.WithError(err).WithField("need_id", needID).Error("failed to reload need after budget conflict")
+				s.internalServerError(w)
+				return
+			}
+			s.renderProfileNeedEditBudget(w, r, need, rows, needEditConflictMessage)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", need.ID).Error("failed to save need line items")
+		s.internalServerError(w)
+		return
+	}
+
+	s.recordNeedProgress(ctx, need.ID, types.NeedStepBudget)
+	s.recordNeedRevision(ctx, need.ID, need.UserID, types.NeedRevisionSourceBudget)
+	http.Redirect(w, r, s.route(RouteProfileNeedEditDocs, Param("needID", need.ID)), http.StatusSeeOther)
+}
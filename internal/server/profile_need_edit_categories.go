@@ -1,11 +1,15 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"christjesus/internal/store"
 	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s *Service) handleGetProfileNeedEditCategories(w http.ResponseWriter, r *http.Request) {
@@ -18,33 +22,38 @@ func (s *Service) handleGetProfileNeedEditCategories(w http.ResponseWriter, r *h
 		return
 	}
 
-	categories, err := s.categoryRepo.Categories(ctx)
+	selectedPrimaryCategoryID, selectedSecondaryCategoryIDs, err := s.selectedNeedCategories(ctx, needID)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to load categories from database")
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to load selected categories for profile edit")
 		s.internalServerError(w)
 		return
 	}
 
-	if len(categories) == 0 {
-		s.logger.Warn("no categories found in database - run 'just seed' to populate categories")
-	}
+	s.renderProfileNeedEditCategories(w, r, need, selectedPrimaryCategoryID, selectedSecondaryCategoryIDs, strings.TrimSpace(r.URL.Query().Get("error")))
+}
 
-	selectedPrimaryCategoryID, selectedSecondaryCategoryIDs, err := s.selectedNeedCategories(ctx, needID)
+func (s *Service) renderProfileNeedEditCategories(w http.ResponseWriter, r *http.Request, need *types.Need, primaryID string, secondaryIDs map[string]bool, errorMessage string) {
+	categories, err := s.categoryRepo.Categories(r.Context())
 	if err != nil {
-		s.logger.WithError(err).WithField("need_id", needID).Error("failed to load selected categories for profile edit")
+		s.logger.WithError(err).Error("failed to load categories from database")
 		s.internalServerError(w)
 		return
 	}
 
+	if len(categories) == 0 {
+		s.logger.Warn("no categories found in database - run 'just seed' to populate categories")
+	}
+
 	data := &types.NeedCategoriesPageData{
 		BasePageData:                 types.BasePageData{Title: "Edit Need Categories"},
 		Need:                         need,
 		Categories:                   categories,
-		SelectedPrimaryCategoryID:    selectedPrimaryCategoryID,
-		SelectedSecondaryCategoryIDs: selectedSecondaryCategoryIDs,
-		FormAction:                   s.route(RouteProfileNeedEditCategories, Param("needID", needID)),
-		BackHref:                     s.route(RouteProfileNeedEditLocation, Param("needID", needID)),
-		Error:                        strings.TrimSpace(r.URL.Query().Get("error")),
+		SelectedPrimaryCategoryID:    primaryID,
+		SelectedSecondaryCategoryIDs: secondaryIDs,
+		NeedVersion:                  need.Version,
+		FormAction:                   s.route(RouteProfileNeedEditCategories, Param("needID", need.ID)),
+		BackHref:                     s.route(RouteProfileNeedEditLocation, Param("needID", need.ID)),
+		Error:                        errorMessage,
 	}
 
 	if err := s.renderTemplate(w, r, "page.onboarding.need.categories", data); err != nil {
@@ -126,35 +135,49 @@ func (s *Service) handlePostProfileNeedEditCategories(w http.ResponseWriter, r *
 		secondaryCategories = append(secondaryCategories, categoryMap[id])
 	}
 
-	err = s.needCategoryAssignmentsRepo.DeleteAllAssignmentsByNeedID(ctx, need.ID)
-	if err != nil {
-		s.logger.WithError(err).Error("failed to delete existing need category assignments")
-		s.internalServerError(w)
-		return
-	}
-
 	assignments := make([]*types.NeedCategoryAssignment, 0, len(needCategories))
 	assignments = append(assignments, &types.NeedCategoryAssignment{NeedID: need.ID, CategoryID: primaryCategory.ID, IsPrimary: true})
 	for _, cat := range secondaryCategories {
 		assignments = append(assignments, &types.NeedCategoryAssignment{NeedID: need.ID, CategoryID: cat.ID, IsPrimary: false})
 	}
 
-	err = s.needCategoryAssignmentsRepo.CreateAssignments(ctx, assignments)
-	if err != nil {
-		s.logger.WithError(err).Error("failed to create need category assignments")
-		s.internalServerError(w)
+	submittedSecondaryIDs := make(map[string]bool, len(secondaryIDs))
+	for _, id := range secondaryIDs {
+		submittedSecondaryIDs[id] = true
+	}
+
+	if needEditFormStale(r, need) {
+		s.renderProfileNeedEditCategories(w, r, need, primaryID, submittedSecondaryIDs, needEditConflictMessage)
 		return
 	}
 
 	need.CurrentStep = types.NeedStepCategories
-	err = s.needsRepo.UpdateNeed(ctx, need.ID, need)
+	err = store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if err := s.needsRepo.UpdateNeedTx(ctx, tx, need.ID, need); err != nil {
+			return err
+		}
+		if err := s.needCategoryAssignmentsRepo.DeleteAllAssignmentsByNeedIDTx(ctx, tx, need.ID); err != nil {
+			return err
+		}
+		return s.needCategoryAssignmentsRepo.CreateAssignmentsTx(ctx, tx, assignments)
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("failed to update need with selected categories")
+		if errors.Is(err, types.ErrNeedVersionConflict) {
+			if err := s.refreshNeedVersion(ctx, need); err != nil {
+				s.logger.WithError(err).WithField("need_id", needID).Error("failed to reload need after categories conflict")
+				s.internalServerError(w)
+				return
+			}
+			s.renderProfileNeedEditCategories(w, r, need, primaryID, submittedSecondaryIDs, needEditConflictMessage)
+			return
+		}
+		s.logger.WithError(err).Error("failed to save need categories")
 		s.internalServerError(w)
 		return
 	}
 
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepCategories)
+	s.recordNeedRevision(ctx, need.ID, need.UserID, types.NeedRevisionSourceCategories)
 	http.Redirect(w, r, s.route(RouteProfileNeedEditStory, Param("needID", need.ID)), http.StatusSeeOther)
 }
 
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -45,7 +46,7 @@ func (s *Service) handleGetProfileNeedEditDocuments(w http.ResponseWriter, r *ht
 		MetadataAction:      s.route(RouteProfileNeedEditMeta, Param("needID", needID)),
 		UploadAction:        s.route(RouteProfileNeedEditUpload, Param("needID", needID)),
 		ContinueAction:      s.route(RouteProfileNeedEditDocs, Param("needID", needID)),
-		BackHref:            s.route(RouteProfileNeedEditStory, Param("needID", needID)),
+		BackHref:            s.route(RouteProfileNeedEditBudget, Param("needID", needID)),
 		DeleteActions:       s.needDocumentDeleteActions(RouteProfileNeedEditDelete, needID, needDocumentIDs(documents)),
 	}
 
@@ -103,6 +104,8 @@ func (s *Service) handlePostProfileNeedEditDocumentsUpload(w http.ResponseWriter
 		return
 	}
 
+	s.recordNeedRevision(ctx, needID, userID, types.NeedRevisionSourceDocuments)
+
 	if failedCount > 0 {
 		summary := fmt.Sprintf("Uploaded %d file(s). %d file(s) could not be uploaded", uploadedCount, failedCount)
 		if len(failedFiles) > 0 {
@@ -154,12 +157,17 @@ func (s *Service) handlePostProfileNeedEditDocuments(w http.ResponseWriter, r *h
 
 	need.CurrentStep = types.NeedStepDocuments
 	if err := s.needsRepo.UpdateNeed(ctx, need.ID, need); err != nil {
+		if errors.Is(err, types.ErrNeedVersionConflict) {
+			s.redirectProfileNeedEditDocsWithError(w, r, needID, "This need changed since you opened it. Check your documents below and continue again.")
+			return
+		}
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to update need step")
 		s.internalServerError(w)
 		return
 	}
 
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepDocuments)
+	s.recordNeedRevision(ctx, need.ID, need.UserID, types.NeedRevisionSourceDocuments)
 	http.Redirect(w, r, s.route(RouteProfileNeedEditReview, Param("needID", needID)), http.StatusSeeOther)
 }
 
@@ -167,7 +175,8 @@ func (s *Service) handlePostProfileNeedEditDocumentMetadata(w http.ResponseWrite
 	ctx := r.Context()
 	needID := strings.TrimSpace(r.PathValue("needID"))
 
-	if _, err := s.profileEditableNeed(ctx, needID); err != nil {
+	need, err := s.profileEditableNeed(ctx, needID)
+	if err != nil {
 		s.handleProfileEditableNeedError(w, r, needID, err)
 		return
 	}
@@ -221,6 +230,7 @@ func (s *Service) handlePostProfileNeedEditDocumentMetadata(w http.ResponseWrite
 		}
 	}
 
+	s.recordNeedRevision(ctx, needID, need.UserID, types.NeedRevisionSourceDocuments)
 	s.redirectProfileNeedEditDocsWithNotice(w, r, needID, "Document metadata updated just now.")
 }
 
@@ -229,7 +239,8 @@ func (s *Service) handlePostProfileNeedEditDocumentDelete(w http.ResponseWriter,
 	needID := strings.TrimSpace(r.PathValue("needID"))
 	documentID := strings.TrimSpace(r.PathValue("documentID"))
 
-	if _, err := s.profileEditableNeed(ctx, needID); err != nil {
+	need, err := s.profileEditableNeed(ctx, needID)
+	if err != nil {
 		s.handleProfileEditableNeedError(w, r, needID, err)
 		return
 	}
@@ -259,6 +270,7 @@ func (s *Service) handlePostProfileNeedEditDocumentDelete(w http.ResponseWriter,
 		return
 	}
 
+	s.recordNeedRevision(ctx, needID, need.UserID, types.NeedRevisionSourceDocuments)
 	s.redirectProfileNeedEditDocsWithNotice(w, r, needID, "Document removed.")
 }
 
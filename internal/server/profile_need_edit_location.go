@@ -1,11 +1,15 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"christjesus/internal/store"
 	"christjesus/internal/utils"
 	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s *Service) handleGetProfileNeedEditLocation(w http.ResponseWriter, r *http.Request) {
@@ -49,22 +53,42 @@ func (s *Service) handleGetProfileNeedEditLocation(w http.ResponseWriter, r *htt
 		}
 	}
 
-	data := &types.NeedLocationPageData{
+	data := s.profileNeedEditLocationPageData(need, addresses, selectedAddressID, &types.UserAddressForm{}, "")
+	data.ShowSetPrimary = showSetSelectedPrimary
+
+	if err := s.renderTemplate(w, r, "page.onboarding.need.location", data); err != nil {
+		s.logger.WithError(err).Error("failed to render profile need location edit page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) profileNeedEditLocationPageData(need *types.Need, addresses []*types.UserAddress, selectedAddressID string, newAddress *types.UserAddressForm, errorMessage string) *types.NeedLocationPageData {
+	return &types.NeedLocationPageData{
 		BasePageData:      types.BasePageData{Title: "Edit Need Location"},
-		ID:                needID,
+		ID:                need.ID,
 		Addresses:         addresses,
 		HasAddresses:      len(addresses) > 0,
 		SelectedAddressID: selectedAddressID,
-		ShowSetPrimary:    showSetSelectedPrimary,
-		NewAddress:        &types.UserAddressForm{},
-		FormAction:        s.route(RouteProfileNeedEditLocation, Param("needID", needID)),
-		BackHref:          s.route(RouteProfileNeedReview, Param("needID", needID)),
+		NewAddress:        newAddress,
+		NeedVersion:       need.Version,
+		FormAction:        s.route(RouteProfileNeedEditLocation, Param("needID", need.ID)),
+		BackHref:          s.route(RouteProfileNeedReview, Param("needID", need.ID)),
+		Error:             errorMessage,
+	}
+}
+
+// renderProfileNeedEditLocationConflict re-renders the location step after a
+// conflicting save, keeping the address the owner picked selected.
+func (s *Service) renderProfileNeedEditLocationConflict(w http.ResponseWriter, r *http.Request, need *types.Need, addresses []*types.UserAddress, selectedAddressID string, newAddress *types.UserAddressForm) {
+	if newAddress == nil {
+		newAddress = &types.UserAddressForm{}
 	}
 
+	data := s.profileNeedEditLocationPageData(need, addresses, selectedAddressID, newAddress, needEditConflictMessage)
 	if err := s.renderTemplate(w, r, "page.onboarding.need.location", data); err != nil {
-		s.logger.WithError(err).Error("failed to render profile need location edit page")
+		s.logger.WithError(err).Error("failed to render profile need location edit page with conflict notice")
 		s.internalServerError(w)
-		return
 	}
 }
 
@@ -106,7 +130,21 @@ func (s *Service) handlePostProfileNeedEditLocation(w http.ResponseWriter, r *ht
 		selection = "new"
 	}
 
+	if needEditFormStale(r, need) {
+		newAddress := new(types.UserAddressForm)
+		if selection == "new" {
+			if err := decoder.Decode(newAddress, r.Form); err != nil {
+				s.logger.WithError(err).Error("failed to decode form onto location form")
+				s.internalServerError(w)
+				return
+			}
+		}
+		s.renderProfileNeedEditLocationConflict(w, r, need, addresses, selection, newAddress)
+		return
+	}
+
 	var selectedAddress *types.UserAddress
+	var newLocation *types.UserAddressForm
 	usesNonPrimaryAddress := false
 
 	if selection != "new" {
@@ -170,24 +208,14 @@ func (s *Service) handlePostProfileNeedEditLocation(w http.ResponseWriter, r *ht
 			City:                 location.City,
 			State:                location.State,
 			ZipCode:              location.ZipCode,
-			PrivacyDisplay:       location.PrivacyDisplay,
+			PrivacyDisplay:       utils.StringPtr(normalizeAddressPrivacy(location.PrivacyDisplay)),
 			ContactMethods:       location.ContactMethods,
 			PreferredContactTime: location.PreferredContactTime,
 			IsPrimary:            setNewAsPrimary,
 		}
 
 		if validationErr := s.validateAndStandardizeAddress(ctx, selectedAddress); validationErr != "" {
-			data := &types.NeedLocationPageData{
-				BasePageData:      types.BasePageData{Title: "Edit Need Location"},
-				ID:                needID,
-				Addresses:         addresses,
-				HasAddresses:      len(addresses) > 0,
-				SelectedAddressID: "new",
-				NewAddress:        location,
-				FormAction:        s.route(RouteProfileNeedEditLocation, Param("needID", needID)),
-				BackHref:          s.route(RouteProfileNeedReview, Param("needID", needID)),
-				Error:             validationErr,
-			}
+			data := s.profileNeedEditLocationPageData(need, addresses, "new", location, validationErr)
 			if err := s.renderTemplate(w, r, "page.onboarding.need.location", data); err != nil {
 				s.logger.WithError(err).Error("failed to render profile need location edit page with validation error")
 				s.internalServerError(w)
@@ -195,13 +223,7 @@ func (s *Service) handlePostProfileNeedEditLocation(w http.ResponseWriter, r *ht
 			return
 		}
 
-		err = s.userAddressRepo.Create(ctx, selectedAddress)
-		if err != nil {
-			s.logger.WithError(err).WithField("user_id", userID).Error("failed to create user address")
-			s.internalServerError(w)
-			return
-		}
-
+		newLocation = location
 		usesNonPrimaryAddress = !setNewAsPrimary
 	}
 
@@ -209,13 +231,39 @@ func (s *Service) handlePostProfileNeedEditLocation(w http.ResponseWriter, r *ht
 	need.UserAddressID = &selectedAddress.ID
 	need.UsesNonPrimaryAddress = usesNonPrimaryAddress
 
-	err = s.needsRepo.UpdateNeed(ctx, needID, need)
+	err = store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if newLocation != nil {
+			if err := s.userAddressRepo.CreateTx(ctx, tx, selectedAddress); err != nil {
+				return err
+			}
+		}
+		return s.needsRepo.UpdateNeedTx(ctx, tx, needID, need)
+	})
 	if err != nil {
+		if errors.Is(err, types.ErrNeedVersionConflict) {
+			if err := s.refreshNeedVersion(ctx, need); err != nil {
+				s.logger.WithError(err).WithField("need_id", needID).Error("failed to reload need after location conflict")
+				s.internalServerError(w)
+				return
+			}
+			if addresses, err = s.userAddressRepo.AddressesByUserID(ctx, userID); err != nil {
+				s.logger.WithError(err).WithField("user_id", userID).Error("failed to fetch user addresses")
+				s.internalServerError(w)
+				return
+			}
+			if newLocation != nil {
+				s.renderProfileNeedEditLocationConflict(w, r, need, addresses, "new", newLocation)
+				return
+			}
+			s.renderProfileNeedEditLocationConflict(w, r, need, addresses, selectedAddress.ID, nil)
+			return
+		}
 		s.logger.WithError(err).Error("failed to update need with location data")
 		s.internalServerError(w)
 		return
 	}
 
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepLocation)
+	s.recordNeedRevision(ctx, need.ID, userID, types.NeedRevisionSourceLocation)
 	http.Redirect(w, r, s.route(RouteProfileNeedEditCategories, Param("needID", need.ID)), http.StatusSeeOther)
 }
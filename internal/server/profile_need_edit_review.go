@@ -9,7 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"christjesus/internal/store"
 	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type needReviewCoreData struct {
@@ -19,6 +22,7 @@ type needReviewCoreData struct {
 	SecondaryCategories []*types.NeedCategory
 	SelectedAddress     *types.UserAddress
 	Documents           []types.ReviewDocument
+	LineItems           []*types.NeedLineItemView
 }
 
 type needReviewSharedData struct {
@@ -54,13 +58,16 @@ func (s *Service) handleGetProfileNeedEditReview(w http.ResponseWriter, r *http.
 		PrimaryCategory:     core.PrimaryCategory,
 		SecondaryCategories: core.SecondaryCategories,
 		Documents:           core.Documents,
+		LineItems:           core.LineItems,
 		EditLocationHref:    s.route(RouteProfileNeedEditLocation, Param("needID", needID)),
 		EditCategoriesHref:  s.route(RouteProfileNeedEditCategories, Param("needID", needID)),
 		EditStoryHref:       s.route(RouteProfileNeedEditStory, Param("needID", needID)),
+		EditBudgetHref:      s.route(RouteProfileNeedEditBudget, Param("needID", needID)),
 		EditDocumentsHref:   s.route(RouteProfileNeedEditDocs, Param("needID", needID)),
 		SubmitAction:        s.route(RouteProfileNeedEditReview, Param("needID", needID)),
 		BackHref:            s.route(RouteProfileNeedEditDocs, Param("needID", needID)),
 		SubmitLabel:         "Submit Updated Need",
+		NeedVersion:         core.Need.Version,
 		Notice:              strings.TrimSpace(r.URL.Query().Get("notice")),
 		Error:               strings.TrimSpace(r.URL.Query().Get("error")),
 	}
@@ -95,21 +102,55 @@ func (s *Service) handlePostProfileNeedEditReview(w http.ResponseWriter, r *http
 		return
 	}
 
+	if needEditFormStale(r, need) {
+		s.redirectProfileNeedEditReviewConflict(w, r, needID)
+		return
+	}
+
+	if !s.ensureNeedLineItemsMatchGoal(w, r, need, RouteProfileNeedEditReview) {
+		return
+	}
+
 	now := time.Now()
 	need.CurrentStep = types.NeedStepReview
-	need.Status = types.NeedStatusReadyForReview
 	need.SubmittedAt = &now
 
-	if err := s.needsRepo.UpdateNeed(ctx, need.ID, need); err != nil {
+	err = store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if err := s.needsRepo.UpdateNeedTx(ctx, tx, need.ID, need); err != nil {
+			return err
+		}
+		return s.needsRepo.TransitionNeedStatusTx(ctx, tx, need.ID, types.NeedStatusReadyForReview, types.NeedProgressEventSourceUser, need.UserID)
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrNeedVersionConflict) {
+			s.redirectProfileNeedEditReviewConflict(w, r, needID)
+			return
+		}
+		if message, ok := needTransitionErrorMessage(err); ok {
+			s.redirectProfileNeedReviewWithError(w, r, needID, message)
+			return
+		}
 		s.logger.WithError(err).WithField("need_id", needID).Error("failed to update need status on profile edit submit")
 		s.internalServerError(w)
 		return
 	}
+	need.Status = types.NeedStatusReadyForReview
 
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepReview)
+	s.recordNeedRevision(ctx, need.ID, need.UserID, types.NeedRevisionSourceResubmitted)
+	s.detectNeedFlags(ctx, need)
 	s.redirectProfileNeedReviewWithNotice(w, r, needID, "Updated need submitted for review.")
 }
 
+// redirectProfileNeedEditReviewConflict sends the owner back to the review
+// step, which reloads the latest details and version, when someone changed the
+// need after the page was opened.
+func (s *Service) redirectProfileNeedEditReviewConflict(w http.ResponseWriter, r *http.Request, needID string) {
+	q := url.Values{}
+	q.Set("error", "This need changed since you opened it. Check the latest details below and submit again.")
+	http.Redirect(w, r, s.routeWithQuery(RouteProfileNeedEditReview, q, Param("needID", needID)), http.StatusSeeOther)
+}
+
 func (s *Service) profileEditableNeed(ctx context.Context, needID string) (*types.Need, error) {
 	need, err := s.needsRepo.Need(ctx, needID)
 	if err != nil {
@@ -157,6 +198,11 @@ func (s *Service) loadNeedReviewCoreData(ctx context.Context, needID string) (*n
 		reviewDocs = append(reviewDocs, types.ReviewDocument{ID: doc.ID, FileName: doc.FileName, TypeLabel: documentTypeLabel(doc.DocumentType), SizeBytes: doc.FileSizeBytes, UploadedAt: doc.UploadedAt})
 	}
 
+	lineItems, err := s.loadNeedLineItemViews(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &needReviewCoreData{
 		Need:                shared.Need,
 		Story:               shared.Story,
@@ -164,6 +210,7 @@ func (s *Service) loadNeedReviewCoreData(ctx context.Context, needID string) (*n
 		SecondaryCategories: shared.SecondaryCategories,
 		SelectedAddress:     selectedAddress,
 		Documents:           reviewDocs,
+		LineItems:           lineItems,
 	}, nil
 }
 
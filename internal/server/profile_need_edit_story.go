@@ -1,11 +1,15 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"christjesus/internal/store"
 	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s *Service) handleGetProfileNeedEditStory(w http.ResponseWriter, r *http.Request) {
@@ -18,6 +22,22 @@ func (s *Service) handleGetProfileNeedEditStory(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	story, err := s.storyRepo.GetStoryByNeedID(ctx, needID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch story")
+		s.internalServerError(w)
+		return
+	}
+
+	s.renderProfileNeedEditStory(w, r, need, story, "")
+}
+
+// renderProfileNeedEditStory renders the story step for need with the given
+// story, which is the submitted one when re-rendering after a conflict.
+func (s *Service) renderProfileNeedEditStory(w http.ResponseWriter, r *http.Request, need *types.Need, story *types.NeedStory, errorMessage string) {
+	ctx := r.Context()
+	needID := need.ID
+
 	var primaryCategory *types.NeedCategory
 	assignments, err := s.needCategoryAssignmentsRepo.GetAssignmentsByNeedID(ctx, needID)
 	if err != nil {
@@ -40,21 +60,16 @@ func (s *Service) handleGetProfileNeedEditStory(w http.ResponseWriter, r *http.R
 		break
 	}
 
-	story, err := s.storyRepo.GetStoryByNeedID(ctx, needID)
-	if err != nil {
-		s.logger.WithError(err).Error("failed to fetch story")
-		s.internalServerError(w)
-		return
-	}
-
 	data := &types.NeedStoryPageData{
 		BasePageData:      types.BasePageData{Title: "Edit Need Story"},
 		ID:                needID,
 		AmountNeededCents: need.AmountNeededCents,
 		PrimaryCategory:   primaryCategory,
 		Story:             story,
+		NeedVersion:       need.Version,
 		FormAction:        s.route(RouteProfileNeedEditStory, Param("needID", needID)),
 		BackHref:          s.route(RouteProfileNeedEditCategories, Param("needID", needID)),
+		Error:             errorMessage,
 	}
 
 	if err := s.renderTemplate(w, r, "page.onboarding.need.story", data); err != nil {
@@ -95,25 +110,34 @@ func (s *Service) handlePostProfileNeedEditStory(w http.ResponseWriter, r *http.
 		}
 	}
 
-	if err := s.needsRepo.UpdateNeed(ctx, need.ID, need); err != nil {
-		s.logger.WithError(err).Error("failed to update need with amount")
-		s.internalServerError(w)
-		return
-	}
-
-	if err := s.storyRepo.UpsertStory(ctx, story); err != nil {
-		s.logger.WithError(err).Error("failed to upsert story")
-		s.internalServerError(w)
+	if needEditFormStale(r, need) {
+		s.renderProfileNeedEditStory(w, r, need, story, needEditConflictMessage)
 		return
 	}
 
 	need.CurrentStep = types.NeedStepStory
-	if err := s.needsRepo.UpdateNeed(ctx, need.ID, need); err != nil {
-		s.logger.WithError(err).Error("failed to update need step")
+	err = store.WithTx(ctx, s.needsRepo, func(tx pgx.Tx) error {
+		if err := s.needsRepo.UpdateNeedTx(ctx, tx, need.ID, need); err != nil {
+			return err
+		}
+		return s.storyRepo.UpsertStoryTx(ctx, tx, story)
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrNeedVersionConflict) {
+			if err := s.refreshNeedVersion(ctx, need); err != nil {
+				s.logger.WithError(err).WithField("need_id", needID).Error("failed to reload need after story conflict")
+				s.internalServerError(w)
+				return
+			}
+			s.renderProfileNeedEditStory(w, r, need, story, needEditConflictMessage)
+			return
+		}
+		s.logger.WithError(err).Error("failed to save need story")
 		s.internalServerError(w)
 		return
 	}
 
 	s.recordNeedProgress(ctx, need.ID, types.NeedStepStory)
-	http.Redirect(w, r, s.route(RouteProfileNeedEditDocs, Param("needID", need.ID)), http.StatusSeeOther)
+	s.recordNeedRevision(ctx, need.ID, need.UserID, types.NeedRevisionSourceStory)
+	http.Redirect(w, r, s.route(RouteProfileNeedEditBudget, Param("needID", need.ID)), http.StatusSeeOther)
 }
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"christjesus/pkg/types"
+)
+
+func TestNeedEditFormStale(t *testing.T) {
+	need := &types.Need{ID: "need-1", Version: 4}
+
+	cases := map[string]bool{
+		"":     true,
+		"4":    false,
+		"3":    true,
+		"5":    true,
+		"four": true,
+		" 4 ":  false,
+	}
+
+	for version, want := range cases {
+		form := url.Values{}
+		if version != "" {
+			form.Set("version", version)
+		}
+		r := httptest.NewRequest("POST", "/profile/needs/need-1/edit/story", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		if got := needEditFormStale(r, need); got != want {
+			t.Fatalf("version %q: got %v want %v", version, got, want)
+		}
+	}
+}
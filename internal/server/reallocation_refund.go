@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"christjesus/pkg/types"
+
+	"github.com/stripe/stripe-go/v84"
+)
+
+const recentReallocationRefundsLimit = 20
+
+// buildAdminDonationRefundViews renders opted-out shares for the refund queue
+// on the reallocations page. Refund actions are only set on shares still owed.
+func (s *Service) buildAdminDonationRefundViews(ctx context.Context, refunds []*types.DonationReallocationRefund) ([]*types.AdminDonationRefundView, error) {
+	userIDs := make([]string, 0, len(refunds)*2)
+	for _, refund := range refunds {
+		if refund.DonorUserID != nil {
+			userIDs = append(userIDs, *refund.DonorUserID)
+		}
+		if refund.RefundedByUserID != nil {
+			userIDs = append(userIDs, *refund.RefundedByUserID)
+		}
+	}
+
+	nameByUserID := make(map[string]string, len(userIDs))
+	if len(userIDs) > 0 {
+		users, err := s.userRepo.UsersByIDs(ctx, uniqueSortedStrings(userIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			nameByUserID[user.ID] = userDisplayName(user)
+		}
+	}
+
+	views := make([]*types.AdminDonationRefundView, 0, len(refunds))
+	for _, refund := range refunds {
+		view := &types.AdminDonationRefundView{
+			ID:           refund.ID,
+			NeedID:       refund.NeedID,
+			NeedHref:     s.route(RouteAdminNeedReview, Param("needID", refund.NeedID)),
+			Donor:        "Guest donor",
+			Amount:       formatUSDFromCents(refund.AmountCents),
+			OptedOutAt:   "-",
+			RefundedAt:   formatOptionalDateTime(refund.RefundedAt),
+			RefundedBy:   "-",
+			Reference:    "Refunded outside Stripe",
+			CanUseStripe: s.stripeClient != nil && refund.PaymentIntentID != nil,
+		}
+		if refund.DonorUserID != nil && nameByUserID[*refund.DonorUserID] != "" {
+			view.Donor = nameByUserID[*refund.DonorUserID]
+		}
+		if refund.RefundedByUserID != nil && nameByUserID[*refund.RefundedByUserID] != "" {
+			view.RefundedBy = nameByUserID[*refund.RefundedByUserID]
+		}
+		if refund.StripeRefundID != nil {
+			view.Reference = *refund.StripeRefundID
+		}
+		if refund.Status == types.DonationReallocationStatusOptedOut {
+			// Opted-out shares are not touched again until they are refunded.
+			view.OptedOutAt = refund.UpdatedAt.Format("2006-01-02 15:04")
+			view.RefundAction = s.route(RouteAdminReallocationRefund, Param("allocationID", refund.ID))
+		}
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+// handlePostAdminReallocationRefund pays an opted-out share back to the donor,
+// either through Stripe against the original payment or, for gifts settled
+// another way, by recording a refund an admin made by hand.
+func (s *Service) handlePostAdminReallocationRefund(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	allocationID := strings.TrimSpace(r.PathValue("allocationID"))
+	if allocationID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminReallocationsWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.internalServerError(w)
+		return
+	}
+
+	refund, err := s.fundReallocationRepo.RefundOwed(ctx, allocationID)
+	if err != nil {
+		if errors.Is(err, types.ErrFundReallocationNotFound) {
+			s.redirectAdminReallocationsWithError(w, r, "this share is not waiting for a refund")
+			return
+		}
+		s.logger.WithError(err).WithField("allocation_id", allocationID).Error("failed to fetch refund before issuing it")
+		s.internalServerError(w)
+		return
+	}
+
+	var stripeRefundID *string
+	switch strings.TrimSpace(r.FormValue("method")) {
+	case "stripe":
+		if s.stripeClient == nil || refund.PaymentIntentID == nil {
+			s.redirectAdminReallocationsWithError(w, r, "this gift has no Stripe payment to refund; refund it by hand and mark it refunded")
+			return
+		}
+
+		params := &stripe.RefundCreateParams{
+			PaymentIntent: refund.PaymentIntentID,
+			Amount:        stripe.Int64(int64(refund.AmountCents)),
+			Reason:        stripe.String(string(stripe.RefundReasonRequestedByCustomer)),
+			Metadata: map[string]string{
+				"donation_intent_id":       refund.DonationIntentID,
+				"donation_reallocation_id": refund.ID,
+			},
+		}
+		params.SetIdempotencyKey("donation-reallocation-refund-" + refund.ID)
+
+		stripeRefund, err := s.stripeClient.V1Refunds.Create(ctx, params)
+		if err != nil {
+			s.logger.WithError(err).WithField("allocation_id", allocationID).Error("failed to create stripe refund")
+			s.redirectAdminReallocationsWithError(w, r, "Stripe did not accept the refund; try again or refund it by hand")
+			return
+		}
+		stripeRefundID = &stripeRefund.ID
+	case "manual":
+	default:
+		s.redirectAdminReallocationsWithError(w, r, "unknown refund method")
+		return
+	}
+
+	if err := s.fundReallocationRepo.MarkDonationReallocationRefunded(ctx, refund.ID, session.UserID, stripeRefundID); err != nil {
+		if errors.Is(err, types.ErrFundReallocationNotFound) {
+			s.redirectAdminReallocationsWithError(w, r, "this share was already refunded")
+			return
+		}
+		s.logger.WithError(err).WithField("allocation_id", allocationID).Error("failed to record refund")
+		s.internalServerError(w)
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", formatUSDFromCents(refund.AmountCents)+" refunded to the donor")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminReallocations, v), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminReallocationsWithError(w http.ResponseWriter, r *http.Request, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminReallocations, v), http.StatusSeeOther)
+}
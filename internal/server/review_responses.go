@@ -0,0 +1,264 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+const (
+	maxReviewResponseTitleLength  = 120
+	maxReviewResponseReasonLength = 500
+	maxReviewResponseNoteLength   = 2000
+)
+
+func (s *Service) handleGetAdminReviewResponses(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.reviewResponseRepo.Templates(r.Context(), false)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to fetch review response templates")
+		s.internalServerError(w)
+		return
+	}
+
+	items := make([]*types.AdminReviewResponseItem, 0, len(templates))
+	for _, template := range templates {
+		action := reviewResponseFormAction(template.ActionType)
+		items = append(items, &types.AdminReviewResponseItem{
+			ID:            template.ID,
+			Action:        action,
+			ActionLabel:   reviewResponseActionLabel(action),
+			Title:         template.Title,
+			Reason:        template.Reason,
+			Note:          strings.TrimSpace(derefString(template.Note)),
+			IsArchived:    template.ArchivedAt != nil,
+			UpdatedAt:     template.UpdatedAt.Format(time.DateOnly),
+			UpdateAction:  s.route(RouteAdminReviewResponseUpdate, Param("templateID", template.ID)),
+			ArchiveAction: s.route(RouteAdminReviewResponseArchive, Param("templateID", template.ID)),
+		})
+	}
+
+	data := &types.AdminReviewResponsesPageData{
+		BasePageData:  types.BasePageData{Title: "Review Responses"},
+		Templates:     items,
+		ActionOptions: reviewResponseActionOptions(),
+		CreateAction:  s.route(RouteAdminReviewResponseCreate),
+		BackHref:      s.route(RouteAdmin),
+		Notice:        strings.TrimSpace(r.URL.Query().Get("notice")),
+		Error:         strings.TrimSpace(r.URL.Query().Get("error")),
+	}
+
+	if err := s.renderTemplate(w, r, "page.admin.review.responses", data); err != nil {
+		s.logger.WithError(err).Error("failed to render review responses page")
+		s.internalServerError(w)
+		return
+	}
+}
+
+func (s *Service) handlePostAdminReviewResponseCreate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminReviewResponsesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminReviewResponsesWithError(w, r, "missing actor identity")
+		return
+	}
+
+	template, message := parseReviewResponseForm(r)
+	if message != "" {
+		s.redirectAdminReviewResponsesWithError(w, r, message)
+		return
+	}
+
+	actorUserID := session.UserID
+	template.CreatedByUserID = &actorUserID
+
+	if err := s.reviewResponseRepo.CreateTemplate(r.Context(), template); err != nil {
+		s.logger.WithError(err).Error("failed to create review response template")
+		s.redirectAdminReviewResponsesWithError(w, r, "failed to save response")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Response \""+template.Title+"\" added")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminReviewResponses, v), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminReviewResponseUpdate(w http.ResponseWriter, r *http.Request) {
+	templateID := strings.TrimSpace(r.PathValue("templateID"))
+	if templateID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminReviewResponsesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminReviewResponsesWithError(w, r, "missing actor identity")
+		return
+	}
+
+	template, message := parseReviewResponseForm(r)
+	if message != "" {
+		s.redirectAdminReviewResponsesWithError(w, r, message)
+		return
+	}
+
+	actorUserID := session.UserID
+	template.ID = templateID
+	template.UpdatedByUserID = &actorUserID
+
+	if err := s.reviewResponseRepo.UpdateTemplate(r.Context(), template); err != nil {
+		if errors.Is(err, types.ErrReviewResponseTemplateNotFound) {
+			s.redirectAdminReviewResponsesWithError(w, r, "response not found")
+			return
+		}
+		s.logger.WithError(err).WithField("template_id", templateID).Error("failed to update review response template")
+		s.redirectAdminReviewResponsesWithError(w, r, "failed to save response")
+		return
+	}
+
+	v := url.Values{}
+	v.Set("notice", "Response \""+template.Title+"\" updated")
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminReviewResponses, v), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostAdminReviewResponseArchive(w http.ResponseWriter, r *http.Request) {
+	templateID := strings.TrimSpace(r.PathValue("templateID"))
+	if templateID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminReviewResponsesWithError(w, r, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminReviewResponsesWithError(w, r, "missing actor identity")
+		return
+	}
+
+	archived := r.FormValue("archived") != "0"
+	if err := s.reviewResponseRepo.SetTemplateArchived(r.Context(), templateID, session.UserID, archived); err != nil {
+		if errors.Is(err, types.ErrReviewResponseTemplateNotFound) {
+			s.redirectAdminReviewResponsesWithError(w, r, "response not found")
+			return
+		}
+		s.logger.WithError(err).WithField("template_id", templateID).Error("failed to archive review response template")
+		s.redirectAdminReviewResponsesWithError(w, r, "failed to update response")
+		return
+	}
+
+	v := url.Values{}
+	if archived {
+		v.Set("notice", "Response archived")
+	} else {
+		v.Set("notice", "Response restored")
+	}
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminReviewResponses, v), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminReviewResponsesWithError(w http.ResponseWriter, r *http.Request, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminReviewResponses, v), http.StatusSeeOther)
+}
+
+// parseReviewResponseForm reads a template from the create or edit form. The
+// returned message is non-empty when the form is invalid.
+func parseReviewResponseForm(r *http.Request) (*types.ReviewResponseTemplate, string) {
+	actionType, ok := reviewResponseActionType(r.FormValue("action"))
+	if !ok {
+		return nil, "choose request changes or reject"
+	}
+
+	title := strings.TrimSpace(r.FormValue("title"))
+	if title == "" {
+		return nil, "title is required"
+	}
+	if len([]rune(title)) > maxReviewResponseTitleLength {
+		return nil, "title is too long"
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		return nil, "reason is required"
+	}
+	if len([]rune(reason)) > maxReviewResponseReasonLength {
+		return nil, "reason is too long"
+	}
+
+	template := &types.ReviewResponseTemplate{
+		ActionType: actionType,
+		Title:      title,
+		Reason:     reason,
+	}
+
+	if note := strings.TrimSpace(r.FormValue("note")); note != "" {
+		if len([]rune(note)) > maxReviewResponseNoteLength {
+			return nil, "note is too long"
+		}
+		template.Note = &note
+	}
+
+	return template, ""
+}
+
+// reviewResponseActionType maps a moderation form action to the action type a
+// response template is stored under. Only request_changes and reject have
+// canned responses.
+func reviewResponseActionType(action string) (types.NeedModerationActionType, bool) {
+	switch strings.TrimSpace(action) {
+	case "request_changes":
+		return types.NeedModerationActionTypeChangesRequested, true
+	case "reject":
+		return types.NeedModerationActionTypeReviewRejected, true
+	default:
+		return "", false
+	}
+}
+
+func reviewResponseFormAction(actionType types.NeedModerationActionType) string {
+	switch actionType {
+	case types.NeedModerationActionTypeChangesRequested:
+		return "request_changes"
+	case types.NeedModerationActionTypeReviewRejected:
+		return "reject"
+	default:
+		return string(actionType)
+	}
+}
+
+func reviewResponseActionLabel(action string) string {
+	switch action {
+	case "request_changes":
+		return "Request Changes"
+	case "reject":
+		return "Reject"
+	default:
+		return action
+	}
+}
+
+func reviewResponseActionOptions() []types.AdminExplorerOption {
+	return []types.AdminExplorerOption{
+		{Value: "request_changes", Label: reviewResponseActionLabel("request_changes")},
+		{Value: "reject", Label: reviewResponseActionLabel("reject")},
+	}
+}
@@ -10,45 +10,94 @@ import (
 type RouteName string
 
 const (
-	RouteHome                      RouteName = "home"
-	RouteRegister                  RouteName = "register"
-	RouteRegisterConfirm           RouteName = "register.confirm"
-	RouteRegisterConfirmResend     RouteName = "register.confirm.resend"
-	RouteLogin                     RouteName = "login"
-	RouteAuthCallback              RouteName = "auth.callback"
-	RouteLogout                    RouteName = "logout"
-	RouteProfile                   RouteName = "profile"
-	RouteAdmin                     RouteName = "admin.dashboard"
-	RouteAdminNeeds                RouteName = "admin.needs"
-	RouteAdminNeedExplorer         RouteName = "admin.need.explorer"
-	RouteAdminNeedReview           RouteName = "admin.need.review"
-	RouteAdminNeedModerate         RouteName = "admin.need.moderate"
-	RouteAdminNeedDocument         RouteName = "admin.need.document"
-	RouteAdminNeedDelete           RouteName = "admin.need.delete"
-	RouteAdminNeedRestore          RouteName = "admin.need.restore"
-	RouteAdminNeedMessage          RouteName = "admin.need.message"
-	RouteAdminUsers                RouteName = "admin.users"
-	RouteAdminUserDetail           RouteName = "admin.user.detail"
-	RouteProfileNeedDelete         RouteName = "profile.need.delete"
-	RouteProfileNeedReview         RouteName = "profile.need.review"
-	RouteProfileNeedReviewPost     RouteName = "profile.need.review.post"
-	RouteProfileNeedReviewSetReady RouteName = "profile.need.review.set.ready"
-	RouteProfileNeedReviewPullBack RouteName = "profile.need.review.pull.back"
-	RouteProfileNeedDocumentView   RouteName = "profile.need.document.view"
-	RouteProfileNeedEdit           RouteName = "profile.need.edit"
-	RouteProfileNeedEditLocation   RouteName = "profile.need.edit.location"
-	RouteProfileNeedEditCategories RouteName = "profile.need.edit.categories"
-	RouteProfileNeedEditStory      RouteName = "profile.need.edit.story"
-	RouteProfileNeedEditDocs       RouteName = "profile.need.edit.documents"
-	RouteProfileNeedEditUpload     RouteName = "profile.need.edit.documents.upload"
-	RouteProfileNeedEditMeta       RouteName = "profile.need.edit.documents.meta"
-	RouteProfileNeedEditDelete     RouteName = "profile.need.edit.documents.delete"
-	RouteProfileNeedEditReview     RouteName = "profile.need.edit.review"
-	RouteProfileDonationReceipt    RouteName = "profile.donation.receipt"
-	RouteProfileUpdateName             RouteName = "profile.update.name"
-	RouteProfileUpdateEmail            RouteName = "profile.update.email"
-	RouteProfileSendPasswordReset      RouteName = "profile.send.password.reset"
-	RouteProfileDonorPreferences       RouteName = "profile.donor.preferences"
+	RouteHome                              RouteName = "home"
+	RouteRegister                          RouteName = "register"
+	RouteRegisterConfirm                   RouteName = "register.confirm"
+	RouteRegisterConfirmResend             RouteName = "register.confirm.resend"
+	RouteLogin                             RouteName = "login"
+	RouteAuthCallback                      RouteName = "auth.callback"
+	RouteLogout                            RouteName = "logout"
+	RouteProfile                           RouteName = "profile"
+	RouteAdmin                             RouteName = "admin.dashboard"
+	RouteAdminNeeds                        RouteName = "admin.needs"
+	RouteAdminNeedExplorer                 RouteName = "admin.need.explorer"
+	RouteAdminNeedsSecondApproval          RouteName = "admin.needs.second_approval"
+	RouteAdminNeedExplorerBulk             RouteName = "admin.need.explorer.bulk"
+	RouteAdminNeedExplorerExport           RouteName = "admin.need.explorer.export"
+	RouteAdminNeedReview                   RouteName = "admin.need.review"
+	RouteAdminNeedModerate                 RouteName = "admin.need.moderate"
+	RouteAdminNeedDocument                 RouteName = "admin.need.document"
+	RouteAdminNeedDelete                   RouteName = "admin.need.delete"
+	RouteAdminNeedRestore                  RouteName = "admin.need.restore"
+	RouteAdminNeedMessage                  RouteName = "admin.need.message"
+	RouteAdminNeedFlagDismiss              RouteName = "admin.need.flag.dismiss"
+	RouteAdminNeedGoalChangeDecide         RouteName = "admin.need.goal.change.decide"
+	RouteAdminNeedReallocationQueue        RouteName = "admin.need.reallocation.queue"
+	RouteAdminNeedReallocationPropose      RouteName = "admin.need.reallocation.propose"
+	RouteAdminNeedClaim                    RouteName = "admin.need.claim"
+	RouteAdminNeedRelease                  RouteName = "admin.need.release"
+	RouteAdminNeedReassign                 RouteName = "admin.need.reassign"
+	RouteAdminReallocations                RouteName = "admin.reallocations"
+	RouteAdminReallocationRefund           RouteName = "admin.reallocations.refund"
+	RouteAdminDonations                    RouteName = "admin.donations"
+	RouteAdminDonationsExport              RouteName = "admin.donations.export"
+	RouteAdminEmailEvents                  RouteName = "admin.email.events"
+	RouteAdminEmails                       RouteName = "admin.emails"
+	RouteAdminEmailMessage                 RouteName = "admin.email.message"
+	RouteAdminEmailMessageResend           RouteName = "admin.email.message.resend"
+	RouteAdminEmailSuppressions            RouteName = "admin.email.suppressions"
+	RouteAdminEmailSuppressionRemove       RouteName = "admin.email.suppression.remove"
+	RouteAdminRoles                        RouteName = "admin.roles"
+	RouteAdminReviewResponses              RouteName = "admin.review.responses"
+	RouteAdminReviewResponseCreate         RouteName = "admin.review.response.create"
+	RouteAdminReviewResponseUpdate         RouteName = "admin.review.response.update"
+	RouteAdminReviewResponseArchive        RouteName = "admin.review.response.archive"
+	RouteAdminRoleGrant                    RouteName = "admin.role.grant"
+	RouteAdminRoleRevoke                   RouteName = "admin.role.revoke"
+	RouteAdminFeaturing                    RouteName = "admin.featuring"
+	RouteAdminFeaturingCreate              RouteName = "admin.featuring.create"
+	RouteAdminFeaturingPosition            RouteName = "admin.featuring.position"
+	RouteAdminFeaturingEnd                 RouteName = "admin.featuring.end"
+	RouteAdminCategories                   RouteName = "admin.categories"
+	RouteAdminCategoryCreate               RouteName = "admin.category.create"
+	RouteAdminCategoryUpdate               RouteName = "admin.category.update"
+	RouteAdminCategoryActive               RouteName = "admin.category.active"
+	RouteAdminCategoryMove                 RouteName = "admin.category.move"
+	RouteAdminCategoryMerge                RouteName = "admin.category.merge"
+	RouteAdminAudit                        RouteName = "admin.audit"
+	RouteAdminAuditExport                  RouteName = "admin.audit.export"
+	RouteAdminUsers                        RouteName = "admin.users"
+	RouteAdminUsersExport                  RouteName = "admin.users.export"
+	RouteAdminUserDetail                   RouteName = "admin.user.detail"
+	RouteAdminUserRestrict                 RouteName = "admin.user.restrict"
+	RouteAdminUserRestrictionLift          RouteName = "admin.user.restriction.lift"
+	RouteAdminUserNoteCreate               RouteName = "admin.user.note.create"
+	RouteAdminNeedNoteCreate               RouteName = "admin.need.note.create"
+	RouteAdminNoteUpdate                   RouteName = "admin.note.update"
+	RouteAdminNotePin                      RouteName = "admin.note.pin"
+	RouteProfileNeedDelete                 RouteName = "profile.need.delete"
+	RouteProfileNeedReview                 RouteName = "profile.need.review"
+	RouteProfileNeedReviewPost             RouteName = "profile.need.review.post"
+	RouteProfileNeedReviewSetReady         RouteName = "profile.need.review.set.ready"
+	RouteProfileNeedReviewPullBack         RouteName = "profile.need.review.pull.back"
+	RouteProfileNeedGoalChange             RouteName = "profile.need.goal.change"
+	RouteProfileNeedDocumentView           RouteName = "profile.need.document.view"
+	RouteProfileNeedEdit                   RouteName = "profile.need.edit"
+	RouteProfileNeedEditLocation           RouteName = "profile.need.edit.location"
+	RouteProfileNeedEditCategories         RouteName = "profile.need.edit.categories"
+	RouteProfileNeedEditStory              RouteName = "profile.need.edit.story"
+	RouteProfileNeedEditBudget             RouteName = "profile.need.edit.budget"
+	RouteProfileNeedEditDocs               RouteName = "profile.need.edit.documents"
+	RouteProfileNeedEditUpload             RouteName = "profile.need.edit.documents.upload"
+	RouteProfileNeedEditMeta               RouteName = "profile.need.edit.documents.meta"
+	RouteProfileNeedEditDelete             RouteName = "profile.need.edit.documents.delete"
+	RouteProfileNeedEditReview             RouteName = "profile.need.edit.review"
+	RouteProfileDonationReceipt            RouteName = "profile.donation.receipt"
+	RouteProfileDonationReallocationOptOut RouteName = "profile.donation.reallocation.opt.out"
+	RouteProfileUpdateName                 RouteName = "profile.update.name"
+	RouteProfileUpdateEmail                RouteName = "profile.update.email"
+	RouteProfileSendPasswordReset          RouteName = "profile.send.password.reset"
+	RouteProfileDonorPreferences           RouteName = "profile.donor.preferences"
 
 	RouteOnboarding              RouteName = "onboarding"
 	RouteOnboardingAboutYou      RouteName = "onboarding.about.you"
@@ -61,11 +110,14 @@ const (
 	RouteOnboardingSponsorIndividual   RouteName = "onboarding.sponsor.individual.welcome"
 	RouteOnboardingSponsorOrganization RouteName = "onboarding.sponsor.organization.welcome"
 
+	RouteOnboardingNeedNew             RouteName = "onboarding.need.new"
+	RouteOnboardingNeedClone           RouteName = "onboarding.need.clone"
 	RouteOnboardingNeedWelcome         RouteName = "onboarding.need.welcome"
 	RouteOnboardingNeedLocation        RouteName = "onboarding.need.location"
 	RouteOnboardingNeedCategories      RouteName = "onboarding.need.categories"
 	RouteOnboardingNeedDetails         RouteName = "onboarding.need.details"
 	RouteOnboardingNeedStory           RouteName = "onboarding.need.story"
+	RouteOnboardingNeedBudget          RouteName = "onboarding.need.budget"
 	RouteOnboardingNeedDocuments       RouteName = "onboarding.need.documents"
 	RouteOnboardingNeedDocumentsUpload RouteName = "onboarding.need.documents.upload"
 	RouteOnboardingNeedDocumentsMeta   RouteName = "onboarding.need.documents.meta"
@@ -87,83 +139,141 @@ const (
 	RouteNeedDonateConfirmation RouteName = "need.donate.confirmation"
 	RouteNeedSave               RouteName = "need.save"
 	RouteNeedUnsave             RouteName = "need.unsave"
+	RouteNeedShareLink          RouteName = "need.share.link"
+	RouteNeedShareCard          RouteName = "need.share.card"
+	RouteNeedFlyer              RouteName = "need.flyer"
 	RouteStripeWebhook          RouteName = "stripe.webhook"
 	RouteResendWebhook          RouteName = "resend.webhook"
 )
 
 var routePatterns = map[RouteName]string{
-	RouteHome:                          "/",
-	RouteRegister:                      "/register",
-	RouteRegisterConfirm:               "/register/confirm",
-	RouteRegisterConfirmResend:         "/register/confirm/resend",
-	RouteLogin:                         "/login",
-	RouteAuthCallback:                  "/auth/callback",
-	RouteLogout:                        "/logout",
-	RouteProfile:                       "/profile",
-	RouteAdmin:                         "/admin",
-	RouteAdminNeeds:                    "/admin/needs",
-	RouteAdminNeedExplorer:             "/admin/needs/explorer",
-	RouteAdminNeedReview:               "/admin/needs/:needID",
-	RouteAdminNeedModerate:             "/admin/needs/:needID/moderate",
-	RouteAdminNeedDocument:             "/admin/needs/:needID/documents/:documentID",
-	RouteAdminNeedDelete:               "/admin/needs/:needID/delete",
-	RouteAdminNeedRestore:              "/admin/needs/:needID/restore",
-	RouteAdminNeedMessage:              "/admin/needs/:needID/messages",
-	RouteAdminUsers:                    "/admin/users",
-	RouteAdminUserDetail:               "/admin/users/:userID",
-	RouteProfileNeedDelete:             "/profile/needs/:needID/delete",
-	RouteProfileNeedReview:             "/profile/needs/:needID/review",
-	RouteProfileNeedReviewPost:         "/profile/needs/:needID/review/messages",
-	RouteProfileNeedReviewSetReady:     "/profile/needs/:needID/review/set-ready",
-	RouteProfileNeedReviewPullBack:     "/profile/needs/:needID/review/pull-back",
-	RouteProfileNeedDocumentView:       "/profile/needs/:needID/documents/:documentID",
-	RouteProfileNeedEdit:               "/profile/needs/:needID/edit",
-	RouteProfileNeedEditLocation:       "/profile/needs/:needID/edit/location",
-	RouteProfileNeedEditCategories:     "/profile/needs/:needID/edit/categories",
-	RouteProfileNeedEditStory:          "/profile/needs/:needID/edit/story",
-	RouteProfileNeedEditDocs:           "/profile/needs/:needID/edit/documents",
-	RouteProfileNeedEditUpload:         "/profile/needs/:needID/edit/documents/upload",
-	RouteProfileNeedEditMeta:           "/profile/needs/:needID/edit/documents/metadata",
-	RouteProfileNeedEditDelete:         "/profile/needs/:needID/edit/documents/:documentID/delete",
-	RouteProfileNeedEditReview:         "/profile/needs/:needID/edit/review",
-	RouteProfileDonationReceipt:        "/profile/donations/:intentID/receipt",
-	RouteProfileUpdateName:             "/profile/update/name",
-	RouteProfileUpdateEmail:            "/profile/update/email",
-	RouteProfileSendPasswordReset:      "/profile/send-password-reset",
-	RouteProfileDonorPreferences:       "/profile/preferences",
-	RouteOnboarding:                    "/onboarding",
-	RouteOnboardingAboutYou:            "/onboarding/about-you",
-	RouteOnboardingHowWeServeYou:       "/onboarding/how-we-serve-you",
-	RouteOnboardingDonorWelcome:      "/onboarding/donor/welcome",
-	RouteOnboardingDonorPreferences:  "/onboarding/donor/preferences",
-	RouteOnboardingDonorConfirmation: "/onboarding/donor/confirmation",
-	RouteOnboardingNeedWelcome:         "/onboarding/need/:needID/welcome",
-	RouteOnboardingNeedLocation:        "/onboarding/need/:needID/location",
-	RouteOnboardingNeedCategories:      "/onboarding/need/:needID/categories",
-	RouteOnboardingNeedDetails:         "/onboarding/need/:needID/details",
-	RouteOnboardingNeedStory:           "/onboarding/need/:needID/story",
-	RouteOnboardingNeedDocuments:       "/onboarding/need/:needID/documents",
-	RouteOnboardingNeedDocumentsUpload: "/onboarding/need/:needID/documents/upload",
-	RouteOnboardingNeedDocumentsMeta:   "/onboarding/need/:needID/documents/metadata",
-	RouteOnboardingNeedDocumentDelete:  "/onboarding/need/:needID/documents/:documentID/delete",
-	RouteOnboardingNeedReview:          "/onboarding/need/:needID/review",
-	RouteOnboardingNeedConfirmation:    "/onboarding/need/:needID/confirmation",
-	RouteBrowse:                        "/browse",
-	RouteCategories:                    "/categories",
-	RouteCategoryNeeds:                 "/category/:slug",
-	RouteMap:                           "/map",
-	RouteGuidelines:                    "/guidelines",
-	RouteAbout:                         "/about",
-	RouteShare:                         "/share",
-	RouteTerms:                         "/terms",
-	RoutePrivacy:                       "/privacy",
-	RouteNeedDetail:                    "/need/:needID",
-	RouteNeedDonate:                    "/need/:needID/donate",
-	RouteNeedDonateConfirmation:        "/need/:needID/donate/confirmation",
-	RouteNeedSave:                      "/need/:needID/save",
-	RouteNeedUnsave:                    "/need/:needID/unsave",
-	RouteStripeWebhook:                 "/webhooks/stripe",
-	RouteResendWebhook:                 "/webhooks/resend",
+	RouteHome:                              "/",
+	RouteRegister:                          "/register",
+	RouteRegisterConfirm:                   "/register/confirm",
+	RouteRegisterConfirmResend:             "/register/confirm/resend",
+	RouteLogin:                             "/login",
+	RouteAuthCallback:                      "/auth/callback",
+	RouteLogout:                            "/logout",
+	RouteProfile:                           "/profile",
+	RouteAdmin:                             "/admin",
+	RouteAdminNeeds:                        "/admin/needs",
+	RouteAdminNeedExplorer:                 "/admin/needs/explorer",
+	RouteAdminNeedsSecondApproval:          "/admin/needs/second-approval",
+	RouteAdminNeedExplorerBulk:             "/admin/needs/explorer/bulk",
+	RouteAdminNeedExplorerExport:           "/admin/needs/explorer/export",
+	RouteAdminNeedReview:                   "/admin/needs/:needID",
+	RouteAdminNeedModerate:                 "/admin/needs/:needID/moderate",
+	RouteAdminNeedDocument:                 "/admin/needs/:needID/documents/:documentID",
+	RouteAdminNeedDelete:                   "/admin/needs/:needID/delete",
+	RouteAdminNeedRestore:                  "/admin/needs/:needID/restore",
+	RouteAdminNeedMessage:                  "/admin/needs/:needID/messages",
+	RouteAdminNeedFlagDismiss:              "/admin/needs/:needID/flags/:flagID/dismiss",
+	RouteAdminNeedGoalChangeDecide:         "/admin/needs/:needID/goal-changes/:requestID/decide",
+	RouteAdminNeedReallocationQueue:        "/admin/needs/:needID/reallocations",
+	RouteAdminNeedReallocationPropose:      "/admin/needs/:needID/reallocations/:reallocationID/propose",
+	RouteAdminNeedClaim:                    "/admin/needs/:needID/assignment/claim",
+	RouteAdminNeedRelease:                  "/admin/needs/:needID/assignment/release",
+	RouteAdminNeedReassign:                 "/admin/needs/:needID/assignment/reassign",
+	RouteAdminReallocations:                "/admin/reallocations",
+	RouteAdminReallocationRefund:           "/admin/reallocations/refunds/:allocationID",
+	RouteAdminDonations:                    "/admin/donations",
+	RouteAdminDonationsExport:              "/admin/donations/export",
+	RouteAdminEmailEvents:                  "/admin/emails/events",
+	RouteAdminEmails:                       "/admin/emails",
+	RouteAdminEmailMessage:                 "/admin/emails/messages/:messageID",
+	RouteAdminEmailMessageResend:           "/admin/emails/messages/:messageID/resend",
+	RouteAdminEmailSuppressions:            "/admin/emails/suppressions",
+	RouteAdminEmailSuppressionRemove:       "/admin/emails/suppressions/:suppressionID/remove",
+	RouteAdminRoles:                        "/admin/roles",
+	RouteAdminRoleGrant:                    "/admin/roles/grant",
+	RouteAdminRoleRevoke:                   "/admin/roles/:grantID/revoke",
+	RouteAdminReviewResponses:              "/admin/review-responses",
+	RouteAdminReviewResponseCreate:         "/admin/review-responses/create",
+	RouteAdminReviewResponseUpdate:         "/admin/review-responses/:templateID/edit",
+	RouteAdminReviewResponseArchive:        "/admin/review-responses/:templateID/archive",
+	RouteAdminFeaturing:                    "/admin/featuring",
+	RouteAdminFeaturingCreate:              "/admin/featuring/slots",
+	RouteAdminFeaturingPosition:            "/admin/featuring/slots/:slotID/position",
+	RouteAdminFeaturingEnd:                 "/admin/featuring/slots/:slotID/end",
+	RouteAdminCategories:                   "/admin/categories",
+	RouteAdminCategoryCreate:               "/admin/categories/create",
+	RouteAdminCategoryUpdate:               "/admin/categories/:categoryID/edit",
+	RouteAdminCategoryActive:               "/admin/categories/:categoryID/active",
+	RouteAdminCategoryMove:                 "/admin/categories/:categoryID/move",
+	RouteAdminCategoryMerge:                "/admin/categories/:categoryID/merge",
+	RouteAdminAudit:                        "/admin/audit",
+	RouteAdminAuditExport:                  "/admin/audit/export",
+	RouteAdminUsers:                        "/admin/users",
+	RouteAdminUsersExport:                  "/admin/users/export",
+	RouteAdminUserDetail:                   "/admin/users/:userID",
+	RouteAdminUserRestrict:                 "/admin/users/:userID/restrict",
+	RouteAdminUserRestrictionLift:          "/admin/users/:userID/restriction/lift",
+	RouteAdminUserNoteCreate:               "/admin/users/:userID/notes",
+	RouteAdminNeedNoteCreate:               "/admin/needs/:needID/notes",
+	RouteAdminNoteUpdate:                   "/admin/notes/:noteID",
+	RouteAdminNotePin:                      "/admin/notes/:noteID/pin",
+	RouteProfileNeedDelete:                 "/profile/needs/:needID/delete",
+	RouteProfileNeedReview:                 "/profile/needs/:needID/review",
+	RouteProfileNeedReviewPost:             "/profile/needs/:needID/review/messages",
+	RouteProfileNeedReviewSetReady:         "/profile/needs/:needID/review/set-ready",
+	RouteProfileNeedReviewPullBack:         "/profile/needs/:needID/review/pull-back",
+	RouteProfileNeedGoalChange:             "/profile/needs/:needID/goal-change",
+	RouteProfileNeedDocumentView:           "/profile/needs/:needID/documents/:documentID",
+	RouteProfileNeedEdit:                   "/profile/needs/:needID/edit",
+	RouteProfileNeedEditLocation:           "/profile/needs/:needID/edit/location",
+	RouteProfileNeedEditCategories:         "/profile/needs/:needID/edit/categories",
+	RouteProfileNeedEditStory:              "/profile/needs/:needID/edit/story",
+	RouteProfileNeedEditBudget:             "/profile/needs/:needID/edit/budget",
+	RouteProfileNeedEditDocs:               "/profile/needs/:needID/edit/documents",
+	RouteProfileNeedEditUpload:             "/profile/needs/:needID/edit/documents/upload",
+	RouteProfileNeedEditMeta:               "/profile/needs/:needID/edit/documents/metadata",
+	RouteProfileNeedEditDelete:             "/profile/needs/:needID/edit/documents/:documentID/delete",
+	RouteProfileNeedEditReview:             "/profile/needs/:needID/edit/review",
+	RouteProfileDonationReceipt:            "/profile/donations/:intentID/receipt",
+	RouteProfileDonationReallocationOptOut: "/profile/donations/:intentID/reallocations/:allocationID/opt-out",
+	RouteProfileUpdateName:                 "/profile/update/name",
+	RouteProfileUpdateEmail:                "/profile/update/email",
+	RouteProfileSendPasswordReset:          "/profile/send-password-reset",
+	RouteProfileDonorPreferences:           "/profile/preferences",
+	RouteOnboarding:                        "/onboarding",
+	RouteOnboardingAboutYou:                "/onboarding/about-you",
+	RouteOnboardingHowWeServeYou:           "/onboarding/how-we-serve-you",
+	RouteOnboardingDonorWelcome:            "/onboarding/donor/welcome",
+	RouteOnboardingDonorPreferences:        "/onboarding/donor/preferences",
+	RouteOnboardingDonorConfirmation:       "/onboarding/donor/confirmation",
+	RouteOnboardingNeedNew:                 "/onboarding/need/new",
+	RouteOnboardingNeedClone:               "/onboarding/need/:needID/clone",
+	RouteOnboardingNeedWelcome:             "/onboarding/need/:needID/welcome",
+	RouteOnboardingNeedLocation:            "/onboarding/need/:needID/location",
+	RouteOnboardingNeedCategories:          "/onboarding/need/:needID/categories",
+	RouteOnboardingNeedDetails:             "/onboarding/need/:needID/details",
+	RouteOnboardingNeedStory:               "/onboarding/need/:needID/story",
+	RouteOnboardingNeedBudget:              "/onboarding/need/:needID/budget",
+	RouteOnboardingNeedDocuments:           "/onboarding/need/:needID/documents",
+	RouteOnboardingNeedDocumentsUpload:     "/onboarding/need/:needID/documents/upload",
+	RouteOnboardingNeedDocumentsMeta:       "/onboarding/need/:needID/documents/metadata",
+	RouteOnboardingNeedDocumentDelete:      "/onboarding/need/:needID/documents/:documentID/delete",
+	RouteOnboardingNeedReview:              "/onboarding/need/:needID/review",
+	RouteOnboardingNeedConfirmation:        "/onboarding/need/:needID/confirmation",
+	RouteBrowse:                            "/browse",
+	RouteCategories:                        "/categories",
+	RouteCategoryNeeds:                     "/category/:slug",
+	RouteMap:                               "/map",
+	RouteGuidelines:                        "/guidelines",
+	RouteAbout:                             "/about",
+	RouteShare:                             "/share/:code",
+	RouteTerms:                             "/terms",
+	RoutePrivacy:                           "/privacy",
+	RouteNeedDetail:                        "/need/:needID",
+	RouteNeedDonate:                        "/need/:needID/donate",
+	RouteNeedDonateConfirmation:            "/need/:needID/donate/confirmation",
+	RouteNeedSave:                          "/need/:needID/save",
+	RouteNeedUnsave:                        "/need/:needID/unsave",
+	RouteNeedShareLink:                     "/need/:needID/share-link",
+	RouteNeedShareCard:                     "/need/:needID/share-card.png",
+	RouteNeedFlyer:                         "/need/:needID/flyer.pdf",
+	RouteStripeWebhook:                     "/webhooks/stripe",
+	RouteResendWebhook:                     "/webhooks/resend",
 
 	// RouteOnboardingSponsorIndividual:   "/onboarding/sponsor/individual/welcome",
 	// RouteOnboardingSponsorOrganization: "/onboarding/sponsor/organization/welcome",
@@ -47,6 +47,12 @@ type Service struct {
 	storyRepo                   *store.StoryRepository
 	documentRepo                *store.DocumentRepository
 	needReviewMessageRepo       *store.NeedReviewMessageRepository
+	needRevisionRepo            *store.NeedRevisionRepository
+	needFlagRepo                *store.NeedFlagRepository
+	needGoalChangeRepo          *store.NeedGoalChangeRepository
+	fundReallocationRepo        *store.FundReallocationRepository
+	needLineItemRepo            *store.NeedLineItemRepository
+	shareLinkRepo               *store.ShareLinkRepository
 	userAddressRepo             *store.UserAddressRepository
 	userRepo                    *store.UserRepository
 	donorPreferenceRepo         *store.DonorPreferenceRepository
@@ -54,6 +60,12 @@ type Service struct {
 	donationIntentRepo          *store.DonationIntentRepository
 	savedNeedRepo               *store.SavedNeedRepository
 	emailRepo                   *store.EmailRepository
+	adminRoleRepo               *store.AdminRoleRepository
+	reviewResponseRepo          *store.ReviewResponseRepository
+	adminAuditRepo              *store.AdminAuditRepository
+	userRestrictionRepo         *store.UserRestrictionRepository
+	needFeatureRepo             *store.NeedFeatureRepository
+	adminNoteRepo               *store.AdminNoteRepository
 	emailSender                 email.Sender
 
 	cookie           *securecookie.SecureCookie
@@ -61,6 +73,7 @@ type Service struct {
 	jwksURL          string
 	httpClient       *http.Client
 	authIdentityRepo authIdentityRepository
+	restrictionCheck userRestrictionChecker
 
 	server    *http.Server
 	templates *template.Template
@@ -71,6 +84,12 @@ type authIdentityRepository interface {
 	User(ctx context.Context, userID string) (*types.User, error)
 }
 
+// userRestrictionChecker finds the suspension or ban in force for a user at
+// sign-in and on every authenticated request.
+type userRestrictionChecker interface {
+	ActiveRestrictionByUser(ctx context.Context, userID string) (*types.UserRestriction, error)
+}
+
 type Options struct {
 	Config       *types.Config
 	Logger       *logrus.Logger
@@ -85,6 +104,12 @@ type Options struct {
 	StoryRepo                   *store.StoryRepository
 	DocumentRepo                *store.DocumentRepository
 	NeedReviewMessageRepo       *store.NeedReviewMessageRepository
+	NeedRevisionRepo            *store.NeedRevisionRepository
+	NeedFlagRepo                *store.NeedFlagRepository
+	NeedGoalChangeRepo          *store.NeedGoalChangeRepository
+	FundReallocationRepo        *store.FundReallocationRepository
+	NeedLineItemRepo            *store.NeedLineItemRepository
+	ShareLinkRepo               *store.ShareLinkRepository
 	UserAddressRepo             *store.UserAddressRepository
 	UserRepo                    *store.UserRepository
 	DonorPreferenceRepo         *store.DonorPreferenceRepository
@@ -92,6 +117,12 @@ type Options struct {
 	DonationIntentRepo          *store.DonationIntentRepository
 	SavedNeedRepo               *store.SavedNeedRepository
 	EmailRepo                   *store.EmailRepository
+	AdminRoleRepo               *store.AdminRoleRepository
+	ReviewResponseRepo          *store.ReviewResponseRepository
+	AdminAuditRepo              *store.AdminAuditRepository
+	UserRestrictionRepo         *store.UserRestrictionRepository
+	NeedFeatureRepo             *store.NeedFeatureRepository
+	AdminNoteRepo               *store.AdminNoteRepository
 	EmailSender                 email.Sender
 
 	JWKCache *jwk.Cache
@@ -119,6 +150,12 @@ func New(opts Options) (*Service, error) {
 		needCategoryAssignmentsRepo: opts.NeedCategoryAssignmentsRepo,
 		documentRepo:                opts.DocumentRepo,
 		needReviewMessageRepo:       opts.NeedReviewMessageRepo,
+		needRevisionRepo:            opts.NeedRevisionRepo,
+		needFlagRepo:                opts.NeedFlagRepo,
+		needGoalChangeRepo:          opts.NeedGoalChangeRepo,
+		fundReallocationRepo:        opts.FundReallocationRepo,
+		needLineItemRepo:            opts.NeedLineItemRepo,
+		shareLinkRepo:               opts.ShareLinkRepo,
 		userAddressRepo:             opts.UserAddressRepo,
 		userRepo:                    opts.UserRepo,
 		donorPreferenceRepo:         opts.DonorPreferenceRepo,
@@ -126,6 +163,12 @@ func New(opts Options) (*Service, error) {
 		donationIntentRepo:          opts.DonationIntentRepo,
 		savedNeedRepo:               opts.SavedNeedRepo,
 		emailRepo:                   opts.EmailRepo,
+		adminRoleRepo:               opts.AdminRoleRepo,
+		reviewResponseRepo:          opts.ReviewResponseRepo,
+		adminAuditRepo:              opts.AdminAuditRepo,
+		userRestrictionRepo:         opts.UserRestrictionRepo,
+		needFeatureRepo:             opts.NeedFeatureRepo,
+		adminNoteRepo:               opts.AdminNoteRepo,
 		emailSender:                 opts.EmailSender,
 
 		cookie:           securecookie.New(hashKey, blockKey),
@@ -133,6 +176,7 @@ func New(opts Options) (*Service, error) {
 		jwksURL:          opts.JWKSURL,
 		httpClient:       &http.Client{Timeout: authOutboundTimeout},
 		authIdentityRepo: opts.UserRepo,
+		restrictionCheck: opts.UserRestrictionRepo,
 
 		server: &http.Server{
 			Addr:              fmt.Sprintf(":%d", opts.Config.ServerPort),
@@ -201,6 +245,9 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 		r.HandleFunc(RoutePattern(RouteCategories), s.handleCategories, http.MethodGet)
 		r.HandleFunc(RoutePattern(RouteCategoryNeeds), s.handleCategoryNeeds, http.MethodGet)
 		r.HandleFunc(RoutePattern(RouteNeedDetail), s.handleNeedDetail, http.MethodGet)
+		r.HandleFunc(RoutePattern(RouteShare), s.handleGetShare, http.MethodGet)
+		r.HandleFunc(RoutePattern(RouteNeedShareCard), s.handleGetNeedShareCard, http.MethodGet)
+		r.HandleFunc(RoutePattern(RouteNeedFlyer), s.handleGetNeedFlyer, http.MethodGet)
 		r.HandleFunc(RoutePattern(RouteGuidelines), s.handleGetGuidelines, http.MethodGet)
 
 		r.Group(func(r *flow.Mux) {
@@ -212,6 +259,7 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 			r.HandleFunc(RoutePattern(RouteProfileNeedReviewPost), s.handlePostProfileNeedReviewMessage, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileNeedReviewSetReady), s.handlePostProfileNeedReviewSetReady, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileNeedReviewPullBack), s.handlePostProfileNeedReviewPullBack, http.MethodPost)
+			r.HandleFunc(RoutePattern(RouteProfileNeedGoalChange), s.handlePostProfileNeedGoalChange, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileNeedDocumentView), s.handleGetProfileNeedDocument, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEdit), s.handleGetProfileNeedEdit, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditLocation), s.handleGetProfileNeedEditLocation, http.MethodGet)
@@ -220,6 +268,8 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditCategories), s.handlePostProfileNeedEditCategories, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditStory), s.handleGetProfileNeedEditStory, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditStory), s.handlePostProfileNeedEditStory, http.MethodPost)
+			r.HandleFunc(RoutePattern(RouteProfileNeedEditBudget), s.handleGetProfileNeedEditBudget, http.MethodGet)
+			r.HandleFunc(RoutePattern(RouteProfileNeedEditBudget), s.handlePostProfileNeedEditBudget, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditDocs), s.handleGetProfileNeedEditDocuments, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditDocs), s.handlePostProfileNeedEditDocuments, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditUpload), s.handlePostProfileNeedEditDocumentsUpload, http.MethodPost)
@@ -228,6 +278,7 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditReview), s.handleGetProfileNeedEditReview, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteProfileNeedEditReview), s.handlePostProfileNeedEditReview, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileDonationReceipt), s.handleGetProfileDonationReceipt, http.MethodGet)
+			r.HandleFunc(RoutePattern(RouteProfileDonationReallocationOptOut), s.handlePostProfileDonationReallocationOptOut, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileUpdateName), s.handlePostProfileUpdateName, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileUpdateEmail), s.handlePostProfileUpdateEmail, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteProfileSendPasswordReset), s.handlePostProfileSendPasswordReset, http.MethodPost)
@@ -240,6 +291,8 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 			r.HandleFunc(RoutePattern(RouteOnboardingAboutYou), s.handlePostOnboardingAboutYou, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteOnboardingHowWeServeYou), s.handleGetOnboardingHowWeServeYou, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteOnboardingHowWeServeYou), s.handlePostOnboardingHowWeServeYou, http.MethodPost)
+			r.HandleFunc(RoutePattern(RouteOnboardingNeedNew), s.handlePostOnboardingNeedNew, http.MethodPost)
+			r.HandleFunc(RoutePattern(RouteOnboardingNeedClone), s.handlePostOnboardingNeedClone, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedWelcome), s.handleGetOnboardingNeedWelcome, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedWelcome), s.handlePostOnboardingNeedWelcome, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedLocation), s.handleGetOnboardingNeedLocation, http.MethodGet)
@@ -248,6 +301,8 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedCategories), s.handlePostOnboardingNeedCategories, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedStory), s.handleGetOnboardingNeedStory, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedStory), s.handlePostOnboardingNeedStory, http.MethodPost)
+			r.HandleFunc(RoutePattern(RouteOnboardingNeedBudget), s.handleGetOnboardingNeedBudget, http.MethodGet)
+			r.HandleFunc(RoutePattern(RouteOnboardingNeedBudget), s.handlePostOnboardingNeedBudget, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedDocuments), s.handleGetOnboardingNeedDocuments, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedDocuments), s.handlePostOnboardingNeedDocuments, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteOnboardingNeedDocumentsUpload), s.handlePostOnboardingNeedDocumentsUpload, http.MethodPost)
@@ -268,22 +323,160 @@ func (s *Service) buildRouter(r *flow.Mux, csrfKey []byte) {
 			r.HandleFunc(RoutePattern(RouteNeedDonateConfirmation), s.handleGetNeedDonateConfirmation, http.MethodGet)
 			r.HandleFunc(RoutePattern(RouteNeedSave), s.handlePostNeedSave, http.MethodPost)
 			r.HandleFunc(RoutePattern(RouteNeedUnsave), s.handlePostNeedUnsave, http.MethodPost)
+			r.HandleFunc(RoutePattern(RouteNeedShareLink), s.handlePostNeedShareLink, http.MethodPost)
 		})
 
 		r.Group(func(r *flow.Mux) {
 			r.Use(s.RequireAdmin)
 
 			r.HandleFunc(RoutePattern(RouteAdmin), s.handleGetAdminDashboard, http.MethodGet)
-			r.HandleFunc(RoutePattern(RouteAdminNeeds), s.handleGetAdminNeeds, http.MethodGet)
-			r.HandleFunc(RoutePattern(RouteAdminNeedExplorer), s.handleGetAdminNeedExplorer, http.MethodGet)
-			r.HandleFunc(RoutePattern(RouteAdminNeedReview), s.handleGetAdminNeedReview, http.MethodGet)
-			r.HandleFunc(RoutePattern(RouteAdminNeedModerate), s.handlePostAdminNeedModerate, http.MethodPost)
-			r.HandleFunc(RoutePattern(RouteAdminNeedDocument), s.handleGetAdminNeedDocument, http.MethodGet)
-			r.HandleFunc(RoutePattern(RouteAdminNeedDelete), s.handlePostAdminNeedDelete, http.MethodPost)
-			r.HandleFunc(RoutePattern(RouteAdminNeedRestore), s.handlePostAdminNeedRestore, http.MethodPost)
-			r.HandleFunc(RoutePattern(RouteAdminNeedMessage), s.handlePostAdminNeedMessage, http.MethodPost)
-			r.HandleFunc(RoutePattern(RouteAdminUsers), s.handleGetAdminUsers, http.MethodGet)
-			r.HandleFunc(RoutePattern(RouteAdminUserDetail), s.handleGetAdminUserDetail, http.MethodGet)
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionNeedsView))
+
+				r.HandleFunc(RoutePattern(RouteAdminNeeds), s.handleGetAdminNeeds, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminNeedExplorer), s.handleGetAdminNeedExplorer, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminNeedsSecondApproval), s.handleGetAdminNeedsSecondApproval, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminNeedExplorerBulk), s.handlePostAdminNeedExplorerBulk, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedExplorerExport), s.handleGetAdminNeedExplorerExport, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminNeedReview), s.handleGetAdminNeedReview, http.MethodGet)
+
+				r.Group(func(r *flow.Mux) {
+					r.Use(s.RequirePermission(adminPermissionNotesWrite))
+
+					r.HandleFunc(RoutePattern(RouteAdminNeedNoteCreate), s.handlePostAdminNeedNoteCreate, http.MethodPost)
+				})
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionNeedsModerate))
+
+				r.HandleFunc(RoutePattern(RouteAdminNeedModerate), s.handlePostAdminNeedModerate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedMessage), s.handlePostAdminNeedMessage, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedFlagDismiss), s.handlePostAdminNeedFlagDismiss, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedGoalChangeDecide), s.handlePostAdminNeedGoalChangeDecide, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedClaim), s.handlePostAdminNeedClaim, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedRelease), s.handlePostAdminNeedRelease, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminReviewResponses), s.handleGetAdminReviewResponses, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminReviewResponseCreate), s.handlePostAdminReviewResponseCreate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminReviewResponseUpdate), s.handlePostAdminReviewResponseUpdate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminReviewResponseArchive), s.handlePostAdminReviewResponseArchive, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminFeaturing), s.handleGetAdminFeaturing, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminFeaturingCreate), s.handlePostAdminFeaturingCreate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminFeaturingPosition), s.handlePostAdminFeaturingPosition, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminFeaturingEnd), s.handlePostAdminFeaturingEnd, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionNeedsAssign))
+
+				r.HandleFunc(RoutePattern(RouteAdminNeedReassign), s.handlePostAdminNeedReassign, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionNeedsDelete))
+
+				r.HandleFunc(RoutePattern(RouteAdminNeedDelete), s.handlePostAdminNeedDelete, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedRestore), s.handlePostAdminNeedRestore, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionDocumentsView))
+				r.Use(s.AuditAdminRequest(types.AdminAuditActionDocumentDownloaded, types.AdminAuditTargetDocument, "documentID"))
+
+				r.HandleFunc(RoutePattern(RouteAdminNeedDocument), s.handleGetAdminNeedDocument, http.MethodGet)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionFundsManage))
+
+				r.HandleFunc(RoutePattern(RouteAdminNeedReallocationQueue), s.handlePostAdminNeedReallocationQueue, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNeedReallocationPropose), s.handlePostAdminNeedReallocationPropose, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminReallocations), s.handleGetAdminReallocations, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminReallocationRefund), s.handlePostAdminReallocationRefund, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionDonationsView))
+
+				r.HandleFunc(RoutePattern(RouteAdminDonations), s.handleGetAdminDonations, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminDonationsExport), s.handleGetAdminDonationsExport, http.MethodGet)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionEmailsView))
+
+				r.HandleFunc(RoutePattern(RouteAdminEmailEvents), s.handleGetAdminEmailEvents, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminEmails), s.handleGetAdminEmails, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminEmailMessage), s.handleGetAdminEmailMessage, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminEmailSuppressions), s.handleGetAdminEmailSuppressions, http.MethodGet)
+
+				r.Group(func(r *flow.Mux) {
+					r.Use(s.RequirePermission(adminPermissionEmailsManage))
+
+					r.HandleFunc(RoutePattern(RouteAdminEmailMessageResend), s.handlePostAdminEmailMessageResend, http.MethodPost)
+					r.HandleFunc(RoutePattern(RouteAdminEmailSuppressionRemove), s.handlePostAdminEmailSuppressionRemove, http.MethodPost)
+				})
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionUsersView))
+
+				r.HandleFunc(RoutePattern(RouteAdminUsers), s.handleGetAdminUsers, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminUsersExport), s.handleGetAdminUsersExport, http.MethodGet)
+
+				r.Group(func(r *flow.Mux) {
+					r.Use(s.AuditAdminRequest(types.AdminAuditActionUserViewed, types.AdminAuditTargetUser, "userID"))
+
+					r.HandleFunc(RoutePattern(RouteAdminUserDetail), s.handleGetAdminUserDetail, http.MethodGet)
+				})
+
+				r.Group(func(r *flow.Mux) {
+					r.Use(s.RequirePermission(adminPermissionUsersManage))
+
+					r.HandleFunc(RoutePattern(RouteAdminUserRestrict), s.handlePostAdminUserRestrict, http.MethodPost)
+					r.HandleFunc(RoutePattern(RouteAdminUserRestrictionLift), s.handlePostAdminUserRestrictionLift, http.MethodPost)
+				})
+
+				r.Group(func(r *flow.Mux) {
+					r.Use(s.RequirePermission(adminPermissionNotesWrite))
+
+					r.HandleFunc(RoutePattern(RouteAdminUserNoteCreate), s.handlePostAdminUserNoteCreate, http.MethodPost)
+				})
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionNotesWrite))
+
+				r.HandleFunc(RoutePattern(RouteAdminNoteUpdate), s.handlePostAdminNoteUpdate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminNotePin), s.handlePostAdminNotePin, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionRolesManage))
+
+				r.HandleFunc(RoutePattern(RouteAdminRoles), s.handleGetAdminRoles, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminRoleGrant), s.handlePostAdminRoleGrant, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminRoleRevoke), s.handlePostAdminRoleRevoke, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionCategoriesManage))
+
+				r.HandleFunc(RoutePattern(RouteAdminCategories), s.handleGetAdminCategories, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminCategoryCreate), s.handlePostAdminCategoryCreate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminCategoryUpdate), s.handlePostAdminCategoryUpdate, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminCategoryActive), s.handlePostAdminCategoryActive, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminCategoryMove), s.handlePostAdminCategoryMove, http.MethodPost)
+				r.HandleFunc(RoutePattern(RouteAdminCategoryMerge), s.handlePostAdminCategoryMerge, http.MethodPost)
+			})
+
+			r.Group(func(r *flow.Mux) {
+				r.Use(s.RequirePermission(adminPermissionAuditView))
+
+				r.HandleFunc(RoutePattern(RouteAdminAudit), s.handleGetAdminAudit, http.MethodGet)
+				r.HandleFunc(RoutePattern(RouteAdminAuditExport), s.handleGetAdminAuditExport, http.MethodGet)
+			})
 		})
 	})
 
@@ -391,8 +584,9 @@ func templateFuncMap() template.FuncMap {
 			}
 			return values[key]
 		},
-		"param": Param,
-		"query": Query,
+		"param":        Param,
+		"privacyLabel": addressPrivacyLabel,
+		"query":        Query,
 		"route": func(name string, opts ...RouteOption) (string, error) {
 			trimmedName := strings.TrimSpace(name)
 			path, err := BuildRoute(RouteName(trimmedName), opts...)
@@ -0,0 +1,279 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"christjesus/internal"
+	"christjesus/pkg/types"
+)
+
+const (
+	shareReferralCookieAge   = 30 * 24 * time.Hour
+	shareDescriptionMaxChars = 200
+)
+
+// handleGetShare resolves a tracked share link, remembers the referral in a
+// cookie so a later donation can be attributed, and forwards to the need.
+func (s *Service) handleGetShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	code := strings.TrimSpace(r.PathValue("code"))
+
+	link, err := s.shareLinkRepo.ShareLinkByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, types.ErrShareLinkNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("code", code).Error("failed to fetch share link")
+		s.internalServerError(w)
+		return
+	}
+
+	viewerUserID := ""
+	if session, ok := sessionFromRequest(r); ok {
+		viewerUserID = session.UserID
+	}
+
+	// Sharers opening their own link shouldn't count as a referral.
+	if viewerUserID != link.UserID {
+		if err := s.shareLinkRepo.RecordClick(ctx, link.ID); err != nil {
+			s.logger.WithError(err).WithField("share_link_id", link.ID).Warn("failed to record share link click")
+		}
+		s.setShareReferralCookie(w, link.Code)
+	}
+
+	http.Redirect(w, r, s.route(RouteNeedDetail, Param("needID", link.NeedID)), http.StatusSeeOther)
+}
+
+func (s *Service) handlePostNeedShareLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.internalServerError(w)
+		return
+	}
+
+	need, err := s.needsRepo.Need(ctx, needID)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to fetch need for share link")
+		s.internalServerError(w)
+		return
+	}
+	if !needIsShareable(need) {
+		http.NotFound(w, r)
+		return
+	}
+
+	if _, err := s.shareLinkRepo.GetOrCreateShareLink(ctx, need.ID, session.UserID); err != nil {
+		s.logger.WithError(err).WithField("need_id", need.ID).Error("failed to create share link")
+		s.internalServerError(w)
+		return
+	}
+
+	http.Redirect(w, r, s.route(RouteNeedDetail, Param("needID", need.ID))+"#share", http.StatusSeeOther)
+}
+
+func (s *Service) setShareReferralCookie(w http.ResponseWriter, code string) {
+	encoded, err := s.cookie.Encode(internal.COOKIE_SHARE_REFERRAL, code)
+	if err != nil {
+		s.logger.WithError(err).Warn("failed to encode share referral cookie")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     internal.COOKIE_SHARE_REFERRAL,
+		Value:    encoded,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+		MaxAge:   int(shareReferralCookieAge.Seconds()),
+	})
+}
+
+// shareReferralForDonation returns the share link that referred the donor to
+// this need, if any. Referrals for other needs and self-referrals are ignored.
+func (s *Service) shareReferralForDonation(ctx context.Context, r *http.Request, needID, donorUserID string) *types.NeedShareLink {
+	cookie, err := r.Cookie(internal.COOKIE_SHARE_REFERRAL)
+	if err != nil {
+		return nil
+	}
+
+	var code string
+	if err := s.cookie.Decode(internal.COOKIE_SHARE_REFERRAL, cookie.Value, &code); err != nil {
+		return nil
+	}
+
+	link, err := s.shareLinkRepo.ShareLinkByCode(ctx, code)
+	if err != nil {
+		if !errors.Is(err, types.ErrShareLinkNotFound) {
+			s.logger.WithError(err).WithField("code", code).Warn("failed to resolve share referral for donation")
+		}
+		return nil
+	}
+
+	if link.NeedID != needID || link.UserID == donorUserID {
+		return nil
+	}
+
+	return link
+}
+
+// buildNeedShareData fills the share panel on the need detail page. Signed-in
+// visitors share their own tracked link once they have one; everyone else
+// shares the canonical need URL.
+func (s *Service) buildNeedShareData(ctx context.Context, r *http.Request, data *types.NeedDetailPageData) {
+	data.ShareURL = s.absoluteRoute(RouteNeedDetail, nil, Param("needID", data.ID))
+	if data.Need != nil && needIsShareable(data.Need) {
+		data.CreateShareLinkAction = s.route(RouteNeedShareLink, Param("needID", data.ID))
+	}
+	if data.Need != nil && data.Need.Status == types.NeedStatusActive {
+		data.FlyerHref = s.route(RouteNeedFlyer, Param("needID", data.ID))
+	}
+
+	if session, ok := sessionFromRequest(r); ok && session.UserID != "" {
+		link, err := s.shareLinkRepo.ShareLinkByNeedAndUser(ctx, data.ID, session.UserID)
+		switch {
+		case err == nil:
+			data.ShareURL = s.absoluteRoute(RouteShare, nil, Param("code", link.Code))
+			data.HasShareLink = true
+		case !errors.Is(err, types.ErrShareLinkNotFound):
+			s.logger.WithError(err).WithField("need_id", data.ID).Warn("failed to load share link for need detail")
+		}
+	}
+
+	data.FacebookShareHref = "https://www.facebook.com/sharer/sharer.php?" + url.Values{"u": {data.ShareURL}}.Encode()
+	data.XShareHref = "https://twitter.com/intent/tweet?" + url.Values{
+		"url":  {data.ShareURL},
+		"text": {data.Meta.Title},
+	}.Encode()
+}
+
+func (s *Service) needPageMeta(need *types.Need, ownerName string, story *types.NeedStory) types.PageMeta {
+	description := ""
+	if need.ShortDescription != nil {
+		description = *need.ShortDescription
+	}
+	if strings.TrimSpace(description) == "" && story != nil && story.Need != nil {
+		description = *story.Need
+	}
+	if strings.TrimSpace(description) == "" {
+		description = "Help a verified neighbor in need through Body of Christ."
+	}
+
+	meta := types.PageMeta{
+		Title:       ownerName + "'s Request | Body of Christ",
+		Description: truncateShareDescription(description, shareDescriptionMaxChars),
+		URL:         s.absoluteRoute(RouteNeedDetail, nil, Param("needID", need.ID)),
+		Type:        "website",
+	}
+	if needIsShareable(need) {
+		meta.ImageURL = s.absoluteRoute(RouteNeedShareCard, nil, Param("needID", need.ID))
+	}
+
+	return meta
+}
+
+// needIsShareable reports whether need is publicly visible and can carry a
+// share card or a tracked share link.
+func needIsShareable(need *types.Need) bool {
+	if need.DeletedAt != nil || need.HiddenAt != nil {
+		return false
+	}
+	return need.Status == types.NeedStatusActive || need.Status == types.NeedStatusFunded
+}
+
+// truncateShareDescription collapses whitespace and trims text to maxChars
+// runes at a word boundary for link previews.
+func truncateShareDescription(text string, maxChars int) string {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(collapsed) <= maxChars {
+		return collapsed
+	}
+
+	runes := []rune(collapsed)
+	cut := string(runes[:maxChars-1])
+	if idx := strings.LastIndex(cut, " "); idx > maxChars/2 {
+		cut = cut[:idx]
+	}
+
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+// buildShareSummaries lists the user's shared links with what each raised.
+func (s *Service) buildShareSummaries(ctx context.Context, userID string) ([]types.ProfileShareSummary, int, error) {
+	stats, err := s.shareLinkRepo.ShareStatsByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(stats) == 0 {
+		return []types.ProfileShareSummary{}, 0, nil
+	}
+
+	needIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		needIDs = append(needIDs, stat.NeedID)
+	}
+
+	needs, err := s.needsRepo.NeedsByIDs(ctx, needIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ownerIDByNeedID := make(map[string]string, len(needs))
+	ownerIDs := make([]string, 0, len(needs))
+	for _, need := range needs {
+		if need == nil {
+			continue
+		}
+		ownerIDByNeedID[need.ID] = need.UserID
+		ownerIDs = append(ownerIDs, need.UserID)
+	}
+
+	ownerNameByID := make(map[string]string)
+	if len(ownerIDs) > 0 {
+		owners, err := s.userRepo.UsersByIDs(ctx, uniqueSortedStrings(ownerIDs))
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, owner := range owners {
+			if owner != nil {
+				ownerNameByID[owner.ID] = userDisplayName(owner)
+			}
+		}
+	}
+
+	summaries := make([]types.ProfileShareSummary, 0, len(stats))
+	totalRaisedCents := 0
+	for _, stat := range stats {
+		ownerName := ownerNameByID[ownerIDByNeedID[stat.NeedID]]
+		if ownerName == "" {
+			ownerName = "Anonymous"
+		}
+
+		totalRaisedCents += stat.RaisedCents
+		summaries = append(summaries, types.ProfileShareSummary{
+			NeedID:        stat.NeedID,
+			OwnerName:     ownerName,
+			ShareURL:      s.absoluteRoute(RouteShare, nil, Param("code", stat.Code)),
+			ClickCount:    stat.ClickCount,
+			DonationCount: stat.DonationCount,
+			RaisedAmount:  formatUSDFromCents(stat.RaisedCents),
+			DetailHref:    s.route(RouteNeedDetail, Param("needID", stat.NeedID)),
+		})
+	}
+
+	return summaries, totalRaisedCents, nil
+}
@@ -0,0 +1,476 @@
+package server
+
+import (
+	"bytes"
+	"christjesus/internal/qrcode"
+	"christjesus/pkg/types"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	shareCardWidth  = 1200
+	shareCardHeight = 630
+
+	// Share assets change whenever the need is edited or a donation lands, and
+	// the ETag covers both, so a short max-age is enough to absorb crawler and
+	// bulletin-board traffic.
+	shareAssetCacheControl = "public, max-age=600"
+)
+
+var (
+	shareCardBackground = color.RGBA{R: 0xF8, G: 0xF5, B: 0xEE, A: 0xFF}
+	shareCardInk        = color.RGBA{R: 0x0F, G: 0x17, B: 0x2A, A: 0xFF}
+	shareCardMuted      = color.RGBA{R: 0x47, G: 0x55, B: 0x69, A: 0xFF}
+	shareCardGold       = color.RGBA{R: 0xB8, G: 0x86, B: 0x2B, A: 0xFF}
+	shareCardTrack      = color.RGBA{R: 0xE2, G: 0xE8, B: 0xF0, A: 0xFF}
+	shareCardWhite      = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+)
+
+// needShareCard is the privacy-respecting summary printed on share cards and
+// flyers: first name only, the location at the owner's chosen privacy level,
+// and the public story summary.
+type needShareCard struct {
+	NeedID         string
+	Title          string
+	CategoryName   string
+	Location       string
+	Summary        string
+	RaisedCents    int
+	GoalCents      int
+	FundingPercent int
+	DonateURL      string
+	ETag           string
+}
+
+func (s *Service) handleGetNeedShareCard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+
+	card, err := s.loadNeedShareCard(ctx, needID, types.NeedStatusActive, types.NeedStatusFunded)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to load need for share card")
+		s.internalServerError(w)
+		return
+	}
+
+	s.writeShareAsset(w, r, card.ETag+"-card", "image/png", "", func() ([]byte, error) {
+		return renderNeedShareCardPNG(card)
+	})
+}
+
+func (s *Service) handleGetNeedFlyer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	needID := strings.TrimSpace(r.PathValue("needID"))
+
+	card, err := s.loadNeedShareCard(ctx, needID, types.NeedStatusActive)
+	if err != nil {
+		if errors.Is(err, types.ErrNeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("need_id", needID).Error("failed to load need for flyer")
+		s.internalServerError(w)
+		return
+	}
+
+	filename := fmt.Sprintf("christjesus-need-%s.pdf", card.NeedID)
+	s.writeShareAsset(w, r, card.ETag+"-flyer", "application/pdf", filename, func() ([]byte, error) {
+		return buildNeedFlyerPDF(card)
+	})
+}
+
+// writeShareAsset serves a generated share asset with public caching. Clients
+// revalidating with a matching ETag get a 304 without the asset being rebuilt.
+func (s *Service) writeShareAsset(w http.ResponseWriter, r *http.Request, etag, contentType, filename string, build func() ([]byte, error)) {
+	quoted := `"` + etag + `"`
+	w.Header().Set("Cache-Control", shareAssetCacheControl)
+	w.Header().Set("ETag", quoted)
+
+	if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, quoted) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body, err := build()
+	if err != nil {
+		s.logger.WithError(err).WithField("content_type", contentType).Error("failed to build share asset")
+		s.internalServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+	if filename != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		s.logger.WithError(err).WithField("content_type", contentType).Error("failed to write share asset response")
+	}
+}
+
+// loadNeedShareCard gathers the public summary for a need in one of the given
+// statuses. Needs in any other state are reported as types.ErrNeedNotFound.
+func (s *Service) loadNeedShareCard(ctx context.Context, needID string, statuses ...types.NeedStatus) (*needShareCard, error) {
+	need, err := s.needsRepo.Need(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+	if need.DeletedAt != nil || need.HiddenAt != nil || !needStatusIn(need.Status, statuses) {
+		return nil, types.ErrNeedNotFound
+	}
+
+	title := "A Neighbor's Request"
+	user, err := s.userRepo.User(ctx, need.UserID)
+	if err != nil && !errors.Is(err, types.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to fetch need owner: %w", err)
+	}
+	if user != nil && strings.TrimSpace(derefString(user.GivenName)) != "" {
+		title = strings.TrimSpace(derefString(user.GivenName)) + "'s Request"
+	}
+
+	categoryName := ""
+	assignments, err := s.needCategoryAssignmentsRepo.GetAssignmentsByNeedID(ctx, need.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch need category assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		if !assignment.IsPrimary {
+			continue
+		}
+		categories, err := s.categoryRepo.CategoriesByIDs(ctx, []string{assignment.CategoryID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch need category: %w", err)
+		}
+		if len(categories) > 0 && categories[0] != nil {
+			categoryName = categories[0].Name
+		}
+		break
+	}
+
+	var address *types.UserAddress
+	if addressID := derefString(need.UserAddressID); addressID != "" {
+		address, err = s.userAddressRepo.ByIDAndUserID(ctx, addressID, need.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch need address: %w", err)
+		}
+	}
+	if address == nil {
+		address, err = s.userAddressRepo.PrimaryByUserID(ctx, need.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch primary address: %w", err)
+		}
+	}
+	location := ""
+	if _, _, label := s.publicLocation(ctx, address); label != "N/A" {
+		location = label
+	}
+
+	summary := derefString(need.ShortDescription)
+	if summary == "" {
+		story, err := s.storyRepo.GetStoryByNeedID(ctx, need.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch need story: %w", err)
+		}
+		if story != nil {
+			summary = derefString(story.Need)
+		}
+	}
+	if summary == "" {
+		summary = "Help a verified neighbor in need through Body of Christ."
+	}
+
+	return &needShareCard{
+		NeedID:         need.ID,
+		Title:          title,
+		CategoryName:   categoryName,
+		Location:       location,
+		Summary:        summary,
+		RaisedCents:    need.AmountRaisedCents,
+		GoalCents:      need.AmountNeededCents,
+		FundingPercent: fundingPercentFromCents(need.AmountRaisedCents, need.AmountNeededCents),
+		DonateURL:      s.absoluteRoute(RouteNeedDonate, nil, Param("needID", need.ID)),
+		ETag:           shareCardETag(need, location),
+	}, nil
+}
+
+// shareCardETag changes whenever anything printed on the card can change:
+// edits, new donations, or the owner's location privacy.
+func shareCardETag(need *types.Need, location string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(location))
+	return fmt.Sprintf("%s-%d-%d-%08x", need.ID, need.UpdatedAt.Unix(), need.AmountRaisedCents, hash.Sum32())
+}
+
+func shareCardSubtitle(card *needShareCard) string {
+	parts := make([]string, 0, 2)
+	if card.CategoryName != "" {
+		parts = append(parts, card.CategoryName)
+	}
+	if card.Location != "" {
+		parts = append(parts, card.Location)
+	}
+	return strings.Join(parts, " - ")
+}
+
+func needStatusIn(status types.NeedStatus, statuses []types.NeedStatus) bool {
+	for _, candidate := range statuses {
+		if status == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// renderNeedShareCardPNG draws the 1200x630 Open Graph card: summary and
+// progress on the left, a donate QR code on the right.
+func renderNeedShareCardPNG(card *needShareCard) ([]byte, error) {
+	code, err := qrcode.Encode(card.DonateURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode donate qr code: %w", err)
+	}
+
+	faces, err := newShareCardFaces()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, shareCardWidth, shareCardHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: shareCardBackground}, image.Point{}, draw.Src)
+	fillRect(img, 0, 0, shareCardWidth, 12, shareCardGold)
+
+	const left, textWidth = 64, 680
+	drawCardText(img, left, 56, "BODY OF CHRIST", faces.brand, shareCardGold)
+
+	y := 104
+	for _, line := range wrapShareCardText(card.Title, textMeasurer(faces.title), textWidth, 2) {
+		drawCardText(img, left, y, line, faces.title, shareCardInk)
+		y += 56
+	}
+
+	if subtitle := wrapShareCardText(strings.ToUpper(shareCardSubtitle(card)), textMeasurer(faces.subtitle), textWidth, 1); len(subtitle) > 0 {
+		y += 8
+		drawCardText(img, left, y, subtitle[0], faces.subtitle, shareCardMuted)
+		y += 32
+	}
+
+	y += 16
+	for _, line := range wrapShareCardText(card.Summary, textMeasurer(faces.body), textWidth, 4) {
+		drawCardText(img, left, y, line, faces.body, shareCardInk)
+		y += 32
+	}
+
+	const barTop, barHeight = 470, 28
+	fillRect(img, left, barTop, textWidth, barHeight, shareCardTrack)
+	fillRect(img, left, barTop, textWidth*min(card.FundingPercent, 100)/100, barHeight, shareCardGold)
+
+	progress := fmt.Sprintf("%s raised of %s", formatUSDFromCents(card.RaisedCents), formatUSDFromCents(card.GoalCents))
+	drawCardText(img, left, barTop+48, progress, faces.progress, shareCardInk)
+	drawCardText(img, left, barTop+96, fmt.Sprintf("%d%% funded", card.FundingPercent), faces.subtitle, shareCardMuted)
+
+	const panelLeft, panelTop, panelSize = 810, 110, 330
+	fillRect(img, panelLeft, panelTop, panelSize, panelSize, shareCardWhite)
+	modulePx := panelSize / (code.Size + 8)
+	offset := (panelSize - modulePx*code.Size) / 2
+	for my := 0; my < code.Size; my++ {
+		for mx := 0; mx < code.Size; mx++ {
+			if code.Dark(mx, my) {
+				fillRect(img, panelLeft+offset+mx*modulePx, panelTop+offset+my*modulePx, modulePx, modulePx, shareCardInk)
+			}
+		}
+	}
+
+	caption := "SCAN TO GIVE"
+	drawCardText(img, panelLeft+(panelSize-cardTextWidth(caption, faces.caption))/2, panelTop+panelSize+28, caption, faces.caption, shareCardInk)
+
+	var output bytes.Buffer
+	if err := png.Encode(&output, img); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.RGBA) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	draw.Draw(img, image.Rect(x, y, x+w, y+h), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+// drawCardText renders text in face with y as the top of the line, so callers
+// can lay out lines without knowing each face's ascent.
+func drawCardText(img *image.RGBA, x, y int, text string, face font.Face, c color.RGBA) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y+face.Metrics().Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+}
+
+func cardTextWidth(text string, face font.Face) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+func textMeasurer(face font.Face) func(string) int {
+	return func(text string) int {
+		return cardTextWidth(text, face)
+	}
+}
+
+// wrapShareCardText splits text into at most maxLines lines no wider than
+// maxWidth as reported by measure, breaking on spaces and marking truncation
+// with "...".
+func wrapShareCardText(text string, measure func(string) int, maxWidth, maxLines int) []string {
+	words := strings.Fields(text)
+	lines := make([]string, 0, maxLines)
+	current := ""
+
+	for _, word := range words {
+		word = fitShareCardText(word, measure, maxWidth, "")
+
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if measure(candidate) <= maxWidth {
+			current = candidate
+			continue
+		}
+
+		lines = append(lines, current)
+		current = word
+		if len(lines) == maxLines {
+			last := strings.TrimRight(lines[maxLines-1], " ,.;:")
+			lines[maxLines-1] = fitShareCardText(last, measure, maxWidth, "...")
+			return lines
+		}
+	}
+
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+// fitShareCardText trims runes off the end of text until it fits in maxWidth
+// with suffix appended.
+func fitShareCardText(text string, measure func(string) int, maxWidth int, suffix string) string {
+	if measure(text+suffix) <= maxWidth {
+		return text + suffix
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && measure(string(runes)+suffix) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ,.;:") + suffix
+}
+
+// buildNeedFlyerPDF lays out a one-page printable flyer for bulletin boards
+// and handouts, using the same fpdf tooling as donation receipts.
+func buildNeedFlyerPDF(card *needShareCard) ([]byte, error) {
+	code, err := qrcode.Encode(card.DonateURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode donate qr code: %w", err)
+	}
+
+	const margin, pageWidth = 20.0, 215.9
+	contentWidth := pageWidth - 2*margin
+
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle(pdfSafeText(card.Title), false)
+	pdf.SetAuthor("Body of Christ", false)
+	pdf.SetSubject("Need flyer", false)
+	pdf.AddPage()
+
+	pdf.SetFillColor(184, 134, 43)
+	pdf.Rect(0, 0, pageWidth, 6, "F")
+
+	pdf.SetY(margin)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetTextColor(184, 134, 43)
+	pdf.CellFormat(0, 8, "BODY OF CHRIST", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 30)
+	pdf.SetTextColor(15, 23, 42)
+	pdf.MultiCell(0, 13, pdfSafeText(card.Title), "", "L", false)
+
+	if subtitle := shareCardSubtitle(card); subtitle != "" {
+		pdf.SetFont("Helvetica", "", 13)
+		pdf.SetTextColor(71, 85, 105)
+		pdf.CellFormat(0, 8, pdfSafeText(subtitle), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.SetTextColor(15, 23, 42)
+	pdf.MultiCell(0, 7, pdfSafeText(truncateShareDescription(card.Summary, 600)), "", "L", false)
+	pdf.Ln(6)
+
+	barY := pdf.GetY()
+	pdf.SetFillColor(226, 232, 240)
+	pdf.Rect(margin, barY, contentWidth, 7, "F")
+	if filled := contentWidth * float64(min(card.FundingPercent, 100)) / 100; filled > 0 {
+		pdf.SetFillColor(184, 134, 43)
+		pdf.Rect(margin, barY, filled, 7, "F")
+	}
+	pdf.SetY(barY + 10)
+	pdf.SetFont("Helvetica", "B", 14)
+	progress := fmt.Sprintf("%s raised of %s goal (%d%% funded)", formatUSDFromCents(card.RaisedCents), formatUSDFromCents(card.GoalCents), card.FundingPercent)
+	pdf.CellFormat(0, 8, progress, "", 1, "L", false, 0, "")
+
+	const qrSize = 70.0
+	qrTop := max(pdf.GetY()+10, 150)
+	qrLeft := (pageWidth - qrSize) / 2
+	module := qrSize / float64(code.Size)
+	pdf.SetFillColor(15, 23, 42)
+	for my := 0; my < code.Size; my++ {
+		for mx := 0; mx < code.Size; mx++ {
+			if code.Dark(mx, my) {
+				pdf.Rect(qrLeft+float64(mx)*module, qrTop+float64(my)*module, module, module, "F")
+			}
+		}
+	}
+
+	pdf.SetY(qrTop + qrSize + 6)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 9, "Scan to give", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(37, 99, 235)
+	pdf.CellFormat(0, 6, pdfSafeText(card.DonateURL), "", 1, "C", false, 0, card.DonateURL)
+
+	pdf.SetY(255)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.MultiCell(0, 5, "Every need on Body of Christ is reviewed before it is published. Gifts go directly toward this need and are processed securely online.", "", "C", false)
+
+	var output bytes.Buffer
+	if err := pdf.Output(&output); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// shareCardFonts parses the Go fonts once. They cover Latin, Greek and
+// Cyrillic, so names and summaries outside ASCII render as written.
+var shareCardFonts = sync.OnceValues(func() ([2]*opentype.Font, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, fmt.Errorf("failed to parse regular share card font: %w", err)
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, fmt.Errorf("failed to parse bold share card font: %w", err)
+	}
+	return [2]*opentype.Font{regular, bold}, nil
+})
+
+// shareCardFaces holds the sized faces for one card render. Faces cache glyph
+// data and are not safe for concurrent use, so each render gets its own.
+type shareCardFaces struct {
+	brand    font.Face
+	title    font.Face
+	subtitle font.Face
+	body     font.Face
+	progress font.Face
+	caption  font.Face
+}
+
+func newShareCardFaces() (*shareCardFaces, error) {
+	fonts, err := shareCardFonts()
+	if err != nil {
+		return nil, err
+	}
+	regular, bold := fonts[0], fonts[1]
+
+	faces := &shareCardFaces{}
+	for _, spec := range []struct {
+		face *font.Face
+		font *opentype.Font
+		size float64
+	}{
+		{&faces.brand, bold, 24},
+		{&faces.title, bold, 50},
+		{&faces.subtitle, regular, 24},
+		{&faces.body, regular, 26},
+		{&faces.progress, bold, 32},
+		{&faces.caption, bold, 30},
+	} {
+		face, err := opentype.NewFace(spec.font, &opentype.FaceOptions{Size: spec.size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to size share card font: %w", err)
+		}
+		*spec.face = face
+	}
+
+	return faces, nil
+}
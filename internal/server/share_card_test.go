@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testNeedShareCard() *needShareCard {
+	return &needShareCard{
+		NeedID:         "need-123",
+		Title:          "Maria's Request",
+		CategoryName:   "Housing",
+		Summary:        "Help Maria cover two months of rent after an unexpected hospital stay kept her from work.",
+		RaisedCents:    45000,
+		GoalCents:      120000,
+		FundingPercent: 37,
+		DonateURL:      "https://bodyofchrist.example/need/need-123/donate",
+	}
+}
+
+func runeWidth(text string) int {
+	return len([]rune(text))
+}
+
+func TestWrapShareCardText_WrapsOnWords(t *testing.T) {
+	lines := wrapShareCardText("help with rent and utilities this month", runeWidth, 15, 4)
+	want := []string{"help with rent", "and utilities", "this month"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+}
+
+func TestWrapShareCardText_TruncatesExtraLines(t *testing.T) {
+	lines := wrapShareCardText("one two three four five six seven eight nine ten", runeWidth, 10, 2)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), lines)
+	}
+	if !strings.HasSuffix(lines[1], "...") {
+		t.Fatalf("expected truncation marker, got %q", lines[1])
+	}
+	for _, line := range lines {
+		if len(line) > 10 {
+			t.Fatalf("line %q exceeds max width", line)
+		}
+	}
+}
+
+func TestRenderNeedShareCardPNG(t *testing.T) {
+	body, err := renderNeedShareCardPNG(testNeedShareCard())
+	if err != nil {
+		t.Fatalf("render share card: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("decode share card: %v", err)
+	}
+	if img.Bounds().Dx() != shareCardWidth || img.Bounds().Dy() != shareCardHeight {
+		t.Fatalf("unexpected card size %v", img.Bounds())
+	}
+}
+
+func TestDrawCardText_RendersAccentedLetters(t *testing.T) {
+	faces, err := newShareCardFaces()
+	if err != nil {
+		t.Fatalf("load share card faces: %v", err)
+	}
+
+	render := func(text string) []byte {
+		img := image.NewRGBA(image.Rect(0, 0, 80, 80))
+		drawCardText(img, 8, 8, text, faces.title, shareCardInk)
+		return img.Pix
+	}
+
+	accented := render("é")
+	if bytes.Equal(accented, render("e")) || bytes.Equal(accented, render("?")) {
+		t.Fatalf("expected é to render as its own glyph")
+	}
+}
+
+func TestBuildNeedFlyerPDF(t *testing.T) {
+	body, err := buildNeedFlyerPDF(testNeedShareCard())
+	if err != nil {
+		t.Fatalf("build flyer: %v", err)
+	}
+	if !bytes.HasPrefix(body, []byte("%PDF-")) {
+		t.Fatalf("expected pdf output")
+	}
+}
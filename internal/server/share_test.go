@@ -0,0 +1,57 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"christjesus/pkg/types"
+)
+
+func TestTruncateShareDescription_ShortTextUnchanged(t *testing.T) {
+	got := truncateShareDescription("Help with rent this month.", 200)
+	if got != "Help with rent this month." {
+		t.Fatalf("unexpected description: %q", got)
+	}
+}
+
+func TestTruncateShareDescription_CollapsesWhitespace(t *testing.T) {
+	got := truncateShareDescription("  Help   with\n\nrent\tthis month. ", 200)
+	if got != "Help with rent this month." {
+		t.Fatalf("unexpected description: %q", got)
+	}
+}
+
+func TestTruncateShareDescription_CutsAtWordBoundary(t *testing.T) {
+	text := strings.Repeat("neighbor ", 40)
+
+	got := truncateShareDescription(text, 50)
+	if utf8.RuneCountInString(got) > 50 {
+		t.Fatalf("expected at most 50 runes, got %d", utf8.RuneCountInString(got))
+	}
+	if !strings.HasSuffix(got, "neighbor…") {
+		t.Fatalf("expected cut at word boundary with ellipsis, got %q", got)
+	}
+}
+
+func TestNeedIsShareable(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name string
+		need *types.Need
+		want bool
+	}{
+		{"active", &types.Need{Status: types.NeedStatusActive}, true},
+		{"funded", &types.Need{Status: types.NeedStatusFunded}, true},
+		{"draft", &types.Need{Status: types.NeedStatusDraft}, false},
+		{"under review", &types.Need{Status: types.NeedStatusUnderReview}, false},
+		{"hidden", &types.Need{Status: types.NeedStatusActive, HiddenAt: &now}, false},
+		{"deleted", &types.Need{Status: types.NeedStatusFunded, DeletedAt: &now}, false},
+	}
+	for _, tc := range cases {
+		if got := needIsShareable(tc.need); got != tc.want {
+			t.Errorf("%s: needIsShareable = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
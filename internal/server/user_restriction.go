@@ -0,0 +1,326 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"christjesus/internal/store"
+	"christjesus/pkg/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const maxRestrictionReasonLength = 1000
+
+// suspensionDayOptions are the suspension lengths offered to admins. Anything
+// longer should be a ban, which can still be lifted.
+var suspensionDayOptions = []int{1, 3, 7, 14, 30, 90}
+
+// restrictionMessage is what a suspended or banned user sees when they try to
+// sign in or reach a signed-in page. The admin's reason stays internal.
+func restrictionMessage(restriction *types.UserRestriction) string {
+	if restriction.Kind == types.UserRestrictionBanned || restriction.ExpiresAt == nil {
+		return "This account has been banned. Contact us if you believe this is a mistake."
+	}
+	return fmt.Sprintf("This account is suspended until %s. Contact us if you believe this is a mistake.", restriction.ExpiresAt.Format("January 2, 2006"))
+}
+
+func (s *Service) redirectRestrictedUser(w http.ResponseWriter, r *http.Request, restriction *types.UserRestriction) {
+	v := url.Values{}
+	v.Set("error", restrictionMessage(restriction))
+	http.Redirect(w, r, s.routeWithQuery(RouteHome, v), http.StatusSeeOther)
+}
+
+// parseUserRestrictionForm reads a suspend or ban request. The returned
+// message is non-empty when the form is invalid.
+func parseUserRestrictionForm(r *http.Request, now time.Time) (*types.UserRestriction, string) {
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		return nil, "a reason is required"
+	}
+	if len([]rune(reason)) > maxRestrictionReasonLength {
+		return nil, "reason is too long"
+	}
+
+	restriction := &types.UserRestriction{Reason: reason}
+
+	switch types.UserRestrictionKind(strings.TrimSpace(r.FormValue("kind"))) {
+	case types.UserRestrictionSuspended:
+		days, err := strconv.Atoi(strings.TrimSpace(r.FormValue("duration_days")))
+		if err != nil || !slices.Contains(suspensionDayOptions, days) {
+			return nil, "choose a suspension length"
+		}
+		expiresAt := now.AddDate(0, 0, days)
+		restriction.Kind = types.UserRestrictionSuspended
+		restriction.ExpiresAt = &expiresAt
+	case types.UserRestrictionBanned:
+		if r.FormValue("confirm") != "1" {
+			return nil, "confirm the ban before continuing"
+		}
+		restriction.Kind = types.UserRestrictionBanned
+	default:
+		return nil, "choose suspend or ban"
+	}
+
+	return restriction, ""
+}
+
+func (s *Service) handlePostAdminUserRestrict(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := strings.TrimSpace(r.PathValue("userID"))
+	if userID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminUserDetailWithError(w, r, userID, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminUserDetailWithError(w, r, userID, "missing actor identity")
+		return
+	}
+	if session.UserID == userID {
+		s.redirectAdminUserDetailWithError(w, r, userID, "you cannot suspend or ban your own account")
+		return
+	}
+
+	user, err := s.userRepo.User(ctx, userID)
+	if err != nil {
+		if errors.Is(err, types.ErrUserNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to fetch user to restrict")
+		s.internalServerError(w)
+		return
+	}
+
+	now := time.Now()
+	restriction, message := parseUserRestrictionForm(r, now)
+	if message != "" {
+		s.redirectAdminUserDetailWithError(w, r, user.ID, message)
+		return
+	}
+	restriction.UserID = user.ID
+	restriction.CreatedByUserID = &session.UserID
+	restriction.CreatedAt = now
+
+	var hiddenCount int
+	err = store.WithTx(ctx, s.userRestrictionRepo, func(tx pgx.Tx) error {
+		open, err := s.userRestrictionRepo.OpenRestrictionForUpdateTx(ctx, tx, user.ID)
+		if err != nil {
+			return err
+		}
+		if open != nil {
+			if open.ActiveAt(now) {
+				return types.ErrUserAlreadyRestricted
+			}
+			// A suspension that has run out but that the expiry job has not
+			// reached yet; close it out before starting the new one.
+			if _, err := s.liftUserRestrictionTx(ctx, tx, open, nil, "suspension expired"); err != nil {
+				return err
+			}
+		}
+
+		if err := s.userRestrictionRepo.CreateRestrictionTx(ctx, tx, restriction); err != nil {
+			return err
+		}
+
+		hiddenCount, err = s.needsRepo.HideActiveNeedsByUserTx(ctx, tx, user.ID, restriction.ID)
+		if err != nil {
+			return err
+		}
+
+		action := types.AdminAuditActionUserBanned
+		detail := fmt.Sprintf("banned (restriction %s), %d active needs hidden: %s", restriction.ID, hiddenCount, restriction.Reason)
+		if restriction.Kind == types.UserRestrictionSuspended {
+			action = types.AdminAuditActionUserSuspended
+			detail = fmt.Sprintf("suspended until %s (restriction %s), %d active needs hidden: %s",
+				restriction.ExpiresAt.Format(time.DateTime), restriction.ID, hiddenCount, restriction.Reason)
+		}
+		return s.adminAuditRepo.RecordEventTx(ctx, tx, s.adminAuditEvent(r, action, types.AdminAuditTargetUser, user.ID, detail))
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrUserAlreadyRestricted) {
+			s.redirectAdminUserDetailWithError(w, r, user.ID, "user is already suspended or banned; lift that first")
+			return
+		}
+		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to restrict user")
+		s.redirectAdminUserDetailWithError(w, r, user.ID, "failed to restrict user; nothing was changed")
+		return
+	}
+
+	s.logger.WithField("user_id", user.ID).WithField("restriction_id", restriction.ID).WithField("kind", restriction.Kind).WithField("needs_hidden", hiddenCount).Info("user restricted")
+
+	notice := fmt.Sprintf("User banned and %d active needs hidden", hiddenCount)
+	if restriction.Kind == types.UserRestrictionSuspended {
+		notice = fmt.Sprintf("User suspended until %s and %d active needs hidden", restriction.ExpiresAt.Format(time.DateOnly), hiddenCount)
+	}
+	s.redirectAdminUserDetailWithNotice(w, r, user.ID, notice)
+}
+
+func (s *Service) handlePostAdminUserRestrictionLift(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := strings.TrimSpace(r.PathValue("userID"))
+	if userID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.redirectAdminUserDetailWithError(w, r, userID, "invalid form submission")
+		return
+	}
+
+	session, ok := sessionFromRequest(r)
+	if !ok {
+		s.logger.Error("session not found on context")
+		s.redirectAdminUserDetailWithError(w, r, userID, "missing actor identity")
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		s.redirectAdminUserDetailWithError(w, r, userID, "a reason is required to lift a restriction")
+		return
+	}
+	if len([]rune(reason)) > maxRestrictionReasonLength {
+		s.redirectAdminUserDetailWithError(w, r, userID, "reason is too long")
+		return
+	}
+
+	var unhiddenCount int
+	err := store.WithTx(ctx, s.userRestrictionRepo, func(tx pgx.Tx) error {
+		open, err := s.userRestrictionRepo.OpenRestrictionForUpdateTx(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if open == nil {
+			return types.ErrUserRestrictionNotFound
+		}
+
+		unhiddenCount, err = s.liftUserRestrictionTx(ctx, tx, open, &session.UserID, reason)
+		if err != nil {
+			return err
+		}
+
+		detail := fmt.Sprintf("lifted %s (restriction %s), %d needs visible again: %s", open.Kind, open.ID, unhiddenCount, reason)
+		return s.adminAuditRepo.RecordEventTx(ctx, tx, s.adminAuditEvent(r, types.AdminAuditActionRestrictionLifted, types.AdminAuditTargetUser, userID, detail))
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrUserRestrictionNotFound) {
+			s.redirectAdminUserDetailWithError(w, r, userID, "user has no restriction to lift")
+			return
+		}
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to lift user restriction")
+		s.redirectAdminUserDetailWithError(w, r, userID, "failed to lift restriction; nothing was changed")
+		return
+	}
+
+	s.logger.WithField("user_id", userID).WithField("needs_unhidden", unhiddenCount).Info("user restriction lifted")
+
+	s.redirectAdminUserDetailWithNotice(w, r, userID, fmt.Sprintf("Restriction lifted and %d needs visible again", unhiddenCount))
+}
+
+// liftUserRestrictionTx ends a restriction and brings back the needs it hid.
+func (s *Service) liftUserRestrictionTx(ctx context.Context, tx pgx.Tx, restriction *types.UserRestriction, liftedByUserID *string, reason string) (int, error) {
+	if err := s.userRestrictionRepo.LiftRestrictionTx(ctx, tx, restriction.ID, liftedByUserID, reason); err != nil {
+		return 0, err
+	}
+	return s.needsRepo.UnhideNeedsByRestrictionTx(ctx, tx, restriction.ID)
+}
+
+// populateAdminUserRestrictions fills the account restriction section of the
+// user detail page.
+func (s *Service) populateAdminUserRestrictions(ctx context.Context, data *types.AdminUserDetailPageData, userID string) {
+	restrictions, err := s.userRestrictionRepo.RestrictionsByUser(ctx, userID)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", userID).Error("failed to fetch restrictions for admin user detail")
+		return
+	}
+	if len(restrictions) == 0 {
+		return
+	}
+
+	actorIDs := make([]string, 0, len(restrictions)*2)
+	for _, restriction := range restrictions {
+		if restriction.CreatedByUserID != nil {
+			actorIDs = append(actorIDs, *restriction.CreatedByUserID)
+		}
+		if restriction.LiftedByUserID != nil {
+			actorIDs = append(actorIDs, *restriction.LiftedByUserID)
+		}
+	}
+
+	actorNames := make(map[string]string)
+	if len(actorIDs) > 0 {
+		actors, err := s.userRepo.UsersByIDs(ctx, uniqueSortedStrings(actorIDs))
+		if err != nil {
+			s.logger.WithError(err).WithField("user_id", userID).Warn("failed to fetch restriction actors for admin user detail")
+		}
+		for _, actor := range actors {
+			actorNames[actor.ID] = userDisplayName(actor)
+		}
+	}
+	actorName := func(id *string) string {
+		if id == nil {
+			return "system"
+		}
+		if name, ok := actorNames[*id]; ok {
+			return name
+		}
+		return *id
+	}
+
+	now := time.Now()
+	items := make([]*types.AdminUserRestrictionItem, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		item := &types.AdminUserRestrictionItem{
+			Kind:      restriction.Kind,
+			Reason:    restriction.Reason,
+			CreatedAt: restriction.CreatedAt.Format(time.DateTime),
+			CreatedBy: actorName(restriction.CreatedByUserID),
+			ExpiresAt: "Never",
+			IsActive:  restriction.ActiveAt(now),
+		}
+		if restriction.ExpiresAt != nil {
+			item.ExpiresAt = restriction.ExpiresAt.Format(time.DateTime)
+		}
+		if restriction.LiftedAt != nil {
+			item.LiftedAt = restriction.LiftedAt.Format(time.DateTime)
+			item.LiftedBy = actorName(restriction.LiftedByUserID)
+			item.LiftReason = formatOptionalString(restriction.LiftReason)
+		}
+		// An unlifted suspension past its expiry no longer blocks the user
+		// but still hides their needs until it is lifted.
+		if restriction.LiftedAt == nil && data.Restriction == nil {
+			data.Restriction = item
+		}
+		items = append(items, item)
+	}
+	data.RestrictionHistory = items
+}
+
+func (s *Service) redirectAdminUserDetailWithError(w http.ResponseWriter, r *http.Request, userID, message string) {
+	v := url.Values{}
+	v.Set("error", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminUserDetail, v, Param("userID", userID)), http.StatusSeeOther)
+}
+
+func (s *Service) redirectAdminUserDetailWithNotice(w http.ResponseWriter, r *http.Request, userID, message string) {
+	v := url.Values{}
+	v.Set("notice", message)
+	http.Redirect(w, r, s.routeWithQuery(RouteAdminUserDetail, v, Param("userID", userID)), http.StatusSeeOther)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"christjesus/pkg/types"
+)
+
+func TestParseUserRestrictionForm(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	parse := func(values url.Values) (*types.UserRestriction, string) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/u1/restrict", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return parseUserRestrictionForm(req, now)
+	}
+
+	restriction, message := parse(url.Values{"kind": {"suspended"}, "duration_days": {"7"}, "reason": {" spam "}})
+	if message != "" {
+		t.Fatalf("suspend message = %q", message)
+	}
+	if restriction.Kind != types.UserRestrictionSuspended || restriction.Reason != "spam" {
+		t.Fatalf("suspend = %+v", restriction)
+	}
+	if restriction.ExpiresAt == nil || !restriction.ExpiresAt.Equal(now.AddDate(0, 0, 7)) {
+		t.Fatalf("suspend expires at = %v", restriction.ExpiresAt)
+	}
+
+	restriction, message = parse(url.Values{"kind": {"banned"}, "reason": {"fraud"}, "confirm": {"1"}})
+	if message != "" {
+		t.Fatalf("ban message = %q", message)
+	}
+	if restriction.Kind != types.UserRestrictionBanned || restriction.ExpiresAt != nil {
+		t.Fatalf("ban = %+v", restriction)
+	}
+
+	invalid := []url.Values{
+		{"kind": {"suspended"}, "duration_days": {"7"}},
+		{"kind": {"suspended"}, "duration_days": {"5"}, "reason": {"spam"}},
+		{"kind": {"suspended"}, "duration_days": {"-1"}, "reason": {"spam"}},
+		{"kind": {"banned"}, "reason": {"fraud"}},
+		{"kind": {"muted"}, "reason": {"spam"}},
+		{"kind": {"banned"}, "reason": {strings.Repeat("x", maxRestrictionReasonLength+1)}, "confirm": {"1"}},
+	}
+	for _, values := range invalid {
+		if _, message := parse(values); message == "" {
+			t.Fatalf("parse(%v) accepted invalid form", values)
+		}
+	}
+}
+
+func TestUserRestrictionActiveAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		restriction *types.UserRestriction
+		want        bool
+	}{
+		{"nil", nil, false},
+		{"ban", &types.UserRestriction{Kind: types.UserRestrictionBanned}, true},
+		{"running suspension", &types.UserRestriction{Kind: types.UserRestrictionSuspended, ExpiresAt: &future}, true},
+		{"expired suspension", &types.UserRestriction{Kind: types.UserRestrictionSuspended, ExpiresAt: &past}, false},
+		{"lifted ban", &types.UserRestriction{Kind: types.UserRestrictionBanned, LiftedAt: &past}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.restriction.ActiveAt(now); got != tt.want {
+			t.Fatalf("%s: ActiveAt = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequireAuth_RestrictedUserRedirectsHome(t *testing.T) {
+	t.Parallel()
+
+	s := &Service{}
+
+	h := s.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called")
+	}))
+
+	expiresAt := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	req := httptest.NewRequest(http.MethodGet, "/onboarding/need/new", nil)
+	req = req.WithContext(context.WithValue(req.Context(), contextKeyRestriction, &types.UserRestriction{
+		Kind:      types.UserRestrictionSuspended,
+		ExpiresAt: &expiresAt,
+	}))
+	rr := httptest.NewRecorder()
+
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusSeeOther)
+	}
+
+	location, err := url.Parse(rr.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("parse Location: %v", err)
+	}
+	if location.Path != "/" {
+		t.Fatalf("Location path = %q, want %q", location.Path, "/")
+	}
+	if got := location.Query().Get("error"); !strings.Contains(got, "suspended until April 2, 2026") {
+		t.Fatalf("error = %q, want suspension notice", got)
+	}
+}
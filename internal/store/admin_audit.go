@@ -0,0 +1,156 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const adminAuditEventsTableName = "christjesus.admin_audit_events"
+
+var adminAuditEventColumns = utils.StructTagValues(types.AdminAuditEvent{})
+
+type AdminAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdminAuditRepository(pool *pgxpool.Pool) *AdminAuditRepository {
+	return &AdminAuditRepository{pool: pool}
+}
+
+// AdminAuditFilter narrows the audit log. Empty fields match everything;
+// Until is exclusive.
+type AdminAuditFilter struct {
+	ActorUserID string
+	Action      string
+	TargetType  string
+	TargetID    string
+	Since       *time.Time
+	Until       *time.Time
+}
+
+func applyAdminAuditFilter(qb sq.SelectBuilder, f AdminAuditFilter) sq.SelectBuilder {
+	if actor := strings.TrimSpace(f.ActorUserID); actor != "" {
+		qb = qb.Where(sq.Eq{"actor_user_id": actor})
+	}
+	if action := strings.TrimSpace(f.Action); action != "" {
+		qb = qb.Where(sq.Eq{"action": action})
+	}
+	if targetType := strings.TrimSpace(f.TargetType); targetType != "" {
+		qb = qb.Where(sq.Eq{"target_type": targetType})
+	}
+	if targetID := strings.TrimSpace(f.TargetID); targetID != "" {
+		qb = qb.Where(sq.Eq{"target_id": targetID})
+	}
+	if f.Since != nil {
+		qb = qb.Where(sq.GtOrEq{"created_at": *f.Since})
+	}
+	if f.Until != nil {
+		qb = qb.Where(sq.Lt{"created_at": *f.Until})
+	}
+	return qb
+}
+
+func (r *AdminAuditRepository) RecordEvent(ctx context.Context, event *types.AdminAuditEvent) error {
+	return r.recordEventWithExec(ctx, r.pool, event)
+}
+
+// RecordEventTx records event inside tx so it commits or rolls back with the
+// change it describes.
+func (r *AdminAuditRepository) RecordEventTx(ctx context.Context, tx pgx.Tx, event *types.AdminAuditEvent) error {
+	return r.recordEventWithExec(ctx, tx, event)
+}
+
+func (r *AdminAuditRepository) recordEventWithExec(ctx context.Context, execer needExecer, event *types.AdminAuditEvent) error {
+	event.ID = utils.NanoID()
+	event.CreatedAt = time.Now()
+
+	query, args, err := psql().
+		Insert(adminAuditEventsTableName).
+		Columns("id", "actor_user_id", "action", "target_type", "target_id", "ip_address", "detail", "created_at").
+		Values(event.ID, event.ActorUserID, event.Action, event.TargetType, event.TargetID, event.IPAddress, event.Detail, event.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate record admin audit event query: %w", err)
+	}
+
+	_, err = execer.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to record admin audit event")
+}
+
+// EventsPage returns one page of matching events, newest first.
+func (r *AdminAuditRepository) EventsPage(ctx context.Context, filter AdminAuditFilter, page, pageSize int) ([]*types.AdminAuditEvent, error) {
+	offset := (page - 1) * pageSize
+
+	query, args, err := applyAdminAuditFilter(psql().Select(adminAuditEventColumns...).From(adminAuditEventsTableName), filter).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(pageSize)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate admin audit events query: %w", err)
+	}
+
+	events := make([]*types.AdminAuditEvent, 0)
+	err = pgxscan.Select(ctx, r.pool, &events, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return events, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load admin audit events")
+	}
+
+	return events, nil
+}
+
+func (r *AdminAuditRepository) EventsCount(ctx context.Context, filter AdminAuditFilter) (int, error) {
+	query, args, err := applyAdminAuditFilter(psql().Select("COUNT(*)").From(adminAuditEventsTableName), filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate admin audit events count query: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to count admin audit events")
+	}
+
+	return total, nil
+}
+
+// EachEvent streams every matching event, newest first, without holding the
+// full result in memory. Iteration stops at the first error fn returns.
+func (r *AdminAuditRepository) EachEvent(ctx context.Context, filter AdminAuditFilter, fn func(*types.AdminAuditEvent) error) error {
+	query, args, err := applyAdminAuditFilter(psql().Select(adminAuditEventColumns...).From(adminAuditEventsTableName), filter).
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate admin audit export query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to query admin audit events")
+	}
+	defer rows.Close()
+
+	scanner := pgxscan.NewRowScanner(rows)
+	for rows.Next() {
+		var event types.AdminAuditEvent
+		if err := scanner.Scan(&event); err != nil {
+			return utils.ErrorWrapOrNil(err, "failed to scan admin audit event")
+		}
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+
+	return utils.ErrorWrapOrNil(rows.Err(), "failed to read admin audit events")
+}
@@ -0,0 +1,226 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	adminNotesTableName         = "christjesus.admin_notes"
+	adminNoteRevisionsTableName = "christjesus.admin_note_revisions"
+)
+
+var (
+	adminNoteColumns         = utils.StructTagValues(types.AdminNote{})
+	adminNoteRevisionColumns = utils.StructTagValues(types.AdminNoteRevision{})
+)
+
+type AdminNoteRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdminNoteRepository(pool *pgxpool.Pool) *AdminNoteRepository {
+	return &AdminNoteRepository{pool: pool}
+}
+
+func (r *AdminNoteRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// NotesByNeed returns the notes about a need, pinned notes first and then
+// newest first.
+func (r *AdminNoteRepository) NotesByNeed(ctx context.Context, needID string) ([]*types.AdminNote, error) {
+	return r.notesWhere(ctx, sq.Eq{"need_id": needID})
+}
+
+// NotesByUser returns the notes about a user, pinned notes first and then
+// newest first.
+func (r *AdminNoteRepository) NotesByUser(ctx context.Context, userID string) ([]*types.AdminNote, error) {
+	return r.notesWhere(ctx, sq.Eq{"user_id": userID})
+}
+
+func (r *AdminNoteRepository) notesWhere(ctx context.Context, pred sq.Eq) ([]*types.AdminNote, error) {
+	query, args, err := psql().
+		Select(adminNoteColumns...).
+		From(adminNotesTableName).
+		Where(pred).
+		OrderBy("pinned_at IS NULL", "pinned_at DESC", "created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate admin notes query: %w", err)
+	}
+
+	notes := make([]*types.AdminNote, 0)
+	err = pgxscan.Select(ctx, r.pool, &notes, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return notes, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load admin notes")
+	}
+
+	return notes, nil
+}
+
+func (r *AdminNoteRepository) Note(ctx context.Context, noteID string) (*types.AdminNote, error) {
+	query, args, err := psql().
+		Select(adminNoteColumns...).
+		From(adminNotesTableName).
+		Where(sq.Eq{"id": noteID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate admin note query: %w", err)
+	}
+
+	var note types.AdminNote
+	if err := pgxscan.Get(ctx, r.pool, &note, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrAdminNoteNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load admin note")
+	}
+
+	return &note, nil
+}
+
+// RevisionsByNotes returns the earlier bodies of the given notes keyed by
+// note ID, newest first.
+func (r *AdminNoteRepository) RevisionsByNotes(ctx context.Context, noteIDs []string) (map[string][]*types.AdminNoteRevision, error) {
+	byNote := make(map[string][]*types.AdminNoteRevision)
+	if len(noteIDs) == 0 {
+		return byNote, nil
+	}
+
+	query, args, err := psql().
+		Select(adminNoteRevisionColumns...).
+		From(adminNoteRevisionsTableName).
+		Where(sq.Eq{"note_id": noteIDs}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate admin note revisions query: %w", err)
+	}
+
+	revisions := make([]*types.AdminNoteRevision, 0)
+	if err := pgxscan.Select(ctx, r.pool, &revisions, query, args...); err != nil && !pgxscan.NotFound(err) {
+		return nil, utils.ErrorWrapOrNil(err, "failed to load admin note revisions")
+	}
+
+	for _, revision := range revisions {
+		byNote[revision.NoteID] = append(byNote[revision.NoteID], revision)
+	}
+	return byNote, nil
+}
+
+func (r *AdminNoteRepository) CreateNote(ctx context.Context, note *types.AdminNote) error {
+	now := time.Now()
+	note.ID = utils.NanoID()
+	note.CreatedAt = now
+	note.UpdatedAt = now
+
+	query, args, err := psql().
+		Insert(adminNotesTableName).
+		Columns("id", "need_id", "user_id", "author_user_id", "body", "created_at", "updated_at").
+		Values(note.ID, note.NeedID, note.UserID, note.AuthorUserID, note.Body, note.CreatedAt, note.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create admin note query: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create admin note")
+}
+
+// UpdateNoteBodyTx replaces a note's body and keeps the old body as a
+// revision. It returns the note as it was before the edit, or
+// types.ErrAdminNoteNotFound. An unchanged body records nothing.
+func (r *AdminNoteRepository) UpdateNoteBodyTx(ctx context.Context, tx pgx.Tx, noteID, body, editorUserID string) (*types.AdminNote, error) {
+	query, args, err := psql().
+		Select(adminNoteColumns...).
+		From(adminNotesTableName).
+		Where(sq.Eq{"id": noteID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock admin note query: %w", err)
+	}
+
+	var note types.AdminNote
+	if err := pgxscan.Get(ctx, tx, &note, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrAdminNoteNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to lock admin note")
+	}
+
+	if note.Body == body {
+		return &note, nil
+	}
+
+	now := time.Now()
+	query, args, err = psql().
+		Insert(adminNoteRevisionsTableName).
+		Columns("id", "note_id", "body", "edited_by_user_id", "created_at").
+		Values(utils.NanoID(), note.ID, note.Body, editorUserID, now).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate insert admin note revision query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return nil, utils.ErrorWrapOrNil(err, "failed to record admin note revision")
+	}
+
+	query, args, err = psql().
+		Update(adminNotesTableName).
+		Set("body", body).
+		Set("updated_by_user_id", editorUserID).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": note.ID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate update admin note query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return nil, utils.ErrorWrapOrNil(err, "failed to update admin note")
+	}
+
+	return &note, nil
+}
+
+// SetNotePinnedTx pins a note to the top of its list or unpins it. Pinning
+// does not count as an edit; callers audit it instead.
+func (r *AdminNoteRepository) SetNotePinnedTx(ctx context.Context, tx pgx.Tx, noteID string, pinned bool) error {
+	var pinnedAt *time.Time
+	if pinned {
+		now := time.Now()
+		pinnedAt = &now
+	}
+
+	query, args, err := psql().
+		Update(adminNotesTableName).
+		Set("pinned_at", pinnedAt).
+		Where(sq.Eq{"id": noteID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate pin admin note query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to pin admin note")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrAdminNoteNotFound
+	}
+
+	return nil
+}
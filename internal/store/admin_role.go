@@ -0,0 +1,201 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const adminRoleGrantsTableName = "christjesus.admin_role_grants"
+
+var adminRoleGrantColumns = utils.StructTagValues(types.AdminRoleGrant{})
+
+type AdminRoleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdminRoleRepository(pool *pgxpool.Pool) *AdminRoleRepository {
+	return &AdminRoleRepository{pool: pool}
+}
+
+func (r *AdminRoleRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// ActiveRolesByUser returns the roles a user currently holds.
+func (r *AdminRoleRepository) ActiveRolesByUser(ctx context.Context, userID string) ([]types.AdminRole, error) {
+	query, args, err := psql().
+		Select("role").
+		From(adminRoleGrantsTableName).
+		Where(sq.Eq{"user_id": userID, "revoked_at": nil}).
+		OrderBy("role").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate active admin roles query: %w", err)
+	}
+
+	roles := make([]types.AdminRole, 0)
+	err = pgxscan.Select(ctx, r.pool, &roles, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return roles, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load active admin roles")
+	}
+
+	return roles, nil
+}
+
+// ActiveGrants returns every grant that has not been revoked, grouped by role.
+func (r *AdminRoleRepository) ActiveGrants(ctx context.Context) ([]*types.AdminRoleGrant, error) {
+	query, args, err := psql().
+		Select(adminRoleGrantColumns...).
+		From(adminRoleGrantsTableName).
+		Where(sq.Eq{"revoked_at": nil}).
+		OrderBy("role", "created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate active admin role grants query: %w", err)
+	}
+
+	grants := make([]*types.AdminRoleGrant, 0)
+	err = pgxscan.Select(ctx, r.pool, &grants, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return grants, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load active admin role grants")
+	}
+
+	return grants, nil
+}
+
+// ActiveRoleCount returns how many users currently hold role.
+func (r *AdminRoleRepository) ActiveRoleCount(ctx context.Context, role types.AdminRole) (int, error) {
+	query, args, err := psql().
+		Select("COUNT(*)").
+		From(adminRoleGrantsTableName).
+		Where(sq.Eq{"role": role, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate admin role count query: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count admin role grants: %w", err)
+	}
+
+	return total, nil
+}
+
+// GrantRole gives userID the role. grantedByUserID is nil for grants made
+// outside the admin UI. It returns types.ErrAdminRoleAlreadyHeld when the user
+// already has an active grant for the role.
+func (r *AdminRoleRepository) GrantRole(ctx context.Context, userID string, role types.AdminRole, grantedByUserID *string) (*types.AdminRoleGrant, error) {
+	grant := &types.AdminRoleGrant{
+		ID:              utils.NanoID(),
+		UserID:          userID,
+		Role:            role,
+		GrantedByUserID: grantedByUserID,
+		CreatedAt:       time.Now(),
+	}
+
+	query, args, err := psql().
+		Insert(adminRoleGrantsTableName).
+		Columns("id", "user_id", "role", "granted_by_user_id", "created_at").
+		Values(grant.ID, grant.UserID, grant.Role, grant.GrantedByUserID, grant.CreatedAt).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate grant admin role query: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, types.ErrAdminRoleAlreadyHeld
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to grant admin role")
+	}
+
+	return grant, nil
+}
+
+// RevokeGrant ends an active grant. The last active superadmin grant cannot
+// be revoked so the roles page always has someone able to use it.
+func (r *AdminRoleRepository) RevokeGrant(ctx context.Context, grantID, revokedByUserID string) (*types.AdminRoleGrant, error) {
+	var grant types.AdminRoleGrant
+	err := WithTx(ctx, r, func(tx pgx.Tx) error {
+		lockQuery, lockArgs, err := psql().
+			Select(adminRoleGrantColumns...).
+			From(adminRoleGrantsTableName).
+			Where(sq.Eq{"id": grantID, "revoked_at": nil}).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to generate lock admin role grant query: %w", err)
+		}
+
+		if err := pgxscan.Get(ctx, tx, &grant, lockQuery, lockArgs...); err != nil {
+			if pgxscan.NotFound(err) {
+				return types.ErrAdminRoleGrantNotFound
+			}
+			return utils.ErrorWrapOrNil(err, "failed to lock admin role grant")
+		}
+
+		if grant.Role == types.AdminRoleSuperadmin {
+			// Lock every active superadmin grant so two concurrent revokes
+			// cannot both see a second superadmin.
+			countQuery, countArgs, err := psql().
+				Select("id").
+				From(adminRoleGrantsTableName).
+				Where(sq.Eq{"role": types.AdminRoleSuperadmin, "revoked_at": nil}).
+				Suffix("FOR UPDATE").
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to generate lock superadmin grants query: %w", err)
+			}
+
+			var superadminIDs []string
+			if err := pgxscan.Select(ctx, tx, &superadminIDs, countQuery, countArgs...); err != nil {
+				return utils.ErrorWrapOrNil(err, "failed to lock superadmin grants")
+			}
+			if len(superadminIDs) <= 1 {
+				return types.ErrLastSuperadmin
+			}
+		}
+
+		now := time.Now()
+		revokeQuery, revokeArgs, err := psql().
+			Update(adminRoleGrantsTableName).
+			Set("revoked_at", now).
+			Set("revoked_by_user_id", revokedByUserID).
+			Where(sq.Eq{"id": grantID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to generate revoke admin role grant query: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, revokeQuery, revokeArgs...); err != nil {
+			return utils.ErrorWrapOrNil(err, "failed to revoke admin role grant")
+		}
+
+		grant.RevokedAt = &now
+		grant.RevokedByUserID = &revokedByUserID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &grant, nil
+}
@@ -4,10 +4,13 @@ import (
 	"christjesus/internal/utils"
 	"christjesus/pkg/types"
 	"context"
+	"errors"
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,6 +26,10 @@ func NewCategoryRepository(pool *pgxpool.Pool) *CategoryRepository {
 	return &CategoryRepository{pool: pool}
 }
 
+func (r *CategoryRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
 func (r *CategoryRepository) Categories(ctx context.Context) ([]*types.NeedCategory, error) {
 	query, args, err := psql().
 		Select(categoryColumns...).
@@ -136,6 +143,10 @@ func (r *CategoryRepository) CreateCategory(ctx context.Context, category *types
 
 	_, err = r.pool.Exec(ctx, query, args...)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return types.ErrCategorySlugTaken
+		}
 		return fmt.Errorf("failed to insert category: %w", err)
 	}
 
@@ -192,6 +203,236 @@ func (r *CategoryRepository) DeleteCategory(ctx context.Context, id string) erro
 	return nil
 }
 
+// UpdateCategory saves the admin-editable fields of a category. Order and
+// active state have their own methods.
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, category *types.NeedCategory) error {
+	query, args, err := psql().
+		Update(categoryTableName).
+		Set("name", category.Name).
+		Set("slug", category.Slug).
+		Set("description", category.Description).
+		Set("icon", category.Icon).
+		Where(sq.Eq{"id": category.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate update category query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return types.ErrCategorySlugTaken
+		}
+		return utils.ErrorWrapOrNil(err, "failed to update category")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) SetCategoryActive(ctx context.Context, id string, active bool) error {
+	return r.setCategoryActiveWithExec(ctx, r.pool, id, active)
+}
+
+func (r *CategoryRepository) SetCategoryActiveTx(ctx context.Context, tx pgx.Tx, id string, active bool) error {
+	return r.setCategoryActiveWithExec(ctx, tx, id, active)
+}
+
+func (r *CategoryRepository) setCategoryActiveWithExec(ctx context.Context, execer needExecer, id string, active bool) error {
+	query, args, err := psql().
+		Update(categoryTableName).
+		Set("is_active", active).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate set category active query: %w", err)
+	}
+
+	tag, err := execer.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to set category active")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// ReorderCategoriesTx numbers categories 1..n in the order of ids.
+func (r *CategoryRepository) ReorderCategoriesTx(ctx context.Context, tx pgx.Tx, ids []string) error {
+	for index, id := range ids {
+		query, args, err := psql().
+			Update(categoryTableName).
+			Set("display_order", index+1).
+			Where(sq.Eq{"id": id}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to generate reorder category query: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return utils.ErrorWrapOrNil(err, "failed to reorder categories")
+		}
+	}
+
+	return nil
+}
+
+// CategoryUsage counts the needs and donor preferences referencing each
+// category, keyed by category ID. Every need is counted whatever its status.
+func (r *CategoryRepository) CategoryUsage(ctx context.Context) (map[string]types.CategoryUsage, error) {
+	query, args, err := psql().
+		Select(
+			"c.id",
+			"(SELECT COUNT(*) FROM "+assignmentTableName+" a WHERE a.category_id = c.id) AS need_count",
+			"(SELECT COUNT(*) FROM "+donorPreferenceAssignmentTableName+" d WHERE d.category_id = c.id) AS donor_preference_count",
+		).
+		From(categoryTableName + " c").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate category usage query: %w", err)
+	}
+
+	rows := make([]struct {
+		ID string `db:"id"`
+		types.CategoryUsage
+	}, 0)
+	if err := pgxscan.Select(ctx, r.pool, &rows, query, args...); err != nil {
+		return nil, utils.ErrorWrapOrNil(err, "failed to load category usage")
+	}
+
+	usage := make(map[string]types.CategoryUsage, len(rows))
+	for _, row := range rows {
+		usage[row.ID] = row.CategoryUsage
+	}
+
+	return usage, nil
+}
+
+// MergeCategoryTx moves every need, donor preference, line item, pending
+// reallocation and open feature slot from sourceID onto targetID. A need or donor already holding the target keeps
+// that row and drops the source one; a need whose primary category was the
+// source gets the target as its primary instead.
+func (r *CategoryRepository) MergeCategoryTx(ctx context.Context, tx pgx.Tx, sourceID, targetID string) (*types.CategoryMergeResult, error) {
+	result := &types.CategoryMergeResult{}
+
+	// Needs that already have the target: drop the source row, remembering
+	// the ones where it was primary.
+	query, args, err := psql().
+		Delete(assignmentTableName+" s").
+		Where(sq.Eq{"s.category_id": sourceID}).
+		Where("EXISTS (SELECT 1 FROM "+assignmentTableName+" t WHERE t.need_id = s.need_id AND t.category_id = ?)", targetID).
+		Suffix("RETURNING s.need_id, s.is_primary").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate merge duplicate assignments query: %w", err)
+	}
+
+	duplicates := make([]struct {
+		NeedID    string `db:"need_id"`
+		IsPrimary bool   `db:"is_primary"`
+	}, 0)
+	if err := pgxscan.Select(ctx, tx, &duplicates, query, args...); err != nil {
+		return nil, utils.ErrorWrapOrNil(err, "failed to drop duplicate need category assignments")
+	}
+
+	promote := make([]string, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		if duplicate.IsPrimary {
+			promote = append(promote, duplicate.NeedID)
+		}
+	}
+	if len(promote) > 0 {
+		query, args, err = psql().
+			Update(assignmentTableName).
+			Set("is_primary", true).
+			Where(sq.Eq{"need_id": promote, "category_id": targetID}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate promote merged assignments query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return nil, utils.ErrorWrapOrNil(err, "failed to promote merged need category assignments")
+		}
+	}
+
+	moved, err := moveCategoryReferences(ctx, tx, assignmentTableName, "category_id", sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	result.NeedsMoved = moved
+
+	query, args, err = psql().
+		Delete(donorPreferenceAssignmentTableName+" s").
+		Where(sq.Eq{"s.category_id": sourceID}).
+		Where("EXISTS (SELECT 1 FROM "+donorPreferenceAssignmentTableName+" t WHERE t.user_id = s.user_id AND t.category_id = ?)", targetID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate merge duplicate donor preferences query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return nil, utils.ErrorWrapOrNil(err, "failed to drop duplicate donor preference assignments")
+	}
+
+	moved, err = moveCategoryReferences(ctx, tx, donorPreferenceAssignmentTableName, "category_id", sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	result.DonorPreferencesMoved = moved
+
+	moved, err = moveCategoryReferences(ctx, tx, needLineItemsTableName, "category_id", sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	result.LineItemsMoved = moved
+
+	// Completed reallocations and ended feature slots are history and keep
+	// pointing at the source.
+	moved, err = moveCategoryReferences(ctx, tx, fundReallocationsTableName, "target_category_id", sourceID, targetID,
+		sq.NotEq{"status": types.FundReallocationStatusCompleted})
+	if err != nil {
+		return nil, err
+	}
+	result.ReallocationsMoved = moved
+
+	moved, err = moveCategoryReferences(ctx, tx, needFeatureSlotsTableName, "category_id", sourceID, targetID,
+		sq.Eq{"ended_at": nil})
+	if err != nil {
+		return nil, err
+	}
+	result.FeatureSlotsMoved = moved
+
+	return result, nil
+}
+
+func moveCategoryReferences(ctx context.Context, tx pgx.Tx, table, column, sourceID, targetID string, filters ...sq.Sqlizer) (int, error) {
+	update := psql().
+		Update(table).
+		Set(column, targetID).
+		Where(sq.Eq{column: sourceID})
+	for _, filter := range filters {
+		update = update.Where(filter)
+	}
+
+	query, args, err := update.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate move category references query for %s: %w", table, err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to move category references in "+table)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
 // buildUpdateClause creates the SET clause for ON CONFLICT DO UPDATE
 // e.g., "name = EXCLUDED.name, slug = EXCLUDED.slug, ..."
 func buildUpdateClause(fields map[string]interface{}) string {
@@ -21,6 +21,7 @@ var documentTableColumns = []string{
 	"file_size_bytes",
 	"mime_type",
 	"storage_key",
+	"content_sha256",
 	"uploaded_at",
 }
 
@@ -81,6 +82,29 @@ func (r *DocumentRepository) DocumentsByNeedID(ctx context.Context, needID strin
 	return docs, nil
 }
 
+// DocumentsByContentHashes returns documents on other needs whose uploaded
+// bytes hash to one of the given values.
+func (r *DocumentRepository) DocumentsByContentHashes(ctx context.Context, excludeNeedID string, hashes []string) ([]types.NeedDocument, error) {
+	if len(hashes) == 0 {
+		return []types.NeedDocument{}, nil
+	}
+
+	query, args, _ := psql().
+		Select(documentTableColumns...).
+		From(documentTableName).
+		Where(squirrel.Eq{"content_sha256": hashes}).
+		Where(squirrel.NotEq{"need_id": excludeNeedID}).
+		OrderBy("uploaded_at DESC").
+		ToSql()
+
+	var docs []types.NeedDocument
+	err := pgxscan.Select(ctx, r.pool, &docs, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
 // CreateDocument inserts a new document record
 func (r *DocumentRepository) CreateDocument(ctx context.Context, doc *types.NeedDocument) error {
 	query, args, _ := psql().
@@ -95,6 +119,7 @@ func (r *DocumentRepository) CreateDocument(ctx context.Context, doc *types.Need
 			doc.FileSizeBytes,
 			doc.MimeType,
 			doc.StorageKey,
+			doc.ContentSHA256,
 			doc.UploadedAt,
 		).
 		ToSql()
@@ -175,29 +175,45 @@ func (r *DonationIntentRepository) FinalizeIntentByID(ctx context.Context, inten
 
 		finalized = true
 
-		syncQuery, syncArgs, err := psql().
-			Update(needTableName).
-			Set("amount_raised_cents", sq.Expr(
-				"(SELECT COALESCE(SUM(amount_cents), 0) FROM "+donationIntentTableName+" WHERE need_id = ? AND LOWER(payment_status) = ?)",
-				needID, types.DonationPaymentStatusFinalized,
-			)).
-			Set("updated_at", now).
-			Where(sq.Eq{"id": needID}).
-			ToSql()
-		if err != nil {
-			return fmt.Errorf("failed to generate sync need raised amount query: %w", err)
-		}
-
-		if _, err := tx.Exec(ctx, syncQuery, syncArgs...); err != nil {
-			return fmt.Errorf("failed to sync need raised amount for need %s: %w", needID, err)
-		}
-
-		return nil
+		return syncNeedAmountRaisedTx(ctx, tx, needID, now)
 	})
 
 	return finalized, err
 }
 
+// syncNeedAmountRaisedTx recomputes a need's amount_raised_cents from its
+// finalized gifts, plus funds redirected to it from other needs, minus funds
+// it has given up through completed reallocations, whether redirected or owed
+// back to donors who opted out. Opted-out shares stay in the admin refund
+// queue until they are refunded.
+func syncNeedAmountRaisedTx(ctx context.Context, tx pgx.Tx, needID string, now time.Time) error {
+	reallocatedSum := "(SELECT COALESCE(SUM(dr.amount_cents), 0) FROM " + donationReallocationsTableName + " dr" +
+		" JOIN " + fundReallocationsTableName + " fr ON fr.id = dr.fund_reallocation_id"
+
+	syncQuery, syncArgs, err := psql().
+		Update(needTableName).
+		Set("amount_raised_cents", sq.Expr(
+			"(SELECT COALESCE(SUM(amount_cents), 0) FROM "+donationIntentTableName+" WHERE need_id = ? AND LOWER(payment_status) = ?)"+
+				" + "+reallocatedSum+" WHERE fr.target_need_id = ? AND dr.status = ?)"+
+				" - "+reallocatedSum+" WHERE fr.need_id = ? AND fr.status = ? AND dr.status IN (?, ?, ?))",
+			needID, types.DonationPaymentStatusFinalized,
+			needID, types.DonationReallocationStatusRedirected,
+			needID, types.FundReallocationStatusCompleted, types.DonationReallocationStatusRedirected, types.DonationReallocationStatusOptedOut, types.DonationReallocationStatusRefunded,
+		)).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": needID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate sync need raised amount query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, syncQuery, syncArgs...); err != nil {
+		return fmt.Errorf("failed to sync need raised amount for need %s: %w", needID, err)
+	}
+
+	return nil
+}
+
 func (r *DonationIntentRepository) MarkIntentFailedByID(ctx context.Context, intentID string, checkoutSessionID, paymentIntentID *string) (bool, error) {
 	now := time.Now()
 
@@ -339,6 +355,87 @@ func (r *DonationIntentRepository) FinalizedAmountsByNeedIDs(ctx context.Context
 	return amountsByNeedID, nil
 }
 
+// FinalizedAmountsByLineItem returns finalized donation totals for a need keyed
+// by the line item each gift was directed to. Undirected gifts are omitted.
+func (r *DonationIntentRepository) FinalizedAmountsByLineItem(ctx context.Context, needID string) (map[string]int, error) {
+	query, args, err := psql().
+		Select("line_item_id", "COALESCE(SUM(amount_cents), 0) AS total_amount_cents").
+		From(donationIntentTableName).
+		Where(sq.Eq{"payment_status": types.DonationPaymentStatusFinalized}).
+		Where(sq.Eq{"need_id": needID}).
+		Where(sq.NotEq{"line_item_id": nil}).
+		GroupBy("line_item_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate finalized amounts by line item query: %w", err)
+	}
+
+	rows := make([]struct {
+		LineItemID       string `db:"line_item_id"`
+		TotalAmountCents int    `db:"total_amount_cents"`
+	}, 0)
+	if err := pgxscan.Select(ctx, r.pool, &rows, query, args...); err != nil {
+		if !pgxscan.NotFound(err) {
+			return nil, fmt.Errorf("failed to fetch finalized amounts by line item: %w", err)
+		}
+	}
+
+	amountsByLineItemID := make(map[string]int, len(rows))
+	for _, row := range rows {
+		amountsByLineItemID[row.LineItemID] = row.TotalAmountCents
+	}
+
+	return amountsByLineItemID, nil
+}
+
+// FinalizedIntentsByNeedID returns the need's finalized gifts, newest first.
+func (r *DonationIntentRepository) FinalizedIntentsByNeedID(ctx context.Context, needID string) ([]*types.DonationIntent, error) {
+	query, args, err := psql().
+		Select(donationIntentColumns...).
+		From(donationIntentTableName).
+		Where(sq.Eq{"need_id": needID, "payment_status": types.DonationPaymentStatusFinalized}).
+		OrderBy("created_at desc", "id desc").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate finalized intents by need query: %w", err)
+	}
+
+	intents := make([]*types.DonationIntent, 0)
+	err = pgxscan.Select(ctx, r.pool, &intents, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return intents, nil
+		}
+		return nil, fmt.Errorf("failed to fetch finalized intents by need: %w", err)
+	}
+
+	return intents, nil
+}
+
+// DonorUserIDsByNeedID returns the distinct signed-in donors with a finalized
+// gift to the need.
+func (r *DonationIntentRepository) DonorUserIDsByNeedID(ctx context.Context, needID string) ([]string, error) {
+	query, args, err := psql().
+		Select("DISTINCT donor_user_id").
+		From(donationIntentTableName).
+		Where(sq.Eq{"need_id": needID, "payment_status": types.DonationPaymentStatusFinalized}).
+		Where(sq.NotEq{"donor_user_id": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate donor user ids by need query: %w", err)
+	}
+
+	userIDs := make([]string, 0)
+	if err := pgxscan.Select(ctx, r.pool, &userIDs, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return userIDs, nil
+		}
+		return nil, fmt.Errorf("failed to fetch donor user ids by need: %w", err)
+	}
+
+	return userIDs, nil
+}
+
 func (r *DonationIntentRepository) DonationIntentsByDonorUserID(ctx context.Context, donorUserID string) ([]*types.DonationIntent, error) {
 	query, args, err := psql().
 		Select(donationIntentColumns...).
@@ -406,3 +503,203 @@ func (r *DonationIntentRepository) HomeImpactStats(ctx context.Context) (types.S
 
 	return stats, nil
 }
+
+// DonationVolumeSince returns the number and total of finalized gifts created
+// at or after since.
+func (r *DonationIntentRepository) DonationVolumeSince(ctx context.Context, since time.Time) (int, int, error) {
+	query, args, err := psql().
+		Select("COUNT(*)", "COALESCE(SUM(amount_cents), 0)").
+		From(donationIntentTableName).
+		Where(sq.Eq{"payment_status": types.DonationPaymentStatusFinalized}).
+		Where(sq.GtOrEq{"created_at": since}).
+		ToSql()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to generate donation volume query: %w", err)
+	}
+
+	var count, amountCents int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count, &amountCents); err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch donation volume: %w", err)
+	}
+
+	return count, amountCents, nil
+}
+
+// IntentCountsByStatus returns how many donation intents are in each payment
+// status.
+func (r *DonationIntentRepository) IntentCountsByStatus(ctx context.Context) (map[string]int, error) {
+	query, args, err := psql().
+		Select("payment_status", "COUNT(*) AS count").
+		From(donationIntentTableName).
+		GroupBy("payment_status").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate donation intent status counts query: %w", err)
+	}
+
+	rows := make([]struct {
+		PaymentStatus string `db:"payment_status"`
+		Count         int    `db:"count"`
+	}, 0)
+	if err := pgxscan.Select(ctx, r.pool, &rows, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return map[string]int{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch donation intent status counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.PaymentStatus] = row.Count
+	}
+
+	return counts, nil
+}
+
+func adminDonationsFilter(qb sq.SelectBuilder, status string, since *time.Time) sq.SelectBuilder {
+	if status != "" {
+		qb = qb.Where(sq.Eq{"payment_status": status})
+	}
+	if since != nil {
+		qb = qb.Where(sq.GtOrEq{"created_at": *since})
+	}
+	return qb
+}
+
+// AdminDonationsPage lists donation intents for the admin donations list,
+// newest first, optionally filtered by payment status and creation time.
+func (r *DonationIntentRepository) AdminDonationsPage(ctx context.Context, page, pageSize int, status string, since *time.Time) ([]*types.DonationIntent, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	offset := uint64((page - 1) * pageSize)
+
+	query, args, err := adminDonationsFilter(psql().Select(donationIntentColumns...).From(donationIntentTableName), status, since).
+		OrderBy("created_at desc", "id asc").
+		Limit(uint64(pageSize)).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate admin donations query: %w", err)
+	}
+
+	intents := make([]*types.DonationIntent, 0)
+	err = pgxscan.Select(ctx, r.pool, &intents, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return intents, nil
+		}
+		return nil, fmt.Errorf("failed to fetch admin donations: %w", err)
+	}
+
+	return intents, nil
+}
+
+func (r *DonationIntentRepository) AdminDonationsCount(ctx context.Context, status string, since *time.Time) (int, int, error) {
+	query, args, err := adminDonationsFilter(psql().Select("COUNT(*)", "COALESCE(SUM(amount_cents), 0)").From(donationIntentTableName), status, since).
+		ToSql()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to generate admin donations count query: %w", err)
+	}
+
+	var count, amountCents int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count, &amountCents); err != nil {
+		return 0, 0, fmt.Errorf("failed to count admin donations: %w", err)
+	}
+
+	return count, amountCents, nil
+}
+
+// AdminDonationReportFilter narrows the donation report. From is inclusive
+// and Until exclusive; empty fields do not filter.
+type AdminDonationReportFilter struct {
+	From       *time.Time
+	Until      *time.Time
+	Status     string
+	NeedID     string
+	CategoryID string
+}
+
+// AdminDonationReportRow is one donation in the report together with the
+// need it went to.
+type AdminDonationReportRow struct {
+	ID                  string    `db:"id"`
+	CreatedAt           time.Time `db:"created_at"`
+	PaymentStatus       string    `db:"payment_status"`
+	PaymentProvider     string    `db:"payment_provider"`
+	PaymentIntentID     *string   `db:"payment_intent_id"`
+	AmountCents         int       `db:"amount_cents"`
+	IsAnonymous         bool      `db:"is_anonymous"`
+	DonorUserID         *string   `db:"donor_user_id"`
+	NeedID              string    `db:"need_id"`
+	NeedDescription     *string   `db:"need_description"`
+	PrimaryCategoryName *string   `db:"primary_category_name"`
+}
+
+// EachAdminDonationReportRow streams donations matching filter, oldest first,
+// without holding the full result in memory. Iteration stops at the first
+// error fn returns.
+func (r *DonationIntentRepository) EachAdminDonationReportRow(ctx context.Context, filter AdminDonationReportFilter, fn func(*AdminDonationReportRow) error) error {
+	qb := psql().
+		Select(
+			"d.id",
+			"d.created_at",
+			"d.payment_status",
+			"d.payment_provider",
+			"d.payment_intent_id",
+			"d.amount_cents",
+			"d.is_anonymous",
+			"d.donor_user_id",
+			"d.need_id",
+			"n.short_description AS need_description",
+			"c.name AS primary_category_name",
+		).
+		From(donationIntentTableName + " d").
+		LeftJoin("christjesus.needs n ON n.id = d.need_id").
+		LeftJoin("christjesus.need_category_assignments pa ON pa.need_id = d.need_id AND pa.is_primary = true").
+		LeftJoin("christjesus.need_categories c ON c.id = pa.category_id")
+
+	if filter.From != nil {
+		qb = qb.Where(sq.GtOrEq{"d.created_at": *filter.From})
+	}
+	if filter.Until != nil {
+		qb = qb.Where(sq.Lt{"d.created_at": *filter.Until})
+	}
+	if filter.Status != "" {
+		qb = qb.Where(sq.Eq{"d.payment_status": filter.Status})
+	}
+	if filter.NeedID != "" {
+		qb = qb.Where(sq.Eq{"d.need_id": filter.NeedID})
+	}
+	if filter.CategoryID != "" {
+		qb = qb.Where(sq.Expr("EXISTS (SELECT 1 FROM christjesus.need_category_assignments fa WHERE fa.need_id = d.need_id AND fa.category_id = ?)", filter.CategoryID))
+	}
+
+	query, args, err := qb.OrderBy("d.created_at asc", "d.id asc").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate donation report query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to query donation report")
+	}
+	defer rows.Close()
+
+	scanner := pgxscan.NewRowScanner(rows)
+	for rows.Next() {
+		var row AdminDonationReportRow
+		if err := scanner.Scan(&row); err != nil {
+			return utils.ErrorWrapOrNil(err, "failed to scan donation report row")
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return utils.ErrorWrapOrNil(rows.Err(), "failed to read donation report")
+}
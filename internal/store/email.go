@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"christjesus/internal/utils"
@@ -10,15 +11,16 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
-	emailMessagesTable         = "christjesus.email_messages"
-	emailEventsTable           = "christjesus.email_events"
-	emailSuppressionsTable     = "christjesus.email_suppressions"
-	donationIntentEmailsTable  = "christjesus.donation_intent_emails"
-	userEmailsTable            = "christjesus.user_emails"
+	emailMessagesTable        = "christjesus.email_messages"
+	emailEventsTable          = "christjesus.email_events"
+	emailSuppressionsTable    = "christjesus.email_suppressions"
+	donationIntentEmailsTable = "christjesus.donation_intent_emails"
+	userEmailsTable           = "christjesus.user_emails"
 )
 
 var (
@@ -37,6 +39,10 @@ func NewEmailRepository(pool *pgxpool.Pool) *EmailRepository {
 	return &EmailRepository{pool: pool}
 }
 
+func (r *EmailRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
 // InsertEmailMessage inserts a new email message record with status "queued".
 func (r *EmailRepository) InsertEmailMessage(ctx context.Context, msg *types.EmailMessage) error {
 	now := time.Now()
@@ -228,3 +234,281 @@ func (r *EmailRepository) InsertUserEmail(ctx context.Context, link *types.UserE
 	}
 	return nil
 }
+
+// EmailEventCountSince counts webhook events of the given type received at or
+// after since.
+func (r *EmailRepository) EmailEventCountSince(ctx context.Context, eventType string, since time.Time) (int, error) {
+	query, args, err := psql().
+		Select("COUNT(*)").
+		From(emailEventsTable).
+		Where(sq.Eq{"event_type": eventType}).
+		Where(sq.GtOrEq{"created_at": since}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build email event count query: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count email events: %w", err)
+	}
+	return total, nil
+}
+
+// EmailEventsSince returns webhook events received at or after since, newest
+// first, with the recipient and subject of their message. An empty eventType
+// matches every event.
+func (r *EmailRepository) EmailEventsSince(ctx context.Context, eventType string, since time.Time, limit int) ([]*types.EmailEventDetail, error) {
+	columns := make([]string, 0, len(emailEventColumns)+3)
+	for _, column := range emailEventColumns {
+		columns = append(columns, "ev."+column)
+	}
+	columns = append(columns, "m.recipient", "m.subject", "m.email_type AS message_email_type")
+
+	qb := psql().
+		Select(columns...).
+		From(emailEventsTable + " ev").
+		LeftJoin(emailMessagesTable + " m ON m.id = ev.email_message_id").
+		Where(sq.GtOrEq{"ev.created_at": since})
+	if eventType != "" {
+		qb = qb.Where(sq.Eq{"ev.event_type": eventType})
+	}
+
+	query, args, err := qb.
+		OrderBy("ev.created_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build email events since query: %w", err)
+	}
+
+	events := make([]*types.EmailEventDetail, 0)
+	err = pgxscan.Select(ctx, r.pool, &events, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch email events: %w", err)
+	}
+	return events, nil
+}
+
+// EmailMessageFilter narrows the admin message search. Recipient matches any
+// part of the address, case-insensitively; empty fields match everything.
+type EmailMessageFilter struct {
+	Recipient string
+	EmailType string
+	Status    string
+}
+
+func applyEmailMessageFilter(qb sq.SelectBuilder, f EmailMessageFilter) sq.SelectBuilder {
+	if recipient := strings.TrimSpace(f.Recipient); recipient != "" {
+		qb = qb.Where(sq.ILike{"recipient": "%" + recipient + "%"})
+	}
+	if emailType := strings.TrimSpace(f.EmailType); emailType != "" {
+		qb = qb.Where(sq.Eq{"email_type": emailType})
+	}
+	if status := strings.TrimSpace(f.Status); status != "" {
+		qb = qb.Where(sq.Eq{"status": status})
+	}
+	return qb
+}
+
+// EmailMessagesPage returns one page of matching messages, newest first.
+func (r *EmailRepository) EmailMessagesPage(ctx context.Context, filter EmailMessageFilter, page, pageSize int) ([]*types.EmailMessage, error) {
+	offset := (page - 1) * pageSize
+
+	query, args, err := applyEmailMessageFilter(psql().Select(emailMessageColumns...).From(emailMessagesTable), filter).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(pageSize)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build email messages page query: %w", err)
+	}
+
+	messages := make([]*types.EmailMessage, 0)
+	err = pgxscan.Select(ctx, r.pool, &messages, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch email messages: %w", err)
+	}
+	return messages, nil
+}
+
+func (r *EmailRepository) EmailMessagesCount(ctx context.Context, filter EmailMessageFilter) (int, error) {
+	query, args, err := applyEmailMessageFilter(psql().Select("COUNT(*)").From(emailMessagesTable), filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build email messages count query: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count email messages: %w", err)
+	}
+	return total, nil
+}
+
+// EmailMessageByID returns the message with the given ID, or nil if not found.
+func (r *EmailRepository) EmailMessageByID(ctx context.Context, id string) (*types.EmailMessage, error) {
+	query, args, err := psql().
+		Select(emailMessageColumns...).
+		From(emailMessagesTable).
+		Where(sq.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build email message by id query: %w", err)
+	}
+
+	var msg types.EmailMessage
+	err = pgxscan.Get(ctx, r.pool, &msg, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch email message: %w", err)
+	}
+	if pgxscan.NotFound(err) {
+		return nil, nil
+	}
+	return &msg, nil
+}
+
+// EmailMessagesResentFrom returns the resends of a message, oldest first.
+func (r *EmailRepository) EmailMessagesResentFrom(ctx context.Context, messageID string) ([]*types.EmailMessage, error) {
+	query, args, err := psql().
+		Select(emailMessageColumns...).
+		From(emailMessagesTable).
+		Where(sq.Eq{"resent_from_message_id": messageID}).
+		OrderBy("created_at ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build email messages resent from query: %w", err)
+	}
+
+	messages := make([]*types.EmailMessage, 0)
+	err = pgxscan.Select(ctx, r.pool, &messages, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch resent email messages: %w", err)
+	}
+	return messages, nil
+}
+
+// EmailEventsByMessage returns the webhook events recorded for a message in
+// the order they were received.
+func (r *EmailRepository) EmailEventsByMessage(ctx context.Context, messageID string) ([]*types.EmailEvent, error) {
+	query, args, err := psql().
+		Select(emailEventColumns...).
+		From(emailEventsTable).
+		Where(sq.Eq{"email_message_id": messageID}).
+		OrderBy("created_at ASC", "id ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build email events by message query: %w", err)
+	}
+
+	events := make([]*types.EmailEvent, 0)
+	err = pgxscan.Select(ctx, r.pool, &events, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch email events for message: %w", err)
+	}
+	return events, nil
+}
+
+// ActiveEmailSuppression returns the suppression currently blocking an
+// address, or nil if mail can be sent to it.
+func (r *EmailRepository) ActiveEmailSuppression(ctx context.Context, emailAddress string) (*types.EmailSuppression, error) {
+	query, args, err := psql().
+		Select(emailSuppressionColumns...).
+		From(emailSuppressionsTable).
+		Where(sq.Eq{"email_address": emailAddress, "removed_at": nil}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build active email suppression query: %w", err)
+	}
+
+	var suppression types.EmailSuppression
+	err = pgxscan.Get(ctx, r.pool, &suppression, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch active email suppression: %w", err)
+	}
+	if pgxscan.NotFound(err) {
+		return nil, nil
+	}
+	return &suppression, nil
+}
+
+// EmailSuppressionFilter narrows the admin suppression list. Removed
+// suppressions are only included when IncludeRemoved is set.
+type EmailSuppressionFilter struct {
+	EmailAddress   string
+	IncludeRemoved bool
+}
+
+func applyEmailSuppressionFilter(qb sq.SelectBuilder, f EmailSuppressionFilter) sq.SelectBuilder {
+	if address := strings.TrimSpace(f.EmailAddress); address != "" {
+		qb = qb.Where(sq.ILike{"email_address": "%" + address + "%"})
+	}
+	if !f.IncludeRemoved {
+		qb = qb.Where(sq.Eq{"removed_at": nil})
+	}
+	return qb
+}
+
+// EmailSuppressionsPage returns one page of matching suppressions, newest
+// first.
+func (r *EmailRepository) EmailSuppressionsPage(ctx context.Context, filter EmailSuppressionFilter, page, pageSize int) ([]*types.EmailSuppression, error) {
+	offset := (page - 1) * pageSize
+
+	query, args, err := applyEmailSuppressionFilter(psql().Select(emailSuppressionColumns...).From(emailSuppressionsTable), filter).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(pageSize)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build email suppressions page query: %w", err)
+	}
+
+	suppressions := make([]*types.EmailSuppression, 0)
+	err = pgxscan.Select(ctx, r.pool, &suppressions, query, args...)
+	if err != nil && !pgxscan.NotFound(err) {
+		return nil, fmt.Errorf("fetch email suppressions: %w", err)
+	}
+	return suppressions, nil
+}
+
+func (r *EmailRepository) EmailSuppressionsCount(ctx context.Context, filter EmailSuppressionFilter) (int, error) {
+	query, args, err := applyEmailSuppressionFilter(psql().Select("COUNT(*)").From(emailSuppressionsTable), filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build email suppressions count query: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count email suppressions: %w", err)
+	}
+	return total, nil
+}
+
+// RemoveEmailSuppressionTx lifts an active suppression and returns the
+// updated row. It returns types.ErrEmailSuppressionNotFound when the
+// suppression does not exist or has already been removed.
+func (r *EmailRepository) RemoveEmailSuppressionTx(ctx context.Context, tx pgx.Tx, id, removedByUserID, reason string) (*types.EmailSuppression, error) {
+	query, args, err := psql().
+		Update(emailSuppressionsTable).
+		SetMap(sq.Eq{
+			"removed_at":         time.Now(),
+			"removed_by_user_id": removedByUserID,
+			"remove_reason":      reason,
+		}).
+		Where(sq.Eq{"id": id, "removed_at": nil}).
+		Suffix("RETURNING " + strings.Join(emailSuppressionColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build remove email suppression query: %w", err)
+	}
+
+	var suppression types.EmailSuppression
+	if err := pgxscan.Get(ctx, tx, &suppression, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrEmailSuppressionNotFound
+		}
+		return nil, fmt.Errorf("remove email suppression: %w", err)
+	}
+	return &suppression, nil
+}
@@ -0,0 +1,493 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	fundReallocationsTableName     = "christjesus.fund_reallocations"
+	donationReallocationsTableName = "christjesus.donation_reallocations"
+)
+
+var (
+	fundReallocationColumns     = utils.StructTagValues(types.FundReallocation{})
+	donationReallocationColumns = utils.StructTagValues(types.DonationReallocation{})
+)
+
+type FundReallocationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewFundReallocationRepository(pool *pgxpool.Pool) *FundReallocationRepository {
+	return &FundReallocationRepository{pool: pool}
+}
+
+func (r *FundReallocationRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// QueueReallocation adds funds a need no longer requires to the reallocation
+// queue.
+func (r *FundReallocationRepository) QueueReallocation(ctx context.Context, reallocation *types.FundReallocation) error {
+	return r.queueReallocationWithExec(ctx, r.pool, reallocation)
+}
+
+func (r *FundReallocationRepository) QueueReallocationTx(ctx context.Context, tx pgx.Tx, reallocation *types.FundReallocation) error {
+	return r.queueReallocationWithExec(ctx, tx, reallocation)
+}
+
+func (r *FundReallocationRepository) queueReallocationWithExec(ctx context.Context, execer needExecer, reallocation *types.FundReallocation) error {
+	now := time.Now()
+	reallocation.ID = utils.NanoID()
+	reallocation.Status = types.FundReallocationStatusQueued
+	reallocation.CreatedAt = now
+	reallocation.UpdatedAt = now
+
+	query, args, err := psql().
+		Insert(fundReallocationsTableName).
+		Columns("id", "need_id", "amount_cents", "source", "goal_change_request_id", "status", "created_at", "updated_at").
+		Values(reallocation.ID, reallocation.NeedID, reallocation.AmountCents, reallocation.Source, reallocation.GoalChangeRequestID, reallocation.Status, reallocation.CreatedAt, reallocation.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate queue fund reallocation query: %w", err)
+	}
+
+	_, err = execer.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to queue fund reallocation")
+}
+
+// ReallocationsByNeed returns every reallocation of a need's funds, newest
+// first.
+func (r *FundReallocationRepository) ReallocationsByNeed(ctx context.Context, needID string) ([]*types.FundReallocation, error) {
+	return r.reallocationsByNeed(ctx, r.pool, needID)
+}
+
+func (r *FundReallocationRepository) ReallocationsByNeedTx(ctx context.Context, tx pgx.Tx, needID string) ([]*types.FundReallocation, error) {
+	return r.reallocationsByNeed(ctx, tx, needID)
+}
+
+func (r *FundReallocationRepository) reallocationsByNeed(ctx context.Context, querier pgxscan.Querier, needID string) ([]*types.FundReallocation, error) {
+	query, args, err := psql().
+		Select(fundReallocationColumns...).
+		From(fundReallocationsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate fund reallocations by need query: %w", err)
+	}
+
+	reallocations := make([]*types.FundReallocation, 0)
+	err = pgxscan.Select(ctx, querier, &reallocations, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return reallocations, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load fund reallocations")
+	}
+
+	return reallocations, nil
+}
+
+// ReallocationsByStatus returns reallocations in any of the given statuses,
+// oldest first, for the admin queue.
+func (r *FundReallocationRepository) ReallocationsByStatus(ctx context.Context, statuses []types.FundReallocationStatus) ([]*types.FundReallocation, error) {
+	query, args, err := psql().
+		Select(fundReallocationColumns...).
+		From(fundReallocationsTableName).
+		Where(sq.Eq{"status": statuses}).
+		OrderBy("created_at ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate fund reallocations by status query: %w", err)
+	}
+
+	reallocations := make([]*types.FundReallocation, 0)
+	err = pgxscan.Select(ctx, r.pool, &reallocations, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return reallocations, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load fund reallocations")
+	}
+
+	return reallocations, nil
+}
+
+// ProposeReallocationTx sets the destination of a queued reallocation and
+// opens the donor consent window. It returns
+// types.ErrFundReallocationNotFound when the reallocation does not exist on
+// the need or is no longer queued.
+func (r *FundReallocationRepository) ProposeReallocationTx(ctx context.Context, tx pgx.Tx, needID, reallocationID string, targetNeedID, targetCategoryID *string, actorUserID string, consentDeadline time.Time) (*types.FundReallocation, error) {
+	now := time.Now()
+	query, args, err := psql().
+		Update(fundReallocationsTableName).
+		Set("status", types.FundReallocationStatusProposed).
+		Set("target_need_id", targetNeedID).
+		Set("target_category_id", targetCategoryID).
+		Set("proposed_by_user_id", actorUserID).
+		Set("proposed_at", now).
+		Set("consent_deadline", consentDeadline).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": reallocationID, "need_id": needID, "status": types.FundReallocationStatusQueued}).
+		Suffix("RETURNING " + strings.Join(fundReallocationColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate propose fund reallocation query: %w", err)
+	}
+
+	reallocation := new(types.FundReallocation)
+	err = pgxscan.Get(ctx, tx, reallocation, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrFundReallocationNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to propose fund reallocation")
+	}
+
+	return reallocation, nil
+}
+
+// AllocatedCentsByIntent returns how much of each of the need's gifts is
+// already covered by a reallocation, whatever its status.
+func (r *FundReallocationRepository) AllocatedCentsByIntent(ctx context.Context, needID string) (map[string]int, error) {
+	return r.allocatedCentsByIntent(ctx, r.pool, needID)
+}
+
+func (r *FundReallocationRepository) AllocatedCentsByIntentTx(ctx context.Context, tx pgx.Tx, needID string) (map[string]int, error) {
+	return r.allocatedCentsByIntent(ctx, tx, needID)
+}
+
+func (r *FundReallocationRepository) allocatedCentsByIntent(ctx context.Context, querier pgxscan.Querier, needID string) (map[string]int, error) {
+	query, args, err := psql().
+		Select("dr.donation_intent_id", "COALESCE(SUM(dr.amount_cents), 0) AS total_amount_cents").
+		From(donationReallocationsTableName + " dr").
+		Join(fundReallocationsTableName + " fr ON fr.id = dr.fund_reallocation_id").
+		Where(sq.Eq{"fr.need_id": needID}).
+		GroupBy("dr.donation_intent_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate allocated cents by intent query: %w", err)
+	}
+
+	rows := make([]struct {
+		DonationIntentID string `db:"donation_intent_id"`
+		TotalAmountCents int    `db:"total_amount_cents"`
+	}, 0)
+	if err := pgxscan.Select(ctx, querier, &rows, query, args...); err != nil {
+		if !pgxscan.NotFound(err) {
+			return nil, utils.ErrorWrapOrNil(err, "failed to load allocated cents by intent")
+		}
+	}
+
+	allocated := make(map[string]int, len(rows))
+	for _, row := range rows {
+		allocated[row.DonationIntentID] = row.TotalAmountCents
+	}
+
+	return allocated, nil
+}
+
+func (r *FundReallocationRepository) CreateDonationReallocationsTx(ctx context.Context, tx pgx.Tx, allocations []*types.DonationReallocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	qb := psql().
+		Insert(donationReallocationsTableName).
+		Columns(donationReallocationColumns...)
+
+	for _, allocation := range allocations {
+		allocation.ID = utils.NanoID()
+		allocation.Status = types.DonationReallocationStatusPending
+		allocation.CreatedAt = now
+		allocation.UpdatedAt = now
+		qb = qb.Values(allocation.ID, allocation.FundReallocationID, allocation.DonationIntentID, allocation.AmountCents, allocation.Status, nil, nil, nil, allocation.CreatedAt, allocation.UpdatedAt)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create donation reallocations query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create donation reallocations")
+}
+
+// DonationReallocationsByIntentIDs returns the reallocations touching the
+// given gifts along with their destination, oldest first.
+func (r *FundReallocationRepository) DonationReallocationsByIntentIDs(ctx context.Context, intentIDs []string) ([]*types.DonationReallocationDetail, error) {
+	details := make([]*types.DonationReallocationDetail, 0)
+	if len(intentIDs) == 0 {
+		return details, nil
+	}
+
+	columns := make([]string, 0, len(donationReallocationColumns)+3)
+	for _, column := range donationReallocationColumns {
+		columns = append(columns, "dr."+column)
+	}
+	columns = append(columns, "fr.target_need_id", "fr.target_category_id", "fr.consent_deadline")
+
+	query, args, err := psql().
+		Select(columns...).
+		From(donationReallocationsTableName + " dr").
+		Join(fundReallocationsTableName + " fr ON fr.id = dr.fund_reallocation_id").
+		Where(sq.Eq{"dr.donation_intent_id": intentIDs}).
+		OrderBy("dr.created_at ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate donation reallocations by intent query: %w", err)
+	}
+
+	err = pgxscan.Select(ctx, r.pool, &details, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return details, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load donation reallocations")
+	}
+
+	return details, nil
+}
+
+// OptOutDonationReallocation records a donor's refusal to have their gift
+// redirected. It returns types.ErrFundReallocationNotFound when the
+// allocation is not pending on that gift or the consent window has closed.
+func (r *FundReallocationRepository) OptOutDonationReallocation(ctx context.Context, intentID, allocationID string) error {
+	now := time.Now()
+	query, args, err := psql().
+		Update(donationReallocationsTableName).
+		Set("status", types.DonationReallocationStatusOptedOut).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": allocationID, "donation_intent_id": intentID, "status": types.DonationReallocationStatusPending}).
+		Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM "+fundReallocationsTableName+" fr WHERE fr.id = fund_reallocation_id AND fr.status = ? AND fr.consent_deadline > ?)",
+			types.FundReallocationStatusProposed, now,
+		)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate opt out donation reallocation query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to opt out of donation reallocation")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrFundReallocationNotFound
+	}
+
+	return nil
+}
+
+func donationReallocationRefundsQuery() sq.SelectBuilder {
+	columns := make([]string, 0, len(donationReallocationColumns)+3)
+	for _, column := range donationReallocationColumns {
+		columns = append(columns, "dr."+column)
+	}
+	columns = append(columns, "fr.need_id", "di.donor_user_id", "di.payment_intent_id")
+
+	return psql().
+		Select(columns...).
+		From(donationReallocationsTableName + " dr").
+		Join(fundReallocationsTableName + " fr ON fr.id = dr.fund_reallocation_id").
+		Join(donationIntentTableName + " di ON di.id = dr.donation_intent_id")
+}
+
+// RefundsOwed returns every opted-out share that has not been refunded yet,
+// longest waiting first.
+func (r *FundReallocationRepository) RefundsOwed(ctx context.Context) ([]*types.DonationReallocationRefund, error) {
+	query, args, err := donationReallocationRefundsQuery().
+		Where(sq.Eq{"dr.status": types.DonationReallocationStatusOptedOut}).
+		OrderBy("dr.updated_at ASC", "dr.id ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refunds owed query: %w", err)
+	}
+
+	refunds := make([]*types.DonationReallocationRefund, 0)
+	err = pgxscan.Select(ctx, r.pool, &refunds, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return refunds, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load refunds owed")
+	}
+
+	return refunds, nil
+}
+
+// RecentRefunds returns the most recently refunded shares, newest first.
+func (r *FundReallocationRepository) RecentRefunds(ctx context.Context, limit int) ([]*types.DonationReallocationRefund, error) {
+	query, args, err := donationReallocationRefundsQuery().
+		Where(sq.Eq{"dr.status": types.DonationReallocationStatusRefunded}).
+		OrderBy("dr.refunded_at DESC", "dr.id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recent refunds query: %w", err)
+	}
+
+	refunds := make([]*types.DonationReallocationRefund, 0)
+	err = pgxscan.Select(ctx, r.pool, &refunds, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return refunds, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load recent refunds")
+	}
+
+	return refunds, nil
+}
+
+// RefundOwed returns one opted-out share that is still waiting for its
+// refund, or types.ErrFundReallocationNotFound.
+func (r *FundReallocationRepository) RefundOwed(ctx context.Context, allocationID string) (*types.DonationReallocationRefund, error) {
+	query, args, err := donationReallocationRefundsQuery().
+		Where(sq.Eq{"dr.id": allocationID, "dr.status": types.DonationReallocationStatusOptedOut}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refund owed query: %w", err)
+	}
+
+	var refund types.DonationReallocationRefund
+	err = pgxscan.Get(ctx, r.pool, &refund, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrFundReallocationNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load refund owed")
+	}
+
+	return &refund, nil
+}
+
+// MarkDonationReallocationRefunded records that an opted-out share was paid
+// back to the donor. stripeRefundID is nil when the refund happened outside
+// Stripe. It returns types.ErrFundReallocationNotFound when the share is not
+// waiting for a refund.
+func (r *FundReallocationRepository) MarkDonationReallocationRefunded(ctx context.Context, allocationID, actorUserID string, stripeRefundID *string) error {
+	now := time.Now()
+	query, args, err := psql().
+		Update(donationReallocationsTableName).
+		Set("status", types.DonationReallocationStatusRefunded).
+		Set("refunded_at", now).
+		Set("refunded_by_user_id", actorUserID).
+		Set("stripe_refund_id", stripeRefundID).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": allocationID, "status": types.DonationReallocationStatusOptedOut}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate mark donation reallocation refunded query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to mark donation reallocation refunded")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrFundReallocationNotFound
+	}
+
+	return nil
+}
+
+// DueReallocations returns proposed reallocations whose consent window closed
+// before cutoff.
+func (r *FundReallocationRepository) DueReallocations(ctx context.Context, cutoff time.Time, limit int) ([]*types.FundReallocation, error) {
+	query, args, err := psql().
+		Select(fundReallocationColumns...).
+		From(fundReallocationsTableName).
+		Where(sq.Eq{"status": types.FundReallocationStatusProposed}).
+		Where(sq.LtOrEq{"consent_deadline": cutoff}).
+		OrderBy("consent_deadline ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate due fund reallocations query: %w", err)
+	}
+
+	reallocations := make([]*types.FundReallocation, 0)
+	err = pgxscan.Select(ctx, r.pool, &reallocations, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return reallocations, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load due fund reallocations")
+	}
+
+	return reallocations, nil
+}
+
+// CompleteReallocation redirects every allocation that was not opted out,
+// marks the reallocation completed and resyncs the raised totals of the
+// source and destination needs. It returns the amount moved.
+func (r *FundReallocationRepository) CompleteReallocation(ctx context.Context, reallocationID string) (int, error) {
+	var movedCents int
+	err := WithTx(ctx, r, func(tx pgx.Tx) error {
+		now := time.Now()
+		completeQuery, completeArgs, err := psql().
+			Update(fundReallocationsTableName).
+			Set("status", types.FundReallocationStatusCompleted).
+			Set("completed_at", now).
+			Set("updated_at", now).
+			Where(sq.Eq{"id": reallocationID, "status": types.FundReallocationStatusProposed}).
+			Suffix("RETURNING need_id, target_need_id").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to generate complete fund reallocation query: %w", err)
+		}
+
+		var needID string
+		var targetNeedID *string
+		if err := tx.QueryRow(ctx, completeQuery, completeArgs...).Scan(&needID, &targetNeedID); err != nil {
+			if pgxscan.NotFound(err) {
+				return types.ErrFundReallocationNotFound
+			}
+			return utils.ErrorWrapOrNil(err, "failed to complete fund reallocation")
+		}
+
+		redirectQuery, redirectArgs, err := psql().
+			Update(donationReallocationsTableName).
+			Set("status", types.DonationReallocationStatusRedirected).
+			Set("updated_at", now).
+			Where(sq.Eq{"fund_reallocation_id": reallocationID, "status": types.DonationReallocationStatusPending}).
+			Suffix("RETURNING amount_cents").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to generate redirect donation reallocations query: %w", err)
+		}
+
+		amounts := make([]int, 0)
+		if err := pgxscan.Select(ctx, tx, &amounts, redirectQuery, redirectArgs...); err != nil && !pgxscan.NotFound(err) {
+			return utils.ErrorWrapOrNil(err, "failed to redirect donation reallocations")
+		}
+		for _, amount := range amounts {
+			movedCents += amount
+		}
+
+		if err := syncNeedAmountRaisedTx(ctx, tx, needID, now); err != nil {
+			return err
+		}
+		if targetNeedID != nil {
+			return syncNeedAmountRaisedTx(ctx, tx, *targetNeedID, now)
+		}
+
+		return nil
+	})
+
+	return movedCents, err
+}
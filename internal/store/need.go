@@ -21,6 +21,11 @@ var needColumns = utils.StructTagValues(types.Need{})
 
 type NeedRepository struct {
 	pool *pgxpool.Pool
+
+	// SecondApprovalThresholdCents is the requested amount above which a
+	// need under review cannot be published by a single admin. Zero turns
+	// the check off.
+	SecondApprovalThresholdCents int
 }
 
 type needExecer interface {
@@ -82,10 +87,30 @@ const (
 	browseJoinSelectedAddr    = "christjesus.user_addresses sa ON sa.id = n.user_address_id"
 	browseJoinPrimaryAddr     = "christjesus.user_addresses pa ON pa.user_id = n.user_id AND pa.is_primary = true AND n.user_address_id IS NULL"
 	browseJoinPrimaryCategory = "christjesus.need_category_assignments nca ON nca.need_id = n.id AND nca.is_primary = true"
-	browseJoinZipCentroid     = "christjesus.zip_centroids zc ON zc.zip_code = COALESCE(sa.zip_code, pa.zip_code)"
+	// Needs whose owners chose county or region privacy never join a centroid,
+	// so they carry no distance and can't be located by probing radius filters.
+	browseJoinZipCentroid = "christjesus.zip_centroids zc ON zc.zip_code = COALESCE(sa.zip_code, pa.zip_code) AND COALESCE(sa.privacy_display, pa.privacy_display, 'city') NOT IN ('county', 'region')"
 
 	browseFundingPercentExpr = "CASE WHEN n.amount_needed_cents > 0 THEN (n.amount_raised_cents * 100 / n.amount_needed_cents) ELSE 0 END"
 	browseUrgencyOrderExpr   = "CASE n.urgency WHEN 'urgent' THEN 4 WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 2 END"
+
+	// Distances to city-level needs are shifted by a fixed per-need offset of
+	// up to browseDistanceJitterMiles, then rounded up to the bucket size. The
+	// offset is derived from the need ID so repeated queries can't average it
+	// away.
+	browseDistanceJitterMiles = 2.0
+	browseDistanceBucketMiles = 5.0
+)
+
+// browseDistanceExpr is the fuzzed donor-to-need distance in miles. The radius
+// filter, the nearest sort and the displayed distance all use it, so none of
+// them reveal more than the others. It takes the donor ZIP as its only
+// argument and is NULL when either centroid is unknown.
+var browseDistanceExpr = fmt.Sprintf(
+	"(SELECT GREATEST(%[2]g, CEIL((raw.miles + (('x' || SUBSTR(MD5(n.id), 1, 8))::bit(32)::bigint %% 1000) / 999.0 * 2 * %[1]g - %[1]g) / %[2]g) * %[2]g) "+
+		"FROM (SELECT ST_Distance(zc.geog, (SELECT geog FROM christjesus.zip_centroids WHERE zip_code = ?)) / 1609.344 AS miles) raw "+
+		"WHERE raw.miles IS NOT NULL)",
+	browseDistanceJitterMiles, browseDistanceBucketMiles,
 )
 
 func browseBaseQuery(columns ...string) sq.SelectBuilder {
@@ -96,18 +121,16 @@ func browseBaseQuery(columns ...string) sq.SelectBuilder {
 		LeftJoin(browseJoinPrimaryCategory).
 		LeftJoin(browseJoinZipCentroid).
 		Where(sq.Eq{"n.status": []types.NeedStatus{types.NeedStatusActive, types.NeedStatusFunded}}).
-		Where(sq.Eq{"n.deleted_at": nil})
+		Where(sq.Eq{"n.deleted_at": nil, "n.hidden_at": nil})
 }
 
 // applyBrowseWhereFilters adds only WHERE clauses (no extra columns).
 // Used by both the results query and the count query.
 func applyBrowseWhereFilters(qb sq.SelectBuilder, f BrowseNeedsFilter) sq.SelectBuilder {
-	donorGeogSubquery := "(SELECT geog FROM christjesus.zip_centroids WHERE zip_code = ?)"
 	if f.ZipCode != "" && f.RadiusMiles != nil {
-		radiusMeters := *f.RadiusMiles * 1609.344
 		qb = qb.Where(
-			fmt.Sprintf("(zc.geog IS NULL OR ST_DWithin(zc.geog, %s, ?))", donorGeogSubquery),
-			f.ZipCode, radiusMeters,
+			fmt.Sprintf("(zc.geog IS NULL OR %s <= ?)", browseDistanceExpr),
+			f.ZipCode, *f.RadiusMiles,
 		)
 	}
 	if len(f.CategoryIDs) > 0 {
@@ -132,18 +155,48 @@ func applyBrowseWhereFilters(qb sq.SelectBuilder, f BrowseNeedsFilter) sq.Select
 // Used by the results query only.
 func applyBrowseFilters(qb sq.SelectBuilder, f BrowseNeedsFilter) sq.SelectBuilder {
 	qb = applyBrowseWhereFilters(qb, f)
-	donorGeogSubquery := "(SELECT geog FROM christjesus.zip_centroids WHERE zip_code = ?)"
 	if f.ZipCode != "" {
-		qb = qb.Column(
-			fmt.Sprintf("ST_Distance(zc.geog, %s) / 1609.344 AS distance_miles", donorGeogSubquery),
-			f.ZipCode,
-		)
+		qb = qb.Column(browseDistanceExpr+" AS distance_miles", f.ZipCode)
 	} else {
 		qb = qb.Column("NULL::float8 AS distance_miles")
 	}
 	return qb
 }
 
+// CountyNamesByZipCodes maps ZIP codes to the county recorded for their
+// centroid. ZIPs without a known county are omitted.
+func (r *NeedRepository) CountyNamesByZipCodes(ctx context.Context, zipCodes []string) (map[string]string, error) {
+	countyByZip := make(map[string]string)
+	if len(zipCodes) == 0 {
+		return countyByZip, nil
+	}
+
+	query, args, err := psql().
+		Select("zip_code", "county_name").
+		From("christjesus.zip_centroids").
+		Where(sq.Eq{"zip_code": zipCodes}).
+		Where(sq.NotEq{"county_name": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate county names by zip codes query: %w", err)
+	}
+
+	var rows []struct {
+		ZipCode    string `db:"zip_code"`
+		CountyName string `db:"county_name"`
+	}
+	err = pgxscan.Select(ctx, r.pool, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch county names by zip codes: %w", err)
+	}
+
+	for _, row := range rows {
+		countyByZip[row.ZipCode] = row.CountyName
+	}
+
+	return countyByZip, nil
+}
+
 func (r *NeedRepository) BrowseNeedsPage(ctx context.Context, f BrowseNeedsFilter) ([]*BrowseNeedRow, error) {
 	if f.Page < 1 {
 		f.Page = 1
@@ -209,12 +262,13 @@ func (r *NeedRepository) BrowseNeedsCount(ctx context.Context, f BrowseNeedsFilt
 	return total, nil
 }
 
-
 func (r *NeedRepository) ModerationQueueNeeds(ctx context.Context) ([]*types.Need, error) {
-	return r.ModerationQueueNeedsPage(ctx, 1, 500)
+	return r.ModerationQueueNeedsPage(ctx, 1, 500, "")
 }
 
-func (r *NeedRepository) ModerationQueueNeedsPage(ctx context.Context, page, pageSize int) ([]*types.Need, error) {
+// ModerationQueueNeedsPage returns a page of the moderation queue. When
+// reviewerUserID is set, only needs claimed by that reviewer are returned.
+func (r *NeedRepository) ModerationQueueNeedsPage(ctx context.Context, page, pageSize int, reviewerUserID string) ([]*types.Need, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -224,9 +278,14 @@ func (r *NeedRepository) ModerationQueueNeedsPage(ctx context.Context, page, pag
 
 	offset := uint64((page - 1) * pageSize)
 
-	query, args, err := psql().Select(needColumns...).From(needTableName).
+	builder := psql().Select(needColumns...).From(needTableName).
 		Where(sq.Eq{"status": []types.NeedStatus{types.NeedStatusReadyForReview, types.NeedStatusUnderReview}}).
-		Where(sq.Eq{"deleted_at": nil}).
+		Where(sq.Eq{"deleted_at": nil})
+	if reviewerUserID != "" {
+		builder = builder.Where(sq.Eq{"assigned_reviewer_user_id": reviewerUserID})
+	}
+
+	query, args, err := builder.
 		OrderBy("submitted_at desc nulls last", "created_at desc").
 		Limit(uint64(pageSize)).
 		Offset(offset).
@@ -247,13 +306,19 @@ func (r *NeedRepository) ModerationQueueNeedsPage(ctx context.Context, page, pag
 	return needs, nil
 }
 
-func (r *NeedRepository) ModerationQueueNeedsCount(ctx context.Context) (int, error) {
-	query, args, err := psql().
+// ModerationQueueNeedsCount counts the needs ModerationQueueNeedsPage pages
+// through, with the same reviewer filter.
+func (r *NeedRepository) ModerationQueueNeedsCount(ctx context.Context, reviewerUserID string) (int, error) {
+	builder := psql().
 		Select("COUNT(*)").
 		From(needTableName).
 		Where(sq.Eq{"status": []types.NeedStatus{types.NeedStatusReadyForReview, types.NeedStatusUnderReview}}).
-		Where(sq.Eq{"deleted_at": nil}).
-		ToSql()
+		Where(sq.Eq{"deleted_at": nil})
+	if reviewerUserID != "" {
+		builder = builder.Where(sq.Eq{"assigned_reviewer_user_id": reviewerUserID})
+	}
+
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return 0, fmt.Errorf("failed to generate moderation queue needs count query: %w", err)
 	}
@@ -266,7 +331,95 @@ func (r *NeedRepository) ModerationQueueNeedsCount(ctx context.Context) (int, er
 	return total, nil
 }
 
-func (r *NeedRepository) AdminExplorerNeedsPage(ctx context.Context, page, pageSize int, statusFilter *types.NeedStatus, sortBy string) ([]*types.Need, error) {
+// ModerationQueueCandidates returns every need in the moderation queue along
+// with the document and history signals used to prioritise it. The queue is
+// ordered by the caller once scores are known, so nothing is paged here.
+// reviewerUserID filters the queue as in ModerationQueueNeedsPage.
+func (r *NeedRepository) ModerationQueueCandidates(ctx context.Context, reviewerUserID string) ([]*types.ModerationQueueCandidate, error) {
+	columns := make([]string, 0, len(needColumns))
+	for _, column := range needColumns {
+		columns = append(columns, "n."+column)
+	}
+
+	builder := psql().
+		Select(columns...).
+		Column(sq.Alias(sq.Expr("EXISTS (SELECT 1 FROM "+documentTableName+" d WHERE d.need_id = n.id AND d.document_type = ?)", types.DocTypeEvictionNotice), "has_eviction_notice")).
+		Column(sq.Alias(sq.Expr("EXISTS (SELECT 1 FROM "+documentTableName+" d WHERE d.need_id = n.id AND d.document_type = ?)", types.DocTypeMedicalRecord), "has_medical_record")).
+		Column(sq.Alias(sq.Expr("EXISTS (SELECT 1 FROM "+needTableName+" p WHERE p.user_id = n.user_id AND p.id <> n.id AND (p.verified_at IS NOT NULL OR p.published_at IS NOT NULL))"), "recipient_previously_verified")).
+		From(needTableName + " n").
+		Where(sq.Eq{"n.status": []types.NeedStatus{types.NeedStatusReadyForReview, types.NeedStatusUnderReview}}).
+		Where(sq.Eq{"n.deleted_at": nil})
+	if reviewerUserID != "" {
+		builder = builder.Where(sq.Eq{"n.assigned_reviewer_user_id": reviewerUserID})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate moderation queue candidates query: %w", err)
+	}
+
+	candidates := make([]*types.ModerationQueueCandidate, 0)
+	err = pgxscan.Select(ctx, r.pool, &candidates, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return candidates, nil
+		}
+		return nil, fmt.Errorf("failed to fetch moderation queue candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// SecondApprovalQueueNeeds returns the needs waiting for a second admin's
+// approval, longest-waiting first.
+func (r *NeedRepository) SecondApprovalQueueNeeds(ctx context.Context) ([]*types.Need, error) {
+	query, args, err := psql().
+		Select(needColumns...).
+		From(needTableName).
+		Where(sq.Eq{"status": types.NeedStatusAwaitingSecondApproval}).
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("first_approved_at asc nulls first", "created_at asc").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate second approval queue query: %w", err)
+	}
+
+	needs := make([]*types.Need, 0)
+	err = pgxscan.Select(ctx, r.pool, &needs, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return needs, nil
+		}
+		return nil, fmt.Errorf("failed to fetch second approval queue: %w", err)
+	}
+
+	return needs, nil
+}
+
+// OldestModerationQueueSubmittedAt returns when the longest-waiting need in
+// the moderation queue was submitted, or nil when the queue is empty.
+func (r *NeedRepository) OldestModerationQueueSubmittedAt(ctx context.Context) (*time.Time, error) {
+	query, args, err := psql().
+		Select("MIN(COALESCE(submitted_at, created_at))").
+		From(needTableName).
+		Where(sq.Eq{"status": []types.NeedStatus{types.NeedStatusReadyForReview, types.NeedStatusUnderReview}}).
+		Where(sq.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate oldest moderation queue need query: %w", err)
+	}
+
+	var oldest *time.Time
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&oldest); err != nil {
+		return nil, fmt.Errorf("failed to fetch oldest moderation queue need: %w", err)
+	}
+
+	return oldest, nil
+}
+
+// AdminExplorerNeedsPage lists needs for the admin explorer. When deleted is
+// true only soft-deleted needs are listed, otherwise only live ones.
+func (r *NeedRepository) AdminExplorerNeedsPage(ctx context.Context, page, pageSize int, statusFilter *types.NeedStatus, sortBy string, deleted bool) ([]*types.Need, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -276,8 +429,58 @@ func (r *NeedRepository) AdminExplorerNeedsPage(ctx context.Context, page, pageS
 
 	offset := uint64((page - 1) * pageSize)
 
+	query, args, err := adminExplorerNeedsQuery(statusFilter, sortBy, deleted).
+		Limit(uint64(pageSize)).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate admin explorer needs query: %w", err)
+	}
+
+	needs := make([]*types.Need, 0)
+	err = pgxscan.Select(ctx, r.pool, &needs, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return needs, nil
+		}
+		return nil, fmt.Errorf("failed to fetch admin explorer needs: %w", err)
+	}
+
+	return needs, nil
+}
+
+// EachAdminExplorerNeed streams every need the explorer would list for the
+// same filter and sort, without holding the full result in memory. Iteration
+// stops at the first error fn returns.
+func (r *NeedRepository) EachAdminExplorerNeed(ctx context.Context, statusFilter *types.NeedStatus, sortBy string, deleted bool, fn func(*types.Need) error) error {
+	query, args, err := adminExplorerNeedsQuery(statusFilter, sortBy, deleted).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate admin explorer export query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to query admin explorer needs")
+	}
+	defer rows.Close()
+
+	scanner := pgxscan.NewRowScanner(rows)
+	for rows.Next() {
+		var need types.Need
+		if err := scanner.Scan(&need); err != nil {
+			return utils.ErrorWrapOrNil(err, "failed to scan admin explorer need")
+		}
+		if err := fn(&need); err != nil {
+			return err
+		}
+	}
+
+	return utils.ErrorWrapOrNil(rows.Err(), "failed to read admin explorer needs")
+}
+
+func adminExplorerNeedsQuery(statusFilter *types.NeedStatus, sortBy string, deleted bool) sq.SelectBuilder {
 	queryBuilder := psql().Select(needColumns...).From(needTableName).
-		Where(sq.Eq{"deleted_at": nil})
+		Where(adminExplorerDeletedFilter(deleted))
 
 	if statusFilter != nil {
 		queryBuilder = queryBuilder.Where(sq.Eq{"status": *statusFilter})
@@ -296,31 +499,14 @@ func (r *NeedRepository) AdminExplorerNeedsPage(ctx context.Context, page, pageS
 		queryBuilder = queryBuilder.OrderBy("updated_at desc", "id asc")
 	}
 
-	query, args, err := queryBuilder.
-		Limit(uint64(pageSize)).
-		Offset(offset).
-		ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate admin explorer needs query: %w", err)
-	}
-
-	needs := make([]*types.Need, 0)
-	err = pgxscan.Select(ctx, r.pool, &needs, query, args...)
-	if err != nil {
-		if pgxscan.NotFound(err) {
-			return needs, nil
-		}
-		return nil, fmt.Errorf("failed to fetch admin explorer needs: %w", err)
-	}
-
-	return needs, nil
+	return queryBuilder
 }
 
-func (r *NeedRepository) AdminExplorerNeedsCount(ctx context.Context, statusFilter *types.NeedStatus) (int, error) {
+func (r *NeedRepository) AdminExplorerNeedsCount(ctx context.Context, statusFilter *types.NeedStatus, deleted bool) (int, error) {
 	queryBuilder := psql().
 		Select("COUNT(*)").
 		From(needTableName).
-		Where(sq.Eq{"deleted_at": nil})
+		Where(adminExplorerDeletedFilter(deleted))
 
 	if statusFilter != nil {
 		queryBuilder = queryBuilder.Where(sq.Eq{"status": *statusFilter})
@@ -339,6 +525,13 @@ func (r *NeedRepository) AdminExplorerNeedsCount(ctx context.Context, statusFilt
 	return total, nil
 }
 
+func adminExplorerDeletedFilter(deleted bool) sq.Sqlizer {
+	if deleted {
+		return sq.NotEq{"deleted_at": nil}
+	}
+	return sq.Eq{"deleted_at": nil}
+}
+
 func (r *NeedRepository) AdminExplorerNeedsCountByStatus(ctx context.Context) (map[types.NeedStatus]int, error) {
 	query, args, err := psql().
 		Select("status", "COUNT(*)").
@@ -378,7 +571,7 @@ func (r *NeedRepository) LatestNeeds(ctx context.Context, limit int) ([]*types.N
 
 	query, args, err := psql().Select(needColumns...).From(needTableName).
 		Where(sq.NotEq{"status": types.NeedStatusDraft}).
-		Where(sq.Eq{"deleted_at": nil}).
+		Where(sq.Eq{"deleted_at": nil, "hidden_at": nil}).
 		OrderBy("created_at desc").
 		Limit(uint64(limit)).
 		ToSql()
@@ -468,36 +661,51 @@ func (r *NeedRepository) NeedsByStatus(ctx context.Context, userID string) ([]*t
 	return needs, nil
 }
 
-func (r *NeedRepository) DraftNeedsByUser(ctx context.Context, userID string) (*types.Need, error) {
+// activeNeedStatuses are the statuses that count toward a user's cap on
+// simultaneous needs: anything submitted that has not been rejected, funded or
+// closed.
+var activeNeedStatuses = []types.NeedStatus{
+	types.NeedStatusSubmitted,
+	types.NeedStatusReadyForReview,
+	types.NeedStatusUnderReview,
+	types.NeedStatusAwaitingSecondApproval,
+	types.NeedStatusChangesRequested,
+	types.NeedStatusActive,
+}
 
-	query, args, err := psql().Select(needColumns...).From(needTableName).
-		Where(sq.Eq{"user_id": userID, "status": "draft"}).
-		OrderBy("created_at desc").
-		Limit(1).
+// ActiveNeedCountByUser counts the user's open, non-deleted needs that have
+// left draft.
+func (r *NeedRepository) ActiveNeedCountByUser(ctx context.Context, userID string) (int, error) {
+	query, args, err := psql().Select("COUNT(*)").From(needTableName).
+		Where(sq.Eq{"user_id": userID, "status": activeNeedStatuses, "deleted_at": nil, "closed_at": nil}).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate draft need query: %w", err)
+		return 0, fmt.Errorf("failed to generate active need count query: %w", err)
 	}
 
-	var need = new(types.Need)
-	err = pgxscan.Get(ctx, r.pool, need, query, args...)
-	if err != nil && !pgxscan.NotFound(err) {
-		return nil, err
-	}
-
-	if err != nil {
-		return nil, types.ErrNeedNotFound
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count active needs: %w", err)
 	}
 
-	return need, nil
+	return count, nil
 }
 
 func (r *NeedRepository) CreateNeed(ctx context.Context, need *types.Need) error {
+	return r.createNeedWithExec(ctx, r.pool, need)
+}
+
+func (r *NeedRepository) CreateNeedTx(ctx context.Context, tx pgx.Tx, need *types.Need) error {
+	return r.createNeedWithExec(ctx, tx, need)
+}
+
+func (r *NeedRepository) createNeedWithExec(ctx context.Context, execer needExecer, need *types.Need) error {
 
 	now := time.Now()
 	need.ID = utils.NanoID()
 	need.UpdatedAt = now
 	need.CreatedAt = now
+	need.Version = 1
 
 	needMap := utils.StructToMap(need)
 
@@ -506,50 +714,58 @@ func (r *NeedRepository) CreateNeed(ctx context.Context, need *types.Need) error
 		return fmt.Errorf("failed to generate insert need query: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
+	_, err = execer.Exec(ctx, query, args...)
 	return utils.ErrorWrapOrNil(err, "failed to create need")
 
 }
 
+// UpdateNeed saves the editable fields of a need, provided need.Version still
+// matches the stored row, and bumps the version. It returns
+// types.ErrNeedVersionConflict when someone else changed the need first.
+// Status is deliberately left out; it only changes through
+// TransitionNeedStatus.
 func (r *NeedRepository) UpdateNeed(ctx context.Context, needID string, need *types.Need) error {
+	return r.updateNeedWithExec(ctx, r.pool, needID, need)
+}
+
+func (r *NeedRepository) UpdateNeedTx(ctx context.Context, tx pgx.Tx, needID string, need *types.Need) error {
+	return r.updateNeedWithExec(ctx, tx, needID, need)
+}
+
+func (r *NeedRepository) updateNeedWithExec(ctx context.Context, execer needExecer, needID string, need *types.Need) error {
 
 	now := time.Now()
 	need.ID = needID
 	need.UpdatedAt = now
 
 	needMap := utils.StructToMap(need)
+	delete(needMap, "status")
+	needMap["version"] = sq.Expr("version + 1")
 
-	query, args, err := psql().Update(needTableName).SetMap(needMap).Where(sq.Eq{"id": needID}).ToSql()
+	query, args, err := psql().Update(needTableName).SetMap(needMap).Where(sq.Eq{"id": needID, "version": need.Version}).ToSql()
 	if err != nil {
 		return fmt.Errorf("failed to generate update need query for need %s: %w", needID, err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
-
-	return utils.ErrorWrapOrNil(err, "failed to update need")
-
-}
-
-func (r *NeedRepository) SetNeedStatus(ctx context.Context, needID string, status types.NeedStatus) error {
-	return r.setNeedStatusWithExec(ctx, r.pool, needID, status)
-}
+	tag, err := execer.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to update need")
+	}
 
-func (r *NeedRepository) SetNeedStatusTx(ctx context.Context, tx pgx.Tx, needID string, status types.NeedStatus) error {
-	return r.setNeedStatusWithExec(ctx, tx, needID, status)
-}
+	if tag.RowsAffected() == 0 {
+		return types.ErrNeedVersionConflict
+	}
 
-func (r *NeedRepository) PublishNeed(ctx context.Context, needID string) error {
-	return r.publishNeedWithExec(ctx, r.pool, needID)
-}
+	need.Version++
+	return nil
 
-func (r *NeedRepository) PublishNeedTx(ctx context.Context, tx pgx.Tx, needID string) error {
-	return r.publishNeedWithExec(ctx, tx, needID)
 }
 
 func (r *NeedRepository) SetNeedUrgency(ctx context.Context, needID string, urgency types.NeedUrgency) error {
 	query, args, err := psql().
 		Update(needTableName).
 		Set("urgency", urgency).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now()).
 		Where(sq.Eq{"id": needID}).
 		ToSql()
@@ -561,10 +777,29 @@ func (r *NeedRepository) SetNeedUrgency(ctx context.Context, needID string, urge
 	return utils.ErrorWrapOrNil(err, "failed to set need urgency")
 }
 
+// SetNeedNeededBy records the date the recipient needs the money by, or
+// clears it when neededBy is nil.
+func (r *NeedRepository) SetNeedNeededBy(ctx context.Context, needID string, neededBy *time.Time) error {
+	query, args, err := psql().
+		Update(needTableName).
+		Set("needed_by", neededBy).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": needID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate set need needed by query for need %s: %w", needID, err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to set need needed by date")
+}
+
 func (r *NeedRepository) SetNeedUrgencyTx(ctx context.Context, tx pgx.Tx, needID string, urgency types.NeedUrgency) error {
 	query, args, err := psql().
 		Update(needTableName).
 		Set("urgency", urgency).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now()).
 		Where(sq.Eq{"id": needID}).
 		ToSql()
@@ -576,35 +811,120 @@ func (r *NeedRepository) SetNeedUrgencyTx(ctx context.Context, tx pgx.Tx, needID
 	return utils.ErrorWrapOrNil(err, "failed to set need urgency")
 }
 
-func (r *NeedRepository) setNeedStatusWithExec(ctx context.Context, execer needExecer, needID string, status types.NeedStatus) error {
+// SetNeedFeaturedTx features or unfeatures a live need. Like reviewer
+// assignment it leaves the need version alone so owner edits are not bounced.
+func (r *NeedRepository) SetNeedFeaturedTx(ctx context.Context, tx pgx.Tx, needID string, featured bool) error {
 	query, args, err := psql().
 		Update(needTableName).
-		Set("status", status).
+		Set("is_featured", featured).
 		Set("updated_at", time.Now()).
-		Where(sq.Eq{"id": needID}).
+		Where(sq.Eq{"id": needID, "deleted_at": nil}).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to generate set need status query for need %s: %w", needID, err)
+		return fmt.Errorf("failed to generate set need featured query for need %s: %w", needID, err)
 	}
 
-	_, err = execer.Exec(ctx, query, args...)
-	return utils.ErrorWrapOrNil(err, "failed to set need status")
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to set need featured")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrNeedNotFound
+	}
+
+	return nil
+}
+
+// UnfeatureNeedTx clears the featured flag whatever state the need is in,
+// including deleted needs the unfeature job cleans up after.
+func (r *NeedRepository) UnfeatureNeedTx(ctx context.Context, tx pgx.Tx, needID string) error {
+	query, args, err := psql().
+		Update(needTableName).
+		Set("is_featured", false).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": needID, "is_featured": true}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate unfeature need query for need %s: %w", needID, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to unfeature need")
 }
 
-func (r *NeedRepository) publishNeedWithExec(ctx context.Context, execer needExecer, needID string) error {
+// SetNeedGoalTx changes the funding goal of a need and returns the amount
+// raised so far, read from the same locked row.
+func (r *NeedRepository) SetNeedGoalTx(ctx context.Context, tx pgx.Tx, needID string, amountNeededCents int) (int, error) {
 	query, args, err := psql().
 		Update(needTableName).
-		Set("status", types.NeedStatusActive).
-		Set("published_at", sq.Expr("COALESCE(published_at, NOW())")).
+		Set("amount_needed_cents", amountNeededCents).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": needID, "deleted_at": nil}).
+		Suffix("RETURNING amount_raised_cents").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate set need goal query for need %s: %w", needID, err)
+	}
+
+	var amountRaisedCents int
+	if err := tx.QueryRow(ctx, query, args...).Scan(&amountRaisedCents); err != nil {
+		if pgxscan.NotFound(err) {
+			return 0, types.ErrNeedNotFound
+		}
+		return 0, utils.ErrorWrapOrNil(err, "failed to set need goal")
+	}
+
+	return amountRaisedCents, nil
+}
+
+// NeedForUpdateTx locks the need row for the rest of the transaction and
+// returns it, deleted or not.
+func (r *NeedRepository) NeedForUpdateTx(ctx context.Context, tx pgx.Tx, needID string) (*types.Need, error) {
+	query, args, err := psql().
+		Select(needColumns...).
+		From(needTableName).
 		Where(sq.Eq{"id": needID}).
+		Suffix("FOR UPDATE").
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to generate publish need query for need %s: %w", needID, err)
+		return nil, fmt.Errorf("failed to generate lock need query for need %s: %w", needID, err)
 	}
 
-	_, err = execer.Exec(ctx, query, args...)
-	return utils.ErrorWrapOrNil(err, "failed to publish need")
+	need := new(types.Need)
+	if err := pgxscan.Get(ctx, tx, need, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrNeedNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to lock need")
+	}
+
+	return need, nil
+}
+
+// AmountRaisedForUpdateTx locks the need row for the rest of the transaction
+// and returns the amount raised so far.
+func (r *NeedRepository) AmountRaisedForUpdateTx(ctx context.Context, tx pgx.Tx, needID string) (int, error) {
+	query, args, err := psql().
+		Select("amount_raised_cents").
+		From(needTableName).
+		Where(sq.Eq{"id": needID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate lock need amount raised query for need %s: %w", needID, err)
+	}
+
+	var amountRaisedCents int
+	if err := tx.QueryRow(ctx, query, args...).Scan(&amountRaisedCents); err != nil {
+		if pgxscan.NotFound(err) {
+			return 0, types.ErrNeedNotFound
+		}
+		return 0, utils.ErrorWrapOrNil(err, "failed to lock need amount raised")
+	}
+
+	return amountRaisedCents, nil
 }
 
 func (r *NeedRepository) SoftDeleteNeed(ctx context.Context, needID, actorUserID, reason string) error {
@@ -622,6 +942,7 @@ func (r *NeedRepository) softDeleteNeedWithExec(ctx context.Context, execer need
 		Set("deleted_at", now).
 		Set("deleted_by_user_id", actorUserID).
 		Set("delete_reason", reason).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", now).
 		Where(sq.Eq{"id": needID, "deleted_at": nil}).
 		ToSql()
@@ -656,6 +977,7 @@ func (r *NeedRepository) restoreNeedWithExec(ctx context.Context, execer needExe
 		Set("deleted_at", nil).
 		Set("deleted_by_user_id", nil).
 		Set("delete_reason", nil).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", now).
 		Where(sq.Eq{"id": needID}).
 		Where(sq.NotEq{"deleted_at": nil}).
@@ -676,6 +998,52 @@ func (r *NeedRepository) restoreNeedWithExec(ctx context.Context, execer needExe
 	return utils.ErrorWrapOrNil(err, "failed to restore need")
 }
 
+// HideActiveNeedsByUserTx hides every live ACTIVE need owned by userID from
+// public pages, tagging each with the restriction responsible so lifting it
+// brings back exactly those needs. It returns how many needs were hidden.
+func (r *NeedRepository) HideActiveNeedsByUserTx(ctx context.Context, tx pgx.Tx, userID, restrictionID string) (int, error) {
+	now := time.Now()
+	query, args, err := psql().
+		Update(needTableName).
+		Set("hidden_at", now).
+		Set("hidden_by_restriction_id", restrictionID).
+		Set("updated_at", now).
+		Where(sq.Eq{"user_id": userID, "status": types.NeedStatusActive, "deleted_at": nil, "hidden_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate hide needs query for user %s: %w", userID, err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to hide user needs")
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// UnhideNeedsByRestrictionTx reverses HideActiveNeedsByUserTx for one
+// restriction and returns how many needs are visible again.
+func (r *NeedRepository) UnhideNeedsByRestrictionTx(ctx context.Context, tx pgx.Tx, restrictionID string) (int, error) {
+	query, args, err := psql().
+		Update(needTableName).
+		Set("hidden_at", nil).
+		Set("hidden_by_restriction_id", nil).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"hidden_by_restriction_id": restrictionID}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate unhide needs query for restriction %s: %w", restrictionID, err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to unhide needs")
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
 func (r *NeedRepository) DeleteNeed(ctx context.Context, needID string) error {
 
 	query, args, err := psql().Delete(needTableName).Where(sq.Eq{"id": needID}).ToSql()
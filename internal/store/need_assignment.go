@@ -0,0 +1,111 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+// NeedReviewerForUpdateTx locks the need row for the rest of the transaction
+// and returns the reviewer it is currently assigned to, or nil when unclaimed.
+func (r *NeedRepository) NeedReviewerForUpdateTx(ctx context.Context, tx pgx.Tx, needID string) (*string, error) {
+	query, args, err := psql().
+		Select("assigned_reviewer_user_id").
+		From(needTableName).
+		Where(sq.Eq{"id": needID, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock need reviewer query for need %s: %w", needID, err)
+	}
+
+	var reviewerUserID *string
+	if err := tx.QueryRow(ctx, query, args...).Scan(&reviewerUserID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrNeedNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to lock need reviewer")
+	}
+
+	return reviewerUserID, nil
+}
+
+// SetNeedReviewerTx assigns the need to a reviewer, or clears the assignment
+// when reviewerUserID is nil. The need version is left alone so that an owner
+// editing their need is not bounced by a claim.
+func (r *NeedRepository) SetNeedReviewerTx(ctx context.Context, tx pgx.Tx, needID string, reviewerUserID *string) error {
+	var assignedAt *time.Time
+	if reviewerUserID != nil {
+		now := time.Now()
+		assignedAt = &now
+	}
+
+	query, args, err := psql().
+		Update(needTableName).
+		Set("assigned_reviewer_user_id", reviewerUserID).
+		Set("assigned_at", assignedAt).
+		Where(sq.Eq{"id": needID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate set need reviewer query for need %s: %w", needID, err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to set need reviewer")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrNeedNotFound
+	}
+
+	return nil
+}
+
+// StaleReviewerClaims returns queued needs that were claimed before cutoff
+// where the assigned reviewer has not recorded any moderation action since.
+func (r *NeedRepository) StaleReviewerClaims(ctx context.Context, cutoff time.Time, limit int) ([]*types.Need, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	columns := make([]string, 0, len(needColumns))
+	for _, column := range needColumns {
+		columns = append(columns, "n."+column)
+	}
+
+	query, args, err := psql().
+		Select(columns...).
+		From(needTableName + " n").
+		Where(sq.NotEq{"n.assigned_reviewer_user_id": nil}).
+		Where(sq.Lt{"n.assigned_at": cutoff}).
+		Where(sq.Eq{"n.status": []types.NeedStatus{types.NeedStatusReadyForReview, types.NeedStatusUnderReview}}).
+		Where(sq.Eq{"n.deleted_at": nil}).
+		Where(sq.Expr(
+			"NOT EXISTS (SELECT 1 FROM "+needModerationActionsTableName+" a WHERE a.need_id = n.id AND a.actor_user_id = n.assigned_reviewer_user_id AND a.created_at >= ?)",
+			cutoff,
+		)).
+		OrderBy("n.assigned_at asc").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate stale reviewer claims query: %w", err)
+	}
+
+	needs := make([]*types.Need, 0)
+	err = pgxscan.Select(ctx, r.pool, &needs, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return needs, nil
+		}
+		return nil, fmt.Errorf("failed to fetch stale reviewer claims: %w", err)
+	}
+
+	return needs, nil
+}
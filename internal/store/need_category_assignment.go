@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -81,7 +82,7 @@ func (r *AssignmentRepository) PrimaryNeedCountsByCategoryIDs(ctx context.Contex
 		Join("christjesus.needs n ON n.id = a.need_id").
 		Where(sq.Eq{"a.category_id": categoryIDs, "a.is_primary": true}).
 		Where(sq.NotEq{"n.status": types.NeedStatusDraft}).
-		Where(sq.Eq{"n.deleted_at": nil}).
+		Where(sq.Eq{"n.deleted_at": nil, "n.hidden_at": nil}).
 		GroupBy("a.category_id").
 		ToSql()
 	if err != nil {
@@ -138,6 +139,26 @@ func (r *AssignmentRepository) CreateAssignments(ctx context.Context, assignment
 		_ = tx.Rollback(ctx)
 	}()
 
+	if err := insertAssignmentsWithExec(ctx, tx, assignments, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAssignmentsTx inserts assignments inside a caller-managed transaction.
+func (r *AssignmentRepository) CreateAssignmentsTx(ctx context.Context, tx pgx.Tx, assignments []*types.NeedCategoryAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+	return insertAssignmentsWithExec(ctx, tx, assignments, time.Now())
+}
+
+func insertAssignmentsWithExec(ctx context.Context, execer needExecer, assignments []*types.NeedCategoryAssignment, now time.Time) error {
 	builder := psql().
 		Insert(assignmentTableName).Columns(assignmentColumns...)
 
@@ -151,15 +172,11 @@ func (r *AssignmentRepository) CreateAssignments(ctx context.Context, assignment
 		return fmt.Errorf("failed to generate insert query: %w", err)
 	}
 
-	_, err = tx.Exec(ctx, query, args...)
+	_, err = execer.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to insert assignment: %w", err)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -183,6 +200,16 @@ func (r *AssignmentRepository) DeleteAssignment(ctx context.Context, needID, cat
 
 // DeleteAllAssignmentsByNeedID deletes all category assignments for a need
 func (r *AssignmentRepository) DeleteAllAssignmentsByNeedID(ctx context.Context, needID string) error {
+	return deleteAllAssignmentsWithExec(ctx, r.pool, needID)
+}
+
+// DeleteAllAssignmentsByNeedIDTx deletes all category assignments for a need
+// inside a caller-managed transaction.
+func (r *AssignmentRepository) DeleteAllAssignmentsByNeedIDTx(ctx context.Context, tx pgx.Tx, needID string) error {
+	return deleteAllAssignmentsWithExec(ctx, tx, needID)
+}
+
+func deleteAllAssignmentsWithExec(ctx context.Context, execer needExecer, needID string) error {
 	query, args, err := psql().
 		Delete(assignmentTableName).
 		Where(sq.Eq{"need_id": needID}).
@@ -191,7 +218,7 @@ func (r *AssignmentRepository) DeleteAllAssignmentsByNeedID(ctx context.Context,
 		return fmt.Errorf("failed to generate delete query: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
+	_, err = execer.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete assignments: %w", err)
 	}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+const needChangeRequestItemsTableName = "christjesus.need_change_request_items"
+
+var needChangeRequestItemColumns = utils.StructTagValues(types.NeedChangeRequestItem{})
+
+// CreateChangeRequestItemsTx attaches checklist items to the changes_requested
+// moderation action they were submitted with.
+func (r *NeedProgressRepository) CreateChangeRequestItemsTx(ctx context.Context, tx pgx.Tx, needID, moderationActionID string, items []*types.NeedChangeRequestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	queryBuilder := psql().
+		Insert(needChangeRequestItemsTableName).
+		Columns("id", "need_id", "moderation_action_id", "field", "document_id", "note", "created_at")
+
+	for _, item := range items {
+		item.ID = utils.NanoID()
+		item.NeedID = needID
+		item.ModerationActionID = moderationActionID
+		item.CreatedAt = now
+		queryBuilder = queryBuilder.Values(item.ID, item.NeedID, item.ModerationActionID, item.Field, item.DocumentID, item.Note, item.CreatedAt)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create change request items query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create change request items")
+}
+
+// ChangeRequestItemsByAction returns the checklist of one change request.
+func (r *NeedProgressRepository) ChangeRequestItemsByAction(ctx context.Context, moderationActionID string) ([]*types.NeedChangeRequestItem, error) {
+	query, args, err := psql().
+		Select(needChangeRequestItemColumns...).
+		From(needChangeRequestItemsTableName).
+		Where(sq.Eq{"moderation_action_id": moderationActionID}).
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate change request items query: %w", err)
+	}
+
+	items := make([]*types.NeedChangeRequestItem, 0)
+	err = pgxscan.Select(ctx, r.pool, &items, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return items, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load change request items")
+	}
+
+	return items, nil
+}
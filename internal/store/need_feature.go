@@ -0,0 +1,311 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const needFeatureSlotsTableName = "christjesus.need_feature_slots"
+
+var needFeatureSlotColumns = utils.StructTagValues(types.NeedFeatureSlot{})
+
+// unfeaturableNeedStatuses are the statuses a need can no longer be featured
+// in. Deleted needs are unfeatured whatever their status.
+var unfeaturableNeedStatuses = []types.NeedStatus{types.NeedStatusFunded, types.NeedStatusClosed}
+
+type NeedFeatureRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNeedFeatureRepository(pool *pgxpool.Pool) *NeedFeatureRepository {
+	return &NeedFeatureRepository{pool: pool}
+}
+
+func (r *NeedFeatureRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// openSlotWhere matches slots that have not been ended and whose window has
+// not closed at now. Upcoming slots match too.
+func openSlotWhere(prefix string, now time.Time) sq.Sqlizer {
+	return sq.And{
+		sq.Eq{prefix + "ended_at": nil},
+		sq.Or{sq.Eq{prefix + "ends_at": nil}, sq.Gt{prefix + "ends_at": now}},
+	}
+}
+
+// OpenSlots returns every live or upcoming slot, grouped by placement and
+// ordered the way each placement shows them.
+func (r *NeedFeatureRepository) OpenSlots(ctx context.Context, now time.Time) ([]*types.NeedFeatureSlot, error) {
+	query, args, err := psql().
+		Select(needFeatureSlotColumns...).
+		From(needFeatureSlotsTableName).
+		Where(openSlotWhere("", now)).
+		OrderBy("placement desc", "category_id", "position", "starts_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate open feature slots query: %w", err)
+	}
+
+	slots := make([]*types.NeedFeatureSlot, 0)
+	if err := pgxscan.Select(ctx, r.pool, &slots, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return slots, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load open feature slots")
+	}
+
+	return slots, nil
+}
+
+// PastSlots returns slots that were ended or whose window closed, most
+// recently finished first.
+func (r *NeedFeatureRepository) PastSlots(ctx context.Context, now time.Time, limit int) ([]*types.NeedFeatureSlot, error) {
+	query, args, err := psql().
+		Select(needFeatureSlotColumns...).
+		From(needFeatureSlotsTableName).
+		Where(sq.Or{sq.NotEq{"ended_at": nil}, sq.LtOrEq{"ends_at": now}}).
+		OrderBy("COALESCE(ended_at, ends_at) desc").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate past feature slots query: %w", err)
+	}
+
+	slots := make([]*types.NeedFeatureSlot, 0)
+	if err := pgxscan.Select(ctx, r.pool, &slots, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return slots, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load past feature slots")
+	}
+
+	return slots, nil
+}
+
+// LiveFeaturedNeeds returns the publicly visible active needs scheduled onto
+// a placement at now, in slot order. categoryID is ignored for the homepage.
+// A need with several live slots is listed once, at its best position.
+func (r *NeedFeatureRepository) LiveFeaturedNeeds(ctx context.Context, placement types.NeedFeaturePlacement, categoryID string, now time.Time, limit int) ([]*types.Need, error) {
+	cols := make([]string, len(needColumns))
+	for i, c := range needColumns {
+		cols[i] = "n." + c
+	}
+
+	qb := psql().
+		Select(cols...).
+		From(needTableName + " n").
+		Join(needFeatureSlotsTableName + " fs ON fs.need_id = n.id").
+		Where(sq.Eq{"fs.placement": placement}).
+		Where(openSlotWhere("fs.", now)).
+		Where(sq.LtOrEq{"fs.starts_at": now}).
+		Where(sq.Eq{"n.status": types.NeedStatusActive, "n.deleted_at": nil, "n.hidden_at": nil})
+	if placement == types.NeedFeaturePlacementCategory {
+		qb = qb.Where(sq.Eq{"fs.category_id": categoryID})
+	}
+
+	query, args, err := qb.
+		GroupBy("n.id").
+		OrderBy("MIN(fs.position)", "MIN(fs.starts_at)").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate live featured needs query: %w", err)
+	}
+
+	needs := make([]*types.Need, 0)
+	if err := pgxscan.Select(ctx, r.pool, &needs, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return needs, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load live featured needs")
+	}
+
+	return needs, nil
+}
+
+// SlotForUpdateTx locks a slot. It returns types.ErrNeedFeatureSlotNotFound
+// when there is no such slot.
+func (r *NeedFeatureRepository) SlotForUpdateTx(ctx context.Context, tx pgx.Tx, slotID string) (*types.NeedFeatureSlot, error) {
+	query, args, err := psql().
+		Select(needFeatureSlotColumns...).
+		From(needFeatureSlotsTableName).
+		Where(sq.Eq{"id": slotID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock feature slot query: %w", err)
+	}
+
+	var slot types.NeedFeatureSlot
+	if err := pgxscan.Get(ctx, tx, &slot, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrNeedFeatureSlotNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to lock feature slot")
+	}
+
+	return &slot, nil
+}
+
+// CreateSlotTx schedules a need onto a placement.
+func (r *NeedFeatureRepository) CreateSlotTx(ctx context.Context, tx pgx.Tx, slot *types.NeedFeatureSlot) error {
+	if slot.ID == "" {
+		slot.ID = utils.NanoID()
+	}
+	if slot.CreatedAt.IsZero() {
+		slot.CreatedAt = time.Now()
+	}
+
+	query, args, err := psql().
+		Insert(needFeatureSlotsTableName).
+		Columns("id", "need_id", "placement", "category_id", "position", "starts_at", "ends_at", "created_by_user_id", "created_at").
+		Values(slot.ID, slot.NeedID, slot.Placement, slot.CategoryID, slot.Position, slot.StartsAt, slot.EndsAt, slot.CreatedByUserID, slot.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create feature slot query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create feature slot")
+}
+
+// SetSlotPosition moves an open slot within its placement.
+func (r *NeedFeatureRepository) SetSlotPosition(ctx context.Context, slotID string, position int) error {
+	query, args, err := psql().
+		Update(needFeatureSlotsTableName).
+		Set("position", position).
+		Where(sq.Eq{"id": slotID}).
+		Where(openSlotWhere("", time.Now())).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate set feature slot position query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to set feature slot position")
+	}
+	if tag.RowsAffected() == 0 {
+		return types.ErrNeedFeatureSlotNotFound
+	}
+
+	return nil
+}
+
+// EndSlotTx takes an open slot off display before its window closes.
+// endedByUserID is nil when the unfeature job ends it.
+func (r *NeedFeatureRepository) EndSlotTx(ctx context.Context, tx pgx.Tx, slotID string, endedByUserID *string, reason string) error {
+	now := time.Now()
+	query, args, err := psql().
+		Update(needFeatureSlotsTableName).
+		Set("ended_at", now).
+		Set("ended_by_user_id", endedByUserID).
+		Set("end_reason", reason).
+		Where(sq.Eq{"id": slotID}).
+		Where(openSlotWhere("", now)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate end feature slot query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to end feature slot")
+	}
+	if tag.RowsAffected() == 0 {
+		return types.ErrNeedFeatureSlotNotFound
+	}
+
+	return nil
+}
+
+// EndSlotsForNeedTx ends every open slot of a need and returns how many
+// were ended.
+func (r *NeedFeatureRepository) EndSlotsForNeedTx(ctx context.Context, tx pgx.Tx, needID string, endedByUserID *string, reason string) (int, error) {
+	now := time.Now()
+	query, args, err := psql().
+		Update(needFeatureSlotsTableName).
+		Set("ended_at", now).
+		Set("ended_by_user_id", endedByUserID).
+		Set("end_reason", reason).
+		Where(sq.Eq{"need_id": needID}).
+		Where(openSlotWhere("", now)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate end need feature slots query for need %s: %w", needID, err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to end need feature slots")
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// CountOpenSlotsByNeedTx counts the live and upcoming slots of a need, so
+// callers can tell whether ending one leaves the need featured.
+func (r *NeedFeatureRepository) CountOpenSlotsByNeedTx(ctx context.Context, tx pgx.Tx, needID string) (int, error) {
+	query, args, err := psql().
+		Select("COUNT(*)").
+		From(needFeatureSlotsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		Where(openSlotWhere("", time.Now())).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate count need feature slots query for need %s: %w", needID, err)
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to count need feature slots")
+	}
+
+	return count, nil
+}
+
+// NeedsToUnfeature returns needs that are featured or still hold an open
+// slot but are funded, closed or deleted, oldest change first.
+func (r *NeedFeatureRepository) NeedsToUnfeature(ctx context.Context, limit int) ([]*types.Need, error) {
+	cols := make([]string, len(needColumns))
+	for i, c := range needColumns {
+		cols[i] = "n." + c
+	}
+
+	query, args, err := psql().
+		Select(cols...).
+		From(needTableName + " n").
+		Where(sq.Or{
+			sq.Eq{"n.status": unfeaturableNeedStatuses},
+			sq.NotEq{"n.deleted_at": nil},
+		}).
+		Where(sq.Or{
+			sq.Eq{"n.is_featured": true},
+			sq.Expr("EXISTS (SELECT 1 FROM "+needFeatureSlotsTableName+" fs WHERE fs.need_id = n.id AND fs.ended_at IS NULL AND (fs.ends_at IS NULL OR fs.ends_at > ?))", time.Now()),
+		}).
+		OrderBy("n.updated_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate needs to unfeature query: %w", err)
+	}
+
+	needs := make([]*types.Need, 0)
+	if err := pgxscan.Select(ctx, r.pool, &needs, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return needs, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load needs to unfeature")
+	}
+
+	return needs, nil
+}
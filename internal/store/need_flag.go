@@ -0,0 +1,184 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const needFlagsTableName = "christjesus.need_flags"
+
+var needFlagsColumns = utils.StructTagValues(types.NeedFlag{})
+
+type NeedFlagRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNeedFlagRepository(pool *pgxpool.Pool) *NeedFlagRepository {
+	return &NeedFlagRepository{pool: pool}
+}
+
+func (r *NeedFlagRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// FlagsByNeed returns every flag raised against a need, open flags first.
+func (r *NeedFlagRepository) FlagsByNeed(ctx context.Context, needID string) ([]*types.NeedFlag, error) {
+	query, args, err := psql().
+		Select(needFlagsColumns...).
+		From(needFlagsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		OrderBy("(dismissed_at IS NOT NULL)", "similarity DESC", "created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate need flags query: %w", err)
+	}
+
+	flags := make([]*types.NeedFlag, 0)
+	err = pgxscan.Select(ctx, r.pool, &flags, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return flags, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load need flags")
+	}
+
+	return flags, nil
+}
+
+// CreateFlags inserts new flags. A flag that already exists for the same need,
+// matched need and signal is left untouched so dismissals survive resubmission.
+func (r *NeedFlagRepository) CreateFlags(ctx context.Context, flags []*types.NeedFlag) error {
+	if len(flags) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	qb := psql().
+		Insert(needFlagsTableName).
+		Columns("id", "need_id", "matched_need_id", "signal", "detail", "similarity", "created_at")
+
+	for _, flag := range flags {
+		if flag == nil {
+			continue
+		}
+		flag.ID = utils.NanoID()
+		flag.CreatedAt = now
+		qb = qb.Values(flag.ID, flag.NeedID, flag.MatchedNeedID, flag.Signal, flag.Detail, flag.Similarity, flag.CreatedAt)
+	}
+
+	query, args, err := qb.
+		Suffix("ON CONFLICT (need_id, matched_need_id, signal) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create need flags query: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create need flags")
+}
+
+// DismissFlagTx marks an open flag as dismissed. It returns
+// types.ErrNeedFlagNotFound when the flag does not exist on the need or was
+// already dismissed.
+func (r *NeedFlagRepository) DismissFlagTx(ctx context.Context, tx pgx.Tx, needID, flagID, actorUserID, reason string) (*types.NeedFlag, error) {
+	query, args, err := psql().
+		Update(needFlagsTableName).
+		Set("dismissed_at", time.Now()).
+		Set("dismissed_by_user_id", actorUserID).
+		Set("dismiss_reason", reason).
+		Where(sq.Eq{"id": flagID, "need_id": needID}).
+		Where(sq.Eq{"dismissed_at": nil}).
+		Suffix("RETURNING " + strings.Join(needFlagsColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate dismiss need flag query: %w", err)
+	}
+
+	flag := new(types.NeedFlag)
+	err = pgxscan.Get(ctx, tx, flag, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrNeedFlagNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to dismiss need flag")
+	}
+
+	return flag, nil
+}
+
+// AddressCandidates returns submitted needs owned by other users whose
+// resolved address shares the given ZIP5.
+func (r *NeedFlagRepository) AddressCandidates(ctx context.Context, excludeUserID, zip5 string) ([]*types.NeedAddressCandidate, error) {
+	query, args, err := psql().
+		Select(
+			"n.id AS need_id",
+			"n.user_id",
+			"a.address",
+			"a.address_ext",
+			"a.city",
+			"a.state",
+			"a.zip_code",
+		).
+		From(needTableName + " n").
+		Join(userAddressTableName + " a ON a.user_id = n.user_id AND (a.id = n.user_address_id OR (n.user_address_id IS NULL AND a.is_primary))").
+		Where(sq.NotEq{"n.user_id": excludeUserID}).
+		Where(sq.NotEq{"n.status": types.NeedStatusDraft}).
+		Where(sq.Expr("left(a.zip_code, 5) = ?", zip5)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate need address candidates query: %w", err)
+	}
+
+	candidates := make([]*types.NeedAddressCandidate, 0)
+	err = pgxscan.Select(ctx, r.pool, &candidates, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return candidates, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load need address candidates")
+	}
+
+	return candidates, nil
+}
+
+// StoryCandidates returns the stories of the most recently submitted needs
+// owned by other users, for fuzzy comparison.
+func (r *NeedFlagRepository) StoryCandidates(ctx context.Context, excludeUserID string, limit int) ([]*types.NeedStory, error) {
+	columns := make([]string, 0, len(storyColumns))
+	for _, column := range storyColumns {
+		columns = append(columns, "s."+column)
+	}
+
+	query, args, err := psql().
+		Select(columns...).
+		From(storyTableName + " s").
+		Join(needTableName + " n ON n.id = s.need_id").
+		Where(sq.NotEq{"n.user_id": excludeUserID}).
+		Where(sq.NotEq{"n.status": types.NeedStatusDraft}).
+		OrderBy("n.submitted_at DESC NULLS LAST").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate story candidates query: %w", err)
+	}
+
+	stories := make([]*types.NeedStory, 0)
+	err = pgxscan.Select(ctx, r.pool, &stories, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return stories, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load story candidates")
+	}
+
+	return stories, nil
+}
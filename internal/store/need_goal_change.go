@@ -0,0 +1,165 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const needGoalChangeRequestsTableName = "christjesus.need_goal_change_requests"
+
+var needGoalChangeRequestColumns = utils.StructTagValues(types.NeedGoalChangeRequest{})
+
+type NeedGoalChangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNeedGoalChangeRepository(pool *pgxpool.Pool) *NeedGoalChangeRepository {
+	return &NeedGoalChangeRepository{pool: pool}
+}
+
+func (r *NeedGoalChangeRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// CreateRequest records a new pending request. It returns
+// types.ErrGoalChangeRequestPending when the need already has one waiting for
+// a decision.
+func (r *NeedGoalChangeRepository) CreateRequest(ctx context.Context, request *types.NeedGoalChangeRequest) error {
+	request.ID = utils.NanoID()
+	request.Status = types.NeedGoalChangeStatusPending
+	request.CreatedAt = time.Now()
+
+	query, args, err := psql().
+		Insert(needGoalChangeRequestsTableName).
+		Columns("id", "need_id", "requested_by_user_id", "kind", "previous_goal_cents", "requested_goal_cents", "justification", "status", "created_at").
+		Values(request.ID, request.NeedID, request.RequestedByUserID, request.Kind, request.PreviousGoalCents, request.RequestedGoalCents, request.Justification, request.Status, request.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create goal change request query: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return types.ErrGoalChangeRequestPending
+		}
+		return utils.ErrorWrapOrNil(err, "failed to create goal change request")
+	}
+
+	return nil
+}
+
+// RequestsByNeed returns every goal change request for a need, newest first.
+func (r *NeedGoalChangeRepository) RequestsByNeed(ctx context.Context, needID string) ([]*types.NeedGoalChangeRequest, error) {
+	query, args, err := psql().
+		Select(needGoalChangeRequestColumns...).
+		From(needGoalChangeRequestsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate goal change requests query: %w", err)
+	}
+
+	requests := make([]*types.NeedGoalChangeRequest, 0)
+	err = pgxscan.Select(ctx, r.pool, &requests, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return requests, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load goal change requests")
+	}
+
+	return requests, nil
+}
+
+// PendingRequestForUpdateTx locks a pending request so its approval can be
+// checked and applied before another admin decides it. It returns
+// types.ErrGoalChangeRequestNotFound when the request does not exist on the
+// need or has already been decided.
+func (r *NeedGoalChangeRepository) PendingRequestForUpdateTx(ctx context.Context, tx pgx.Tx, needID, requestID string) (*types.NeedGoalChangeRequest, error) {
+	query, args, err := psql().
+		Select(needGoalChangeRequestColumns...).
+		From(needGoalChangeRequestsTableName).
+		Where(sq.Eq{"id": requestID, "need_id": needID, "status": types.NeedGoalChangeStatusPending}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock goal change request query: %w", err)
+	}
+
+	request := new(types.NeedGoalChangeRequest)
+	err = pgxscan.Get(ctx, tx, request, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrGoalChangeRequestNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to lock goal change request")
+	}
+
+	return request, nil
+}
+
+// RecordFirstApprovalTx records the first of two approvals on a pending
+// request. The request stays pending until a different admin approves it.
+func (r *NeedGoalChangeRepository) RecordFirstApprovalTx(ctx context.Context, tx pgx.Tx, needID, requestID, actorUserID string) error {
+	query, args, err := psql().
+		Update(needGoalChangeRequestsTableName).
+		Set("first_approved_by_user_id", actorUserID).
+		Set("first_approved_at", time.Now()).
+		Where(sq.Eq{"id": requestID, "need_id": needID, "status": types.NeedGoalChangeStatusPending, "first_approved_by_user_id": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate first approval goal change request query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to record goal change first approval")
+	}
+	if tag.RowsAffected() == 0 {
+		return types.ErrGoalChangeRequestNotFound
+	}
+
+	return nil
+}
+
+// DecideRequestTx approves or denies a pending request. It returns
+// types.ErrGoalChangeRequestNotFound when the request does not exist on the
+// need or has already been decided.
+func (r *NeedGoalChangeRepository) DecideRequestTx(ctx context.Context, tx pgx.Tx, needID, requestID string, status types.NeedGoalChangeStatus, actorUserID string, note *string) (*types.NeedGoalChangeRequest, error) {
+	query, args, err := psql().
+		Update(needGoalChangeRequestsTableName).
+		Set("status", status).
+		Set("decided_by_user_id", actorUserID).
+		Set("decided_at", time.Now()).
+		Set("decision_note", note).
+		Where(sq.Eq{"id": requestID, "need_id": needID, "status": types.NeedGoalChangeStatusPending}).
+		Suffix("RETURNING " + strings.Join(needGoalChangeRequestColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate decide goal change request query: %w", err)
+	}
+
+	request := new(types.NeedGoalChangeRequest)
+	err = pgxscan.Get(ctx, tx, request, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrGoalChangeRequestNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to decide goal change request")
+	}
+
+	return request, nil
+}
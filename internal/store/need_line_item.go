@@ -0,0 +1,96 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const needLineItemsTableName = "christjesus.need_line_items"
+
+var needLineItemsColumns = utils.StructTagValues(types.NeedLineItem{})
+
+type NeedLineItemRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNeedLineItemRepository(pool *pgxpool.Pool) *NeedLineItemRepository {
+	return &NeedLineItemRepository{pool: pool}
+}
+
+func (r *NeedLineItemRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// LineItemsByNeedID returns a need's line items in display order.
+func (r *NeedLineItemRepository) LineItemsByNeedID(ctx context.Context, needID string) ([]*types.NeedLineItem, error) {
+	query, args, err := psql().
+		Select(needLineItemsColumns...).
+		From(needLineItemsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		OrderBy("sort_order", "created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate need line items query: %w", err)
+	}
+
+	items := make([]*types.NeedLineItem, 0)
+	err = pgxscan.Select(ctx, r.pool, &items, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return items, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load need line items")
+	}
+
+	return items, nil
+}
+
+// ReplaceLineItemsTx deletes a need's existing line items and inserts the
+// given ones in their slice order.
+func (r *NeedLineItemRepository) ReplaceLineItemsTx(ctx context.Context, tx pgx.Tx, needID string, items []*types.NeedLineItem) error {
+	query, args, err := psql().
+		Delete(needLineItemsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate delete need line items query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete need line items: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	builder := psql().
+		Insert(needLineItemsTableName).
+		Columns(needLineItemsColumns...)
+
+	for i, item := range items {
+		item.ID = utils.NanoID()
+		item.NeedID = needID
+		item.SortOrder = i
+		item.CreatedAt = now
+		item.UpdatedAt = now
+		builder = builder.Values(item.ID, item.NeedID, item.Label, item.AmountCents, item.CategoryID, item.SortOrder, item.CreatedAt, item.UpdatedAt)
+	}
+
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate insert need line items query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to insert need line items")
+}
@@ -98,6 +98,27 @@ func (r *NeedProgressRepository) RecordModerationActionEventTx(
 	reason *string,
 	note *string,
 	documentID *string,
+) (*types.NeedModerationAction, error) {
+	return recordModerationActionEventTx(ctx, tx, needID, actionType, types.NeedProgressEventSourceAdmin, &actorUserID, reason, note, documentID)
+}
+
+// RecordSystemModerationActionEventTx records a moderation action taken by a
+// scheduled job rather than an admin. It has no actor; note should say whom
+// the action concerned.
+func (r *NeedProgressRepository) RecordSystemModerationActionEventTx(ctx context.Context, tx pgx.Tx, needID string, actionType types.NeedModerationActionType, note *string) (*types.NeedModerationAction, error) {
+	return recordModerationActionEventTx(ctx, tx, needID, actionType, types.NeedProgressEventSourceSystem, nil, nil, note, nil)
+}
+
+func recordModerationActionEventTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	needID string,
+	actionType types.NeedModerationActionType,
+	source types.NeedProgressEventSource,
+	actorUserID *string,
+	reason *string,
+	note *string,
+	documentID *string,
 ) (*types.NeedModerationAction, error) {
 	action := &types.NeedModerationAction{
 		ID:          utils.NanoID(),
@@ -126,7 +147,7 @@ func (r *NeedProgressRepository) RecordModerationActionEventTx(
 	eventQuery, eventArgs, err := psql().
 		Insert(needProgressEventsTableName).
 		Columns("id", "need_id", "step", "event_source", "actor_user_id", "moderation_action_id").
-		Values(eventID, needID, types.NeedProgressEventStep(actionType), types.NeedProgressEventSourceAdmin, actorUserID, action.ID).
+		Values(eventID, needID, types.NeedProgressEventStep(actionType), source, actorUserID, action.ID).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate insert moderation event query: %w", err)
@@ -118,3 +118,61 @@ func (r *NeedReviewMessageRepository) CreateMessage(ctx context.Context, message
 	_, err = r.pool.Exec(ctx, query, args...)
 	return utils.ErrorWrapOrNil(err, "failed to create need review message")
 }
+
+func latestNeedReviewMessagesQuery() sq.SelectBuilder {
+	return psql().
+		Select(needReviewMessagesColumns...).
+		Options("DISTINCT ON (need_id)").
+		From(needReviewMessagesTableName).
+		OrderBy("need_id", "created_at DESC", "id DESC")
+}
+
+func unansweredNeedReviewMessagesQuery(columns ...string) sq.SelectBuilder {
+	return psql().
+		Select(columns...).
+		FromSelect(latestNeedReviewMessagesQuery(), "m").
+		Join(needTableName + " n ON n.id = m.need_id").
+		Where(sq.Eq{"m.sender_role": types.NeedReviewMessageSenderRoleUser, "n.deleted_at": nil})
+}
+
+// OldestUnansweredMessages returns the latest message of each need thread
+// whose last word came from the owner, oldest first.
+func (r *NeedReviewMessageRepository) OldestUnansweredMessages(ctx context.Context, limit int) ([]*types.NeedReviewMessage, error) {
+	columns := make([]string, 0, len(needReviewMessagesColumns))
+	for _, column := range needReviewMessagesColumns {
+		columns = append(columns, "m."+column)
+	}
+
+	query, args, err := unansweredNeedReviewMessagesQuery(columns...).
+		OrderBy("m.created_at ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate unanswered need review messages query: %w", err)
+	}
+
+	messages := make([]*types.NeedReviewMessage, 0)
+	err = pgxscan.Select(ctx, r.pool, &messages, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return messages, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load unanswered need review messages")
+	}
+
+	return messages, nil
+}
+
+func (r *NeedReviewMessageRepository) UnansweredMessagesCount(ctx context.Context) (int, error) {
+	query, args, err := unansweredNeedReviewMessagesQuery("COUNT(*)").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate unanswered need review messages count query: %w", err)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, utils.ErrorWrapOrNil(err, "failed to count unanswered need review messages")
+	}
+
+	return total, nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const needRevisionsTableName = "christjesus.need_revisions"
+
+var needRevisionsColumns = utils.StructTagValues(types.NeedRevision{})
+
+type NeedRevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNeedRevisionRepository(pool *pgxpool.Pool) *NeedRevisionRepository {
+	return &NeedRevisionRepository{pool: pool}
+}
+
+// RevisionsByNeed returns every snapshot for a need, newest first.
+func (r *NeedRevisionRepository) RevisionsByNeed(ctx context.Context, needID string) ([]*types.NeedRevision, error) {
+	query, args, err := psql().
+		Select(needRevisionsColumns...).
+		From(needRevisionsTableName).
+		Where(sq.Eq{"need_id": needID}).
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate need revisions query: %w", err)
+	}
+
+	revisions := make([]*types.NeedRevision, 0)
+	err = pgxscan.Select(ctx, r.pool, &revisions, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return revisions, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load need revisions")
+	}
+
+	return revisions, nil
+}
+
+func (r *NeedRevisionRepository) CreateRevision(ctx context.Context, revision *types.NeedRevision) error {
+	if revision == nil {
+		return fmt.Errorf("revision is required")
+	}
+
+	revision.NeedID = strings.TrimSpace(revision.NeedID)
+	if revision.NeedID == "" {
+		return fmt.Errorf("need id is required")
+	}
+
+	revision.ActorUserID = strings.TrimSpace(revision.ActorUserID)
+	if revision.ActorUserID == "" {
+		return fmt.Errorf("actor user id is required")
+	}
+
+	if len(revision.Snapshot) == 0 {
+		return fmt.Errorf("snapshot is required")
+	}
+
+	revision.ID = utils.NanoID()
+	revision.CreatedAt = time.Now()
+
+	query, args, err := psql().
+		Insert(needRevisionsTableName).
+		Columns(needRevisionsColumns...).
+		Values(revision.ID, revision.NeedID, revision.ActorUserID, revision.Source, revision.Snapshot, revision.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create need revision query: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create need revision")
+}
@@ -0,0 +1,218 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// needTransitionEffect is extra bookkeeping applied to the need row alongside
+// the status change.
+type needTransitionEffect int
+
+const (
+	needTransitionEffectNone needTransitionEffect = iota
+	// needTransitionEffectSubmit stamps submitted_at.
+	needTransitionEffectSubmit
+	// needTransitionEffectPublish stamps published_at the first time a need
+	// goes live; a need that is re-published keeps its original date.
+	needTransitionEffectPublish
+	// needTransitionEffectClose stamps closed_at.
+	needTransitionEffectClose
+	// needTransitionEffectFirstApproval records who gave the first of two
+	// approvals and when.
+	needTransitionEffectFirstApproval
+	// needTransitionEffectClearFirstApproval forgets the first approval when
+	// a need held for a second one is sent back, so a later approval starts
+	// over.
+	needTransitionEffectClearFirstApproval
+)
+
+type needTransitionRule struct {
+	actors []types.NeedProgressEventSource
+	effect needTransitionEffect
+	// secondApprover requires the actor to be someone other than the admin
+	// who gave the first approval.
+	secondApprover bool
+	// withinApprovalThreshold limits the move to needs asking for no more
+	// than the second approval threshold.
+	withinApprovalThreshold bool
+}
+
+var (
+	needTransitionByUser   = []types.NeedProgressEventSource{types.NeedProgressEventSourceUser}
+	needTransitionByAdmin  = []types.NeedProgressEventSource{types.NeedProgressEventSourceAdmin}
+	needTransitionBySystem = []types.NeedProgressEventSource{types.NeedProgressEventSourceSystem}
+)
+
+// needStatusTransitions is the complete set of status changes a need may go
+// through. Anything not listed here is rejected by TransitionNeedStatus.
+var needStatusTransitions = map[types.NeedStatus]map[types.NeedStatus]needTransitionRule{
+	types.NeedStatusDraft: {
+		types.NeedStatusSubmitted: {actors: needTransitionByUser, effect: needTransitionEffectSubmit},
+	},
+	types.NeedStatusSubmitted: {
+		types.NeedStatusReadyForReview: {actors: needTransitionByUser},
+	},
+	types.NeedStatusReadyForReview: {
+		types.NeedStatusSubmitted:   {actors: needTransitionByUser},
+		types.NeedStatusUnderReview: {actors: needTransitionByAdmin},
+	},
+	types.NeedStatusUnderReview: {
+		types.NeedStatusActive:                 {actors: needTransitionByAdmin, effect: needTransitionEffectPublish, withinApprovalThreshold: true},
+		types.NeedStatusAwaitingSecondApproval: {actors: needTransitionByAdmin, effect: needTransitionEffectFirstApproval},
+		types.NeedStatusRejected:               {actors: needTransitionByAdmin},
+		types.NeedStatusChangesRequested:       {actors: needTransitionByAdmin},
+	},
+	types.NeedStatusAwaitingSecondApproval: {
+		types.NeedStatusActive:           {actors: needTransitionByAdmin, effect: needTransitionEffectPublish, secondApprover: true},
+		types.NeedStatusRejected:         {actors: needTransitionByAdmin, effect: needTransitionEffectClearFirstApproval},
+		types.NeedStatusChangesRequested: {actors: needTransitionByAdmin, effect: needTransitionEffectClearFirstApproval},
+	},
+	types.NeedStatusChangesRequested: {
+		types.NeedStatusReadyForReview: {actors: needTransitionByUser},
+	},
+	types.NeedStatusActive: {
+		types.NeedStatusFunded: {actors: needTransitionBySystem, effect: needTransitionEffectClose},
+		types.NeedStatusClosed: {actors: needTransitionByAdmin, effect: needTransitionEffectClose},
+	},
+}
+
+// checkNeedTransition returns the rule for moving from one status to another
+// on behalf of actor, or a *types.NeedTransitionError explaining why the move
+// is not allowed.
+func checkNeedTransition(from, to types.NeedStatus, actor types.NeedProgressEventSource) (needTransitionRule, error) {
+	rule, ok := needStatusTransitions[from][to]
+	if !ok {
+		return needTransitionRule{}, &types.NeedTransitionError{From: from, To: to, Actor: actor, Err: types.ErrIllegalNeedTransition}
+	}
+
+	if !slices.Contains(rule.actors, actor) {
+		return needTransitionRule{}, &types.NeedTransitionError{From: from, To: to, Actor: actor, Err: types.ErrNeedTransitionNotPermitted}
+	}
+
+	return rule, nil
+}
+
+// CanTransitionNeedStatus reports whether actor may move a need from one
+// status to another. Handlers use it to decide which controls to show; the
+// transition itself is still checked when it is applied.
+func CanTransitionNeedStatus(from, to types.NeedStatus, actor types.NeedProgressEventSource) bool {
+	_, err := checkNeedTransition(from, to, actor)
+	return err == nil
+}
+
+// TransitionNeedStatus moves a need to a new status if the state machine
+// allows it for the given actor, applies the transition's side effects and
+// records a progress event. actorUserID may be empty for system transitions.
+func (r *NeedRepository) TransitionNeedStatus(ctx context.Context, needID string, to types.NeedStatus, actor types.NeedProgressEventSource, actorUserID string) error {
+	return WithTx(ctx, r, func(tx pgx.Tx) error {
+		return transitionNeedStatusTx(ctx, tx, needID, to, actor, actorUserID, r.SecondApprovalThresholdCents)
+	})
+}
+
+func (r *NeedRepository) TransitionNeedStatusTx(ctx context.Context, tx pgx.Tx, needID string, to types.NeedStatus, actor types.NeedProgressEventSource, actorUserID string) error {
+	return transitionNeedStatusTx(ctx, tx, needID, to, actor, actorUserID, r.SecondApprovalThresholdCents)
+}
+
+// transitionNeedStatusTx applies a status change under a row lock. The
+// amount is read under the same lock, so a need cannot be published by one
+// admin once its goal has grown past approvalThresholdCents.
+func transitionNeedStatusTx(ctx context.Context, tx pgx.Tx, needID string, to types.NeedStatus, actor types.NeedProgressEventSource, actorUserID string, approvalThresholdCents int) error {
+	lockQuery, lockArgs, err := psql().
+		Select("status", "deleted_at IS NOT NULL", "first_approved_by_user_id", "amount_needed_cents").
+		From(needTableName).
+		Where(sq.Eq{"id": needID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate lock need status query for need %s: %w", needID, err)
+	}
+
+	var from string
+	var deleted bool
+	var firstApprovedBy *string
+	var amountNeededCents int
+	if err := tx.QueryRow(ctx, lockQuery, lockArgs...).Scan(&from, &deleted, &firstApprovedBy, &amountNeededCents); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ErrNeedNotFound
+		}
+		return utils.ErrorWrapOrNil(err, "failed to lock need status")
+	}
+
+	if deleted {
+		return types.ErrNeedDeleted
+	}
+
+	rule, err := checkNeedTransition(types.NeedStatus(from), to, actor)
+	if err != nil {
+		return err
+	}
+
+	actorUserID = strings.TrimSpace(actorUserID)
+	if rule.secondApprover && (actorUserID == "" || (firstApprovedBy != nil && *firstApprovedBy == actorUserID)) {
+		return types.ErrSecondApproverRequired
+	}
+
+	if rule.withinApprovalThreshold && approvalThresholdCents > 0 && amountNeededCents > approvalThresholdCents {
+		return types.ErrSecondApprovalRequired
+	}
+
+	now := time.Now()
+	update := psql().
+		Update(needTableName).
+		Set("status", to).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": needID})
+
+	switch rule.effect {
+	case needTransitionEffectSubmit:
+		update = update.Set("submitted_at", now)
+	case needTransitionEffectPublish:
+		update = update.Set("published_at", sq.Expr("COALESCE(published_at, ?)", now))
+	case needTransitionEffectClose:
+		update = update.Set("closed_at", sq.Expr("COALESCE(closed_at, ?)", now))
+	case needTransitionEffectFirstApproval:
+		update = update.Set("first_approved_by_user_id", actorUserID).Set("first_approved_at", now)
+	case needTransitionEffectClearFirstApproval:
+		update = update.Set("first_approved_by_user_id", nil).Set("first_approved_at", nil)
+	}
+
+	updateQuery, updateArgs, err := update.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate set need status query for need %s: %w", needID, err)
+	}
+
+	if _, err := tx.Exec(ctx, updateQuery, updateArgs...); err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to set need status")
+	}
+
+	var actorUserIDPtr *string
+	if actorUserID != "" {
+		actorUserIDPtr = &actorUserID
+	}
+
+	eventQuery, eventArgs, err := psql().
+		Insert(needProgressEventsTableName).
+		Columns("id", "need_id", "step", "event_source", "actor_user_id").
+		Values(utils.NanoID(), needID, types.NeedStatusChangedStep(to), actor, actorUserIDPtr).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate insert status change event query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, eventQuery, eventArgs...); err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to record status change event")
+	}
+
+	return nil
+}
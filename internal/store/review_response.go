@@ -0,0 +1,162 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const reviewResponseTemplatesTableName = "christjesus.review_response_templates"
+
+var reviewResponseTemplateColumns = utils.StructTagValues(types.ReviewResponseTemplate{})
+
+type ReviewResponseRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReviewResponseRepository(pool *pgxpool.Pool) *ReviewResponseRepository {
+	return &ReviewResponseRepository{pool: pool}
+}
+
+func (r *ReviewResponseRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// Templates returns every template, archived ones last. When activeOnly is
+// true archived templates are left out.
+func (r *ReviewResponseRepository) Templates(ctx context.Context, activeOnly bool) ([]*types.ReviewResponseTemplate, error) {
+	queryBuilder := psql().
+		Select(reviewResponseTemplateColumns...).
+		From(reviewResponseTemplatesTableName)
+
+	if activeOnly {
+		queryBuilder = queryBuilder.Where(sq.Eq{"archived_at": nil})
+	}
+
+	query, args, err := queryBuilder.
+		OrderBy("archived_at IS NOT NULL", "action_type", "title").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate review response templates query: %w", err)
+	}
+
+	templates := make([]*types.ReviewResponseTemplate, 0)
+	err = pgxscan.Select(ctx, r.pool, &templates, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return templates, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load review response templates")
+	}
+
+	return templates, nil
+}
+
+func (r *ReviewResponseRepository) Template(ctx context.Context, templateID string) (*types.ReviewResponseTemplate, error) {
+	query, args, err := psql().
+		Select(reviewResponseTemplateColumns...).
+		From(reviewResponseTemplatesTableName).
+		Where(sq.Eq{"id": templateID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate review response template query: %w", err)
+	}
+
+	var template types.ReviewResponseTemplate
+	err = pgxscan.Get(ctx, r.pool, &template, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrReviewResponseTemplateNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load review response template")
+	}
+
+	return &template, nil
+}
+
+func (r *ReviewResponseRepository) CreateTemplate(ctx context.Context, template *types.ReviewResponseTemplate) error {
+	now := time.Now()
+	template.ID = utils.NanoID()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	query, args, err := psql().
+		Insert(reviewResponseTemplatesTableName).
+		Columns("id", "action_type", "title", "reason", "note", "created_by_user_id", "created_at", "updated_at").
+		Values(template.ID, template.ActionType, template.Title, template.Reason, template.Note, template.CreatedByUserID, template.CreatedAt, template.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create review response template query: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to create review response template")
+}
+
+func (r *ReviewResponseRepository) UpdateTemplate(ctx context.Context, template *types.ReviewResponseTemplate) error {
+	template.UpdatedAt = time.Now()
+
+	query, args, err := psql().
+		Update(reviewResponseTemplatesTableName).
+		Set("action_type", template.ActionType).
+		Set("title", template.Title).
+		Set("reason", template.Reason).
+		Set("note", template.Note).
+		Set("updated_by_user_id", template.UpdatedByUserID).
+		Set("updated_at", template.UpdatedAt).
+		Where(sq.Eq{"id": template.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate update review response template query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to update review response template")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrReviewResponseTemplateNotFound
+	}
+
+	return nil
+}
+
+// SetTemplateArchived archives a template so reviewers no longer see it, or
+// brings an archived one back.
+func (r *ReviewResponseRepository) SetTemplateArchived(ctx context.Context, templateID, actorUserID string, archived bool) error {
+	now := time.Now()
+	var archivedAt *time.Time
+	if archived {
+		archivedAt = &now
+	}
+
+	query, args, err := psql().
+		Update(reviewResponseTemplatesTableName).
+		Set("archived_at", archivedAt).
+		Set("updated_by_user_id", actorUserID).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": templateID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate archive review response template query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to archive review response template")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrReviewResponseTemplateNotFound
+	}
+
+	return nil
+}
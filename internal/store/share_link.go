@@ -0,0 +1,150 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const shareLinkTableName = "christjesus.need_share_links"
+
+const (
+	shareLinkCodeSize       = 8
+	shareLinkCreateAttempts = 3
+)
+
+var shareLinkColumns = utils.StructTagValues(types.NeedShareLink{})
+
+type ShareLinkRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewShareLinkRepository(pool *pgxpool.Pool) *ShareLinkRepository {
+	return &ShareLinkRepository{pool: pool}
+}
+
+// ShareLinkByCode returns the share link for a public code, or
+// types.ErrShareLinkNotFound.
+func (r *ShareLinkRepository) ShareLinkByCode(ctx context.Context, code string) (*types.NeedShareLink, error) {
+	return r.shareLinkWhere(ctx, sq.Eq{"code": code})
+}
+
+// ShareLinkByNeedAndUser returns the user's share link for a need, or
+// types.ErrShareLinkNotFound if they have not shared it yet.
+func (r *ShareLinkRepository) ShareLinkByNeedAndUser(ctx context.Context, needID, userID string) (*types.NeedShareLink, error) {
+	return r.shareLinkWhere(ctx, sq.Eq{"need_id": needID, "user_id": userID})
+}
+
+func (r *ShareLinkRepository) shareLinkWhere(ctx context.Context, where sq.Eq) (*types.NeedShareLink, error) {
+	query, args, err := psql().
+		Select(shareLinkColumns...).
+		From(shareLinkTableName).
+		Where(where).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate share link query: %w", err)
+	}
+
+	link := new(types.NeedShareLink)
+	err = pgxscan.Get(ctx, r.pool, link, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrShareLinkNotFound
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to fetch share link")
+	}
+
+	return link, nil
+}
+
+// GetOrCreateShareLink returns the user's existing share link for a need or
+// issues a new one. Each user gets exactly one code per need.
+func (r *ShareLinkRepository) GetOrCreateShareLink(ctx context.Context, needID, userID string) (*types.NeedShareLink, error) {
+	for attempt := 0; attempt < shareLinkCreateAttempts; attempt++ {
+		query, args, err := psql().
+			Insert(shareLinkTableName).
+			Columns("id", "code", "need_id", "user_id", "created_at").
+			Values(utils.NanoID(), utils.NanoIDSize(shareLinkCodeSize), needID, userID, time.Now()).
+			Suffix("ON CONFLICT DO NOTHING").
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate create share link query: %w", err)
+		}
+
+		if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to create share link: %w", err)
+		}
+
+		// Either our insert landed, a link already existed for this need and
+		// user, or the random code collided and we try again.
+		link, err := r.ShareLinkByNeedAndUser(ctx, needID, userID)
+		if err == nil {
+			return link, nil
+		}
+		if !errors.Is(err, types.ErrShareLinkNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("failed to create share link after %d attempts", shareLinkCreateAttempts)
+}
+
+// RecordClick counts a visit through a share link.
+func (r *ShareLinkRepository) RecordClick(ctx context.Context, linkID string) error {
+	query, args, err := psql().
+		Update(shareLinkTableName).
+		Set("click_count", sq.Expr("click_count + 1")).
+		Set("last_clicked_at", time.Now()).
+		Where(sq.Eq{"id": linkID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate record share click query: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return utils.ErrorWrapOrNil(err, "failed to record share click")
+}
+
+// ShareStatsByUser returns every link the user has shared along with the
+// finalized donations each one referred, newest first.
+func (r *ShareLinkRepository) ShareStatsByUser(ctx context.Context, userID string) ([]*types.NeedShareLinkStats, error) {
+	columns := make([]string, 0, len(shareLinkColumns)+2)
+	for _, column := range shareLinkColumns {
+		columns = append(columns, "l."+column)
+	}
+	columns = append(columns,
+		"COUNT(d.id) AS donation_count",
+		"COALESCE(SUM(d.amount_cents), 0) AS raised_cents",
+	)
+
+	query, args, err := psql().
+		Select(columns...).
+		From(shareLinkTableName+" l").
+		LeftJoin(donationIntentTableName+" d ON d.share_link_id = l.id AND d.payment_status = ?", types.DonationPaymentStatusFinalized).
+		Where(sq.Eq{"l.user_id": userID}).
+		GroupBy("l.id").
+		OrderBy("l.created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate share stats query: %w", err)
+	}
+
+	stats := make([]*types.NeedShareLinkStats, 0)
+	err = pgxscan.Select(ctx, r.pool, &stats, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return stats, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load share stats")
+	}
+
+	return stats, nil
+}
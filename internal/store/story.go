@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -50,6 +51,14 @@ func (r *StoryRepository) GetStoryByNeedID(ctx context.Context, needID string) (
 
 // CreateStory creates a new story for a need
 func (r *StoryRepository) CreateStory(ctx context.Context, story *types.NeedStory) error {
+	return r.createStoryWithExec(ctx, r.pool, story)
+}
+
+func (r *StoryRepository) CreateStoryTx(ctx context.Context, tx pgx.Tx, story *types.NeedStory) error {
+	return r.createStoryWithExec(ctx, tx, story)
+}
+
+func (r *StoryRepository) createStoryWithExec(ctx context.Context, execer needExecer, story *types.NeedStory) error {
 	now := time.Now()
 	story.CreatedAt = now
 	story.UpdatedAt = now
@@ -62,7 +71,7 @@ func (r *StoryRepository) CreateStory(ctx context.Context, story *types.NeedStor
 		return fmt.Errorf("failed to generate insert query: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
+	_, err = execer.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to insert story: %w", err)
 	}
@@ -98,6 +107,14 @@ func (r *StoryRepository) UpdateStory(ctx context.Context, needID string, story
 
 // UpsertStory creates or updates a story (insert if not exists, update if exists)
 func (r *StoryRepository) UpsertStory(ctx context.Context, story *types.NeedStory) error {
+	return r.upsertStoryWithExec(ctx, r.pool, story)
+}
+
+func (r *StoryRepository) UpsertStoryTx(ctx context.Context, tx pgx.Tx, story *types.NeedStory) error {
+	return r.upsertStoryWithExec(ctx, tx, story)
+}
+
+func (r *StoryRepository) upsertStoryWithExec(ctx context.Context, execer needExecer, story *types.NeedStory) error {
 	now := time.Now()
 	story.CreatedAt = now
 	story.UpdatedAt = now
@@ -123,7 +140,7 @@ func (r *StoryRepository) UpsertStory(ctx context.Context, story *types.NeedStor
 		return fmt.Errorf("failed to generate upsert query: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
+	_, err = execer.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to upsert story: %w", err)
 	}
@@ -51,6 +51,31 @@ func (r *UserRepository) User(ctx context.Context, userID string) (*types.User,
 	return &user, nil
 }
 
+// UserByEmail looks a user up by email, ignoring case.
+func (r *UserRepository) UserByEmail(ctx context.Context, email string) (*types.User, error) {
+	query, args, err := psql().
+		Select(userColumns...).
+		From(userTableName).
+		Where(sq.Expr("LOWER(email) = LOWER(?)", strings.TrimSpace(email))).
+		OrderBy("created_at").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user by email query: %w", err)
+	}
+
+	var user types.User
+	err = pgxscan.Get(ctx, r.pool, &user, query, args...)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, types.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch user by email: %w", err)
+	}
+
+	return &user, nil
+}
+
 func (r *UserRepository) UsersByIDs(ctx context.Context, userIDs []string) ([]*types.User, error) {
 	if len(userIDs) == 0 {
 		return []*types.User{}, nil
@@ -117,25 +142,10 @@ func (r *UserRepository) Update(ctx context.Context, userID string, user *types.
 }
 
 func (r *UserRepository) ListUsers(ctx context.Context, page, pageSize int, search, userType string) ([]*types.User, error) {
-	builder := psql().
+	builder := applyUserListFilter(psql().
 		Select(userColumns...).
 		From(userTableName).
-		OrderBy("created_at DESC")
-
-	search = strings.TrimSpace(search)
-	if search != "" {
-		like := "%" + search + "%"
-		builder = builder.Where(sq.Or{
-			sq.ILike{"email": like},
-			sq.ILike{"given_name": like},
-			sq.ILike{"family_name": like},
-		})
-	}
-
-	userType = strings.TrimSpace(userType)
-	if userType != "" {
-		builder = builder.Where(sq.Eq{"user_type": userType})
-	}
+		OrderBy("created_at DESC"), search, userType)
 
 	offset := (page - 1) * pageSize
 	builder = builder.Limit(uint64(pageSize)).Offset(uint64(offset))
@@ -154,11 +164,61 @@ func (r *UserRepository) ListUsers(ctx context.Context, page, pageSize int, sear
 	return users, nil
 }
 
+// EachUser streams every user ListUsers would return for the same search and
+// type, newest first, without holding the full result in memory. Iteration
+// stops at the first error fn returns.
+func (r *UserRepository) EachUser(ctx context.Context, search, userType string, fn func(*types.User) error) error {
+	query, args, err := applyUserListFilter(psql().
+		Select(userColumns...).
+		From(userTableName).
+		OrderBy("created_at DESC", "id"), search, userType).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate users export query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to query users")
+	}
+	defer rows.Close()
+
+	scanner := pgxscan.NewRowScanner(rows)
+	for rows.Next() {
+		var user types.User
+		if err := scanner.Scan(&user); err != nil {
+			return utils.ErrorWrapOrNil(err, "failed to scan user")
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+
+	return utils.ErrorWrapOrNil(rows.Err(), "failed to read users")
+}
+
 func (r *UserRepository) CountUsers(ctx context.Context, search, userType string) (int, error) {
-	builder := psql().
+	builder := applyUserListFilter(psql().
 		Select("COUNT(*)").
-		From(userTableName)
+		From(userTableName), search, userType)
 
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate count users query: %w", err)
+	}
+
+	var count int
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// applyUserListFilter matches search against email and names and narrows to
+// one user type when given.
+func applyUserListFilter(builder sq.SelectBuilder, search, userType string) sq.SelectBuilder {
 	search = strings.TrimSpace(search)
 	if search != "" {
 		like := "%" + search + "%"
@@ -174,18 +234,7 @@ func (r *UserRepository) CountUsers(ctx context.Context, search, userType string
 		builder = builder.Where(sq.Eq{"user_type": userType})
 	}
 
-	query, args, err := builder.ToSql()
-	if err != nil {
-		return 0, fmt.Errorf("failed to generate count users query: %w", err)
-	}
-
-	var count int
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count users: %w", err)
-	}
-
-	return count, nil
+	return builder
 }
 
 func (r *UserRepository) UpsertIdentity(ctx context.Context, authSubject, email, givenName, familyName string) (string, error) {
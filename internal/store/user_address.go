@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -137,10 +138,6 @@ func (r *UserAddressRepository) AddressesByUserID(ctx context.Context, userID st
 }
 
 func (r *UserAddressRepository) Create(ctx context.Context, address *types.UserAddress) error {
-	now := time.Now()
-	address.CreatedAt = now
-	address.UpdatedAt = now
-
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin tx for user address create: %w", err)
@@ -149,6 +146,24 @@ func (r *UserAddressRepository) Create(ctx context.Context, address *types.UserA
 		_ = tx.Rollback(ctx)
 	}()
 
+	if err := r.CreateTx(ctx, tx, address); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit user address create tx: %w", err)
+	}
+
+	return nil
+}
+
+// CreateTx inserts address within tx, clearing the user's current primary
+// address first when address is marked primary.
+func (r *UserAddressRepository) CreateTx(ctx context.Context, tx pgx.Tx, address *types.UserAddress) error {
+	now := time.Now()
+	address.CreatedAt = now
+	address.UpdatedAt = now
+
 	if address.IsPrimary {
 		clearPrimaryQuery, clearPrimaryArgs, err := psql().
 			Update(userAddressTableName).
@@ -194,10 +209,6 @@ func (r *UserAddressRepository) Create(ctx context.Context, address *types.UserA
 		return fmt.Errorf("failed to insert user address: %w", err)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit user address create tx: %w", err)
-	}
-
 	return nil
 }
 
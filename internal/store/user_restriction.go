@@ -0,0 +1,189 @@
+package store
+
+import (
+	"christjesus/internal/utils"
+	"christjesus/pkg/types"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const userRestrictionsTableName = "christjesus.user_restrictions"
+
+var userRestrictionColumns = utils.StructTagValues(types.UserRestriction{})
+
+type UserRestrictionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserRestrictionRepository(pool *pgxpool.Pool) *UserRestrictionRepository {
+	return &UserRestrictionRepository{pool: pool}
+}
+
+func (r *UserRestrictionRepository) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return r.pool.BeginTx(ctx, txOptions)
+}
+
+// ActiveRestrictionByUser returns the restriction currently in force for a
+// user, or nil when they are in good standing. Suspensions past their expiry
+// no longer count even before the expiry job lifts them.
+func (r *UserRestrictionRepository) ActiveRestrictionByUser(ctx context.Context, userID string) (*types.UserRestriction, error) {
+	query, args, err := psql().
+		Select(userRestrictionColumns...).
+		From(userRestrictionsTableName).
+		Where(sq.Eq{"user_id": userID, "lifted_at": nil}).
+		Where(sq.Or{sq.Eq{"expires_at": nil}, sq.Gt{"expires_at": time.Now()}}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate active user restriction query: %w", err)
+	}
+
+	var restriction types.UserRestriction
+	if err := pgxscan.Get(ctx, r.pool, &restriction, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load active user restriction")
+	}
+
+	return &restriction, nil
+}
+
+// RestrictionsByUser returns every restriction ever placed on a user, newest
+// first.
+func (r *UserRestrictionRepository) RestrictionsByUser(ctx context.Context, userID string) ([]*types.UserRestriction, error) {
+	query, args, err := psql().
+		Select(userRestrictionColumns...).
+		From(userRestrictionsTableName).
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("created_at desc").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user restrictions query: %w", err)
+	}
+
+	restrictions := make([]*types.UserRestriction, 0)
+	if err := pgxscan.Select(ctx, r.pool, &restrictions, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return restrictions, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load user restrictions")
+	}
+
+	return restrictions, nil
+}
+
+// OpenRestrictionForUpdateTx locks the unlifted restriction on a user, which
+// may be a suspension that has already expired. It returns nil when there is
+// none.
+func (r *UserRestrictionRepository) OpenRestrictionForUpdateTx(ctx context.Context, tx pgx.Tx, userID string) (*types.UserRestriction, error) {
+	query, args, err := psql().
+		Select(userRestrictionColumns...).
+		From(userRestrictionsTableName).
+		Where(sq.Eq{"user_id": userID, "lifted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock user restriction query: %w", err)
+	}
+
+	var restriction types.UserRestriction
+	if err := pgxscan.Get(ctx, tx, &restriction, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to lock user restriction")
+	}
+
+	return &restriction, nil
+}
+
+// CreateRestrictionTx records a new suspension or ban. It returns
+// types.ErrUserAlreadyRestricted when the user still has an unlifted
+// restriction.
+func (r *UserRestrictionRepository) CreateRestrictionTx(ctx context.Context, tx pgx.Tx, restriction *types.UserRestriction) error {
+	if restriction.ID == "" {
+		restriction.ID = utils.NanoID()
+	}
+	if restriction.CreatedAt.IsZero() {
+		restriction.CreatedAt = time.Now()
+	}
+
+	query, args, err := psql().
+		Insert(userRestrictionsTableName).
+		Columns("id", "user_id", "kind", "reason", "expires_at", "created_by_user_id", "created_at").
+		Values(restriction.ID, restriction.UserID, restriction.Kind, restriction.Reason, restriction.ExpiresAt, restriction.CreatedByUserID, restriction.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate create user restriction query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return types.ErrUserAlreadyRestricted
+		}
+		return utils.ErrorWrapOrNil(err, "failed to create user restriction")
+	}
+
+	return nil
+}
+
+// LiftRestrictionTx ends an unlifted restriction. liftedByUserID is nil when
+// the expiry job lifts a lapsed suspension.
+func (r *UserRestrictionRepository) LiftRestrictionTx(ctx context.Context, tx pgx.Tx, restrictionID string, liftedByUserID *string, reason string) error {
+	query, args, err := psql().
+		Update(userRestrictionsTableName).
+		Set("lifted_at", time.Now()).
+		Set("lifted_by_user_id", liftedByUserID).
+		Set("lift_reason", reason).
+		Where(sq.Eq{"id": restrictionID, "lifted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to generate lift user restriction query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return utils.ErrorWrapOrNil(err, "failed to lift user restriction")
+	}
+	if tag.RowsAffected() == 0 {
+		return types.ErrUserRestrictionNotFound
+	}
+
+	return nil
+}
+
+// ExpiredRestrictions returns suspensions whose expiry has passed but which
+// have not been lifted yet, oldest expiry first.
+func (r *UserRestrictionRepository) ExpiredRestrictions(ctx context.Context, now time.Time, limit int) ([]*types.UserRestriction, error) {
+	query, args, err := psql().
+		Select(userRestrictionColumns...).
+		From(userRestrictionsTableName).
+		Where(sq.Eq{"lifted_at": nil}).
+		Where(sq.LtOrEq{"expires_at": now}).
+		OrderBy("expires_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate expired user restrictions query: %w", err)
+	}
+
+	restrictions := make([]*types.UserRestriction, 0)
+	if err := pgxscan.Select(ctx, r.pool, &restrictions, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return restrictions, nil
+		}
+		return nil, utils.ErrorWrapOrNil(err, "failed to load expired user restrictions")
+	}
+
+	return restrictions, nil
+}
@@ -0,0 +1,138 @@
+// Package xlsx writes single-sheet Excel workbooks one row at a time on top of
+// excelize's stream writer, which spills rows to disk rather than holding the
+// whole sheet in memory.
+package xlsx
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrClosed is returned when writing to a workbook that has been closed.
+var ErrClosed = errors.New("xlsx: writer closed")
+
+const maxSheetNameLength = 31
+
+// Writer writes rows to the only sheet of a workbook. Cells are written as
+// text unless their column was marked numeric. Nothing reaches the
+// underlying writer until Close.
+type Writer struct {
+	w       io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	numeric map[int]bool
+	row     int
+	closed  bool
+}
+
+// NewWriter starts a workbook for w with one sheet called sheetName.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	file := excelize.NewFile()
+
+	name := sanitizeSheetName(sheetName)
+	if err := file.SetSheetName(file.GetSheetName(0), name); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(name)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &Writer{w: w, file: file, stream: stream, numeric: make(map[int]bool)}, nil
+}
+
+// SetNumericColumns marks zero-based columns whose values should be stored
+// as numbers so spreadsheets can sum them. Values that do not parse as a
+// number are still written as text.
+func (w *Writer) SetNumericColumns(columns ...int) {
+	for _, column := range columns {
+		w.numeric[column] = true
+	}
+}
+
+// Write appends one row.
+func (w *Writer) Write(record []string) error {
+	if w.closed {
+		return ErrClosed
+	}
+
+	values := make([]any, len(record))
+	for i, value := range record {
+		values[i] = value
+		if w.numeric[i] && isDecimal(value) {
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				values[i] = number
+			}
+		}
+	}
+
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	return w.stream.SetRow(cell, values)
+}
+
+// Close finishes the workbook, writes it to the underlying writer and
+// removes any rows spilled to disk. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+
+	err := w.stream.Flush()
+	if err == nil {
+		err = w.file.Write(w.w)
+	}
+	return errors.Join(err, w.file.Close())
+}
+
+// isDecimal reports whether value is a plain decimal such as -12 or 3.50.
+// Exponents, hex and NaN parse as floats but are kept as text so a cell
+// never reads differently from the exported value.
+func isDecimal(value string) bool {
+	value = strings.TrimPrefix(value, "-")
+	if value == "" {
+		return false
+	}
+	seenDigit, seenPoint := false, false
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			seenDigit = true
+		case r == '.' && !seenPoint:
+			seenPoint = true
+		default:
+			return false
+		}
+	}
+	return seenDigit
+}
+
+// sanitizeSheetName drops the characters Excel forbids in sheet names and
+// trims the name to Excel's limit.
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '[', ']', ':', '*', '?', '/', '\\':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if runes := []rune(name); len(runes) > maxSheetNameLength {
+		name = string(runes[:maxSheetNameLength])
+	}
+	if name == "" {
+		return "Sheet1"
+	}
+	return name
+}
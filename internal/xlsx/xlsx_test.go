@@ -0,0 +1,119 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriter_WritesRows(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Needs")
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	w.SetNumericColumns(1)
+
+	rows := [][]string{
+		{"id", "amount"},
+		{"need_1 <&>", "12.50"},
+		{"=HYPERLINK(1)", "n/a"},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("write %v: %v", row, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer f.Close()
+
+	if sheets := f.GetSheetList(); len(sheets) != 1 || sheets[0] != "Needs" {
+		t.Fatalf("sheets = %v, want [Needs]", sheets)
+	}
+
+	got, err := f.GetRows("Needs", excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("GetRows: %v", err)
+	}
+	want := [][]string{
+		{"id", "amount"},
+		{"need_1 <&>", "12.5"},
+		{"=HYPERLINK(1)", "n/a"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("rows = %q, want %q", got, want)
+	}
+	for i := range want {
+		if strings.Join(got[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// Numeric cells carry no type attribute, which readers take as a number.
+	isNumber := func(cell string) bool {
+		cellType, err := f.GetCellType("Needs", cell)
+		if err != nil {
+			t.Fatalf("GetCellType(%s): %v", cell, err)
+		}
+		return cellType == excelize.CellTypeUnset || cellType == excelize.CellTypeNumber
+	}
+	if !isNumber("B2") {
+		t.Fatal("B2 should be stored as a number")
+	}
+	if isNumber("B3") {
+		t.Fatal("B3 should be stored as text")
+	}
+	if formula, err := f.GetCellFormula("Needs", "A3"); err != nil || formula != "" {
+		t.Fatalf("A3 formula = %q (%v), want none", formula, err)
+	}
+
+	if err := w.Write([]string{"late"}); err != ErrClosed {
+		t.Fatalf("write after close = %v, want ErrClosed", err)
+	}
+}
+
+func TestIsDecimal(t *testing.T) {
+	tests := map[string]bool{
+		"12":     true,
+		"-3.50":  true,
+		"0.5":    true,
+		"":       false,
+		"-":      false,
+		".":      false,
+		"1.2.3":  false,
+		"NaN":    false,
+		"Inf":    false,
+		"1e5":    false,
+		"0x10":   false,
+		" 12":    false,
+		"$12.00": false,
+	}
+	for in, want := range tests {
+		if got := isDecimal(in); got != want {
+			t.Fatalf("isDecimal(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestSanitizeSheetName(t *testing.T) {
+	tests := map[string]string{
+		"Donations":             "Donations",
+		"  a/b:c  ":             "abc",
+		"[]*?":                  "Sheet1",
+		strings.Repeat("x", 40): strings.Repeat("x", 31),
+	}
+	for in, want := range tests {
+		if got := sanitizeSheetName(in); got != want {
+			t.Fatalf("sanitizeSheetName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
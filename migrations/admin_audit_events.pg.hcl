@@ -0,0 +1,66 @@
+# Admin actions and sensitive reads that fall outside need moderation:
+# document downloads, user detail views, need deletions (including owners
+# deleting drafts), role changes, category merges, email resends and
+# suppression removals. Rows are append-only and have no foreign keys so they
+# outlive the records they describe.
+table "admin_audit_events" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "actor_user_id" {
+    type = text
+    null = false
+  }
+
+  column "action" {
+    type    = text
+    null    = false
+    comment = "document.downloaded, user.viewed, need.deleted, need.restored, role.granted, role.revoked, audit.exported, category.merged, user.suspended, user.banned, user.restriction_lifted, data.exported, email.resent, email.suppression_removed, note.pinned, note.unpinned"
+  }
+
+  column "target_type" {
+    type    = text
+    null    = false
+    comment = "need, document, user, role_grant, audit_log, category, export, email_message, email_suppression, admin_note"
+  }
+
+  column "target_id" {
+    type = text
+    null = false
+  }
+
+  column "ip_address" {
+    type = text
+    null = true
+  }
+
+  column "detail" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "idx_admin_audit_events_created_at" {
+    columns = [column.created_at]
+  }
+
+  index "idx_admin_audit_events_target" {
+    columns = [column.target_type, column.target_id, column.created_at]
+  }
+
+  index "idx_admin_audit_events_actor" {
+    columns = [column.actor_user_id, column.created_at]
+  }
+}
@@ -0,0 +1,50 @@
+# Earlier bodies of edited admin notes, one row per edit.
+table "admin_note_revisions" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "note_id" {
+    type = text
+    null = false
+  }
+
+  column "body" {
+    type    = text
+    null    = false
+    comment = "Body before the edit"
+  }
+
+  column "edited_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_admin_note_revisions_note" {
+    columns     = [column.note_id]
+    ref_columns = [table.admin_notes.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_admin_note_revisions_edited_by" {
+    columns     = [column.edited_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_admin_note_revisions_note_created" {
+    columns = [column.note_id, column.created_at]
+  }
+}
@@ -0,0 +1,92 @@
+# Private admin notes about a user or a need. Exactly one of need_id and
+# user_id is set. Notes are admin-only and kept apart from
+# need_review_messages, which the need owner can read.
+table "admin_notes" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = true
+  }
+
+  column "user_id" {
+    type    = text
+    null    = true
+    comment = "User the note is about, not its author"
+  }
+
+  column "author_user_id" {
+    type = text
+    null = true
+  }
+
+  column "body" {
+    type = text
+    null = false
+  }
+
+  column "pinned_at" {
+    type    = timestamptz
+    null    = true
+    comment = "Pinned notes are listed first"
+  }
+
+  column "updated_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "updated_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_admin_notes_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_admin_notes_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_admin_notes_author" {
+    columns     = [column.author_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_admin_notes_updated_by" {
+    columns     = [column.updated_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_admin_notes_need" {
+    columns = [column.need_id, column.created_at]
+    where   = "need_id IS NOT NULL"
+  }
+
+  index "idx_admin_notes_user" {
+    columns = [column.user_id, column.created_at]
+    where   = "user_id IS NOT NULL"
+  }
+}
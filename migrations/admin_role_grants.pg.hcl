@@ -0,0 +1,70 @@
+# Admin roles held by users. Revoked grants stay on record so role changes are
+# auditable; at most one active grant exists per user and role.
+table "admin_role_grants" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "user_id" {
+    type = text
+    null = false
+  }
+
+  column "role" {
+    type    = text
+    null    = false
+    comment = "reviewer, finance, support, superadmin"
+  }
+
+  column "granted_by_user_id" {
+    type    = text
+    null    = true
+    comment = "Null when granted from the CLI bootstrap"
+  }
+
+  column "revoked_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "revoked_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_admin_role_grants_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_admin_role_grants_granted_by" {
+    columns     = [column.granted_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_admin_role_grants_revoked_by" {
+    columns     = [column.revoked_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_admin_role_grants_active" {
+    columns = [column.user_id, column.role]
+    unique  = true
+    where   = "revoked_at IS NULL"
+  }
+}
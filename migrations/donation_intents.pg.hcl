@@ -33,6 +33,24 @@ table "donation_intents" {
     null = false
   }
 
+  column "line_item_id" {
+    type    = text
+    null    = true
+    comment = "Optional need line item the donor directed this gift to"
+  }
+
+  column "share_link_id" {
+    type    = text
+    null    = true
+    comment = "Share link the donor arrived through, if any"
+  }
+
+  column "referrer_user_id" {
+    type    = text
+    null    = true
+    comment = "User whose share link referred this donation"
+  }
+
   column "private_message" {
     type = text
     null = true
@@ -84,6 +102,24 @@ table "donation_intents" {
     on_delete   = CASCADE
   }
 
+  foreign_key "fk_donation_intents_line_item" {
+    columns     = [column.line_item_id]
+    ref_columns = [table.need_line_items.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_donation_intents_share_link" {
+    columns     = [column.share_link_id]
+    ref_columns = [table.need_share_links.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_donation_intents_referrer" {
+    columns     = [column.referrer_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
   index "idx_donation_intents_need_created" {
     columns = [column.need_id, column.created_at]
   }
@@ -103,6 +139,16 @@ table "donation_intents" {
     where   = "payment_intent_id IS NOT NULL"
   }
 
+  index "idx_donation_intents_line_item_id" {
+    columns = [column.line_item_id]
+    where   = "line_item_id IS NOT NULL"
+  }
+
+  index "idx_donation_intents_share_link_id" {
+    columns = [column.share_link_id]
+    where   = "share_link_id IS NOT NULL"
+  }
+
   index "idx_donation_intents_pending_created_at" {
     columns = [column.created_at]
     where   = "(payment_status = 'pending'::text)"
@@ -0,0 +1,95 @@
+# Per-gift share of a fund reallocation, tracking each donor's consent
+table "donation_reallocations" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "fund_reallocation_id" {
+    type = text
+    null = false
+  }
+
+  column "donation_intent_id" {
+    type = text
+    null = false
+  }
+
+  column "amount_cents" {
+    type    = integer
+    null    = false
+    comment = "Portion of the gift covered by the reallocation"
+  }
+
+  column "status" {
+    type    = text
+    null    = false
+    default = "pending"
+    comment = "pending, opted_out, redirected, refunded"
+  }
+
+  column "refunded_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When an admin refunded the opted-out share to the donor"
+  }
+
+  column "refunded_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "stripe_refund_id" {
+    type    = text
+    null    = true
+    comment = "Stripe refund for the share; empty when it was refunded outside Stripe"
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "updated_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_donation_reallocations_fund_reallocation" {
+    columns     = [column.fund_reallocation_id]
+    ref_columns = [table.fund_reallocations.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_donation_reallocations_donation_intent" {
+    columns     = [column.donation_intent_id]
+    ref_columns = [table.donation_intents.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_donation_reallocations_refunded_by" {
+    columns     = [column.refunded_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_donation_reallocations_fund_intent" {
+    columns = [column.fund_reallocation_id, column.donation_intent_id]
+    unique  = true
+  }
+
+  index "idx_donation_reallocations_intent" {
+    columns = [column.donation_intent_id]
+  }
+
+  index "idx_donation_reallocations_status" {
+    columns = [column.status, column.updated_at]
+  }
+}
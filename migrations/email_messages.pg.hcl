@@ -40,6 +40,28 @@ table "email_messages" {
     comment = "queued, sent, delivered, bounced, complained"
   }
 
+  column "from_address" {
+    type    = text
+    null    = true
+    comment = "Kept with the bodies so support can resend the message"
+  }
+
+  column "html_body" {
+    type = text
+    null = true
+  }
+
+  column "text_body" {
+    type = text
+    null = true
+  }
+
+  column "resent_from_message_id" {
+    type    = text
+    null    = true
+    comment = "Set when an admin resent an earlier message"
+  }
+
   column "created_at" {
     type    = timestamptz
     null    = false
@@ -56,10 +78,20 @@ table "email_messages" {
     columns = [column.id]
   }
 
+  foreign_key "fk_email_messages_resent_from" {
+    columns     = [column.resent_from_message_id]
+    ref_columns = [table.email_messages.column.id]
+    on_delete   = SET_NULL
+  }
+
   index "idx_email_messages_recipient" {
     columns = [column.recipient]
   }
 
+  index "idx_email_messages_type_created" {
+    columns = [column.email_type, column.created_at]
+  }
+
   index "idx_email_messages_status_created" {
     columns = [column.status, column.created_at]
   }
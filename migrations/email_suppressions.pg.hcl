@@ -34,6 +34,17 @@ table "email_suppressions" {
     comment = "Null if still active; set when suppression is lifted"
   }
 
+  column "removed_by_user_id" {
+    type    = text
+    null    = true
+    comment = "Admin who lifted the suppression"
+  }
+
+  column "remove_reason" {
+    type = text
+    null = true
+  }
+
   primary_key {
     columns = [column.id]
   }
@@ -0,0 +1,130 @@
+# Raised funds a need no longer requires, waiting to be redirected
+table "fund_reallocations" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type    = text
+    null    = false
+    comment = "Need the funds were originally given to"
+  }
+
+  column "amount_cents" {
+    type = integer
+    null = false
+  }
+
+  column "source" {
+    type    = text
+    null    = false
+    comment = "early_close, goal_reduced, deleted, overfunded"
+  }
+
+  column "goal_change_request_id" {
+    type    = text
+    null    = true
+    comment = "Approved goal change request that freed the funds"
+  }
+
+  column "status" {
+    type    = text
+    null    = false
+    default = "queued"
+    comment = "queued, proposed, completed"
+  }
+
+  column "target_need_id" {
+    type    = text
+    null    = true
+    comment = "Need receiving the funds; null when moving to a category fund"
+  }
+
+  column "target_category_id" {
+    type    = text
+    null    = true
+    comment = "Category fund receiving the funds; null when moving to a need"
+  }
+
+  column "proposed_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "proposed_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "consent_deadline" {
+    type    = timestamptz
+    null    = true
+    comment = "Donors can opt out for a refund until this time"
+  }
+
+  column "completed_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "updated_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_fund_reallocations_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_fund_reallocations_goal_change_request" {
+    columns     = [column.goal_change_request_id]
+    ref_columns = [table.need_goal_change_requests.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_fund_reallocations_target_need" {
+    columns     = [column.target_need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_fund_reallocations_target_category" {
+    columns     = [column.target_category_id]
+    ref_columns = [table.need_categories.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_fund_reallocations_proposed_by" {
+    columns     = [column.proposed_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_fund_reallocations_status_created" {
+    columns = [column.status, column.created_at]
+  }
+
+  index "idx_fund_reallocations_need" {
+    columns = [column.need_id]
+  }
+
+  index "idx_fund_reallocations_target_need" {
+    columns = [column.target_need_id]
+    where   = "target_need_id IS NOT NULL"
+  }
+}
@@ -0,0 +1,68 @@
+# Checklist items attached to a changes_requested moderation action. Each item
+# names one part of the need the owner must fix.
+table "need_change_request_items" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "moderation_action_id" {
+    type = text
+    null = false
+  }
+
+  column "field" {
+    type    = text
+    null    = false
+    comment = "address, contact, categories, amount, story, documents, document"
+  }
+
+  column "document_id" {
+    type    = text
+    null    = true
+    comment = "Set when field is document"
+  }
+
+  column "note" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_change_request_items_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_change_request_items_action" {
+    columns     = [column.moderation_action_id]
+    ref_columns = [table.need_moderation_actions.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_change_request_items_document" {
+    columns     = [column.document_id]
+    ref_columns = [table.need_documents.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_need_change_request_items_action" {
+    columns = [column.moderation_action_id]
+  }
+}
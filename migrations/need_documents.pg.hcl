@@ -45,6 +45,12 @@ table "need_documents" {
     comment = "Supabase storage bucket key/path"
   }
 
+  column "content_sha256" {
+    type    = text
+    null    = true
+    comment = "Hex SHA-256 of the uploaded bytes, used to detect reused documents across needs"
+  }
+
   column "uploaded_at" {
     type    = timestamptz
     null    = false
@@ -74,4 +80,9 @@ table "need_documents" {
   index "idx_documents_user_id" {
     columns = [column.user_id]
   }
+
+  index "idx_documents_content_sha256" {
+    columns = [column.content_sha256]
+    where   = "content_sha256 IS NOT NULL"
+  }
 }
\ No newline at end of file
@@ -0,0 +1,110 @@
+# Scheduled featuring windows. Each slot puts a need on the homepage or on one
+# category page between starts_at and ends_at, ordered by position. Ended
+# slots stay on record so the featuring history of a need is visible.
+table "need_feature_slots" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "placement" {
+    type    = text
+    null    = false
+    comment = "home, category"
+  }
+
+  column "category_id" {
+    type    = text
+    null    = true
+    comment = "Category page the need is featured on. Set only for category placements"
+  }
+
+  column "position" {
+    type    = integer
+    null    = false
+    default = 1
+    comment = "Slot order within the placement, lowest first"
+  }
+
+  column "starts_at" {
+    type = timestamptz
+    null = false
+  }
+
+  column "ends_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When the window closes on its own. Null keeps the need featured until the slot is ended"
+  }
+
+  column "created_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "ended_at" {
+    type    = timestamptz
+    null    = true
+    comment = "Set when an admin or the unfeature job ends the slot early"
+  }
+
+  column "ended_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "end_reason" {
+    type = text
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_feature_slots_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_feature_slots_category" {
+    columns     = [column.category_id]
+    ref_columns = [table.need_categories.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_feature_slots_created_by" {
+    columns     = [column.created_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_need_feature_slots_ended_by" {
+    columns     = [column.ended_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  # Speeds the homepage and category page lookups of slots still in play.
+  index "idx_need_feature_slots_open" {
+    columns = [column.placement, column.category_id, column.position]
+    where   = "ended_at IS NULL"
+  }
+
+  index "idx_need_feature_slots_need" {
+    columns = [column.need_id, column.starts_at]
+  }
+}
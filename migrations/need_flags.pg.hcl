@@ -0,0 +1,91 @@
+# Duplicate and fraud signals raised when a need is submitted
+table "need_flags" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "matched_need_id" {
+    type    = text
+    null    = false
+    comment = "Existing need that triggered the signal"
+  }
+
+  column "signal" {
+    type    = text
+    null    = false
+    comment = "document_hash, address, story"
+  }
+
+  column "detail" {
+    type    = text
+    null    = true
+    comment = "Human-readable description of what matched"
+  }
+
+  column "similarity" {
+    type    = integer
+    null    = false
+    default = 100
+    comment = "Match strength as a percentage; exact matches are 100"
+  }
+
+  column "dismissed_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "dismissed_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "dismiss_reason" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_flags_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_flags_matched_need" {
+    columns     = [column.matched_need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_flags_dismissed_by" {
+    columns     = [column.dismissed_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_need_flags_need_signal_match" {
+    columns = [column.need_id, column.matched_need_id, column.signal]
+    unique  = true
+  }
+
+  index "idx_need_flags_open" {
+    columns = [column.need_id]
+    where   = "dismissed_at IS NULL"
+  }
+}
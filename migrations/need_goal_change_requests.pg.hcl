@@ -0,0 +1,119 @@
+# Owner requests to change the goal of an active need or close it early
+table "need_goal_change_requests" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "requested_by_user_id" {
+    type = text
+    null = false
+  }
+
+  column "kind" {
+    type    = text
+    null    = false
+    comment = "goal_change, early_close"
+  }
+
+  column "previous_goal_cents" {
+    type    = integer
+    null    = false
+    comment = "Goal of the need when the request was made"
+  }
+
+  column "requested_goal_cents" {
+    type    = integer
+    null    = false
+    comment = "New goal, or for early_close the amount the owner keeps"
+  }
+
+  column "justification" {
+    type = text
+    null = false
+  }
+
+  column "status" {
+    type    = text
+    null    = false
+    default = "pending"
+    comment = "pending, approved, denied"
+  }
+
+  column "first_approved_by_user_id" {
+    type    = text
+    null    = true
+    comment = "Admin who gave the first of two approvals for a goal raised above the second approval threshold"
+  }
+
+  column "first_approved_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When the first of two approvals was given"
+  }
+
+  column "decided_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "decided_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "decision_note" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_goal_change_requests_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_goal_change_requests_requested_by" {
+    columns     = [column.requested_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_goal_change_requests_first_approved_by" {
+    columns     = [column.first_approved_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_need_goal_change_requests_decided_by" {
+    columns     = [column.decided_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_need_goal_change_requests_need_created" {
+    columns = [column.need_id, column.created_at]
+  }
+
+  index "idx_need_goal_change_requests_one_pending" {
+    columns = [column.need_id]
+    unique  = true
+    where   = "status = 'pending'"
+  }
+}
@@ -0,0 +1,67 @@
+# Itemized budget lines for a need (e.g. rent $800, utilities $200)
+table "need_line_items" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "label" {
+    type = text
+    null = false
+  }
+
+  column "amount_cents" {
+    type = integer
+    null = false
+  }
+
+  column "category_id" {
+    type    = text
+    null    = true
+    comment = "Optional category this line item falls under"
+  }
+
+  column "sort_order" {
+    type    = integer
+    null    = false
+    default = 0
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "updated_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_line_items_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_line_items_category" {
+    columns     = [column.category_id]
+    ref_columns = [table.need_categories.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_need_line_items_need_sort" {
+    columns = [column.need_id, column.sort_order]
+  }
+}
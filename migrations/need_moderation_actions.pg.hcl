@@ -14,13 +14,13 @@ table "need_moderation_actions" {
   column "action_type" {
     type    = text
     null    = false
-    comment = "review_started, review_note_added, changes_requested, review_approved, review_rejected, document_verified, document_rejected, soft_deleted, restored"
+    comment = "review_started, review_note_added, changes_requested, review_approved, review_rejected, document_verified, document_rejected, soft_deleted, restored, flag_dismissed, goal_change_first_approved, goal_change_approved, goal_change_denied, reallocation_queued, reallocation_proposed, review_claimed, review_released, review_reassigned, review_claim_expired, urgency_changed, featured, unfeatured"
   }
 
   column "actor_user_id" {
     type    = text
-    null    = false
-    comment = "Admin user who performed the moderation action"
+    null    = true
+    comment = "Admin user who performed the moderation action; null for actions taken by scheduled jobs"
   }
 
   column "reason" {
@@ -0,0 +1,57 @@
+# Point-in-time snapshots of owner-editable need content, used for reviewer diffs
+table "need_revisions" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "actor_user_id" {
+    type    = text
+    null    = false
+    comment = "User who triggered the snapshot (need owner for edits, admin for changes_requested)"
+  }
+
+  column "source" {
+    type    = text
+    null    = false
+    comment = "location, categories, story, documents, resubmitted, changes_requested"
+  }
+
+  column "snapshot" {
+    type    = jsonb
+    null    = false
+    comment = "Amount, description, location, story, categories and documents at the time of the revision"
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_revisions_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_revisions_actor" {
+    columns     = [column.actor_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+
+  index "idx_need_revisions_need_created" {
+    columns = [column.need_id, column.created_at]
+  }
+}
@@ -0,0 +1,72 @@
+# Per-user tracked share links for needs, used for referral attribution
+table "need_share_links" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "code" {
+    type    = text
+    null    = false
+    comment = "Short public code used in /share/:code links"
+  }
+
+  column "need_id" {
+    type = text
+    null = false
+  }
+
+  column "user_id" {
+    type    = text
+    null    = false
+    comment = "User who shared the link"
+  }
+
+  column "click_count" {
+    type    = integer
+    null    = false
+    default = 0
+  }
+
+  column "last_clicked_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_need_share_links_need" {
+    columns     = [column.need_id]
+    ref_columns = [table.needs.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_need_share_links_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+
+  index "idx_need_share_links_code" {
+    columns = [column.code]
+    unique  = true
+  }
+
+  index "idx_need_share_links_need_user" {
+    columns = [column.need_id, column.user_id]
+    unique  = true
+  }
+
+  index "idx_need_share_links_user_id" {
+    columns = [column.user_id]
+  }
+}
@@ -57,7 +57,7 @@ table "needs" {
     type    = text
     null    = false
     default = "DRAFT"
-    comment = "DRAFT, SUBMITTED, READY_FOR_REVIEW, UNDER_REVIEW, CHANGES_REQUESTED, REJECTED, ACTIVE, FUNDED, CLOSED"
+    comment = "DRAFT, SUBMITTED, READY_FOR_REVIEW, UNDER_REVIEW, AWAITING_SECOND_APPROVAL, CHANGES_REQUESTED, REJECTED, ACTIVE, FUNDED, CLOSED"
   }
 
   column "urgency" {
@@ -67,6 +67,12 @@ table "needs" {
     comment = "Admin-set urgency level: low, medium, high, urgent"
   }
 
+  column "needed_by" {
+    type    = timestamptz
+    null    = true
+    comment = "Admin-set date the money is needed by, such as an eviction or shutoff date; raises queue priority as it nears"
+  }
+
   column "verified_at" {
     type = timestamptz
     null = true
@@ -83,7 +89,7 @@ table "needs" {
     type    = text
     null    = false
     default = "welcome"
-    comment = "Tracks onboarding progress: welcome, location, categories, story, documents, review"
+    comment = "Tracks onboarding progress: welcome, location, categories, story, budget, documents, review"
   }
 
   # Visibility
@@ -130,6 +136,50 @@ table "needs" {
     comment = "Required reason captured during admin soft delete"
   }
 
+  # Restriction hiding
+  column "hidden_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When the need was hidden from public pages because its owner was suspended or banned"
+  }
+
+  column "hidden_by_restriction_id" {
+    type    = text
+    null    = true
+    comment = "References christjesus.user_restrictions(id); lifting that restriction unhides the need"
+  }
+
+  column "assigned_reviewer_user_id" {
+    type    = text
+    null    = true
+    comment = "Admin reviewer who has claimed this need in the moderation queue"
+  }
+
+  column "assigned_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When the current reviewer claimed the need"
+  }
+
+  column "first_approved_by_user_id" {
+    type    = text
+    null    = true
+    comment = "Admin who gave the first of two approvals; a different admin must give the second before the need is published"
+  }
+
+  column "first_approved_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When the first of two approvals was given"
+  }
+
+  column "version" {
+    type    = integer
+    null    = false
+    default = 1
+    comment = "Bumped on every edit or moderation write; owner edits must match it to save"
+  }
+
   # Metadata
   column "created_at" {
     type    = timestamptz
@@ -165,12 +215,30 @@ table "needs" {
     on_delete   = SET_NULL
   }
 
+  foreign_key "fk_needs_first_approved_by" {
+    columns     = [column.first_approved_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
   foreign_key "fk_needs_deleted_by" {
     columns     = [column.deleted_by_user_id]
     ref_columns = [table.users.column.id]
     on_delete   = SET_NULL
   }
 
+  foreign_key "fk_needs_hidden_by_restriction" {
+    columns     = [column.hidden_by_restriction_id]
+    ref_columns = [table.user_restrictions.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_needs_assigned_reviewer" {
+    columns     = [column.assigned_reviewer_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
   index "idx_needs_user_id" {
     columns = [column.user_id]
   }
@@ -203,12 +271,22 @@ table "needs" {
     where   = "deleted_at IS NOT NULL"
   }
 
+  index "idx_needs_hidden_by_restriction" {
+    columns = [column.hidden_by_restriction_id]
+    where   = "hidden_by_restriction_id IS NOT NULL"
+  }
+
   # Speeds moderation queue pages, which always filter to non-deleted ready/review needs.
   index "idx_needs_queue_active" {
     columns = [column.submitted_at, column.created_at]
     where   = "((deleted_at IS NULL) AND (status = ANY (ARRAY['READY_FOR_REVIEW'::text, 'UNDER_REVIEW'::text])))"
   }
 
+  index "idx_needs_assigned_reviewer" {
+    columns = [column.assigned_reviewer_user_id, column.assigned_at]
+    where   = "assigned_reviewer_user_id IS NOT NULL"
+  }
+
   # Speeds browse/latest lists that only display non-deleted, non-draft needs by recency.
   index "idx_needs_browse_active" {
     columns = [column.created_at]
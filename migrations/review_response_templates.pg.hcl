@@ -0,0 +1,78 @@
+# Reusable reasons and notes reviewers pick from when requesting changes or
+# rejecting a need. Archived templates stay on record but are not offered.
+table "review_response_templates" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "action_type" {
+    type    = text
+    null    = false
+    comment = "changes_requested, review_rejected"
+  }
+
+  column "title" {
+    type = text
+    null = false
+  }
+
+  column "reason" {
+    type = text
+    null = false
+  }
+
+  column "note" {
+    type = text
+    null = true
+  }
+
+  column "created_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "updated_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "archived_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "updated_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_review_response_templates_created_by" {
+    columns     = [column.created_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_review_response_templates_updated_by" {
+    columns     = [column.updated_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_review_response_templates_action" {
+    columns = [column.action_type, column.title]
+    where   = "archived_at IS NULL"
+  }
+}
@@ -41,8 +41,8 @@ table "user_addresses" {
   column "privacy_display" {
     type    = text
     null    = true
-    default = "neighborhood"
-    comment = "What to show publicly: neighborhood, zip, or city"
+    default = "city"
+    comment = "What to show publicly: city, county, or region. Legacy neighborhood and zip values display as city"
   }
 
   column "contact_methods" {
@@ -0,0 +1,90 @@
+# Suspensions and bans placed on users by admins. Lifted restrictions stay on
+# record so account actions are auditable; at most one active restriction
+# exists per user.
+table "user_restrictions" {
+  schema = schema.christjesus
+
+  column "id" {
+    type = text
+  }
+
+  column "user_id" {
+    type = text
+    null = false
+  }
+
+  column "kind" {
+    type    = text
+    null    = false
+    comment = "suspended, banned"
+  }
+
+  column "reason" {
+    type = text
+    null = false
+  }
+
+  column "expires_at" {
+    type    = timestamptz
+    null    = true
+    comment = "When a suspension ends on its own. Always null for bans"
+  }
+
+  column "created_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "created_at" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "lifted_at" {
+    type = timestamptz
+    null = true
+  }
+
+  column "lifted_by_user_id" {
+    type = text
+    null = true
+  }
+
+  column "lift_reason" {
+    type = text
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "fk_user_restrictions_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+
+  foreign_key "fk_user_restrictions_created_by" {
+    columns     = [column.created_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  foreign_key "fk_user_restrictions_lifted_by" {
+    columns     = [column.lifted_by_user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+
+  index "idx_user_restrictions_active" {
+    columns = [column.user_id]
+    unique  = true
+    where   = "lifted_at IS NULL"
+  }
+
+  index "idx_user_restrictions_user_created" {
+    columns = [column.user_id, column.created_at]
+  }
+}
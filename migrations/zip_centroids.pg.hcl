@@ -17,6 +17,12 @@ table "zip_centroids" {
     null = false
   }
 
+  column "county_name" {
+    type    = text
+    null    = true
+    comment = "County containing most of the ZCTA's land area, e.g. Mecklenburg County"
+  }
+
   column "geog" {
     type    = sql("extensions.geography(Point, 4326)")
     null    = true
@@ -0,0 +1,88 @@
+package types
+
+import "time"
+
+// AdminAuditAction names what an admin did in an audit event.
+type AdminAuditAction string
+
+const (
+	AdminAuditActionDocumentDownloaded AdminAuditAction = "document.downloaded"
+	AdminAuditActionUserViewed         AdminAuditAction = "user.viewed"
+	AdminAuditActionNeedDeleted        AdminAuditAction = "need.deleted"
+	AdminAuditActionNeedRestored       AdminAuditAction = "need.restored"
+	AdminAuditActionRoleGranted        AdminAuditAction = "role.granted"
+	AdminAuditActionRoleRevoked        AdminAuditAction = "role.revoked"
+	AdminAuditActionAuditExported      AdminAuditAction = "audit.exported"
+	AdminAuditActionCategoryMerged     AdminAuditAction = "category.merged"
+	AdminAuditActionUserSuspended      AdminAuditAction = "user.suspended"
+	AdminAuditActionUserBanned         AdminAuditAction = "user.banned"
+	AdminAuditActionRestrictionLifted  AdminAuditAction = "user.restriction_lifted"
+	AdminAuditActionDataExported       AdminAuditAction = "data.exported"
+	AdminAuditActionEmailResent        AdminAuditAction = "email.resent"
+	AdminAuditActionSuppressionRemoved AdminAuditAction = "email.suppression_removed"
+	AdminAuditActionNotePinned         AdminAuditAction = "note.pinned"
+	AdminAuditActionNoteUnpinned       AdminAuditAction = "note.unpinned"
+)
+
+// AdminAuditActions lists every action in the order they are offered as
+// filters on the audit page.
+var AdminAuditActions = []AdminAuditAction{
+	AdminAuditActionDocumentDownloaded,
+	AdminAuditActionUserViewed,
+	AdminAuditActionNeedDeleted,
+	AdminAuditActionNeedRestored,
+	AdminAuditActionRoleGranted,
+	AdminAuditActionRoleRevoked,
+	AdminAuditActionAuditExported,
+	AdminAuditActionCategoryMerged,
+	AdminAuditActionUserSuspended,
+	AdminAuditActionUserBanned,
+	AdminAuditActionRestrictionLifted,
+	AdminAuditActionDataExported,
+	AdminAuditActionEmailResent,
+	AdminAuditActionSuppressionRemoved,
+	AdminAuditActionNotePinned,
+	AdminAuditActionNoteUnpinned,
+}
+
+// AdminAuditTargetType names the kind of record an audit event is about.
+type AdminAuditTargetType string
+
+const (
+	AdminAuditTargetNeed             AdminAuditTargetType = "need"
+	AdminAuditTargetDocument         AdminAuditTargetType = "document"
+	AdminAuditTargetUser             AdminAuditTargetType = "user"
+	AdminAuditTargetRoleGrant        AdminAuditTargetType = "role_grant"
+	AdminAuditTargetAuditLog         AdminAuditTargetType = "audit_log"
+	AdminAuditTargetCategory         AdminAuditTargetType = "category"
+	AdminAuditTargetExport           AdminAuditTargetType = "export"
+	AdminAuditTargetEmailMessage     AdminAuditTargetType = "email_message"
+	AdminAuditTargetEmailSuppression AdminAuditTargetType = "email_suppression"
+	AdminAuditTargetAdminNote        AdminAuditTargetType = "admin_note"
+)
+
+var AdminAuditTargetTypes = []AdminAuditTargetType{
+	AdminAuditTargetNeed,
+	AdminAuditTargetDocument,
+	AdminAuditTargetUser,
+	AdminAuditTargetRoleGrant,
+	AdminAuditTargetAuditLog,
+	AdminAuditTargetCategory,
+	AdminAuditTargetExport,
+	AdminAuditTargetEmailMessage,
+	AdminAuditTargetEmailSuppression,
+	AdminAuditTargetAdminNote,
+}
+
+// AdminAuditEvent records one admin action or sensitive read. Detail carries
+// free-form context such as the owning need of a document or a delete reason.
+type AdminAuditEvent struct {
+	ID          string               `db:"id"`
+	ActorUserID string               `db:"actor_user_id"`
+	Action      AdminAuditAction     `db:"action"`
+	TargetType  AdminAuditTargetType `db:"target_type"`
+	TargetID    string               `db:"target_id"`
+	IPAddress   *string              `db:"ip_address"`
+	Detail      *string              `db:"detail"`
+	CreatedAt   time.Time            `db:"created_at"`
+}
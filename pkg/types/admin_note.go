@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+// AdminNote is private context an admin keeps about a user or a need, such
+// as how a recipient was verified. Exactly one of NeedID and UserID is set.
+// Notes are never shown to the people they describe.
+type AdminNote struct {
+	ID              string     `db:"id"`
+	NeedID          *string    `db:"need_id"`
+	UserID          *string    `db:"user_id"`
+	AuthorUserID    *string    `db:"author_user_id"`
+	Body            string     `db:"body"`
+	PinnedAt        *time.Time `db:"pinned_at"`
+	UpdatedByUserID *string    `db:"updated_by_user_id"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// AdminNoteRevision keeps the body a note had before an edit.
+type AdminNoteRevision struct {
+	ID             string    `db:"id"`
+	NoteID         string    `db:"note_id"`
+	Body           string    `db:"body"`
+	EditedByUserID *string   `db:"edited_by_user_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
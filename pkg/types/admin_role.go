@@ -0,0 +1,32 @@
+package types
+
+import "time"
+
+type AdminRole string
+
+const (
+	AdminRoleReviewer   AdminRole = "reviewer"
+	AdminRoleFinance    AdminRole = "finance"
+	AdminRoleSupport    AdminRole = "support"
+	AdminRoleSuperadmin AdminRole = "superadmin"
+)
+
+// AdminRoles lists every role in the order they are offered on the roles page.
+var AdminRoles = []AdminRole{
+	AdminRoleReviewer,
+	AdminRoleFinance,
+	AdminRoleSupport,
+	AdminRoleSuperadmin,
+}
+
+// AdminRoleGrant gives a user one admin role. A grant with RevokedAt set is
+// kept for history and no longer confers the role.
+type AdminRoleGrant struct {
+	ID              string     `db:"id"`
+	UserID          string     `db:"user_id"`
+	Role            AdminRole  `db:"role"`
+	GrantedByUserID *string    `db:"granted_by_user_id"`
+	RevokedAt       *time.Time `db:"revoked_at"`
+	RevokedByUserID *string    `db:"revoked_by_user_id"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
@@ -22,3 +22,21 @@ type NeedCategoryAssignment struct {
 	Need     *Need         `db:"-"`
 	Category *NeedCategory `db:"-"`
 }
+
+// CategoryUsage counts what references a category: needs through
+// need_category_assignments and donors through their preferences.
+type CategoryUsage struct {
+	NeedCount            int `db:"need_count"`
+	DonorPreferenceCount int `db:"donor_preference_count"`
+}
+
+// CategoryMergeResult reports how many references a merge moved onto the
+// target category. Rows that already pointed at the target are folded in
+// rather than moved and are not counted.
+type CategoryMergeResult struct {
+	NeedsMoved            int
+	DonorPreferencesMoved int
+	LineItemsMoved        int
+	ReallocationsMoved    int
+	FeatureSlotsMoved     int
+}
@@ -48,6 +48,37 @@ type Config struct {
 	USPSConsumerKey    string `envconfig:"USPS_CONSUMER_KEY" required:"true"`
 	USPSConsumerSecret string `envconfig:"USPS_CONSUMER_SECRET" required:"true"`
 
+	// Recipient limits (0 disables the cap)
+	MaxActiveNeedsPerUser int `envconfig:"MAX_ACTIVE_NEEDS_PER_USER" default:"3"`
+
+	// Moderation queue: review SLA from submission and how long a claim may
+	// sit idle before it is released
+	ReviewSLAHours        int `envconfig:"REVIEW_SLA_HOURS" default:"48"`
+	ReviewClaimStaleHours int `envconfig:"REVIEW_CLAIM_STALE_HOURS" default:"24"`
+
+	// Needs asking for more than this need a second admin's approval before
+	// they are published (0 disables two-person approval)
+	SecondApprovalThresholdCents int `envconfig:"SECOND_APPROVAL_THRESHOLD_CENTS" default:"500000"`
+
+	// Moderation queue priority weights. Urgency earns its weight per level
+	// above low; the deadline weight is earned in full once the needed-by date
+	// is inside the window and scales down linearly before that; the amount
+	// weight scales with the request up to the cap; waiting earns its weight
+	// per day since submission. Negative weights push needs down the queue.
+	QueueWeightUrgency           int `envconfig:"QUEUE_WEIGHT_URGENCY" default:"10"`
+	QueueWeightEvictionNotice    int `envconfig:"QUEUE_WEIGHT_EVICTION_NOTICE" default:"30"`
+	QueueWeightMedicalRecord     int `envconfig:"QUEUE_WEIGHT_MEDICAL_RECORD" default:"20"`
+	QueueWeightDeadline          int `envconfig:"QUEUE_WEIGHT_DEADLINE" default:"40"`
+	QueueDeadlineWindowDays      int `envconfig:"QUEUE_DEADLINE_WINDOW_DAYS" default:"14"`
+	QueueWeightAmount            int `envconfig:"QUEUE_WEIGHT_AMOUNT" default:"10"`
+	QueueAmountCapCents          int `envconfig:"QUEUE_AMOUNT_CAP_CENTS" default:"500000"`
+	QueueWeightVerifiedRecipient int `envconfig:"QUEUE_WEIGHT_VERIFIED_RECIPIENT" default:"10"`
+	QueueWeightWaitingPerDay     int `envconfig:"QUEUE_WEIGHT_WAITING_PER_DAY" default:"5"`
+
+	// Request header carrying the client IP set by the edge proxy (e.g.
+	// CF-Connecting-IP). Empty trusts the connection's remote address.
+	ClientIPHeader string `envconfig:"CLIENT_IP_HEADER"`
+
 	// Auth Configuration
 	CookieName       string `envconfig:"SESSION_COOKIE_NAME" default:"session_id"`
 	SessionMaxAgeSec int    `envconfig:"SESSION_MAX_AGE_SEC" default:"604800"` // 7 days
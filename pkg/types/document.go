@@ -12,6 +12,7 @@ type NeedDocument struct {
 	FileSizeBytes int64     `db:"file_size_bytes" json:"fileSizeBytes"`
 	MimeType      string    `db:"mime_type" json:"mimeType"`
 	StorageKey    string    `db:"storage_key" json:"storageKey"`
+	ContentSHA256 *string   `db:"content_sha256" json:"contentSha256"`
 	UploadedAt    time.Time `db:"uploaded_at" json:"uploadedAt"`
 }
 
@@ -17,6 +17,9 @@ type DonationIntent struct {
 	CheckoutSessionID *string   `db:"checkout_session_id"`
 	PaymentIntentID   *string   `db:"payment_intent_id"`
 	AmountCents       int       `db:"amount_cents"`
+	LineItemID        *string   `db:"line_item_id"`
+	ShareLinkID       *string   `db:"share_link_id"`
+	ReferrerUserID    *string   `db:"referrer_user_id"`
 	PrivateMessage    *string   `db:"private_message"`
 	IsAnonymous       bool      `db:"is_anonymous"`
 	PaymentProvider   string    `db:"payment_provider"`
@@ -3,15 +3,19 @@ package types
 import "time"
 
 type EmailMessage struct {
-	ID                string     `db:"id"`
-	Recipient         string     `db:"recipient"`
-	EmailType         string     `db:"email_type"`
-	Subject           string     `db:"subject"`
-	Provider          string     `db:"provider"`
-	ProviderMessageID *string    `db:"provider_message_id"`
-	Status            string     `db:"status"`
-	CreatedAt         time.Time  `db:"created_at"`
-	UpdatedAt         time.Time  `db:"updated_at"`
+	ID                  string    `db:"id"`
+	Recipient           string    `db:"recipient"`
+	EmailType           string    `db:"email_type"`
+	Subject             string    `db:"subject"`
+	Provider            string    `db:"provider"`
+	ProviderMessageID   *string   `db:"provider_message_id"`
+	Status              string    `db:"status"`
+	FromAddress         *string   `db:"from_address"`
+	HTMLBody            *string   `db:"html_body"`
+	TextBody            *string   `db:"text_body"`
+	ResentFromMessageID *string   `db:"resent_from_message_id"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
 }
 
 type EmailEvent struct {
@@ -24,20 +28,22 @@ type EmailEvent struct {
 }
 
 type EmailSuppression struct {
-	ID           string     `db:"id"`
-	EmailAddress string     `db:"email_address"`
-	Reason       string     `db:"reason"`
-	SourceEventID *string   `db:"source_event_id"`
-	CreatedAt    time.Time  `db:"created_at"`
-	RemovedAt    *time.Time `db:"removed_at"`
+	ID              string     `db:"id"`
+	EmailAddress    string     `db:"email_address"`
+	Reason          string     `db:"reason"`
+	SourceEventID   *string    `db:"source_event_id"`
+	CreatedAt       time.Time  `db:"created_at"`
+	RemovedAt       *time.Time `db:"removed_at"`
+	RemovedByUserID *string    `db:"removed_by_user_id"`
+	RemoveReason    *string    `db:"remove_reason"`
 }
 
 type DonationIntentEmail struct {
-	ID                 string    `db:"id"`
-	DonationIntentID   string    `db:"donation_intent_id"`
-	EmailMessageID     string    `db:"email_message_id"`
-	EmailType          string    `db:"email_type"`
-	CreatedAt          time.Time `db:"created_at"`
+	ID               string    `db:"id"`
+	DonationIntentID string    `db:"donation_intent_id"`
+	EmailMessageID   string    `db:"email_message_id"`
+	EmailType        string    `db:"email_type"`
+	CreatedAt        time.Time `db:"created_at"`
 }
 
 type UserEmail struct {
@@ -50,10 +56,10 @@ type UserEmail struct {
 
 // Email status values
 const (
-	EmailStatusQueued    = "queued"
-	EmailStatusSent      = "sent"
-	EmailStatusDelivered = "delivered"
-	EmailStatusBounced   = "bounced"
+	EmailStatusQueued     = "queued"
+	EmailStatusSent       = "sent"
+	EmailStatusDelivered  = "delivered"
+	EmailStatusBounced    = "bounced"
 	EmailStatusComplained = "complained"
 )
 
@@ -66,5 +72,29 @@ const (
 
 // Email type values
 const (
-	EmailTypeDonationReceipt = "donation_receipt"
+	EmailTypeDonationReceipt  = "donation_receipt"
+	EmailTypeNeedGoalChange   = "need_goal_change"
+	EmailTypeFundReallocation = "fund_reallocation"
 )
+
+// Email event types as reported by the provider webhook
+const (
+	EmailEventTypeDelivered  = "email.delivered"
+	EmailEventTypeBounced    = "email.bounced"
+	EmailEventTypeComplained = "email.complained"
+)
+
+// EmailEventDetail is a webhook event together with the message it refers to,
+// when that message is known.
+type EmailEventDetail struct {
+	EmailEvent
+	Recipient        *string `db:"recipient"`
+	Subject          *string `db:"subject"`
+	MessageEmailType *string `db:"message_email_type"`
+}
+
+// Resendable reports whether the message was stored with a body that can be
+// sent again as is.
+func (m *EmailMessage) Resendable() bool {
+	return m.FromAddress != nil && (m.HTMLBody != nil || m.TextBody != nil)
+}
@@ -3,8 +3,61 @@ package types
 import "fmt"
 
 var (
-	ErrNeedNotFound       = fmt.Errorf("need not found")
-	ErrNeedAlreadyDeleted = fmt.Errorf("need already deleted")
-	ErrNeedNotDeleted     = fmt.Errorf("need not deleted")
-	ErrUserNotFound       = fmt.Errorf("user not found")
+	ErrNeedNotFound        = fmt.Errorf("need not found")
+	ErrNeedAlreadyDeleted  = fmt.Errorf("need already deleted")
+	ErrNeedNotDeleted      = fmt.Errorf("need not deleted")
+	ErrNeedDeleted         = fmt.Errorf("need is deleted")
+	ErrNeedVersionConflict = fmt.Errorf("need was changed by someone else")
+	ErrUserNotFound        = fmt.Errorf("user not found")
+	ErrNeedFlagNotFound    = fmt.Errorf("need flag not found")
+	ErrShareLinkNotFound   = fmt.Errorf("share link not found")
+
+	ErrGoalChangeRequestNotFound = fmt.Errorf("goal change request not found")
+	ErrGoalChangeRequestPending  = fmt.Errorf("need already has a pending goal change request")
+	ErrFundReallocationNotFound  = fmt.Errorf("fund reallocation not found")
+
+	ErrNeedClaimedByOther = fmt.Errorf("need is claimed by another reviewer")
+	ErrNeedNotClaimed     = fmt.Errorf("need is not claimed by this reviewer")
+
+	ErrAdminRoleGrantNotFound = fmt.Errorf("admin role grant not found")
+	ErrAdminRoleAlreadyHeld   = fmt.Errorf("user already holds this admin role")
+	ErrLastSuperadmin         = fmt.Errorf("cannot revoke the last superadmin")
+
+	ErrReviewResponseTemplateNotFound = fmt.Errorf("review response template not found")
+
+	ErrCategoryNotFound  = fmt.Errorf("category not found")
+	ErrCategorySlugTaken = fmt.Errorf("category slug already in use")
+
+	ErrUserRestrictionNotFound = fmt.Errorf("user restriction not found")
+	ErrUserAlreadyRestricted   = fmt.Errorf("user already has an active restriction")
+
+	ErrNeedFeatureSlotNotFound = fmt.Errorf("need feature slot not found")
+
+	ErrEmailSuppressionNotFound = fmt.Errorf("active email suppression not found")
+
+	ErrAdminNoteNotFound = fmt.Errorf("admin note not found")
+
+	ErrIllegalNeedTransition      = fmt.Errorf("illegal need status transition")
+	ErrNeedTransitionNotPermitted = fmt.Errorf("need status transition not permitted for actor")
+	ErrSecondApproverRequired     = fmt.Errorf("a different admin must give the second approval")
+	ErrSecondApprovalRequired     = fmt.Errorf("need requires a second approval before it is published")
 )
+
+// NeedTransitionError describes a rejected need status change. It unwraps to
+// ErrIllegalNeedTransition when the two statuses are not connected at all, or
+// to ErrNeedTransitionNotPermitted when they are but the actor may not make
+// the move.
+type NeedTransitionError struct {
+	From  NeedStatus
+	To    NeedStatus
+	Actor NeedProgressEventSource
+	Err   error
+}
+
+func (e *NeedTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s by %s", e.Err, e.From, e.To, e.Actor)
+}
+
+func (e *NeedTransitionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,91 @@
+package types
+
+import "time"
+
+type FundReallocationSource string
+
+const (
+	FundReallocationSourceEarlyClose  FundReallocationSource = "early_close"
+	FundReallocationSourceGoalReduced FundReallocationSource = "goal_reduced"
+	FundReallocationSourceDeleted     FundReallocationSource = "deleted"
+	FundReallocationSourceOverfunded  FundReallocationSource = "overfunded"
+)
+
+type FundReallocationStatus string
+
+const (
+	// FundReallocationStatusQueued funds are waiting for an admin to choose
+	// where they should go.
+	FundReallocationStatusQueued FundReallocationStatus = "queued"
+	// FundReallocationStatusProposed funds have a destination and donors have
+	// been asked; they can opt out until the consent deadline.
+	FundReallocationStatusProposed FundReallocationStatus = "proposed"
+	// FundReallocationStatusCompleted funds have been moved to the destination.
+	FundReallocationStatusCompleted FundReallocationStatus = "completed"
+)
+
+// FundReallocation is money raised for a need that the need no longer
+// requires. Entries wait in the queue until an admin proposes a destination,
+// either another need or a category fund, and are moved once the donor
+// consent window closes.
+type FundReallocation struct {
+	ID                  string                 `db:"id"`
+	NeedID              string                 `db:"need_id"`
+	AmountCents         int                    `db:"amount_cents"`
+	Source              FundReallocationSource `db:"source"`
+	GoalChangeRequestID *string                `db:"goal_change_request_id"`
+	Status              FundReallocationStatus `db:"status"`
+	TargetNeedID        *string                `db:"target_need_id"`
+	TargetCategoryID    *string                `db:"target_category_id"`
+	ProposedByUserID    *string                `db:"proposed_by_user_id"`
+	ProposedAt          *time.Time             `db:"proposed_at"`
+	ConsentDeadline     *time.Time             `db:"consent_deadline"`
+	CompletedAt         *time.Time             `db:"completed_at"`
+	CreatedAt           time.Time              `db:"created_at"`
+	UpdatedAt           time.Time              `db:"updated_at"`
+}
+
+type DonationReallocationStatus string
+
+const (
+	DonationReallocationStatusPending    DonationReallocationStatus = "pending"
+	DonationReallocationStatusOptedOut   DonationReallocationStatus = "opted_out"
+	DonationReallocationStatusRedirected DonationReallocationStatus = "redirected"
+	// DonationReallocationStatusRefunded shares were opted out and have since
+	// been paid back to the donor from the admin refund queue.
+	DonationReallocationStatusRefunded DonationReallocationStatus = "refunded"
+)
+
+// DonationReallocation is the share of a single gift covered by a fund
+// reallocation. Donors who opt out before the deadline are refunded instead
+// of redirected.
+type DonationReallocation struct {
+	ID                 string                     `db:"id"`
+	FundReallocationID string                     `db:"fund_reallocation_id"`
+	DonationIntentID   string                     `db:"donation_intent_id"`
+	AmountCents        int                        `db:"amount_cents"`
+	Status             DonationReallocationStatus `db:"status"`
+	RefundedAt         *time.Time                 `db:"refunded_at"`
+	RefundedByUserID   *string                    `db:"refunded_by_user_id"`
+	StripeRefundID     *string                    `db:"stripe_refund_id"`
+	CreatedAt          time.Time                  `db:"created_at"`
+	UpdatedAt          time.Time                  `db:"updated_at"`
+}
+
+// DonationReallocationRefund is an opted-out share together with the gift it
+// came from, for the admin refund queue.
+type DonationReallocationRefund struct {
+	DonationReallocation
+	NeedID          string  `db:"need_id"`
+	DonorUserID     *string `db:"donor_user_id"`
+	PaymentIntentID *string `db:"payment_intent_id"`
+}
+
+// DonationReallocationDetail is a donation reallocation together with the
+// destination and deadline of the fund reallocation it belongs to.
+type DonationReallocationDetail struct {
+	DonationReallocation
+	TargetNeedID     *string    `db:"target_need_id"`
+	TargetCategoryID *string    `db:"target_category_id"`
+	ConsentDeadline  *time.Time `db:"consent_deadline"`
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,11 @@ const (
 	NeedStatusRejected         NeedStatus = "REJECTED"
 	NeedStatusActive           NeedStatus = "ACTIVE"
 	NeedStatusFunded           NeedStatus = "FUNDED"
+	NeedStatusClosed           NeedStatus = "CLOSED"
+
+	// NeedStatusAwaitingSecondApproval holds a high-value need that one admin
+	// has approved until a different admin approves it too.
+	NeedStatusAwaitingSecondApproval NeedStatus = "AWAITING_SECOND_APPROVAL"
 )
 
 type NeedStep string
@@ -33,6 +39,7 @@ const (
 	NeedStepLocation   NeedStep = "location"
 	NeedStepCategories NeedStep = "categories"
 	NeedStepStory      NeedStep = "story"
+	NeedStepBudget     NeedStep = "budget"
 	NeedStepDocuments  NeedStep = "documents"
 	NeedStepReview     NeedStep = "review"
 	NeedStepComplete   NeedStep = "complete"
@@ -43,6 +50,7 @@ var NeedStepOrder = []NeedStep{
 	NeedStepLocation,
 	NeedStepCategories,
 	NeedStepStory,
+	NeedStepBudget,
 	NeedStepDocuments,
 	NeedStepReview,
 	NeedStepComplete,
@@ -54,23 +62,40 @@ type Need struct {
 	UserAddressID         *string `db:"user_address_id"`
 	UsesNonPrimaryAddress bool    `db:"uses_non_primary_address"`
 
-	AmountNeededCents int         `db:"amount_needed_cents"`
-	AmountRaisedCents int         `db:"amount_raised_cents"`
-	ShortDescription  *string     `db:"short_description"`
-	Status            NeedStatus  `db:"status"`
-	Urgency           NeedUrgency `db:"urgency"`
-	VerifiedAt        *time.Time `db:"verified_at"`
-	VerifiedBy        *string    `db:"verified_by"`
-	CurrentStep       NeedStep   `db:"current_step"`
-	PublishedAt       *time.Time `db:"published_at"`
-	ClosedAt          *time.Time `db:"closed_at"`
-	IsFeatured        bool       `db:"is_featured"`
-	SubmittedAt       *time.Time `db:"submitted_at"`
-	DeletedAt         *time.Time `db:"deleted_at"`
-	DeletedByUserID   *string    `db:"deleted_by_user_id"`
-	DeleteReason      *string    `db:"delete_reason"`
-	CreatedAt         time.Time  `db:"created_at"`
-	UpdatedAt         time.Time  `db:"updated_at"`
+	AmountNeededCents     int         `db:"amount_needed_cents"`
+	AmountRaisedCents     int         `db:"amount_raised_cents"`
+	ShortDescription      *string     `db:"short_description"`
+	Status                NeedStatus  `db:"status"`
+	Urgency               NeedUrgency `db:"urgency"`
+	NeededBy              *time.Time  `db:"needed_by"`
+	VerifiedAt            *time.Time  `db:"verified_at"`
+	VerifiedBy            *string     `db:"verified_by"`
+	CurrentStep           NeedStep    `db:"current_step"`
+	PublishedAt           *time.Time  `db:"published_at"`
+	ClosedAt              *time.Time  `db:"closed_at"`
+	IsFeatured            bool        `db:"is_featured"`
+	SubmittedAt           *time.Time  `db:"submitted_at"`
+	DeletedAt             *time.Time  `db:"deleted_at"`
+	DeletedByUserID       *string     `db:"deleted_by_user_id"`
+	DeleteReason          *string     `db:"delete_reason"`
+	HiddenAt              *time.Time  `db:"hidden_at"`
+	HiddenByRestrictionID *string     `db:"hidden_by_restriction_id"`
+	ReviewerUserID        *string     `db:"assigned_reviewer_user_id"`
+	AssignedAt            *time.Time  `db:"assigned_at"`
+	FirstApprovedByUserID *string     `db:"first_approved_by_user_id"`
+	FirstApprovedAt       *time.Time  `db:"first_approved_at"`
+	Version               int         `db:"version"`
+	CreatedAt             time.Time   `db:"created_at"`
+	UpdatedAt             time.Time   `db:"updated_at"`
+}
+
+// ModerationQueueCandidate is a need waiting in the moderation queue together
+// with the signals its queue priority is scored from.
+type ModerationQueueCandidate struct {
+	Need
+	HasEvictionNotice           bool `db:"has_eviction_notice"`
+	HasMedicalRecord            bool `db:"has_medical_record"`
+	RecipientPreviouslyVerified bool `db:"recipient_previously_verified"`
 }
 
 type UserAddress struct {
@@ -89,6 +114,15 @@ type UserAddress struct {
 	UpdatedAt            time.Time `db:"updated_at"`
 }
 
+// Address privacy levels control how much of a recipient's location is shown
+// on public pages. Older addresses may still carry the retired "neighborhood"
+// and "zip" values, which are treated as city.
+const (
+	AddressPrivacyCity   = "city"
+	AddressPrivacyCounty = "county"
+	AddressPrivacyRegion = "region"
+)
+
 type NeedProgressEvent struct {
 	ID                 string                  `db:"id"`
 	NeedID             string                  `db:"need_id"`
@@ -119,13 +153,20 @@ const (
 	NeedProgressEventStepDocumentRejected NeedProgressEventStep = "document_rejected"
 	NeedProgressEventStepSoftDeleted      NeedProgressEventStep = "soft_deleted"
 	NeedProgressEventStepRestored         NeedProgressEventStep = "restored"
+	NeedProgressEventStepFlagDismissed    NeedProgressEventStep = "flag_dismissed"
 )
 
+// NeedStatusChangedStep is the progress event step recorded whenever a need
+// moves into status, e.g. "status_under_review".
+func NeedStatusChangedStep(status NeedStatus) NeedProgressEventStep {
+	return NeedProgressEventStep("status_" + strings.ToLower(string(status)))
+}
+
 type NeedModerationAction struct {
 	ID          string                   `db:"id"`
 	NeedID      string                   `db:"need_id"`
 	ActionType  NeedModerationActionType `db:"action_type"`
-	ActorUserID string                   `db:"actor_user_id"`
+	ActorUserID *string                  `db:"actor_user_id"`
 	Reason      *string                  `db:"reason"`
 	Note        *string                  `db:"note"`
 	DocumentID  *string                  `db:"document_id"`
@@ -135,15 +176,29 @@ type NeedModerationAction struct {
 type NeedModerationActionType string
 
 const (
-	NeedModerationActionTypeReviewStarted    NeedModerationActionType = "review_started"
-	NeedModerationActionTypeReviewNoteAdded  NeedModerationActionType = "review_note_added"
-	NeedModerationActionTypeChangesRequested NeedModerationActionType = "changes_requested"
-	NeedModerationActionTypeReviewApproved   NeedModerationActionType = "review_approved"
-	NeedModerationActionTypeReviewRejected   NeedModerationActionType = "review_rejected"
-	NeedModerationActionTypeDocumentVerified NeedModerationActionType = "document_verified"
-	NeedModerationActionTypeDocumentRejected NeedModerationActionType = "document_rejected"
-	NeedModerationActionTypeSoftDeleted      NeedModerationActionType = "soft_deleted"
-	NeedModerationActionTypeRestored         NeedModerationActionType = "restored"
+	NeedModerationActionTypeReviewStarted           NeedModerationActionType = "review_started"
+	NeedModerationActionTypeReviewNoteAdded         NeedModerationActionType = "review_note_added"
+	NeedModerationActionTypeChangesRequested        NeedModerationActionType = "changes_requested"
+	NeedModerationActionTypeReviewApproved          NeedModerationActionType = "review_approved"
+	NeedModerationActionTypeReviewSecondApproved    NeedModerationActionType = "review_second_approved"
+	NeedModerationActionTypeReviewRejected          NeedModerationActionType = "review_rejected"
+	NeedModerationActionTypeDocumentVerified        NeedModerationActionType = "document_verified"
+	NeedModerationActionTypeDocumentRejected        NeedModerationActionType = "document_rejected"
+	NeedModerationActionTypeSoftDeleted             NeedModerationActionType = "soft_deleted"
+	NeedModerationActionTypeRestored                NeedModerationActionType = "restored"
+	NeedModerationActionTypeFlagDismissed           NeedModerationActionType = "flag_dismissed"
+	NeedModerationActionTypeGoalChangeApproved      NeedModerationActionType = "goal_change_approved"
+	NeedModerationActionTypeGoalChangeFirstApproved NeedModerationActionType = "goal_change_first_approved"
+	NeedModerationActionTypeGoalChangeDenied        NeedModerationActionType = "goal_change_denied"
+	NeedModerationActionTypeReallocationQueued      NeedModerationActionType = "reallocation_queued"
+	NeedModerationActionTypeReallocationProposed    NeedModerationActionType = "reallocation_proposed"
+	NeedModerationActionTypeReviewClaimed           NeedModerationActionType = "review_claimed"
+	NeedModerationActionTypeReviewReleased          NeedModerationActionType = "review_released"
+	NeedModerationActionTypeReviewReassigned        NeedModerationActionType = "review_reassigned"
+	NeedModerationActionTypeReviewClaimExpired      NeedModerationActionType = "review_claim_expired"
+	NeedModerationActionTypeUrgencyChanged          NeedModerationActionType = "urgency_changed"
+	NeedModerationActionTypeFeatured                NeedModerationActionType = "featured"
+	NeedModerationActionTypeUnfeatured              NeedModerationActionType = "unfeatured"
 )
 
 type NeedModerationTimelineEvent struct {
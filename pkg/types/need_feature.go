@@ -0,0 +1,44 @@
+package types
+
+import "time"
+
+// NeedFeaturePlacement says where a featured need is shown.
+type NeedFeaturePlacement string
+
+const (
+	NeedFeaturePlacementHome     NeedFeaturePlacement = "home"
+	NeedFeaturePlacementCategory NeedFeaturePlacement = "category"
+)
+
+// NeedFeatureSlot schedules a need onto the homepage or a category page. A
+// slot is live from StartsAt until EndsAt passes or it is ended early.
+type NeedFeatureSlot struct {
+	ID              string               `db:"id"`
+	NeedID          string               `db:"need_id"`
+	Placement       NeedFeaturePlacement `db:"placement"`
+	CategoryID      *string              `db:"category_id"`
+	Position        int                  `db:"position"`
+	StartsAt        time.Time            `db:"starts_at"`
+	EndsAt          *time.Time           `db:"ends_at"`
+	CreatedByUserID *string              `db:"created_by_user_id"`
+	CreatedAt       time.Time            `db:"created_at"`
+	EndedAt         *time.Time           `db:"ended_at"`
+	EndedByUserID   *string              `db:"ended_by_user_id"`
+	EndReason       *string              `db:"end_reason"`
+}
+
+// LiveAt reports whether the slot puts its need on display at now.
+func (s *NeedFeatureSlot) LiveAt(now time.Time) bool {
+	if s == nil || s.EndedAt != nil || now.Before(s.StartsAt) {
+		return false
+	}
+	return s.EndsAt == nil || now.Before(*s.EndsAt)
+}
+
+// OpenAt reports whether the slot is live or still to come at now.
+func (s *NeedFeatureSlot) OpenAt(now time.Time) bool {
+	if s == nil || s.EndedAt != nil {
+		return false
+	}
+	return s.EndsAt == nil || now.Before(*s.EndsAt)
+}
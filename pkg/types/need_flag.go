@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+type NeedFlagSignal string
+
+const (
+	NeedFlagSignalDocumentHash NeedFlagSignal = "document_hash"
+	NeedFlagSignalAddress      NeedFlagSignal = "address"
+	NeedFlagSignalStory        NeedFlagSignal = "story"
+)
+
+// NeedFlag links a submitted need to an existing need that looks like a
+// duplicate. Flags stay on record after dismissal so the decision is auditable.
+type NeedFlag struct {
+	ID                string         `db:"id"`
+	NeedID            string         `db:"need_id"`
+	MatchedNeedID     string         `db:"matched_need_id"`
+	Signal            NeedFlagSignal `db:"signal"`
+	Detail            *string        `db:"detail"`
+	Similarity        int            `db:"similarity"`
+	DismissedAt       *time.Time     `db:"dismissed_at"`
+	DismissedByUserID *string        `db:"dismissed_by_user_id"`
+	DismissReason     *string        `db:"dismiss_reason"`
+	CreatedAt         time.Time      `db:"created_at"`
+}
+
+// NeedAddressCandidate is a need owned by another user together with the
+// address it resolves to (its selected address, or the owner's primary).
+type NeedAddressCandidate struct {
+	NeedID     string  `db:"need_id"`
+	UserID     string  `db:"user_id"`
+	Address    *string `db:"address"`
+	AddressExt *string `db:"address_ext"`
+	City       *string `db:"city"`
+	State      *string `db:"state"`
+	ZipCode    *string `db:"zip_code"`
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+type NeedGoalChangeKind string
+
+const (
+	// NeedGoalChangeKindGoal raises or lowers the amount a need is asking for.
+	NeedGoalChangeKindGoal NeedGoalChangeKind = "goal_change"
+	// NeedGoalChangeKindEarlyClose stops fundraising before the goal is met.
+	NeedGoalChangeKindEarlyClose NeedGoalChangeKind = "early_close"
+)
+
+type NeedGoalChangeStatus string
+
+const (
+	NeedGoalChangeStatusPending  NeedGoalChangeStatus = "pending"
+	NeedGoalChangeStatusApproved NeedGoalChangeStatus = "approved"
+	NeedGoalChangeStatusDenied   NeedGoalChangeStatus = "denied"
+)
+
+// NeedGoalChangeRequest is an owner's request to change the goal of an active
+// need or close it early. For early closes RequestedGoalCents is the amount
+// the owner wants to keep; anything raised beyond it is queued for
+// reallocation once the request is approved. A goal raised above the second
+// approval threshold stays pending after its first approval until a
+// different admin gives the second.
+type NeedGoalChangeRequest struct {
+	ID                    string               `db:"id"`
+	NeedID                string               `db:"need_id"`
+	RequestedByUserID     string               `db:"requested_by_user_id"`
+	Kind                  NeedGoalChangeKind   `db:"kind"`
+	PreviousGoalCents     int                  `db:"previous_goal_cents"`
+	RequestedGoalCents    int                  `db:"requested_goal_cents"`
+	Justification         string               `db:"justification"`
+	Status                NeedGoalChangeStatus `db:"status"`
+	FirstApprovedByUserID *string              `db:"first_approved_by_user_id"`
+	FirstApprovedAt       *time.Time           `db:"first_approved_at"`
+	DecidedByUserID       *string              `db:"decided_by_user_id"`
+	DecidedAt             *time.Time           `db:"decided_at"`
+	DecisionNote          *string              `db:"decision_note"`
+	CreatedAt             time.Time            `db:"created_at"`
+}
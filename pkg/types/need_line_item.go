@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// NeedLineItem is one itemized piece of a need's budget. When a need has line
+// items their amounts add up to the need's AmountNeededCents.
+type NeedLineItem struct {
+	ID          string    `db:"id"`
+	NeedID      string    `db:"need_id"`
+	Label       string    `db:"label"`
+	AmountCents int       `db:"amount_cents"`
+	CategoryID  *string   `db:"category_id"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// NeedLineItemFormRow holds the raw values of one budget row so the form can
+// be re-rendered as the user typed it.
+type NeedLineItemFormRow struct {
+	Label      string
+	Amount     string
+	CategoryID string
+}
+
+// NeedLineItemView is a line item with its donation progress for display.
+type NeedLineItemView struct {
+	ID             string
+	Label          string
+	CategoryName   string
+	AmountCents    int
+	RaisedCents    int
+	FundingPercent int
+}
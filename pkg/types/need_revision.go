@@ -0,0 +1,54 @@
+package types
+
+import "time"
+
+type NeedRevisionSource string
+
+const (
+	NeedRevisionSourceLocation         NeedRevisionSource = "location"
+	NeedRevisionSourceCategories       NeedRevisionSource = "categories"
+	NeedRevisionSourceStory            NeedRevisionSource = "story"
+	NeedRevisionSourceBudget           NeedRevisionSource = "budget"
+	NeedRevisionSourceDocuments        NeedRevisionSource = "documents"
+	NeedRevisionSourceResubmitted      NeedRevisionSource = "resubmitted"
+	NeedRevisionSourceChangesRequested NeedRevisionSource = "changes_requested"
+)
+
+type NeedRevision struct {
+	ID          string             `db:"id"`
+	NeedID      string             `db:"need_id"`
+	ActorUserID string             `db:"actor_user_id"`
+	Source      NeedRevisionSource `db:"source"`
+	Snapshot    []byte             `db:"snapshot"`
+	CreatedAt   time.Time          `db:"created_at"`
+}
+
+// NeedRevisionSnapshot is the JSON payload stored in need_revisions.snapshot.
+// Values are captured as display strings so a diff stays readable even after
+// the referenced category, address or document rows change.
+type NeedRevisionSnapshot struct {
+	AmountNeededCents   int                    `json:"amountNeededCents"`
+	ShortDescription    string                 `json:"shortDescription"`
+	Location            string                 `json:"location"`
+	StoryCurrent        string                 `json:"storyCurrent"`
+	StoryNeed           string                 `json:"storyNeed"`
+	StoryOutcome        string                 `json:"storyOutcome"`
+	PrimaryCategory     string                 `json:"primaryCategory"`
+	SecondaryCategories []string               `json:"secondaryCategories"`
+	Documents           []string               `json:"documents"`
+	LineItems           []NeedRevisionLineItem `json:"lineItems"`
+}
+
+// NeedRevisionLineItem is one budget line as it stood when a revision was
+// taken.
+type NeedRevisionLineItem struct {
+	Label       string `json:"label"`
+	AmountCents int    `json:"amountCents"`
+	SortOrder   int    `json:"sortOrder"`
+}
+
+type NeedRevisionFieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
@@ -24,6 +24,7 @@ type CSRFFieldSetter interface {
 
 type BasePageData struct {
 	Title     string
+	Meta      PageMeta
 	Navbar    NavbarData
 	CSRFField template.HTML
 }
@@ -84,6 +85,7 @@ type BrowseNeedCard struct {
 	City              string
 	State             string
 	CityState         string
+	LocationPrivacy   string
 	DistanceMiles     *float64
 	UrgencyLabel      string
 	UrgencyDotClass   string
@@ -116,31 +118,39 @@ type CategoryListItem struct {
 
 type CategoryNeedsPageData struct {
 	BasePageData
-	Category   *NeedCategory
-	Needs      []*BrowseNeedCard
-	BackHref   string
-	BrowseHref string
+	Category      *NeedCategory
+	Needs         []*BrowseNeedCard
+	FeaturedNeeds []*BrowseNeedCard
+	BackHref      string
+	BrowseHref    string
 }
 
 type NeedDetailPageData struct {
 	BasePageData
-	ID                  string
-	Need                *Need
-	OwnerName           string
-	SelectedAddress     *UserAddress
-	CityState           string
-	UrgencyLabel        string
-	UrgencyDotClass     string
-	UrgencyTextClass    string
-	FundingPercent      int
-	Story               *NeedStory
-	PrimaryCategory     *NeedCategory
-	SecondaryCategories []*NeedCategory
-	Documents           []ReviewDocument
-	RelatedNeeds        []*BrowseNeedCard
-	IsSaved             bool
-	SaveNeedAction      string
-	UnsaveNeedAction    string
+	ID                    string
+	Need                  *Need
+	OwnerName             string
+	LocationPrivacy       string
+	CityState             string
+	UrgencyLabel          string
+	UrgencyDotClass       string
+	UrgencyTextClass      string
+	FundingPercent        int
+	Story                 *NeedStory
+	PrimaryCategory       *NeedCategory
+	SecondaryCategories   []*NeedCategory
+	Documents             []ReviewDocument
+	LineItems             []*NeedLineItemView
+	RelatedNeeds          []*BrowseNeedCard
+	IsSaved               bool
+	SaveNeedAction        string
+	UnsaveNeedAction      string
+	ShareURL              string
+	HasShareLink          bool
+	CreateShareLinkAction string
+	FacebookShareHref     string
+	XShareHref            string
+	FlyerHref             string
 }
 
 type NeedDonatePageData struct {
@@ -155,9 +165,11 @@ type NeedDonatePageData struct {
 	CustomAmount      string
 	PrivateMessage    string
 	IsAnonymous       bool
+	LineItems         []*NeedLineItemView
+	LineItemID        string
 	Error             string
-	PresetAmounts   []int
-	RemainingPreset int // non-zero when remaining < largest preset; rendered as full-width CTA
+	PresetAmounts     []int
+	RemainingPreset   int // non-zero when remaining < largest preset; rendered as full-width CTA
 }
 
 type NeedDonateConfirmationPageData struct {
@@ -217,6 +229,7 @@ type NeedCategoriesPageData struct {
 	Categories                   []*NeedCategory
 	SelectedPrimaryCategoryID    string
 	SelectedSecondaryCategoryIDs map[string]bool
+	NeedVersion                  int
 	FormAction                   string
 	BackHref                     string
 	Error                        string
@@ -228,8 +241,22 @@ type NeedStoryPageData struct {
 	AmountNeededCents int
 	PrimaryCategory   *NeedCategory
 	Story             *NeedStory
+	NeedVersion       int
+	FormAction        string
+	BackHref          string
+	Error             string
+}
+
+type NeedBudgetPageData struct {
+	BasePageData
+	ID                string
+	AmountNeededCents int
+	Rows              []NeedLineItemFormRow
+	Categories        []*NeedCategory
+	NeedVersion       int
 	FormAction        string
 	BackHref          string
+	Error             string
 }
 
 type NeedDocumentsPageData struct {
@@ -264,13 +291,16 @@ type NeedReviewPageData struct {
 	PrimaryCategory     *NeedCategory
 	SecondaryCategories []*NeedCategory
 	Documents           []ReviewDocument
+	LineItems           []*NeedLineItemView
 	EditLocationHref    string
 	EditCategoriesHref  string
 	EditStoryHref       string
+	EditBudgetHref      string
 	EditDocumentsHref   string
 	SubmitAction        string
 	BackHref            string
 	SubmitLabel         string
+	NeedVersion         int
 	Notice              string
 	Error               string
 }
@@ -294,6 +324,7 @@ type NeedLocationPageData struct {
 	SelectedAddressID string
 	ShowSetPrimary    bool
 	NewAddress        *UserAddressForm
+	NeedVersion       int
 	FormAction        string
 	BackHref          string
 	Error             string
@@ -358,36 +389,49 @@ type ProfilePageData struct {
 	NeedSummaries           []ProfileNeedSummary
 	DonationSummaries       []ProfileDonationSummary
 	SavedNeedSummaries      []ProfileSavedNeedSummary
+	ShareSummaries          []ProfileShareSummary
+	ShareRaisedAmount       string
 	HasNeeds                bool
 	HasDonations            bool
 	HasSavedNeeds           bool
+	HasShares               bool
 	SubmitNeedHref          string
 }
 
+type ProfileShareSummary struct {
+	NeedID        string
+	OwnerName     string
+	ShareURL      string
+	ClickCount    int
+	DonationCount int
+	RaisedAmount  string
+	DetailHref    string
+}
+
 type ProfileSavedNeedSummary struct {
-	NeedID          string
-	OwnerName       string
-	CategoryName    string
-	AmountNeeded    string
-	FundingPercent  int
-	UrgencyLabel    string
-	UrgencyDotClass string
+	NeedID           string
+	OwnerName        string
+	CategoryName     string
+	AmountNeeded     string
+	FundingPercent   int
+	UrgencyLabel     string
+	UrgencyDotClass  string
 	UrgencyTextClass string
-	DetailHref      string
-	UnsaveAction    string
+	DetailHref       string
+	UnsaveAction     string
 }
 
 type ProfileDonorPreferencesPageData struct {
 	BasePageData
-	SidebarItems           []ProfileNavItem
-	Notice                 string
-	Error                  string
-	Categories             []*NeedCategory
-	ZipCode                string
-	Radius                 string
-	DonationRange          string
-	NotificationFrequency  string
-	SelectedCategoryIDs    map[string]bool
+	SidebarItems            []ProfileNavItem
+	Notice                  string
+	Error                   string
+	Categories              []*NeedCategory
+	ZipCode                 string
+	Radius                  string
+	DonationRange           string
+	NotificationFrequency   string
+	SelectedCategoryIDs     map[string]bool
 	UpdatePreferencesAction string
 }
 
@@ -400,6 +444,20 @@ type ProfileNeedSummary struct {
 	CanDelete           bool
 	NeedsAttention      bool
 	ReviewPortalHref    string
+	ContinueHref        string
+	CloneAction         string
+}
+
+type OnboardingNeedPickerPageData struct {
+	BasePageData
+	Drafts          []ProfileNeedSummary
+	PastNeeds       []ProfileNeedSummary
+	NewDraftAction  string
+	ActiveNeedCount int
+	MaxActiveNeeds  int
+	AtActiveCap     bool
+	Notice          string
+	Error           string
 }
 
 type NeedReviewMessageView struct {
@@ -423,25 +481,57 @@ type NeedReviewDocumentFeedback struct {
 
 type NeedReviewPortalPageData struct {
 	BasePageData
-	Need                *Need
-	Story               *NeedStory
-	PrimaryCategory     *NeedCategory
-	SecondaryCategories []*NeedCategory
-	RejectionReason     string
-	RejectionNote       string
-	Documents           []NeedReviewDocumentFeedback
-	Messages            []NeedReviewMessageView
-	PostMessageAction   string
-	SetReadyAction      string
-	PullBackAction      string
-	BackHref            string
-	EditNeedHref        string
-	CanEditNeed         bool
-	CanSetReady         bool
-	CanPullBack         bool
-	CanSendMessage      bool
-	Notice              string
-	Error               string
+	Need                 *Need
+	Story                *NeedStory
+	PrimaryCategory      *NeedCategory
+	SecondaryCategories  []*NeedCategory
+	RejectionReason      string
+	RejectionNote        string
+	Documents            []NeedReviewDocumentFeedback
+	Messages             []NeedReviewMessageView
+	PostMessageAction    string
+	SetReadyAction       string
+	PullBackAction       string
+	BackHref             string
+	EditNeedHref         string
+	CanEditNeed          bool
+	CanSetReady          bool
+	CanPullBack          bool
+	CanSendMessage       bool
+	CanRequestGoalChange bool
+	GoalChangeAction     string
+	GoalChangeItemized   bool
+	GoalChanges          []*NeedGoalChangeView
+	HasPendingGoalChange bool
+	ChangeRequestItems   []*NeedChangeRequestItemView
+	Notice               string
+	Error                string
+}
+
+// NeedChangeRequestItemView is one item of the change request checklist shown
+// to the owner. EditHref is empty when the need cannot be edited.
+type NeedChangeRequestItemView struct {
+	Label    string
+	Note     string
+	EditHref string
+}
+
+type NeedGoalChangeView struct {
+	ID              string
+	KindLabel       string
+	Summary         string
+	Justification   string
+	StatusLabel     string
+	IsPending       bool
+	CreatedAt       string
+	DecidedAt       string
+	DecidedBy       string
+	DecisionNote    string
+	DecideAction    string
+	BlockedReason   string
+	FirstApprovedBy string
+	FirstApprovedAt string
+	IsFirstApprover bool
 }
 
 type ProfileDonationSummary struct {
@@ -453,21 +543,233 @@ type ProfileDonationSummary struct {
 	IsFinalized bool
 	IsAnonymous bool
 	CreatedAt   string
+	Redirects   []ProfileDonationRedirect
+}
+
+// ProfileDonationRedirect describes part of a gift that was, or is about to
+// be, moved to another need or a category fund.
+type ProfileDonationRedirect struct {
+	Message      string
+	OptOutAction string
 }
 
 type AdminDashboardPageData struct {
 	BasePageData
+	QueueTiles          []*AdminDashboardTile
+	StatusTiles         []*AdminDashboardTile
+	DonationTiles       []*AdminDashboardTile
+	EmailTiles          []*AdminDashboardTile
+	UnansweredMessages  []*AdminDashboardMessage
+	UnansweredCount     int
+	CanViewUsers        bool
+	CanManageFunds      bool
+	CanManageResponses  bool
+	CanManageRoles      bool
+	CanViewAudit        bool
+	CanViewEmails       bool
+	CanManageCategories bool
+	CanManageFeaturing  bool
+}
+
+// AdminDashboardTile is a single metric on the admin dashboard. Href points at
+// the list the metric was counted from.
+type AdminDashboardTile struct {
+	Label   string
+	Value   string
+	Detail  string
+	Href    string
+	IsAlert bool
+}
+
+type AdminDashboardMessage struct {
+	NeedID     string
+	Preview    string
+	Waiting    string
+	ReviewHref string
+}
+
+type AdminDonationsPageData struct {
+	BasePageData
+	Donations       []*AdminDonationItem
+	TotalDonations  int
+	TotalAmount     string
+	Page            int
+	PageSize        int
+	TotalPages      int
+	PrevHref        string
+	NextHref        string
+	SelectedStatus  string
+	SelectedWindow  string
+	StatusOptions   []AdminExplorerOption
+	WindowOptions   []AdminExplorerOption
+	FilterAction    string
+	ReportAction    string
+	CategoryOptions []AdminExplorerOption
+	BackHref        string
+}
+
+type AdminDonationItem struct {
+	IntentID    string
+	NeedID      string
+	NeedHref    string
+	Amount      string
+	Status      string
+	DonorUserID string
+	IsAnonymous bool
+	CreatedAt   string
+}
+
+type AdminEmailEventsPageData struct {
+	BasePageData
+	Events         []*AdminEmailEventItem
+	Limit          int
+	SelectedType   string
+	SelectedWindow string
+	TypeOptions    []AdminExplorerOption
+	WindowOptions  []AdminExplorerOption
+	FilterAction   string
+	BackHref       string
+}
+
+type AdminEmailEventItem struct {
+	When      string
+	EventType string
+	Recipient string
+	Subject   string
+	EmailType string
+}
+
+type AdminEmailsPageData struct {
+	BasePageData
+	Messages         []*AdminEmailMessageItem
+	Page             int
+	PageSize         int
+	TotalMessages    int
+	TotalPages       int
+	PrevHref         string
+	NextHref         string
+	Recipient        string
+	SelectedType     string
+	SelectedStatus   string
+	TypeOptions      []AdminExplorerOption
+	StatusOptions    []AdminExplorerOption
+	FilterAction     string
+	EventsHref       string
+	SuppressionsHref string
+	BackHref         string
+}
+
+type AdminEmailMessageItem struct {
+	MessageID  string
+	When       string
+	Recipient  string
+	Subject    string
+	EmailType  string
+	Status     string
+	IsResend   bool
+	DetailHref string
+}
+
+// AdminEmailMessagePageData shows one sent message with its delivery
+// timeline. ResendBlocked explains why the resend button is unavailable.
+type AdminEmailMessagePageData struct {
+	BasePageData
+	Message          *AdminEmailMessageItem
+	FromAddress      string
+	Provider         string
+	ProviderID       string
+	UpdatedAt        string
+	HTMLBody         string
+	TextBody         string
+	ResentFromHref   string
+	Resends          []*AdminEmailMessageItem
+	Timeline         []*AdminEmailTimelineItem
+	Suppression      *AdminEmailSuppressionItem
+	CanManage        bool
+	ResendAction     string
+	ResendBlocked    string
+	SuppressionsHref string
+	BackHref         string
+	Notice           string
+	Error            string
+}
+
+// AdminEmailTimelineItem is one step in a message's delivery history, either
+// the send itself or a provider webhook event.
+type AdminEmailTimelineItem struct {
+	When    string
+	Label   string
+	Detail  string
+	IsAlert bool
+}
+
+type AdminEmailSuppressionsPageData struct {
+	BasePageData
+	Suppressions      []*AdminEmailSuppressionItem
+	Page              int
+	PageSize          int
+	TotalSuppressions int
+	TotalPages        int
+	PrevHref          string
+	NextHref          string
+	Search            string
+	IncludeRemoved    bool
+	CanManage         bool
+	FilterAction      string
+	MessagesHref      string
+	BackHref          string
+	Notice            string
+	Error             string
+}
+
+type AdminEmailSuppressionItem struct {
+	SuppressionID string
+	EmailAddress  string
+	Reason        string
+	CreatedAt     string
+	RemovedAt     string
+	RemovedBy     string
+	RemoveReason  string
+	IsActive      bool
+	MessagesHref  string
+	RemoveAction  string
 }
 
 type AdminNeedsPageData struct {
 	BasePageData
-	Needs      []*AdminNeedQueueItem
-	Page       int
-	PageSize   int
-	TotalNeeds int
-	TotalPages int
-	PrevHref   string
-	NextHref   string
+	Needs        []*AdminNeedQueueItem
+	Page         int
+	PageSize     int
+	TotalNeeds   int
+	TotalPages   int
+	PrevHref     string
+	NextHref     string
+	AssignedToMe bool
+	AllHref      string
+	MineHref     string
+	SLAHours     int
+
+	// PriorityWeights explains how queue scores are computed.
+	PriorityWeights []*AdminQueuePriorityFactor
+}
+
+type AdminNeedsSecondApprovalPageData struct {
+	BasePageData
+	Needs          []*AdminNeedSecondApprovalItem
+	ThresholdLabel string
+	IsEnabled      bool
+	QueueHref      string
+}
+
+type AdminNeedSecondApprovalItem struct {
+	NeedID          string
+	Amount          string
+	FirstApprover   string
+	FirstApprovedAt string
+	Waiting         string
+	ReviewHref      string
+	IsFirstApprover bool
+	CanApprove      bool
 }
 
 type AdminNeedQueueItem struct {
@@ -476,6 +778,150 @@ type AdminNeedQueueItem struct {
 	CreatedAt   string
 	SubmittedAt string
 	ReviewHref  string
+	AssignedTo  string
+	IsMine      bool
+	SLALabel    string
+	IsOverdue   bool
+	ClaimAction string
+	Urgency     NeedUrgency
+	NeededBy    string
+
+	// PriorityScore orders the queue; PriorityFactors break it down.
+	PriorityScore   int
+	PriorityFactors []*AdminQueuePriorityFactor
+}
+
+type AdminQueuePriorityFactor struct {
+	Label       string
+	Points      int
+	PointsLabel string
+}
+
+type AdminRolesPageData struct {
+	BasePageData
+	Grants      []*AdminRoleGrantItem
+	RoleOptions []AdminExplorerOption
+	GrantAction string
+	BackHref    string
+	Notice      string
+	Error       string
+}
+
+type AdminRoleGrantItem struct {
+	GrantID      string
+	UserID       string
+	UserName     string
+	Email        string
+	Role         string
+	GrantedBy    string
+	GrantedAt    string
+	UserHref     string
+	RevokeAction string
+}
+
+type AdminFeaturingPageData struct {
+	BasePageData
+	SlotGroups      []*AdminFeatureSlotGroup
+	PastSlots       []*AdminFeatureSlotItem
+	CategoryOptions []AdminExplorerOption
+	DefaultStartsAt string
+	CreateAction    string
+	BackHref        string
+	Notice          string
+	Error           string
+}
+
+// AdminFeatureSlotGroup lists the open slots of the homepage or of the
+// category pages.
+type AdminFeatureSlotGroup struct {
+	Title     string
+	EmptyText string
+	Slots     []*AdminFeatureSlotItem
+}
+
+// AdminFeatureSlotItem is one featuring window. IsBlocked marks slots whose
+// need is no longer publicly visible, so the slot shows nothing.
+type AdminFeatureSlotItem struct {
+	ID             string
+	NeedID         string
+	NeedTitle      string
+	NeedHref       string
+	NeedStatus     string
+	Placement      string
+	Position       int
+	StartsAt       string
+	EndsAt         string
+	EndedAt        string
+	EndReason      string
+	IsLive         bool
+	IsUpcoming     bool
+	IsBlocked      bool
+	PositionAction string
+	EndAction      string
+}
+
+type AdminCategoriesPageData struct {
+	BasePageData
+	Categories   []*AdminCategoryItem
+	IconOptions  []string
+	CreateAction string
+	BackHref     string
+	Notice       string
+	Error        string
+}
+
+type AdminCategoryItem struct {
+	ID                   string
+	Name                 string
+	Slug                 string
+	Description          string
+	Icon                 string
+	DisplayOrder         int
+	IsActive             bool
+	NeedCount            int
+	DonorPreferenceCount int
+	PublicHref           string
+	CanMoveUp            bool
+	CanMoveDown          bool
+	MergeTargets         []AdminExplorerOption
+	UpdateAction         string
+	ActiveAction         string
+	MoveAction           string
+	MergeAction          string
+}
+
+type AdminAuditPageData struct {
+	BasePageData
+	Events             []*AdminAuditItem
+	Page               int
+	TotalEvents        int
+	TotalPages         int
+	PrevHref           string
+	NextHref           string
+	Actor              string
+	SelectedAction     string
+	SelectedTargetType string
+	TargetID           string
+	From               string
+	To                 string
+	ActionOptions      []AdminExplorerOption
+	TargetTypeOptions  []AdminExplorerOption
+	FilterAction       string
+	ExportHref         string
+	BackHref           string
+}
+
+type AdminAuditItem struct {
+	When       string
+	ActorID    string
+	ActorName  string
+	ActorHref  string
+	Action     string
+	TargetType string
+	TargetID   string
+	TargetHref string
+	IPAddress  string
+	Detail     string
 }
 
 type AdminNeedExplorerPageData struct {
@@ -496,6 +942,16 @@ type AdminNeedExplorerPageData struct {
 	BackHref          string
 	QueueHref         string
 	CurrentStatusText string
+	ShowDeleted       bool
+	ToggleDeletedHref string
+	ExportCSVHref     string
+	ExportXLSXHref    string
+	BulkAction        string
+	BulkOptions       []AdminExplorerOption
+	UrgencyOptions    []AdminExplorerOption
+	Notice            string
+	Error             string
+	Failures          []string
 }
 
 type AdminNeedStatusCard struct {
@@ -516,6 +972,9 @@ type AdminNeedExplorerItem struct {
 	Status            NeedStatus
 	AmountRaisedCents int
 	AmountNeededCents int
+	Urgency           NeedUrgency
+	IsFeatured        bool
+	IsDeleted         bool
 	FundingPercent    int
 	ActivityLabel     string
 	UpdatedAt         string
@@ -527,29 +986,200 @@ type AdminNeedExplorerItem struct {
 
 type AdminNeedReviewPageData struct {
 	BasePageData
-	Need                *Need
-	Story               *NeedStory
-	PrimaryCategory     *NeedCategory
-	SecondaryCategories []*NeedCategory
-	SelectedAddress     *UserAddress
-	CityState           string
-	Documents           []*AdminNeedReviewDocument
-	Timeline            []*AdminNeedTimelineItem
-	BackHref            string
-	ModerateAction      string
-	AcceptReviewAction  string
-	CanAcceptReview     bool
-	CanSubmitModeration bool
-	DeleteAction        string
-	RestoreAction       string
-	IsDeleted           bool
-	DeletedAt           string
-	DeletedByUserID     string
-	DeleteReason        string
-	Messages            []NeedReviewMessageView
-	MessageAction       string
-	Notice              string
-	Error               string
+	Need                    *Need
+	Story                   *NeedStory
+	PrimaryCategory         *NeedCategory
+	SecondaryCategories     []*NeedCategory
+	SelectedAddress         *UserAddress
+	CityState               string
+	NeededBy                string
+	Documents               []*AdminNeedReviewDocument
+	LineItems               []*NeedLineItemView
+	Timeline                []*AdminNeedTimelineItem
+	Assignment              *AdminNeedAssignmentView
+	SecondApproval          *AdminNeedSecondApprovalView
+	RequiresSecondApproval  bool
+	SecondApprovalThreshold string
+	BackHref                string
+	ModerateAction          string
+	AcceptReviewAction      string
+	CanAcceptReview         bool
+	CanSubmitModeration     bool
+	CanModerate             bool
+	CanDelete               bool
+	DeleteAction            string
+	RestoreAction           string
+	IsDeleted               bool
+	DeletedAt               string
+	DeletedByUserID         string
+	DeleteReason            string
+	Messages                []NeedReviewMessageView
+	MessageAction           string
+	RevisionDiff            *AdminNeedRevisionDiff
+	Flags                   []*AdminNeedFlagView
+	OpenFlagCount           int
+	GoalChanges             []*NeedGoalChangeView
+	PendingGoalChangeCount  int
+	Reallocations           []*AdminFundReallocationView
+	UnqueuedBalance         string
+	CanQueueReallocation    bool
+	ReallocationQueueAction string
+	ReallocationCategories  []*NeedCategory
+	RevisionCount           int
+	ResponseTemplates       []*AdminReviewResponseOption
+	ChangeRequestOptions    []AdminExplorerOption
+	ManageResponsesHref     string
+	AdminNotes              *AdminNotesView
+	Notice                  string
+	Error                   string
+}
+
+// AdminNotesView is the internal notes panel shared by the user detail and
+// need review pages.
+type AdminNotesView struct {
+	Notes        []*AdminNoteItem
+	CanWrite     bool
+	CreateAction string
+}
+
+type AdminNoteItem struct {
+	ID           string
+	Body         string
+	Author       string
+	CreatedAt    string
+	EditedAt     string
+	EditedBy     string
+	IsPinned     bool
+	History      []*AdminNoteRevisionItem
+	UpdateAction string
+	PinAction    string
+}
+
+// AdminNoteRevisionItem is a note body as it read before one of its edits.
+type AdminNoteRevisionItem struct {
+	Body     string
+	EditedBy string
+	EditedAt string
+}
+
+// AdminReviewResponseOption is a canned response offered in the moderation
+// modal. Action is the moderation form action it applies to.
+type AdminReviewResponseOption struct {
+	ID     string
+	Action string
+	Label  string
+	Reason string
+	Note   string
+}
+
+type AdminReviewResponsesPageData struct {
+	BasePageData
+	Templates     []*AdminReviewResponseItem
+	ActionOptions []AdminExplorerOption
+	CreateAction  string
+	BackHref      string
+	Notice        string
+	Error         string
+}
+
+type AdminReviewResponseItem struct {
+	ID            string
+	Action        string
+	ActionLabel   string
+	Title         string
+	Reason        string
+	Note          string
+	IsArchived    bool
+	UpdatedAt     string
+	UpdateAction  string
+	ArchiveAction string
+}
+
+type AdminNeedAssignmentView struct {
+	AssignedTo     string
+	AssignedAt     string
+	IsClaimed      bool
+	IsMine         bool
+	SLALabel       string
+	IsOverdue      bool
+	CanClaim       bool
+	CanRelease     bool
+	CanReassign    bool
+	ClaimAction    string
+	ReleaseAction  string
+	ReassignAction string
+}
+
+// AdminNeedSecondApprovalView describes a need held for a second approver on
+// the review page.
+type AdminNeedSecondApprovalView struct {
+	FirstApprover   string
+	FirstApprovedAt string
+	IsFirstApprover bool
+	CanApprove      bool
+}
+
+type AdminFundReallocationView struct {
+	ID              string
+	NeedID          string
+	NeedHref        string
+	Amount          string
+	SourceLabel     string
+	StatusLabel     string
+	IsQueued        bool
+	Destination     string
+	ConsentDeadline string
+	CreatedAt       string
+	ProposeAction   string
+}
+
+// AdminDonationRefundView is one opted-out share in the refund queue.
+// RefundAction is empty once the share has been refunded.
+type AdminDonationRefundView struct {
+	ID           string
+	NeedID       string
+	NeedHref     string
+	Donor        string
+	Amount       string
+	OptedOutAt   string
+	RefundedAt   string
+	RefundedBy   string
+	Reference    string
+	CanUseStripe bool
+	RefundAction string
+}
+
+type AdminReallocationsPageData struct {
+	BasePageData
+	Queued        []*AdminFundReallocationView
+	Proposed      []*AdminFundReallocationView
+	RefundsOwed   []*AdminDonationRefundView
+	RecentRefunds []*AdminDonationRefundView
+	BackHref      string
+	Notice        string
+	Error         string
+}
+
+type AdminNeedRevisionDiff struct {
+	ChangesRequestedAt string
+	ResubmittedAt      string
+	HasResubmission    bool
+	Changes            []*NeedRevisionFieldChange
+}
+
+type AdminNeedFlagView struct {
+	ID              string
+	SignalLabel     string
+	Detail          string
+	Similarity      int
+	MatchedNeedID   string
+	MatchedNeedHref string
+	CreatedAt       string
+	IsDismissed     bool
+	DismissedAt     string
+	DismissedBy     string
+	DismissReason   string
+	DismissAction   string
 }
 
 type AdminNeedReviewDocument struct {
@@ -576,17 +1206,19 @@ type AdminNeedTimelineItem struct {
 
 type AdminUsersPageData struct {
 	BasePageData
-	Users        []*AdminUserListItem
-	Page         int
-	PageSize     int
-	TotalUsers   int
-	TotalPages   int
-	PrevHref     string
-	NextHref     string
-	Search       string
-	SelectedType string
-	FilterAction string
-	BackHref     string
+	Users          []*AdminUserListItem
+	Page           int
+	PageSize       int
+	TotalUsers     int
+	TotalPages     int
+	PrevHref       string
+	NextHref       string
+	Search         string
+	SelectedType   string
+	FilterAction   string
+	ExportCSVHref  string
+	ExportXLSXHref string
+	BackHref       string
 }
 
 type AdminUserListItem struct {
@@ -610,6 +1242,20 @@ type AdminUserDetailPageData struct {
 	CreatedAt   string
 	UpdatedAt   string
 	BackHref    string
+	Notice      string
+	Error       string
+
+	// Account restrictions
+	Restriction           *AdminUserRestrictionItem
+	RestrictionHistory    []*AdminUserRestrictionItem
+	CanManageRestrictions bool
+	IsSelf                bool
+	SuspensionDayOptions  []int
+	RestrictAction        string
+	LiftAction            string
+
+	// Internal notes
+	AdminNotes *AdminNotesView
 
 	// Recipient-specific
 	IsRecipient  bool
@@ -635,6 +1281,20 @@ type AdminUserNeedItem struct {
 	FundingPercent   int
 	CreatedAt        string
 	ReviewHref       string
+	IsHidden         bool
+}
+
+// AdminUserRestrictionItem is one suspension or ban on the user detail page.
+type AdminUserRestrictionItem struct {
+	Kind       UserRestrictionKind
+	Reason     string
+	CreatedAt  string
+	CreatedBy  string
+	ExpiresAt  string
+	IsActive   bool
+	LiftedAt   string
+	LiftedBy   string
+	LiftReason string
 }
 
 type AdminUserDonationItem struct {
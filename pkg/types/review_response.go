@@ -0,0 +1,54 @@
+package types
+
+import "time"
+
+// ReviewResponseTemplate is a canned reason and note a reviewer can pick when
+// requesting changes or rejecting a need.
+type ReviewResponseTemplate struct {
+	ID              string                   `db:"id"`
+	ActionType      NeedModerationActionType `db:"action_type"`
+	Title           string                   `db:"title"`
+	Reason          string                   `db:"reason"`
+	Note            *string                  `db:"note"`
+	CreatedByUserID *string                  `db:"created_by_user_id"`
+	UpdatedByUserID *string                  `db:"updated_by_user_id"`
+	ArchivedAt      *time.Time               `db:"archived_at"`
+	CreatedAt       time.Time                `db:"created_at"`
+	UpdatedAt       time.Time                `db:"updated_at"`
+}
+
+// NeedChangeRequestField names the part of a need a change request asks the
+// owner to fix.
+type NeedChangeRequestField string
+
+const (
+	NeedChangeRequestFieldAddress    NeedChangeRequestField = "address"
+	NeedChangeRequestFieldContact    NeedChangeRequestField = "contact"
+	NeedChangeRequestFieldCategories NeedChangeRequestField = "categories"
+	NeedChangeRequestFieldAmount     NeedChangeRequestField = "amount"
+	NeedChangeRequestFieldStory      NeedChangeRequestField = "story"
+	NeedChangeRequestFieldDocuments  NeedChangeRequestField = "documents"
+	NeedChangeRequestFieldDocument   NeedChangeRequestField = "document"
+)
+
+// NeedChangeRequestFields lists the need-level checklist fields in the order
+// they are offered to reviewers. Individual documents are offered after them.
+var NeedChangeRequestFields = []NeedChangeRequestField{
+	NeedChangeRequestFieldAddress,
+	NeedChangeRequestFieldContact,
+	NeedChangeRequestFieldCategories,
+	NeedChangeRequestFieldAmount,
+	NeedChangeRequestFieldStory,
+	NeedChangeRequestFieldDocuments,
+}
+
+// NeedChangeRequestItem is one checklist entry of a change request.
+type NeedChangeRequestItem struct {
+	ID                 string                 `db:"id"`
+	NeedID             string                 `db:"need_id"`
+	ModerationActionID string                 `db:"moderation_action_id"`
+	Field              NeedChangeRequestField `db:"field"`
+	DocumentID         *string                `db:"document_id"`
+	Note               *string                `db:"note"`
+	CreatedAt          time.Time              `db:"created_at"`
+}
@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+type NeedShareLink struct {
+	ID            string     `db:"id"`
+	Code          string     `db:"code"`
+	NeedID        string     `db:"need_id"`
+	UserID        string     `db:"user_id"`
+	ClickCount    int        `db:"click_count"`
+	LastClickedAt *time.Time `db:"last_clicked_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// NeedShareLinkStats is a share link with the finalized donations it referred.
+type NeedShareLinkStats struct {
+	NeedShareLink
+	DonationCount int `db:"donation_count"`
+	RaisedCents   int `db:"raised_cents"`
+}
+
+// PageMeta carries Open Graph and Twitter card values for the page head.
+type PageMeta struct {
+	Title       string
+	Description string
+	URL         string
+	ImageURL    string
+	Type        string
+}
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// UserRestrictionKind says how an account has been restricted.
+type UserRestrictionKind string
+
+const (
+	UserRestrictionSuspended UserRestrictionKind = "suspended"
+	UserRestrictionBanned    UserRestrictionKind = "banned"
+)
+
+// UserRestriction suspends or bans a user. A restriction is active until it
+// is lifted or, for suspensions, until ExpiresAt passes. While active the user
+// cannot sign in and their live needs are hidden from public pages.
+type UserRestriction struct {
+	ID              string              `db:"id"`
+	UserID          string              `db:"user_id"`
+	Kind            UserRestrictionKind `db:"kind"`
+	Reason          string              `db:"reason"`
+	ExpiresAt       *time.Time          `db:"expires_at"`
+	CreatedByUserID *string             `db:"created_by_user_id"`
+	CreatedAt       time.Time           `db:"created_at"`
+	LiftedAt        *time.Time          `db:"lifted_at"`
+	LiftedByUserID  *string             `db:"lifted_by_user_id"`
+	LiftReason      *string             `db:"lift_reason"`
+}
+
+// ActiveAt reports whether the restriction still applies at now.
+func (r *UserRestriction) ActiveAt(now time.Time) bool {
+	if r == nil || r.LiftedAt != nil {
+		return false
+	}
+	return r.ExpiresAt == nil || now.Before(*r.ExpiresAt)
+}